@@ -4,35 +4,149 @@ package main
 import (
 	"context"
 	"flag"
+	"fmt"
 	"os"
 	"os/signal"
+	"path/filepath"
 	"syscall"
+	"time"
 
+	"github.com/your-org/mcp-k8s-server/internal/config"
 	"github.com/your-org/mcp-k8s-server/internal/delivery/mcp"
+	"github.com/your-org/mcp-k8s-server/internal/domain"
 	"github.com/your-org/mcp-k8s-server/internal/infrastructure"
 	"github.com/your-org/mcp-k8s-server/internal/usecase"
 )
 
 func main() {
-	var configPath string
-	flag.StringVar(&configPath, "config", "", "Path to configuration file")
-	flag.Parse()
+	cfg, err := loadConfig(flag.CommandLine, os.Args[1:])
+	if err != nil {
+		infrastructure.NewLogger().Error("Failed to load configuration", "error", err)
+		os.Exit(1)
+	}
+	if cfg.KeyFile == "" {
+		cfg.KeyFile = filepath.Join(cfg.DataDir, "cluster.key")
+	}
+	if cfg.Audit.Path == "" {
+		cfg.Audit.Path = filepath.Join(cfg.DataDir, "audit.log")
+	}
+	if cfg.LogExport.Dir == "" {
+		cfg.LogExport.Dir = filepath.Join(cfg.DataDir, "exports")
+	}
+	if err := cfg.Validate(); err != nil {
+		infrastructure.NewLogger().Error("Invalid configuration", "error", err)
+		os.Exit(1)
+	}
+
+	transportConfig := mcp.TransportConfig{
+		Type:                 cfg.Transport.Type,
+		Addr:                 cfg.Transport.Listen,
+		AuthToken:            cfg.Transport.AuthToken,
+		AllowUnauthenticated: cfg.Transport.AllowUnauthenticated,
+	}
+	if err := transportConfig.Validate(); err != nil {
+		infrastructure.NewLogger().Error("Invalid configuration", "error", err)
+		os.Exit(1)
+	}
 
 	// Initialize logger
-	logger := infrastructure.NewLogger()
+	level, _ := infrastructure.ParseLogLevel(cfg.LogLevel)
+	logger := infrastructure.NewLeveledLogger(level)
 
 	// Initialize cluster manager
 	clusterManager := infrastructure.GetClusterManager(logger)
+	clusterManager.SetTunnelLimits(
+		time.Duration(cfg.PortForward.IdleTimeoutMinutes)*time.Minute,
+		time.Duration(cfg.PortForward.MaxLifetimeMinutes)*time.Minute,
+	)
 
-	// Initialize repository (in-memory)
-	clusterRepo := infrastructure.NewInMemoryClusterRepository()
+	// Initialize repository (persisted, kubeconfig data encrypted at rest)
+	key, err := infrastructure.LoadOrCreateKey(cfg.KeyFile)
+	if err != nil {
+		logger.Error("Failed to load encryption key", "error", err)
+		os.Exit(1)
+	}
+	persistence := infrastructure.NewEncryptedFilePersistence(filepath.Join(cfg.DataDir, "clusters"), key, logger)
+	clusterRepo, err := infrastructure.NewFileClusterRepository(context.Background(), persistence, logger)
+	if err != nil {
+		logger.Error("Failed to load cluster registry", "error", err)
+		os.Exit(1)
+	}
 
 	// Initialize use cases
 	clusterUseCase := usecase.NewClusterUseCase(clusterManager, clusterRepo, logger)
+	restored, err := clusterUseCase.RestoreClusters(context.Background())
+	if err != nil {
+		logger.Error("Failed to restore clusters", "error", err)
+		os.Exit(1)
+	}
+	logger.Info("Restored registered clusters", "count", restored)
+
+	// Register clusters declared in the configuration
+	for _, c := range cfg.Clusters {
+		clusterConfig := domain.ClusterConfig{
+			KubeconfigPath: c.KubeconfigPath,
+			Context:        c.Context,
+			InCluster:      c.InCluster,
+		}
+		if err := clusterUseCase.RegisterConfiguredCluster(context.Background(), domain.ClusterID(c.ID), clusterConfig); err != nil {
+			logger.Error("Failed to register configured cluster", "clusterID", c.ID, "error", err)
+			os.Exit(1)
+		}
+	}
+	if cfg.DefaultCluster != "" && !clusterUseCase.IsRegistered(domain.ClusterID(cfg.DefaultCluster)) {
+		logger.Error("Invalid configuration", "error", fmt.Sprintf("default_cluster %q is not a registered cluster", cfg.DefaultCluster))
+		os.Exit(1)
+	}
 	k8sUseCase := usecase.NewK8sUseCase(clusterRepo, clusterManager, logger)
 
+	// Initialize audit log
+	var auditUseCase *usecase.AuditUseCase
+	if cfg.Audit.Enabled {
+		auditLog, err := infrastructure.NewFileAuditLog(cfg.Audit.Path, int64(cfg.Audit.MaxSizeMB)<<20, cfg.Audit.MaxBackups)
+		if err != nil {
+			logger.Error("Failed to open audit log", "error", err)
+			os.Exit(1)
+		}
+		defer auditLog.Close()
+		auditUseCase = usecase.NewAuditUseCase(auditLog, logger)
+		logger.Info("Audit log enabled", "path", cfg.Audit.Path)
+	}
+
+	// Load custom prompts
+	var customPrompts []domain.PromptTemplate
+	if cfg.Prompts.Dir != "" {
+		customPrompts, err = infrastructure.LoadPromptTemplates(cfg.Prompts.Dir)
+		if err != nil {
+			logger.Error("Failed to load custom prompts", "error", err)
+			os.Exit(1)
+		}
+	}
+
 	// Create MCP server
-	mcpServer, err := mcp.NewMCPServer(clusterUseCase, k8sUseCase, logger)
+	mcpServer, err := mcp.NewMCPServer(clusterUseCase, k8sUseCase, logger, mcp.ServerOptions{
+		DefaultCluster:     cfg.DefaultCluster,
+		DefaultNamespace:   cfg.DefaultNamespace,
+		EnabledToolGroups:  cfg.Tools.EnabledGroups,
+		DisabledToolGroups: cfg.Tools.DisabledGroups,
+		ReadOnly:           cfg.Safety.ReadOnly,
+		AllowTools:         cfg.Safety.AllowTools,
+		DenyTools:          cfg.Safety.DenyTools,
+		PolicyRules:        cfg.Safety.Rules,
+		ConfirmDestructive: cfg.Safety.ConfirmDestructive,
+		Exec: domain.ExecPolicy{
+			AllowCommands:  cfg.Exec.AllowCommands,
+			DenyCommands:   cfg.Exec.DenyCommands,
+			MaxOutputBytes: int64(cfg.Exec.MaxOutputKB) << 10,
+			MaxTimeout:     time.Duration(cfg.Exec.MaxTimeoutSeconds) * time.Second,
+		},
+		LogExportDir:         cfg.LogExport.Dir,
+		LogExportTTL:         time.Duration(cfg.LogExport.TTLMinutes) * time.Minute,
+		LogExportMaxArchives: cfg.LogExport.MaxArchives,
+		ManifestRoot:         cfg.Manifests.Root,
+		CustomPrompts:        customPrompts,
+		Audit:                auditUseCase,
+	})
 	if err != nil {
 		logger.Error("Failed to create MCP server", "error", err)
 		os.Exit(1)
@@ -60,8 +174,51 @@ func main() {
 	}()
 
 	// Run MCP server
-	if err := mcpServer.Run(ctx); err != nil {
+	if err := mcpServer.Run(ctx, transportConfig); err != nil {
 		logger.Error("MCP server error", "error", err)
 		os.Exit(1)
 	}
 }
+
+// loadConfig parses the command-line flags in args and returns the
+// configuration in the order defaults < config file < MCP_K8S_* env < flags.
+// Only flags that were set override the lower layers.
+func loadConfig(fs *flag.FlagSet, args []string) (config.Config, error) {
+	var configPath, dataDir, keyFile, transport, listenAddr, logLevel string
+	var readOnly, allowUnauthenticated bool
+	fs.StringVar(&configPath, "config", "", "Path to YAML or JSON configuration file")
+	fs.StringVar(&dataDir, "data-dir", "", "Directory where registered clusters are stored (default ./data)")
+	fs.StringVar(&keyFile, "key-file", "", "Path to the key used to encrypt kubeconfig data at rest (default <data-dir>/cluster.key)")
+	fs.StringVar(&transport, "transport", "", "MCP transport: stdio (default), http (streamable HTTP on /mcp) or sse (HTTP+SSE on /sse)")
+	fs.StringVar(&listenAddr, "listen", "", "Listen address for the http and sse transports (default 127.0.0.1:8080)")
+	fs.BoolVar(&allowUnauthenticated, "allow-unauthenticated", false, "Serve http and sse on a non-loopback address without transport.auth_token")
+	fs.StringVar(&logLevel, "log-level", "", "Log level: debug, info (default), warn or error")
+	fs.BoolVar(&readOnly, "read-only", false, "Hide every tool that changes cluster or server state")
+	if err := fs.Parse(args); err != nil {
+		return config.Config{}, err
+	}
+
+	cfg, err := config.Load(configPath)
+	if err != nil {
+		return cfg, err
+	}
+	fs.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "data-dir":
+			cfg.DataDir = dataDir
+		case "key-file":
+			cfg.KeyFile = keyFile
+		case "transport":
+			cfg.Transport.Type = transport
+		case "listen":
+			cfg.Transport.Listen = listenAddr
+		case "allow-unauthenticated":
+			cfg.Transport.AllowUnauthenticated = allowUnauthenticated
+		case "log-level":
+			cfg.LogLevel = logLevel
+		case "read-only":
+			cfg.Safety.ReadOnly = readOnly
+		}
+	})
+	return cfg, nil
+}
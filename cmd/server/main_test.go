@@ -0,0 +1,103 @@
+package main
+
+import (
+	"flag"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/your-org/mcp-k8s-server/internal/config"
+)
+
+// TestLoadConfigPrecedence checks the order defaults < config file <
+// MCP_K8S_* env < flags, and that flags left unset do not override.
+func TestLoadConfigPrecedence(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	data := "log_level: warn\ndata_dir: /from/file\ntransport:\n  type: http\n  listen: 127.0.0.1:9000\nsafety:\n  read_only: true\n"
+	if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv(config.EnvPrefix+"LOG_LEVEL", "error")
+	t.Setenv(config.EnvPrefix+"LISTEN", "127.0.0.1:9100")
+	t.Setenv(config.EnvPrefix+"READ_ONLY", "true")
+
+	for _, tc := range []struct {
+		name string
+		args []string
+		want func(c *config.Config)
+	}{
+		{
+			name: "without flags",
+			args: []string{"-config", path},
+			want: func(c *config.Config) {},
+		},
+		{
+			name: "flags",
+			args: []string{"-config", path, "-log-level", "debug", "-listen", "127.0.0.1:9200", "-read-only=false", "-data-dir", "/from/flag"},
+			want: func(c *config.Config) {
+				c.LogLevel = "debug"
+				c.Transport.Listen = "127.0.0.1:9200"
+				c.Safety.ReadOnly = false
+				c.DataDir = "/from/flag"
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			fs := flag.NewFlagSet("server", flag.ContinueOnError)
+			fs.SetOutput(io.Discard)
+			cfg, err := loadConfig(fs, tc.args)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// Defaults, then the file, then the environment.
+			want := config.Default()
+			want.DataDir = "/from/file"
+			want.Transport.Type = "http"
+			want.LogLevel = "error"
+			want.Transport.Listen = "127.0.0.1:9100"
+			want.Safety.ReadOnly = true
+			tc.want(&want)
+
+			for _, f := range []struct {
+				name      string
+				got, want any
+			}{
+				{"log_level", cfg.LogLevel, want.LogLevel},
+				{"data_dir", cfg.DataDir, want.DataDir},
+				{"transport.type", cfg.Transport.Type, want.Transport.Type},
+				{"transport.listen", cfg.Transport.Listen, want.Transport.Listen},
+				{"safety.read_only", cfg.Safety.ReadOnly, want.Safety.ReadOnly},
+				{"default_namespace", cfg.DefaultNamespace, want.DefaultNamespace},
+			} {
+				if f.got != f.want {
+					t.Errorf("%s = %v, want %v", f.name, f.got, f.want)
+				}
+			}
+		})
+	}
+}
+
+func TestLoadConfigErrors(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		args []string
+		env  string
+	}{
+		{name: "unknown flag", args: []string{"-listen-addr", ":8080"}},
+		{name: "missing config file", args: []string{"-config", filepath.Join(t.TempDir(), "missing.yaml")}},
+		{name: "bad environment", env: "maybe"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.env != "" {
+				t.Setenv(config.EnvPrefix+"READ_ONLY", tc.env)
+			}
+			fs := flag.NewFlagSet("server", flag.ContinueOnError)
+			fs.SetOutput(io.Discard)
+			if _, err := loadConfig(fs, tc.args); err == nil {
+				t.Error("loadConfig() = nil error")
+			}
+		})
+	}
+}
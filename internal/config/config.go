@@ -0,0 +1,443 @@
+// Package config loads the server configuration from a YAML or JSON file and
+// MCP_K8S_* environment variables.
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/your-org/mcp-k8s-server/internal/domain"
+	"github.com/your-org/mcp-k8s-server/internal/infrastructure"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/yaml"
+)
+
+// EnvPrefix prefixes every environment variable override.
+const EnvPrefix = "MCP_K8S_"
+
+type Config struct {
+	// DataDir stores the persisted cluster registry.
+	DataDir string `json:"data_dir,omitempty"`
+	// KeyFile holds the key that encrypts kubeconfig data at rest. Defaults
+	// to <data_dir>/cluster.key.
+	KeyFile  string `json:"key_file,omitempty"`
+	LogLevel string `json:"log_level,omitempty"`
+
+	// DefaultCluster is used when a tool call omits cluster_id.
+	DefaultCluster string `json:"default_cluster,omitempty"`
+	// DefaultNamespace is used when a tool call omits namespace.
+	DefaultNamespace string `json:"default_namespace,omitempty"`
+
+	Transport TransportConfig `json:"transport"`
+
+	// Clusters are registered on startup. They are not written to the
+	// persisted registry; the config file stays their source of truth.
+	Clusters []ClusterConfig `json:"clusters,omitempty"`
+	// Kubeconfig is a shorthand for a single cluster with ID "default".
+	Kubeconfig string `json:"kubeconfig,omitempty"`
+
+	Tools  ToolsConfig  `json:"tools"`
+	Safety SafetyConfig `json:"safety"`
+	Audit  AuditConfig  `json:"audit"`
+
+	PortForward PortForwardConfig `json:"port_forward"`
+	Exec        ExecConfig        `json:"exec"`
+	LogExport   LogExportConfig   `json:"log_export"`
+	Manifests   ManifestsConfig   `json:"manifests"`
+	Prompts     PromptsConfig     `json:"prompts"`
+}
+
+type TransportConfig struct {
+	// Type is stdio, http or sse.
+	Type   string `json:"type,omitempty"`
+	Listen string `json:"listen,omitempty"`
+	// AuthToken is the bearer token HTTP clients must send.
+	AuthToken string `json:"auth_token,omitempty"`
+	// AllowUnauthenticated permits a non-loopback Listen address without
+	// AuthToken.
+	AllowUnauthenticated bool `json:"allow_unauthenticated,omitempty"`
+}
+
+type ClusterConfig struct {
+	ID             string `json:"id"`
+	KubeconfigPath string `json:"kubeconfig_path,omitempty"`
+	Context        string `json:"context,omitempty"`
+	InCluster      bool   `json:"in_cluster,omitempty"`
+}
+
+// ToolsConfig selects tool groups. A tool's group is the resource segment of
+// its name, e.g. "pod" for k8s_pod_list. An empty EnabledGroups enables all.
+type ToolsConfig struct {
+	EnabledGroups  []string `json:"enabled_groups,omitempty"`
+	DisabledGroups []string `json:"disabled_groups,omitempty"`
+}
+
+type SafetyConfig struct {
+	// ReadOnly hides every tool that changes cluster or server state.
+	ReadOnly bool `json:"read_only,omitempty"`
+	// AllowTools, when set, only exposes tools matching one of the globs.
+	AllowTools []string `json:"allow_tools,omitempty"`
+	// DenyTools hides tools matching any of the globs.
+	DenyTools []string `json:"deny_tools,omitempty"`
+	// Rules allow or deny calls per cluster, namespace and tool; the first
+	// matching rule wins.
+	Rules []domain.ToolPolicyRule `json:"rules,omitempty"`
+	// ConfirmDestructive requires user confirmation, with an impact preview,
+	// before deletions and NoExecute taints.
+	ConfirmDestructive bool `json:"confirm_destructive"`
+}
+
+// AuditConfig controls the JSON lines audit log of tool invocations.
+type AuditConfig struct {
+	Enabled bool `json:"enabled"`
+	// Path defaults to <data_dir>/audit.log.
+	Path string `json:"path,omitempty"`
+	// MaxSizeMB rotates the file once it reaches this size.
+	MaxSizeMB int `json:"max_size_mb,omitempty"`
+	// MaxBackups is the number of rotated files kept.
+	MaxBackups int `json:"max_backups,omitempty"`
+}
+
+// PortForwardConfig limits how long port-forward tunnels stay open. Zero
+// disables a limit.
+type PortForwardConfig struct {
+	// IdleTimeoutMinutes closes tunnels without traffic for this long.
+	IdleTimeoutMinutes int `json:"idle_timeout_minutes"`
+	// MaxLifetimeMinutes closes tunnels this long after they started; it
+	// also caps the ttl_seconds a caller asks for.
+	MaxLifetimeMinutes int `json:"max_lifetime_minutes"`
+}
+
+// ExecConfig restricts k8s_pod_exec. Commands are matched as their arguments
+// joined by spaces against globs in which "*" matches any text.
+type ExecConfig struct {
+	// AllowCommands lists the permitted commands; an empty list disables
+	// exec.
+	AllowCommands []string `json:"allow_commands"`
+	// DenyCommands is checked first and overrides AllowCommands.
+	DenyCommands []string `json:"deny_commands"`
+	// MaxOutputKB caps stdout and stderr each.
+	MaxOutputKB int `json:"max_output_kb"`
+	// MaxTimeoutSeconds caps the timeout a caller may ask for.
+	MaxTimeoutSeconds int `json:"max_timeout_seconds"`
+}
+
+// LogExportConfig controls where k8s_logs_export writes archives and how
+// long they are kept. Zero disables a limit.
+type LogExportConfig struct {
+	// Dir defaults to <data_dir>/exports.
+	Dir string `json:"dir,omitempty"`
+	// TTLMinutes deletes archives this long after they were written.
+	TTLMinutes int `json:"ttl_minutes"`
+	// MaxArchives deletes the oldest archives beyond this count.
+	MaxArchives int `json:"max_archives"`
+}
+
+// ManifestsConfig controls where k8s_apply_yaml and k8s_diff_yaml read
+// manifest files from.
+type ManifestsConfig struct {
+	// Root is the directory the path argument is resolved under; files
+	// outside it are rejected. Empty disables path.
+	Root string `json:"root,omitempty"`
+}
+
+// PromptsConfig adds custom MCP prompts to the built-in ones.
+type PromptsConfig struct {
+	// Dir holds one prompt per *.yaml or *.yml file. Empty offers only the
+	// built-in prompts.
+	Dir string `json:"dir,omitempty"`
+}
+
+// Default returns the configuration used when no file is given.
+func Default() Config {
+	return Config{
+		DataDir:          "./data",
+		LogLevel:         "info",
+		DefaultNamespace: "default",
+		Safety: SafetyConfig{
+			ConfirmDestructive: true,
+		},
+		Audit: AuditConfig{
+			Enabled:    true,
+			MaxSizeMB:  100,
+			MaxBackups: 5,
+		},
+		PortForward: PortForwardConfig{
+			IdleTimeoutMinutes: 30,
+			MaxLifetimeMinutes: 240,
+		},
+		LogExport: LogExportConfig{
+			TTLMinutes:  60,
+			MaxArchives: 20,
+		},
+		Exec: ExecConfig{
+			AllowCommands: []string{
+				"cat *", "head *", "tail *", "ls", "ls *",
+				"id", "whoami", "hostname", "date", "uptime",
+				"ps", "ps *", "df", "df *", "du *", "free", "free *", "mount",
+				"nslookup *", "dig *", "getent *", "ip addr", "ip route", "ss *", "netstat *",
+			},
+			// Service account tokens and mounted secrets, and shells, whose
+			// scripts can reach any path.
+			DenyCommands: []string{
+				"*/run/secrets/*",
+				"sh *", "bash *", "ash *", "dash *", "zsh *", "ksh *", "mksh *",
+			},
+			MaxOutputKB:       64,
+			MaxTimeoutSeconds: 120,
+		},
+		Transport: TransportConfig{
+			Type:   "stdio",
+			Listen: "127.0.0.1:8080",
+		},
+	}
+}
+
+// Load reads the file at path (if any) over the defaults and then applies
+// environment variable overrides. The result is not validated.
+func Load(path string) (Config, error) {
+	cfg := Default()
+
+	if path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return cfg, fmt.Errorf("failed to read config file: %w", err)
+		}
+		if err := yaml.UnmarshalStrict(data, &cfg); err != nil {
+			return cfg, fmt.Errorf("failed to parse config file %s: %w", path, err)
+		}
+	}
+
+	if err := cfg.applyEnv(os.LookupEnv); err != nil {
+		return cfg, err
+	}
+
+	if cfg.Kubeconfig != "" {
+		cfg.Clusters = append(cfg.Clusters, ClusterConfig{ID: "default", KubeconfigPath: cfg.Kubeconfig})
+		cfg.Kubeconfig = ""
+	}
+	for i := range cfg.Clusters {
+		cfg.Clusters[i].KubeconfigPath = expandHome(cfg.Clusters[i].KubeconfigPath)
+	}
+
+	return cfg, nil
+}
+
+// applyEnv overrides scalar settings from MCP_K8S_* variables. List settings
+// are comma separated.
+func (c *Config) applyEnv(lookup func(string) (string, bool)) error {
+	strs := map[string]*string{
+		"DATA_DIR":          &c.DataDir,
+		"KEY_FILE":          &c.KeyFile,
+		"LOG_LEVEL":         &c.LogLevel,
+		"DEFAULT_CLUSTER":   &c.DefaultCluster,
+		"DEFAULT_NAMESPACE": &c.DefaultNamespace,
+		"TRANSPORT":         &c.Transport.Type,
+		"LISTEN":            &c.Transport.Listen,
+		"AUTH_TOKEN":        &c.Transport.AuthToken,
+		"KUBECONFIG":        &c.Kubeconfig,
+		"AUDIT_PATH":        &c.Audit.Path,
+		"LOG_EXPORT_DIR":    &c.LogExport.Dir,
+		"MANIFEST_ROOT":     &c.Manifests.Root,
+		"PROMPTS_DIR":       &c.Prompts.Dir,
+	}
+	for name, dst := range strs {
+		if v, ok := lookup(EnvPrefix + name); ok {
+			*dst = v
+		}
+	}
+
+	lists := map[string]*[]string{
+		"ENABLED_TOOL_GROUPS":  &c.Tools.EnabledGroups,
+		"DISABLED_TOOL_GROUPS": &c.Tools.DisabledGroups,
+		"ALLOW_TOOLS":          &c.Safety.AllowTools,
+		"DENY_TOOLS":           &c.Safety.DenyTools,
+		"EXEC_ALLOW_COMMANDS":  &c.Exec.AllowCommands,
+		"EXEC_DENY_COMMANDS":   &c.Exec.DenyCommands,
+	}
+	for name, dst := range lists {
+		if v, ok := lookup(EnvPrefix + name); ok {
+			*dst = splitList(v)
+		}
+	}
+
+	ints := map[string]*int{
+		"AUDIT_MAX_SIZE_MB":                 &c.Audit.MaxSizeMB,
+		"AUDIT_MAX_BACKUPS":                 &c.Audit.MaxBackups,
+		"PORT_FORWARD_IDLE_TIMEOUT_MINUTES": &c.PortForward.IdleTimeoutMinutes,
+		"PORT_FORWARD_MAX_LIFETIME_MINUTES": &c.PortForward.MaxLifetimeMinutes,
+		"EXEC_MAX_OUTPUT_KB":                &c.Exec.MaxOutputKB,
+		"EXEC_MAX_TIMEOUT_SECONDS":          &c.Exec.MaxTimeoutSeconds,
+		"LOG_EXPORT_TTL_MINUTES":            &c.LogExport.TTLMinutes,
+		"LOG_EXPORT_MAX_ARCHIVES":           &c.LogExport.MaxArchives,
+	}
+	for name, dst := range ints {
+		if v, ok := lookup(EnvPrefix + name); ok {
+			n, err := strconv.Atoi(v)
+			if err != nil {
+				return fmt.Errorf("invalid %s%s: %w", EnvPrefix, name, err)
+			}
+			*dst = n
+		}
+	}
+
+	bools := map[string]*bool{
+		"READ_ONLY":             &c.Safety.ReadOnly,
+		"ALLOW_UNAUTHENTICATED": &c.Transport.AllowUnauthenticated,
+		"CONFIRM_DESTRUCTIVE":   &c.Safety.ConfirmDestructive,
+		"AUDIT_ENABLED":         &c.Audit.Enabled,
+	}
+	for name, dst := range bools {
+		if v, ok := lookup(EnvPrefix + name); ok {
+			b, err := strconv.ParseBool(v)
+			if err != nil {
+				return fmt.Errorf("invalid %s%s: %w", EnvPrefix, name, err)
+			}
+			*dst = b
+		}
+	}
+
+	// KUBECONFIG_PATH is the variable documented for desktop clients.
+	if v, ok := lookup("KUBECONFIG_PATH"); ok && c.Kubeconfig == "" && len(c.Clusters) == 0 {
+		c.Kubeconfig = v
+	}
+	return nil
+}
+
+// Validate checks the settings that can be verified without contacting a
+// cluster and returns all problems at once.
+func (c Config) Validate() error {
+	var errs []error
+
+	if _, err := infrastructure.ParseLogLevel(c.LogLevel); err != nil {
+		errs = append(errs, fmt.Errorf("log_level: %w", err))
+	}
+
+	switch c.Transport.Type {
+	case "stdio":
+	case "http", "sse":
+		if c.Transport.Listen == "" {
+			errs = append(errs, fmt.Errorf("transport.listen is required for %s transport", c.Transport.Type))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("transport.type: unknown transport %q (want stdio, http or sse)", c.Transport.Type))
+	}
+
+	if c.DataDir == "" {
+		errs = append(errs, errors.New("data_dir must not be empty"))
+	}
+
+	if c.DefaultNamespace != "" {
+		for _, msg := range validation.IsDNS1123Label(c.DefaultNamespace) {
+			errs = append(errs, fmt.Errorf("default_namespace %q: %s", c.DefaultNamespace, msg))
+		}
+	}
+
+	seen := make(map[string]bool, len(c.Clusters))
+	for i, cl := range c.Clusters {
+		field := fmt.Sprintf("clusters[%d]", i)
+		if cl.ID == "" {
+			errs = append(errs, fmt.Errorf("%s.id is required", field))
+		} else if seen[cl.ID] {
+			errs = append(errs, fmt.Errorf("%s.id %q is declared more than once", field, cl.ID))
+		}
+		seen[cl.ID] = true
+
+		switch {
+		case cl.InCluster && cl.KubeconfigPath != "":
+			errs = append(errs, fmt.Errorf("%s: kubeconfig_path and in_cluster are mutually exclusive", field))
+		case !cl.InCluster && cl.KubeconfigPath == "":
+			errs = append(errs, fmt.Errorf("%s: kubeconfig_path or in_cluster is required", field))
+		case cl.KubeconfigPath != "":
+			if _, err := os.Stat(cl.KubeconfigPath); err != nil {
+				errs = append(errs, fmt.Errorf("%s.kubeconfig_path: %w", field, err))
+			}
+		}
+	}
+
+	for _, g := range c.Tools.EnabledGroups {
+		for _, d := range c.Tools.DisabledGroups {
+			if g == d {
+				errs = append(errs, fmt.Errorf("tools: group %q is both enabled and disabled", g))
+			}
+		}
+	}
+
+	for _, list := range []struct {
+		field    string
+		patterns []string
+	}{
+		{"safety.allow_tools", c.Safety.AllowTools},
+		{"safety.deny_tools", c.Safety.DenyTools},
+	} {
+		for _, p := range list.patterns {
+			if _, err := path.Match(p, ""); err != nil {
+				errs = append(errs, fmt.Errorf("%s: invalid glob %q: %w", list.field, p, err))
+			}
+		}
+	}
+
+	if err := domain.ValidatePolicyRules(c.Safety.Rules); err != nil {
+		errs = append(errs, fmt.Errorf("safety.%w", err))
+	}
+
+	if c.Audit.MaxSizeMB < 0 {
+		errs = append(errs, errors.New("audit.max_size_mb must not be negative"))
+	}
+	if c.Audit.MaxBackups < 0 {
+		errs = append(errs, errors.New("audit.max_backups must not be negative"))
+	}
+	if c.PortForward.IdleTimeoutMinutes < 0 {
+		errs = append(errs, errors.New("port_forward.idle_timeout_minutes must not be negative"))
+	}
+	if c.PortForward.MaxLifetimeMinutes < 0 {
+		errs = append(errs, errors.New("port_forward.max_lifetime_minutes must not be negative"))
+	}
+	if c.Exec.MaxOutputKB < 0 {
+		errs = append(errs, errors.New("exec.max_output_kb must not be negative"))
+	}
+	if c.Exec.MaxTimeoutSeconds < 0 {
+		errs = append(errs, errors.New("exec.max_timeout_seconds must not be negative"))
+	}
+	if c.LogExport.TTLMinutes < 0 {
+		errs = append(errs, errors.New("log_export.ttl_minutes must not be negative"))
+	}
+	if c.LogExport.MaxArchives < 0 {
+		errs = append(errs, errors.New("log_export.max_archives must not be negative"))
+	}
+	if c.Manifests.Root != "" {
+		if info, err := os.Stat(c.Manifests.Root); err != nil {
+			errs = append(errs, fmt.Errorf("manifests.root: %w", err))
+		} else if !info.IsDir() {
+			errs = append(errs, fmt.Errorf("manifests.root: %s is not a directory", c.Manifests.Root))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func splitList(s string) []string {
+	var out []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			out = append(out, item)
+		}
+	}
+	return out
+}
+
+func expandHome(p string) string {
+	if p != "~" && !strings.HasPrefix(p, "~/") {
+		return p
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return p
+	}
+	return filepath.Join(home, strings.TrimPrefix(p, "~"))
+}
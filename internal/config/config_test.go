@@ -0,0 +1,389 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/your-org/mcp-k8s-server/internal/domain"
+)
+
+func TestLoad(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		file    string
+		data    string
+		check   func(t *testing.T, cfg Config)
+		wantErr string
+	}{
+		{
+			name: "yaml",
+			file: "config.yaml",
+			data: "log_level: debug\ntransport:\n  type: http\nsafety:\n  deny_tools: [k8s_*_delete]\n  confirm_destructive: false\nexec:\n  max_timeout_seconds: 30\n",
+			check: func(t *testing.T, cfg Config) {
+				if cfg.LogLevel != "debug" || cfg.Transport.Type != "http" {
+					t.Errorf("log_level, transport.type = %q, %q, want debug, http", cfg.LogLevel, cfg.Transport.Type)
+				}
+				if !reflect.DeepEqual(cfg.Safety.DenyTools, []string{"k8s_*_delete"}) {
+					t.Errorf("safety.deny_tools = %v", cfg.Safety.DenyTools)
+				}
+				if cfg.Safety.ConfirmDestructive {
+					t.Error("safety.confirm_destructive = true, want false from the file")
+				}
+				if cfg.Exec.MaxTimeoutSeconds != 30 || cfg.Exec.MaxOutputKB != 64 {
+					t.Errorf("exec = %+v, want max_timeout_seconds from the file and the default max_output_kb", cfg.Exec)
+				}
+				if cfg.Transport.Listen != "127.0.0.1:8080" {
+					t.Errorf("transport.listen = %q, want the default", cfg.Transport.Listen)
+				}
+			},
+		},
+		{
+			name: "json",
+			file: "config.json",
+			data: `{"data_dir": "/var/lib/mcp", "clusters": [{"id": "prod", "in_cluster": true}], "audit": {"enabled": false}}`,
+			check: func(t *testing.T, cfg Config) {
+				if cfg.DataDir != "/var/lib/mcp" {
+					t.Errorf("data_dir = %q", cfg.DataDir)
+				}
+				if want := []ClusterConfig{{ID: "prod", InCluster: true}}; !reflect.DeepEqual(cfg.Clusters, want) {
+					t.Errorf("clusters = %+v, want %+v", cfg.Clusters, want)
+				}
+				if cfg.Audit.Enabled || cfg.Audit.MaxSizeMB != 100 {
+					t.Errorf("audit = %+v, want disabled with the default max_size_mb", cfg.Audit)
+				}
+			},
+		},
+		{
+			name: "kubeconfig shorthand",
+			file: "config.yaml",
+			data: "kubeconfig: ~/.kube/config\n",
+			check: func(t *testing.T, cfg Config) {
+				home, _ := os.UserHomeDir()
+				want := []ClusterConfig{{ID: "default", KubeconfigPath: filepath.Join(home, ".kube", "config")}}
+				if cfg.Kubeconfig != "" || !reflect.DeepEqual(cfg.Clusters, want) {
+					t.Errorf("kubeconfig, clusters = %q, %+v, want \"\", %+v", cfg.Kubeconfig, cfg.Clusters, want)
+				}
+			},
+		},
+		{
+			name:    "unknown key",
+			file:    "config.yaml",
+			data:    "log_level: debug\nlog_levle: info\n",
+			wantErr: `unknown field "log_levle"`,
+		},
+		{
+			name:    "unknown nested key",
+			file:    "config.json",
+			data:    `{"transport": {"type": "http", "port": 8080}}`,
+			wantErr: `unknown field "port"`,
+		},
+		{
+			name:    "malformed",
+			file:    "config.yaml",
+			data:    "transport: [\n",
+			wantErr: "failed to parse config file",
+		},
+		{
+			name:    "missing file",
+			wantErr: "failed to read config file",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), tc.file)
+			if tc.data != "" {
+				if err := os.WriteFile(path, []byte(tc.data), 0o600); err != nil {
+					t.Fatal(err)
+				}
+			} else {
+				path = filepath.Join(path, "missing.yaml")
+			}
+			cfg, err := Load(path)
+			if tc.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Fatalf("err = %v, want %q", err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			tc.check(t, cfg)
+		})
+	}
+}
+
+// TestLoadPrecedence checks that environment variables override the file,
+// which overrides the defaults.
+func TestLoadPrecedence(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte("log_level: warn\ndefault_namespace: apps\nsafety:\n  read_only: true\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv(EnvPrefix+"LOG_LEVEL", "debug")
+	t.Setenv(EnvPrefix+"READ_ONLY", "false")
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.LogLevel != "debug" {
+		t.Errorf("log_level = %q, want debug from the environment", cfg.LogLevel)
+	}
+	if cfg.Safety.ReadOnly {
+		t.Error("safety.read_only = true, want false from the environment")
+	}
+	if cfg.DefaultNamespace != "apps" {
+		t.Errorf("default_namespace = %q, want apps from the file", cfg.DefaultNamespace)
+	}
+	if cfg.DataDir != "./data" {
+		t.Errorf("data_dir = %q, want the default", cfg.DataDir)
+	}
+}
+
+func TestApplyEnv(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		env     map[string]string
+		base    func(c *Config)
+		check   func(t *testing.T, cfg Config)
+		wantErr string
+	}{
+		{
+			name: "strings",
+			env:  map[string]string{"MCP_K8S_TRANSPORT": "sse", "MCP_K8S_MANIFEST_ROOT": "/srv/manifests", "MCP_K8S_AUTH_TOKEN": ""},
+			base: func(c *Config) { c.Transport.AuthToken = "from-file" },
+			check: func(t *testing.T, cfg Config) {
+				if cfg.Transport.Type != "sse" || cfg.Manifests.Root != "/srv/manifests" {
+					t.Errorf("transport.type, manifests.root = %q, %q", cfg.Transport.Type, cfg.Manifests.Root)
+				}
+				if cfg.Transport.AuthToken != "" {
+					t.Errorf("transport.auth_token = %q, want an empty variable to clear it", cfg.Transport.AuthToken)
+				}
+			},
+		},
+		{
+			name: "lists",
+			env:  map[string]string{"MCP_K8S_DENY_TOOLS": "k8s_*_delete, k8s_pod_exec,,", "MCP_K8S_EXEC_ALLOW_COMMANDS": ""},
+			check: func(t *testing.T, cfg Config) {
+				if want := []string{"k8s_*_delete", "k8s_pod_exec"}; !reflect.DeepEqual(cfg.Safety.DenyTools, want) {
+					t.Errorf("safety.deny_tools = %q, want %q", cfg.Safety.DenyTools, want)
+				}
+				if len(cfg.Exec.AllowCommands) != 0 {
+					t.Errorf("exec.allow_commands = %q, want an empty variable to clear it", cfg.Exec.AllowCommands)
+				}
+				if len(cfg.Exec.DenyCommands) == 0 {
+					t.Error("exec.deny_commands is empty, want the default")
+				}
+			},
+		},
+		{
+			name: "bools",
+			env:  map[string]string{"MCP_K8S_READ_ONLY": "1", "MCP_K8S_CONFIRM_DESTRUCTIVE": "false", "MCP_K8S_AUDIT_ENABLED": "FALSE"},
+			check: func(t *testing.T, cfg Config) {
+				if !cfg.Safety.ReadOnly || cfg.Safety.ConfirmDestructive || cfg.Audit.Enabled {
+					t.Errorf("read_only, confirm_destructive, audit.enabled = %v, %v, %v, want true, false, false", cfg.Safety.ReadOnly, cfg.Safety.ConfirmDestructive, cfg.Audit.Enabled)
+				}
+			},
+		},
+		{
+			name: "ints",
+			env:  map[string]string{"MCP_K8S_EXEC_MAX_TIMEOUT_SECONDS": "30", "MCP_K8S_LOG_EXPORT_MAX_ARCHIVES": "0", "MCP_K8S_AUDIT_MAX_SIZE_MB": "-1"},
+			check: func(t *testing.T, cfg Config) {
+				if cfg.Exec.MaxTimeoutSeconds != 30 || cfg.LogExport.MaxArchives != 0 || cfg.Audit.MaxSizeMB != -1 {
+					t.Errorf("exec.max_timeout_seconds, log_export.max_archives, audit.max_size_mb = %d, %d, %d, want 30, 0, -1", cfg.Exec.MaxTimeoutSeconds, cfg.LogExport.MaxArchives, cfg.Audit.MaxSizeMB)
+				}
+				if cfg.Exec.MaxOutputKB != 64 {
+					t.Errorf("exec.max_output_kb = %d, want the default", cfg.Exec.MaxOutputKB)
+				}
+			},
+		},
+		{
+			name: "kubeconfig path",
+			env:  map[string]string{"KUBECONFIG_PATH": "/etc/kube/config"},
+			check: func(t *testing.T, cfg Config) {
+				if cfg.Kubeconfig != "/etc/kube/config" {
+					t.Errorf("kubeconfig = %q, want KUBECONFIG_PATH", cfg.Kubeconfig)
+				}
+			},
+		},
+		{
+			name: "kubeconfig path with clusters",
+			env:  map[string]string{"KUBECONFIG_PATH": "/etc/kube/config"},
+			base: func(c *Config) { c.Clusters = []ClusterConfig{{ID: "prod", InCluster: true}} },
+			check: func(t *testing.T, cfg Config) {
+				if cfg.Kubeconfig != "" {
+					t.Errorf("kubeconfig = %q, want KUBECONFIG_PATH ignored", cfg.Kubeconfig)
+				}
+			},
+		},
+		{
+			name:    "bad bool",
+			env:     map[string]string{"MCP_K8S_READ_ONLY": "yes"},
+			wantErr: "invalid MCP_K8S_READ_ONLY",
+		},
+		{
+			name:    "bad int",
+			env:     map[string]string{"MCP_K8S_EXEC_MAX_OUTPUT_KB": "64k"},
+			wantErr: "invalid MCP_K8S_EXEC_MAX_OUTPUT_KB",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := Default()
+			if tc.base != nil {
+				tc.base(&cfg)
+			}
+			err := cfg.applyEnv(func(name string) (string, bool) {
+				v, ok := tc.env[name]
+				return v, ok
+			})
+			if tc.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Fatalf("err = %v, want %q", err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			tc.check(t, cfg)
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	dir := t.TempDir()
+	kubeconfig := filepath.Join(dir, "kubeconfig")
+	if err := os.WriteFile(kubeconfig, nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range []struct {
+		name    string
+		modify  func(c *Config)
+		wantErr []string
+	}{
+		{name: "defaults", modify: func(c *Config) {}},
+		{
+			name: "valid",
+			modify: func(c *Config) {
+				c.Transport.Type = "http"
+				c.Clusters = []ClusterConfig{{ID: "a", KubeconfigPath: kubeconfig}, {ID: "b", InCluster: true}}
+				c.Tools.EnabledGroups = []string{"pod"}
+				c.Safety.DenyTools = []string{"k8s_*_delete"}
+				c.Safety.Rules = []domain.ToolPolicyRule{{Name: "prod", Effect: domain.PolicyEffectDeny, Clusters: []string{"prod"}}}
+				c.Manifests.Root = dir
+			},
+		},
+		{
+			name:    "log level",
+			modify:  func(c *Config) { c.LogLevel = "verbose" },
+			wantErr: []string{"log_level:"},
+		},
+		{
+			name:    "transport",
+			modify:  func(c *Config) { c.Transport.Type = "grpc" },
+			wantErr: []string{`unknown transport "grpc"`},
+		},
+		{
+			name:    "listen",
+			modify:  func(c *Config) { c.Transport.Type = "sse"; c.Transport.Listen = "" },
+			wantErr: []string{"transport.listen is required for sse transport"},
+		},
+		{
+			name:    "data dir",
+			modify:  func(c *Config) { c.DataDir = "" },
+			wantErr: []string{"data_dir must not be empty"},
+		},
+		{
+			name:    "default namespace",
+			modify:  func(c *Config) { c.DefaultNamespace = "Apps" },
+			wantErr: []string{`default_namespace "Apps"`},
+		},
+		{
+			name: "clusters",
+			modify: func(c *Config) {
+				c.Clusters = []ClusterConfig{
+					{InCluster: true},
+					{ID: "a", InCluster: true},
+					{ID: "a", InCluster: true, KubeconfigPath: kubeconfig},
+					{ID: "b"},
+					{ID: "c", KubeconfigPath: filepath.Join(dir, "missing")},
+				}
+			},
+			wantErr: []string{
+				"clusters[0].id is required",
+				`clusters[2].id "a" is declared more than once`,
+				"clusters[2]: kubeconfig_path and in_cluster are mutually exclusive",
+				"clusters[3]: kubeconfig_path or in_cluster is required",
+				"clusters[4].kubeconfig_path:",
+			},
+		},
+		{
+			name:    "tool groups",
+			modify:  func(c *Config) { c.Tools.EnabledGroups = []string{"pod"}; c.Tools.DisabledGroups = []string{"pod"} },
+			wantErr: []string{`group "pod" is both enabled and disabled`},
+		},
+		{
+			name:    "tool globs",
+			modify:  func(c *Config) { c.Safety.AllowTools = []string{"k8s_["} },
+			wantErr: []string{`safety.allow_tools: invalid glob "k8s_["`},
+		},
+		{
+			name:    "rules",
+			modify:  func(c *Config) { c.Safety.Rules = []domain.ToolPolicyRule{{Name: "r", Effect: "maybe"}} },
+			wantErr: []string{"safety."},
+		},
+		{
+			name: "negative limits",
+			modify: func(c *Config) {
+				c.Audit.MaxSizeMB = -1
+				c.Audit.MaxBackups = -1
+				c.PortForward.IdleTimeoutMinutes = -1
+				c.PortForward.MaxLifetimeMinutes = -1
+				c.Exec.MaxOutputKB = -1
+				c.Exec.MaxTimeoutSeconds = -1
+				c.LogExport.TTLMinutes = -1
+				c.LogExport.MaxArchives = -1
+			},
+			wantErr: []string{
+				"audit.max_size_mb", "audit.max_backups",
+				"port_forward.idle_timeout_minutes", "port_forward.max_lifetime_minutes",
+				"exec.max_output_kb", "exec.max_timeout_seconds",
+				"log_export.ttl_minutes", "log_export.max_archives",
+			},
+		},
+		{
+			name:    "manifest root missing",
+			modify:  func(c *Config) { c.Manifests.Root = filepath.Join(dir, "missing") },
+			wantErr: []string{"manifests.root:"},
+		},
+		{
+			name:    "manifest root is a file",
+			modify:  func(c *Config) { c.Manifests.Root = kubeconfig },
+			wantErr: []string{"is not a directory"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := Default()
+			tc.modify(&cfg)
+			err := cfg.Validate()
+			if len(tc.wantErr) == 0 {
+				if err != nil {
+					t.Fatalf("Validate() = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("Validate() = nil, want %q", tc.wantErr)
+			}
+			for _, want := range tc.wantErr {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("Validate() = %v, want it to contain %q", err, want)
+				}
+			}
+		})
+	}
+}
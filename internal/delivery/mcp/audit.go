@@ -0,0 +1,330 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/your-org/mcp-k8s-server/internal/domain"
+)
+
+const (
+	defaultAuditQueryLimit = 50
+	maxAuditQueryLimit     = 1000
+	// maxAuditErrorLen bounds the error message stored per entry.
+	maxAuditErrorLen = 1024
+)
+
+// auditedKinds are the kinds whose resourceVersion is recorded before and
+// after mutating calls. The object name is passed as <kind>_name, except for
+// namespaces.
+var auditedKinds = []string{
+	"namespace", "node", "pod", "deployment", "statefulset", "daemonset",
+	"job", "cronjob", "configmap", "secret", "service", "ingress", "hpa",
+}
+
+// auditMiddleware records every tool call, including calls rejected by the
+// policy or waiting for confirmation, in the audit log.
+func (m *MCPServer) auditMiddleware() mcp.Middleware {
+	return func(next mcp.MethodHandler) mcp.MethodHandler {
+		return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+			if method != "tools/call" {
+				return next(ctx, method, req)
+			}
+			params, ok := req.GetParams().(*mcp.CallToolParamsRaw)
+			if !ok {
+				return next(ctx, method, req)
+			}
+
+			var args map[string]any
+			_ = json.Unmarshal(params.Arguments, &args)
+
+			entry := domain.AuditEntry{
+				Time:      time.Now().UTC(),
+				Tool:      params.Name,
+				Arguments: args,
+				Mutating:  !isReadOnlyTool(params.Name),
+			}
+			entry.ClusterID, _ = args["cluster_id"].(string)
+			entry.Namespace, _ = args["namespace"].(string)
+			if session, ok := req.GetSession().(*mcp.ServerSession); ok {
+				entry.SessionID = session.ID()
+				entry.Client = clientIdentity(session)
+			}
+
+			var targets []domain.AuditObject
+			if entry.Mutating {
+				targets = auditTargets(params.Name, args)
+				for i := range targets {
+					targets[i].ResourceVersionBefore = m.resourceVersion(ctx, entry.ClusterID, &targets[i])
+				}
+			}
+
+			start := time.Now()
+			res, err := next(ctx, method, req)
+			entry.DurationMS = time.Since(start).Milliseconds()
+			entry.Status, entry.Error = auditOutcome(res, err)
+
+			if entry.Status == domain.AuditStatusSuccess || entry.Status == domain.AuditStatusError {
+				for i := range targets {
+					targets[i].ResourceVersionAfter = m.resourceVersion(ctx, entry.ClusterID, &targets[i])
+				}
+				if params.Name == "k8s_apply_yaml" {
+					targets = appliedObjects(res)
+				}
+			}
+			entry.Objects = targets
+
+			m.auditUC.Record(entry)
+			return res, err
+		}
+	}
+}
+
+// clientIdentity names the client of a session as reported on initialize.
+func clientIdentity(session *mcp.ServerSession) string {
+	params := session.InitializeParams()
+	if params == nil || params.ClientInfo == nil {
+		return ""
+	}
+	if params.ClientInfo.Version == "" {
+		return params.ClientInfo.Name
+	}
+	return params.ClientInfo.Name + "/" + params.ClientInfo.Version
+}
+
+// auditTargets returns the object a mutating k8s_<kind>_<verb> call acts
+// on, if its kind is known.
+func auditTargets(tool string, args map[string]any) []domain.AuditObject {
+	kind, _, _ := strings.Cut(strings.TrimPrefix(tool, "k8s_"), "_")
+	if kind == "resource" {
+		// k8s_resource_* tools work on any kind, resolved through discovery.
+		kind, _ = args["kind"].(string)
+		name, _ := args["name"].(string)
+		namespace, _ := args["namespace"].(string)
+		if kind == "" || name == "" {
+			return nil
+		}
+		return []domain.AuditObject{{Kind: kind, Namespace: namespace, Name: name}}
+	}
+	if kind == "workload" {
+		// k8s_workload_* tools take the kind and name as arguments.
+		kind, _ = args["kind"].(string)
+		name, _ := args["name"].(string)
+		namespace, _ := args["namespace"].(string)
+		if !slices.Contains(auditedKinds, kind) || name == "" {
+			return nil
+		}
+		return []domain.AuditObject{{Kind: kind, Namespace: namespace, Name: name}}
+	}
+	if !slices.Contains(auditedKinds, kind) {
+		return nil
+	}
+
+	target := domain.AuditObject{Kind: kind}
+	switch kind {
+	case "namespace":
+		target.Name, _ = args["namespace"].(string)
+	case "node":
+		target.Name, _ = args["node_name"].(string)
+	default:
+		target.Name, _ = args[kind+"_name"].(string)
+		target.Namespace, _ = args["namespace"].(string)
+	}
+	if target.Name == "" {
+		return nil
+	}
+	return []domain.AuditObject{target}
+}
+
+// resourceVersion looks up the current version of target. Lookup failures
+// are logged and recorded as an empty version.
+func (m *MCPServer) resourceVersion(ctx context.Context, clusterID string, target *domain.AuditObject) string {
+	if clusterID == "" {
+		return ""
+	}
+	rv, err := m.k8sUC.ResourceVersion(ctx, clusterID, target.Kind, target.Namespace, target.Name)
+	if err != nil {
+		m.logger.Debug("Failed to read resourceVersion for audit", "kind", target.Kind, "name", target.Name, "error", err)
+	}
+	return rv
+}
+
+// appliedObjects reads the per-object versions from a k8s_apply_yaml result.
+func appliedObjects(res mcp.Result) []domain.AuditObject {
+	var out struct {
+		Results []domain.ApplyResult `json:"results"`
+	}
+	if !decodeStructuredContent(res, &out) {
+		return nil
+	}
+	objects := make([]domain.AuditObject, 0, len(out.Results))
+	for _, r := range out.Results {
+		if r.DryRun {
+			continue
+		}
+		objects = append(objects, domain.AuditObject{
+			Kind:                  r.Kind,
+			Namespace:             r.Namespace,
+			Name:                  r.Name,
+			ResourceVersionBefore: r.PreviousResourceVersion,
+			ResourceVersionAfter:  r.ResourceVersion,
+		})
+	}
+	return objects
+}
+
+// auditOutcome classifies a tool call result.
+func auditOutcome(res mcp.Result, err error) (domain.AuditStatus, string) {
+	if err != nil {
+		return domain.AuditStatusError, truncate(err.Error(), maxAuditErrorLen)
+	}
+	cr, ok := res.(*mcp.CallToolResult)
+	if !ok {
+		return domain.AuditStatusSuccess, ""
+	}
+
+	var out struct {
+		Status string `json:"status"`
+		Error  any    `json:"error"`
+	}
+	decodeStructuredContent(cr, &out)
+	switch {
+	case out.Error == "policy_denied":
+		return domain.AuditStatusDenied, firstText(cr)
+	case out.Status == "confirmation_required":
+		return domain.AuditStatusConfirmationRequired, ""
+	case out.Status == "cancelled":
+		return domain.AuditStatusCancelled, ""
+	case cr.IsError:
+		return domain.AuditStatusError, firstText(cr)
+	}
+	return domain.AuditStatusSuccess, ""
+}
+
+// decodeStructuredContent unmarshals the structured content of a tool result
+// into v. Handlers leave it as a map, the SDK as raw JSON.
+func decodeStructuredContent(res mcp.Result, v any) bool {
+	cr, ok := res.(*mcp.CallToolResult)
+	if !ok || cr.StructuredContent == nil {
+		return false
+	}
+	data, err := json.Marshal(cr.StructuredContent)
+	if err != nil {
+		return false
+	}
+	return json.Unmarshal(data, v) == nil
+}
+
+func firstText(res *mcp.CallToolResult) string {
+	for _, c := range res.Content {
+		if tc, ok := c.(*mcp.TextContent); ok {
+			return truncate(tc.Text, maxAuditErrorLen)
+		}
+	}
+	return ""
+}
+
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return s[:n] + "..."
+}
+
+type auditQueryArgs struct {
+	Tool      string `json:"tool,omitempty" jsonschema:"Tool name or glob, e.g. 'k8s_*_delete'"`
+	Cluster   string `json:"cluster,omitempty" jsonschema:"Only entries for this cluster ID"`
+	Namespace string `json:"namespace,omitempty" jsonschema:"Only entries for this namespace"`
+	Status    string `json:"status,omitempty" jsonschema:"Only entries with this result status"`
+	SessionID string `json:"session_id,omitempty" jsonschema:"Only entries of this MCP session"`
+	Since     string `json:"since,omitempty" jsonschema:"RFC 3339 time or duration back from now, e.g. '1h'"`
+	Limit     int    `json:"limit,omitempty"`
+}
+
+func (auditQueryArgs) adjustSchema(s *jsonschema.Schema) {
+	s.Properties["status"].Enum = enumOf(domain.AuditStatusSuccess, domain.AuditStatusError, domain.AuditStatusDenied,
+		domain.AuditStatusCancelled, domain.AuditStatusConfirmationRequired)
+	s.Properties["limit"].Description = fmt.Sprintf("Maximum number of entries to return (max %d)", maxAuditQueryLimit)
+	s.Properties["limit"].Default = defaultOf(defaultAuditQueryLimit)
+}
+
+type auditQueryResult struct {
+	Count   int                 `json:"count"`
+	Entries []domain.AuditEntry `json:"entries"`
+}
+
+func (m *MCPServer) handleAuditQuery(ctx context.Context, req *mcp.CallToolRequest, args auditQueryArgs) (*mcp.CallToolResult, *auditQueryResult, error) {
+	m.logger.Info("Handling audit query request", "args", args)
+
+	q := domain.AuditQuery{
+		Tool:      args.Tool,
+		ClusterID: args.Cluster,
+		Namespace: args.Namespace,
+		SessionID: args.SessionID,
+		Status:    domain.AuditStatus(args.Status),
+		Limit:     defaultAuditQueryLimit,
+	}
+	if args.Limit > 0 {
+		q.Limit = min(args.Limit, maxAuditQueryLimit)
+	}
+	if since := args.Since; since != "" {
+		t, err := parseSince(since)
+		if err != nil {
+			return errorResult(err), nil, nil
+		}
+		q.Since = t
+	}
+
+	entries, err := m.auditUC.Query(q)
+	if err != nil {
+		return errorResult(fmt.Errorf("failed to query audit log: %w", err)), nil, nil
+	}
+	if entries == nil {
+		entries = []domain.AuditEntry{}
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Found %d audit entr(ies), newest first\n", len(entries))
+	for _, e := range entries {
+		target := e.ClusterID
+		if e.Namespace != "" {
+			target += "/" + e.Namespace
+		}
+		fmt.Fprintf(&sb, "%s %s %s [%s] %dms", e.Time.Format(time.RFC3339), e.Tool, target, e.Status, e.DurationMS)
+		if e.Client != "" {
+			fmt.Fprintf(&sb, " client=%s", e.Client)
+		}
+		sb.WriteString("\n")
+	}
+
+	resultData := &auditQueryResult{
+		Count:   len(entries),
+		Entries: entries,
+	}
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{Text: sb.String()},
+			&mcp.TextContent{Text: string(mustMarshalJSON(resultData))},
+		},
+	}, resultData, nil
+}
+
+// parseSince accepts an RFC 3339 timestamp or a duration such as "1h"
+// counted back from now.
+func parseSince(s string) (time.Time, error) {
+	if d, err := time.ParseDuration(s); err == nil {
+		return time.Now().Add(-d), nil
+	}
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid since %q: want an RFC 3339 time or a duration such as 30m", s)
+	}
+	return t, nil
+}
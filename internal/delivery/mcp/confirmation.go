@@ -0,0 +1,378 @@
+package mcp
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/your-org/mcp-k8s-server/internal/domain"
+)
+
+// confirmTokenTTL bounds how long a confirm_token issued by the fallback
+// flow stays valid.
+const confirmTokenTTL = 5 * time.Minute
+
+// confirmTokenArg is the argument that carries a confirm token on the
+// second call of the fallback flow.
+const confirmTokenArg = "confirm_token"
+
+// destructiveDeleteKinds are the resource kinds whose k8s_<kind>_delete tool
+// requires confirmation. The object name is passed as <kind>_name, except
+// for namespaces.
+var destructiveDeleteKinds = []string{
+	"namespace", "statefulset", "daemonset", "job", "cronjob",
+	"configmap", "secret", "service", "ingress", "hpa",
+}
+
+// destructiveOperation identifies a tool call that needs confirmation and
+// knows how to preview its impact.
+type destructiveOperation struct {
+	preview func(ctx context.Context) (*domain.ImpactPreview, error)
+}
+
+// destructiveOperationFor returns the operation for a destructive call, or
+// false for calls that run without confirmation.
+func (m *MCPServer) destructiveOperationFor(tool string, args map[string]any) (destructiveOperation, bool) {
+	clusterID, _ := args["cluster_id"].(string)
+
+	if tool == "k8s_node_taint_apply" {
+		action, _ := args["action"].(string)
+		taintKey, _ := args["taint_key"].(string)
+		nodeName, _ := args["node_name"].(string)
+		if action != "add" || !strings.HasSuffix(taintKey, ":NoExecute") {
+			return destructiveOperation{}, false
+		}
+		return destructiveOperation{preview: func(ctx context.Context) (*domain.ImpactPreview, error) {
+			return m.k8sUC.TaintImpact(ctx, clusterID, nodeName, taintKey)
+		}}, true
+	}
+
+	if tool == "k8s_resource_delete" {
+		if dryRun, _ := args["dry_run"].(bool); dryRun {
+			return destructiveOperation{}, false
+		}
+		kind, _ := args["kind"].(string)
+		apiVersion, _ := args["api_version"].(string)
+		namespace, _ := args["namespace"].(string)
+		name, _ := args["name"].(string)
+		// ResourceDeletionImpact previews a Namespace as the one it names and
+		// ignores namespace for other cluster-scoped kinds.
+		return destructiveOperation{preview: func(ctx context.Context) (*domain.ImpactPreview, error) {
+			return m.k8sUC.ResourceDeletionImpact(ctx, clusterID, kind, apiVersion, namespace, name)
+		}}, true
+	}
+
+	kind, ok := strings.CutSuffix(strings.TrimPrefix(tool, "k8s_"), "_delete")
+	if !ok || !slices.Contains(destructiveDeleteKinds, kind) {
+		return destructiveOperation{}, false
+	}
+
+	namespace, _ := args["namespace"].(string)
+	if namespace == "" {
+		namespace = "default"
+	}
+	name, _ := args[kind+"_name"].(string)
+	if kind == "namespace" {
+		name, _ = args["namespace"].(string)
+	}
+
+	return destructiveOperation{preview: func(ctx context.Context) (*domain.ImpactPreview, error) {
+		return m.k8sUC.DeletionImpact(ctx, clusterID, kind, namespace, name)
+	}}, true
+}
+
+type pendingConfirmation struct {
+	// session is the session the token was issued to. Sessions of stdio
+	// and in-memory transports have no ID, so tokens are bound to the
+	// session itself.
+	session     *mcp.ServerSession
+	fingerprint string
+	expires     time.Time
+}
+
+// confirmationStore holds the single-use tokens of the fallback flow.
+type confirmationStore struct {
+	mu      sync.Mutex
+	pending map[string]pendingConfirmation
+}
+
+func newConfirmationStore() *confirmationStore {
+	return &confirmationStore{pending: make(map[string]pendingConfirmation)}
+}
+
+func (s *confirmationStore) issue(session *mcp.ServerSession, fingerprint string) (string, time.Time, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", time.Time{}, err
+	}
+	token := hex.EncodeToString(buf)
+	expires := time.Now().Add(confirmTokenTTL)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for t, p := range s.pending {
+		if time.Now().After(p.expires) {
+			delete(s.pending, t)
+		}
+	}
+	s.pending[token] = pendingConfirmation{session: session, fingerprint: fingerprint, expires: expires}
+	return token, expires, nil
+}
+
+// consume reports whether token was issued to session for fingerprint and
+// has not expired. A token can only be used once; a call that does not match
+// leaves it valid, so another session cannot burn it.
+func (s *confirmationStore) consume(token string, session *mcp.ServerSession, fingerprint string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	p, ok := s.pending[token]
+	if !ok {
+		return false
+	}
+	if !time.Now().Before(p.expires) {
+		delete(s.pending, token)
+		return false
+	}
+	if p.session != session || p.fingerprint != fingerprint {
+		return false
+	}
+	delete(s.pending, token)
+	return true
+}
+
+// confirmationMiddleware asks the user to confirm destructive tool calls.
+// Clients that support elicitation get a confirmation prompt showing the
+// impact preview. Otherwise the first call returns the preview and a
+// confirm_token, and the call only runs when repeated with the token.
+func (m *MCPServer) confirmationMiddleware() mcp.Middleware {
+	store := newConfirmationStore()
+
+	return func(next mcp.MethodHandler) mcp.MethodHandler {
+		return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+			if method == "tools/list" {
+				res, err := next(ctx, method, req)
+				if lr, ok := res.(*mcp.ListToolsResult); ok && err == nil {
+					return m.withConfirmTokenArg(lr), nil
+				}
+				return res, err
+			}
+			if method != "tools/call" {
+				return next(ctx, method, req)
+			}
+			params, ok := req.GetParams().(*mcp.CallToolParamsRaw)
+			if !ok {
+				return next(ctx, method, req)
+			}
+
+			var args map[string]any
+			if err := json.Unmarshal(params.Arguments, &args); err != nil {
+				return next(ctx, method, req)
+			}
+			op, ok := m.destructiveOperationFor(params.Name, args)
+			if !ok {
+				return next(ctx, method, req)
+			}
+
+			token, _ := args[confirmTokenArg].(string)
+			delete(args, confirmTokenArg)
+			params.Arguments, _ = json.Marshal(args)
+
+			session, _ := req.GetSession().(*mcp.ServerSession)
+			fingerprint := confirmationFingerprint(params.Name, params.Arguments)
+
+			if token != "" {
+				if store.consume(token, session, fingerprint) {
+					return next(ctx, method, req)
+				}
+				return confirmationErrorResult(params.Name, "confirm_token is invalid, expired or was issued for different arguments; call the tool again without it to get a new one"), nil
+			}
+
+			preview, err := op.preview(ctx)
+			if err != nil {
+				m.logger.Warn("Failed to compute impact preview", "tool", params.Name, "error", err)
+			}
+			message := impactMessage(params.Name, preview, err)
+
+			if supportsElicitation(session) {
+				confirmed, err := elicitConfirmation(ctx, session, message)
+				if err == nil {
+					if confirmed {
+						return next(ctx, method, req)
+					}
+					return confirmationCancelledResult(params.Name, preview), nil
+				}
+				m.logger.Warn("Elicitation failed, falling back to confirm token", "tool", params.Name, "error", err)
+			}
+
+			token, expires, err := store.issue(session, fingerprint)
+			if err != nil {
+				return nil, fmt.Errorf("failed to issue confirm token: %w", err)
+			}
+			return confirmationRequiredResult(params.Name, message, preview, token, expires), nil
+		}
+	}
+}
+
+// withConfirmTokenArg advertises confirm_token on destructive tools without
+// modifying the stored schemas.
+func (m *MCPServer) withConfirmTokenArg(lr *mcp.ListToolsResult) *mcp.ListToolsResult {
+	copied := *lr
+	copied.Tools = make([]*mcp.Tool, len(lr.Tools))
+	for i, t := range lr.Tools {
+		copied.Tools[i] = t
+		if !isConfirmableTool(t.Name) {
+			continue
+		}
+		schema, ok := t.InputSchema.(*jsonschema.Schema)
+		if !ok {
+			continue
+		}
+		newSchema := *schema
+		newSchema.Properties = maps.Clone(schema.Properties)
+		newSchema.Properties[confirmTokenArg] = &jsonschema.Schema{
+			Type:        "string",
+			Description: "Token returned by a previous call that required confirmation. Only pass it after the user approved the shown impact.",
+		}
+
+		tool := *t
+		tool.InputSchema = &newSchema
+		copied.Tools[i] = &tool
+	}
+	return &copied
+}
+
+func isConfirmableTool(name string) bool {
+	if name == "k8s_node_taint_apply" {
+		return true
+	}
+	kind, ok := strings.CutSuffix(strings.TrimPrefix(name, "k8s_"), "_delete")
+	return ok && slices.Contains(destructiveDeleteKinds, kind)
+}
+
+func supportsElicitation(session *mcp.ServerSession) bool {
+	if session == nil {
+		return false
+	}
+	params := session.InitializeParams()
+	return params != nil && params.Capabilities != nil && params.Capabilities.Elicitation != nil
+}
+
+func elicitConfirmation(ctx context.Context, session *mcp.ServerSession, message string) (bool, error) {
+	res, err := session.Elicit(ctx, &mcp.ElicitParams{
+		Message: message + "\n\nDo you want to continue?",
+		RequestedSchema: map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"confirm": map[string]any{
+					"type":        "boolean",
+					"title":       "Confirm",
+					"description": "Check to run the operation",
+				},
+			},
+			"required": []string{"confirm"},
+		},
+	})
+	if err != nil {
+		return false, err
+	}
+	confirmed, _ := res.Content["confirm"].(bool)
+	return res.Action == "accept" && confirmed, nil
+}
+
+// confirmationFingerprint binds a token to the tool and exact arguments so
+// it cannot be reused for a different call.
+func confirmationFingerprint(tool string, args json.RawMessage) string {
+	return tool + "\x00" + string(args)
+}
+
+func impactMessage(tool string, preview *domain.ImpactPreview, previewErr error) string {
+	var sb strings.Builder
+	if preview == nil {
+		fmt.Fprintf(&sb, "⚠️ %s is a destructive operation.\n", tool)
+		fmt.Fprintf(&sb, "Impact preview unavailable: %v\n", previewErr)
+		return sb.String()
+	}
+
+	target := preview.Name
+	if preview.Namespace != "" {
+		target = preview.Namespace + "/" + target
+	}
+	fmt.Fprintf(&sb, "⚠️ %s on cluster '%s' (%s %s)\n", preview.Operation, preview.ClusterID, preview.Kind, target)
+
+	if len(preview.Affected) > 0 {
+		resources := make([]string, 0, len(preview.Affected))
+		for r := range preview.Affected {
+			resources = append(resources, r)
+		}
+		sort.Strings(resources)
+		sb.WriteString("Affected:\n")
+		for _, r := range resources {
+			fmt.Fprintf(&sb, "  - %s: %d\n", r, preview.Affected[r])
+		}
+	}
+	for _, w := range preview.Warnings {
+		fmt.Fprintf(&sb, "Note: %s\n", w)
+	}
+	return sb.String()
+}
+
+func confirmationRequiredResult(tool, message string, preview *domain.ImpactPreview, token string, expires time.Time) *mcp.CallToolResult {
+	resultData := map[string]any{
+		"status":        "confirmation_required",
+		"tool":          tool,
+		"preview":       preview,
+		"confirm_token": token,
+		"expires_at":    expires.UTC().Format(time.RFC3339),
+	}
+	text := message + fmt.Sprintf("\nNothing was changed. Show this impact to the user and, only if they approve, call %s again with the same arguments plus \"confirm_token\": %q (valid for %s).", tool, token, confirmTokenTTL)
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{Text: text},
+			&mcp.TextContent{Text: string(mustMarshalJSON(resultData))},
+		},
+		StructuredContent: resultData,
+	}
+}
+
+func confirmationCancelledResult(tool string, preview *domain.ImpactPreview) *mcp.CallToolResult {
+	resultData := map[string]any{
+		"status":  "cancelled",
+		"tool":    tool,
+		"preview": preview,
+	}
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{Text: fmt.Sprintf("The user declined %s. Nothing was changed.", tool)},
+			&mcp.TextContent{Text: string(mustMarshalJSON(resultData))},
+		},
+		StructuredContent: resultData,
+		IsError:           true,
+	}
+}
+
+func confirmationErrorResult(tool, msg string) *mcp.CallToolResult {
+	resultData := map[string]any{
+		"status": "confirmation_failed",
+		"tool":   tool,
+		"error":  msg,
+	}
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{Text: "Error: " + msg},
+			&mcp.TextContent{Text: string(mustMarshalJSON(resultData))},
+		},
+		StructuredContent: resultData,
+		IsError:           true,
+	}
+}
@@ -0,0 +1,190 @@
+package mcp
+
+import (
+	"context"
+	"maps"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// newConfirmationHarness starts a server that confirms destructive calls,
+// with a second config map next to the fixtures.
+func newConfirmationHarness(t *testing.T) *testHarness {
+	t.Helper()
+
+	flags := &corev1.ConfigMap{ObjectMeta: objectMeta("feature-flags", nil)}
+	return newTestHarness(t, ServerOptions{ConfirmDestructive: true}, append(fixtureObjects(), runtime.Object(flags))...)
+}
+
+func configMapDeleteCall(name string) map[string]any {
+	return map[string]any{"cluster_id": testClusterID, "namespace": "default", "configmap_name": name}
+}
+
+// confirmationStatus returns the status and confirm_token of a result of the
+// confirmation middleware.
+func confirmationStatus(res *mcp.CallToolResult) (status, token string) {
+	content, _ := res.StructuredContent.(map[string]any)
+	status, _ = content["status"].(string)
+	token, _ = content["confirm_token"].(string)
+	return status, token
+}
+
+// assertConfigMapExists fails the test unless default/name exists, or
+// unless it is gone when want is false.
+func (h *testHarness) assertConfigMapExists(t *testing.T, name string, want bool) {
+	t.Helper()
+
+	_, err := h.clientset.CoreV1().ConfigMaps("default").Get(context.Background(), name, metav1.GetOptions{})
+	if got := err == nil; got != want {
+		t.Errorf("config map %s exists = %v, want %v (err %v)", name, got, want, err)
+	}
+}
+
+// requestToken makes the first call of the fallback flow and returns the
+// confirm token, checking that nothing was deleted.
+func (h *testHarness) requestToken(t *testing.T, session *mcp.ClientSession, name string) string {
+	t.Helper()
+
+	res := callTool(t, session, "k8s_configmap_delete", configMapDeleteCall(name))
+	status, token := confirmationStatus(res)
+	if res.IsError || status != "confirmation_required" || token == "" {
+		t.Fatalf("first call: want confirmation_required with a token, got:\n%s", renderResult(res))
+	}
+	if !strings.Contains(renderResult(res), "Nothing was changed") {
+		t.Errorf("first call does not say nothing changed:\n%s", renderResult(res))
+	}
+	h.assertConfigMapExists(t, name, true)
+	return token
+}
+
+func withToken(args map[string]any, token string) map[string]any {
+	args = maps.Clone(args)
+	args[confirmTokenArg] = token
+	return args
+}
+
+func TestConfirmToken(t *testing.T) {
+	h := newConfirmationHarness(t)
+	token := h.requestToken(t, h.session, "app-config")
+
+	res := h.call(t, "k8s_configmap_delete", withToken(configMapDeleteCall("app-config"), token))
+	if res.IsError {
+		t.Fatalf("call with token failed:\n%s", renderResult(res))
+	}
+	h.assertConfigMapExists(t, "app-config", false)
+
+	res = h.call(t, "k8s_configmap_delete", withToken(configMapDeleteCall("app-config"), token))
+	if status, _ := confirmationStatus(res); !res.IsError || status != "confirmation_failed" {
+		t.Errorf("replayed token: want confirmation_failed, got:\n%s", renderResult(res))
+	}
+}
+
+func TestConfirmTokenRejected(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		// call uses the token issued for deleting app-config on the
+		// harness session.
+		call func(h *testHarness, token string) *mcp.CallToolResult
+	}{
+		{"changed arguments", func(h *testHarness, token string) *mcp.CallToolResult {
+			return h.call(t, "k8s_configmap_delete", withToken(configMapDeleteCall("feature-flags"), token))
+		}},
+		{"other tool", func(h *testHarness, token string) *mcp.CallToolResult {
+			return h.call(t, "k8s_secret_delete", withToken(map[string]any{"cluster_id": testClusterID, "namespace": "default", "secret_name": "app-secret"}, token))
+		}},
+		{"other session", func(h *testHarness, token string) *mcp.CallToolResult {
+			return callTool(t, h.connect(t, nil), "k8s_configmap_delete", withToken(configMapDeleteCall("app-config"), token))
+		}},
+		{"unknown token", func(h *testHarness, _ string) *mcp.CallToolResult {
+			return h.call(t, "k8s_configmap_delete", withToken(configMapDeleteCall("app-config"), "0123456789abcdef"))
+		}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			h := newConfirmationHarness(t)
+			token := h.requestToken(t, h.session, "app-config")
+
+			res := tc.call(h, token)
+			if status, _ := confirmationStatus(res); !res.IsError || status != "confirmation_failed" {
+				t.Errorf("want confirmation_failed, got:\n%s", renderResult(res))
+			}
+			h.assertConfigMapExists(t, "app-config", true)
+			h.assertConfigMapExists(t, "feature-flags", true)
+			if _, err := h.clientset.CoreV1().Secrets("default").Get(context.Background(), "app-secret", metav1.GetOptions{}); err != nil {
+				t.Errorf("secret deleted: %v", err)
+			}
+
+			// The rejected call must not use up the token.
+			res = h.call(t, "k8s_configmap_delete", withToken(configMapDeleteCall("app-config"), token))
+			if res.IsError {
+				t.Fatalf("call with the issued token failed:\n%s", renderResult(res))
+			}
+			h.assertConfigMapExists(t, "app-config", false)
+		})
+	}
+}
+
+func TestConfirmElicitation(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		result  *mcp.ElicitResult
+		deleted bool
+	}{
+		{"accept", &mcp.ElicitResult{Action: "accept", Content: map[string]any{"confirm": true}}, true},
+		{"accept unchecked", &mcp.ElicitResult{Action: "accept", Content: map[string]any{"confirm": false}}, false},
+		{"decline", &mcp.ElicitResult{Action: "decline"}, false},
+		{"cancel", &mcp.ElicitResult{Action: "cancel"}, false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			h := newConfirmationHarness(t)
+			var mu sync.Mutex
+			var messages []string
+			session := h.connect(t, &mcp.ClientOptions{
+				ElicitationHandler: func(_ context.Context, req *mcp.ElicitRequest) (*mcp.ElicitResult, error) {
+					mu.Lock()
+					defer mu.Unlock()
+					messages = append(messages, req.Params.Message)
+					return tc.result, nil
+				},
+			})
+
+			res := callTool(t, session, "k8s_configmap_delete", configMapDeleteCall("app-config"))
+			h.assertConfigMapExists(t, "app-config", !tc.deleted)
+			if len(messages) != 1 || !strings.Contains(messages[0], "app-config") {
+				t.Errorf("elicitation messages = %q, want one naming app-config", messages)
+			}
+			status, token := confirmationStatus(res)
+			if token != "" {
+				t.Errorf("elicitation returned a confirm token:\n%s", renderResult(res))
+			}
+			if tc.deleted {
+				if res.IsError {
+					t.Errorf("confirmed call failed:\n%s", renderResult(res))
+				}
+			} else if !res.IsError || status != "cancelled" {
+				t.Errorf("want cancelled, got:\n%s", renderResult(res))
+			}
+		})
+	}
+}
+
+// TestConfirmNotDestructive checks that other calls run without
+// confirmation.
+func TestConfirmNotDestructive(t *testing.T) {
+	h := newConfirmationHarness(t)
+
+	for _, call := range []toolCall{
+		{"k8s_configmap_get", map[string]any{"cluster_id": testClusterID, "namespace": "default", "configmap_name": "app-config"}},
+		{"k8s_node_taint_apply", map[string]any{"cluster_id": testClusterID, "node_name": "node-1", "taint_key": "maintenance=true:NoSchedule", "action": "add"}},
+	} {
+		res := h.call(t, call.tool, call.args)
+		if _, token := confirmationStatus(res); res.IsError || token != "" {
+			t.Errorf("%s asked for confirmation:\n%s", call.tool, renderResult(res))
+		}
+	}
+}
@@ -0,0 +1,542 @@
+package mcp
+
+import (
+	"time"
+
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	eventsv1 "k8s.io/api/events/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	metricsv1beta1 "k8s.io/metrics/pkg/apis/metrics/v1beta1"
+	"k8s.io/utils/ptr"
+)
+
+// testAPIResources is what the fake discovery client reports; it covers the
+// kinds the manifest fixtures use.
+var testAPIResources = []*metav1.APIResourceList{
+	{
+		GroupVersion: "v1",
+		APIResources: []metav1.APIResource{
+			{Name: "namespaces", Kind: "Namespace", Verbs: metav1.Verbs{"get", "list", "create", "patch", "delete"}},
+			{Name: "configmaps", Kind: "ConfigMap", Namespaced: true, Verbs: metav1.Verbs{"get", "list", "create", "patch", "delete"}},
+			{Name: "secrets", Kind: "Secret", Namespaced: true, Verbs: metav1.Verbs{"get", "list", "create", "patch", "delete"}},
+			{Name: "services", Kind: "Service", Namespaced: true, ShortNames: []string{"svc"}, Verbs: metav1.Verbs{"get", "list", "create", "patch", "delete"}},
+			{Name: "pods", SingularName: "pod", Kind: "Pod", Namespaced: true, ShortNames: []string{"po"}, Verbs: metav1.Verbs{"get", "list", "delete"}},
+			{Name: "persistentvolumeclaims", SingularName: "persistentvolumeclaim", Kind: "PersistentVolumeClaim", Namespaced: true, ShortNames: []string{"pvc"}, Verbs: metav1.Verbs{"get", "list", "delete"}},
+			{Name: "nodes", SingularName: "node", Kind: "Node", ShortNames: []string{"no"}, Verbs: metav1.Verbs{"get", "list"}},
+		},
+	},
+	{
+		GroupVersion: "apps/v1",
+		APIResources: []metav1.APIResource{
+			{Name: "deployments", Kind: "Deployment", Namespaced: true, ShortNames: []string{"deploy"}, Verbs: metav1.Verbs{"get", "list", "create", "patch", "delete"}},
+		},
+	},
+	{
+		GroupVersion: "cert-manager.io/v1",
+		APIResources: []metav1.APIResource{
+			{Name: "certificates", SingularName: "certificate", Kind: "Certificate", Namespaced: true, ShortNames: []string{"cert"}, Verbs: metav1.Verbs{"get", "list", "delete"}},
+		},
+	},
+}
+
+func objectMeta(name string, labels map[string]string) metav1.ObjectMeta {
+	return metav1.ObjectMeta{
+		Name:              name,
+		Namespace:         "default",
+		UID:               types.UID(name + "-uid"),
+		ResourceVersion:   "100",
+		CreationTimestamp: fixtureTime,
+		Labels:            labels,
+	}
+}
+
+func podTemplate(image string) corev1.PodTemplateSpec {
+	return corev1.PodTemplateSpec{
+		ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "web"}},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{
+				Name:  "app",
+				Image: image,
+				Env:   []corev1.EnvVar{{Name: "LOG_LEVEL", Value: "info"}},
+			}},
+		},
+	}
+}
+
+// fixtureObjects returns one object of every kind the tools read, all in
+// namespace "default" unless cluster-scoped. Fields the API server defaults
+// are set as it would.
+func fixtureObjects() []runtime.Object {
+	webLabels := map[string]string{"app": "web"}
+	selector := &metav1.LabelSelector{MatchLabels: webLabels}
+
+	deployment := &appsv1.Deployment{
+		ObjectMeta: objectMeta("web", webLabels),
+		Spec: appsv1.DeploymentSpec{
+			Replicas: ptr.To[int32](2),
+			Selector: selector,
+			Template: podTemplate("nginx:1.25"),
+		},
+		Status: appsv1.DeploymentStatus{
+			ObservedGeneration: 2,
+			Replicas:           2,
+			UpdatedReplicas:    2,
+			ReadyReplicas:      2,
+			AvailableReplicas:  2,
+			Conditions: []appsv1.DeploymentCondition{{
+				Type:   appsv1.DeploymentProgressing,
+				Status: corev1.ConditionTrue,
+				Reason: "NewReplicaSetAvailable",
+			}},
+		},
+	}
+	deployment.Generation = 2
+	deployment.Annotations = map[string]string{"deployment.kubernetes.io/revision": "2"}
+
+	replicaSet := func(name, revision, image string, replicas int32) *appsv1.ReplicaSet {
+		rs := &appsv1.ReplicaSet{
+			ObjectMeta: objectMeta(name, map[string]string{"app": "web", "pod-template-hash": name[len("web-"):]}),
+			Spec: appsv1.ReplicaSetSpec{
+				Replicas: ptr.To(replicas),
+				Selector: selector,
+				Template: podTemplate(image),
+			},
+		}
+		rs.Annotations = map[string]string{"deployment.kubernetes.io/revision": revision}
+		rs.OwnerReferences = []metav1.OwnerReference{*metav1.NewControllerRef(deployment, appsv1.SchemeGroupVersion.WithKind("Deployment"))}
+		return rs
+	}
+	currentRS := replicaSet("web-7d9c", "2", "nginx:1.25", 2)
+
+	pod := &corev1.Pod{
+		ObjectMeta: objectMeta("web-7d9c-abcde", map[string]string{"app": "web", "pod-template-hash": "7d9c"}),
+		Spec:       podTemplate("nginx:1.25").Spec,
+		Status: corev1.PodStatus{
+			Phase:  corev1.PodRunning,
+			PodIP:  "10.0.0.12",
+			HostIP: "192.168.1.10",
+			Conditions: []corev1.PodCondition{
+				{Type: corev1.PodReady, Status: corev1.ConditionTrue, LastTransitionTime: fixtureTime},
+			},
+			ContainerStatuses: []corev1.ContainerStatus{{
+				Name:         "app",
+				Image:        "nginx:1.25",
+				Ready:        true,
+				RestartCount: 1,
+				State:        corev1.ContainerState{Running: &corev1.ContainerStateRunning{StartedAt: fixtureTime}},
+			}},
+		},
+	}
+	pod.Spec.NodeName = "node-1"
+	pod.Spec.Containers[0].Resources.Requests = corev1.ResourceList{
+		corev1.ResourceCPU:    resource.MustParse("100m"),
+		corev1.ResourceMemory: resource.MustParse("128Mi"),
+	}
+	pod.OwnerReferences = []metav1.OwnerReference{*metav1.NewControllerRef(currentRS, appsv1.SchemeGroupVersion.WithKind("ReplicaSet"))}
+
+	statefulSet := &appsv1.StatefulSet{
+		ObjectMeta: objectMeta("db", map[string]string{"app": "db"}),
+		Spec: appsv1.StatefulSetSpec{
+			Replicas:    ptr.To[int32](1),
+			ServiceName: "db",
+			Selector:    &metav1.LabelSelector{MatchLabels: map[string]string{"app": "db"}},
+			Template:    podTemplate("postgres:16"),
+		},
+		Status: appsv1.StatefulSetStatus{Replicas: 1, ReadyReplicas: 1, CurrentRevision: "db-5f6d", UpdateRevision: "db-5f6d"},
+	}
+
+	daemonSet := &appsv1.DaemonSet{
+		ObjectMeta: objectMeta("agent", map[string]string{"app": "agent"}),
+		Spec: appsv1.DaemonSetSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "agent"}},
+			Template: podTemplate("fluent-bit:3.0"),
+		},
+		Status: appsv1.DaemonSetStatus{DesiredNumberScheduled: 1, CurrentNumberScheduled: 1, NumberReady: 1},
+	}
+	daemonSet.Annotations = map[string]string{"deprecated.daemonset.template.generation": "1"}
+
+	jobTemplate := podTemplate("migrate:1.0")
+	jobTemplate.Spec.RestartPolicy = corev1.RestartPolicyNever
+	job := &batchv1.Job{
+		ObjectMeta: objectMeta("migrate", map[string]string{"app": "migrate"}),
+		Spec: batchv1.JobSpec{
+			Completions:  ptr.To[int32](1),
+			Parallelism:  ptr.To[int32](1),
+			BackoffLimit: ptr.To[int32](6),
+			Template:     jobTemplate,
+		},
+		Status: batchv1.JobStatus{Succeeded: 1, Failed: 1},
+	}
+	// The first attempt of the job failed; the retry succeeded.
+	failedJobPod := jobAttempt("migrate-f4k9q", "migrate", corev1.PodFailed, fixtureTime.Time, 1, "Error")
+	jobPod := jobAttempt("migrate-x7k2p", "migrate", corev1.PodSucceeded, fixtureTime.Add(time.Minute), 0, "Completed")
+
+	cronJob := &batchv1.CronJob{
+		ObjectMeta: objectMeta("nightly", map[string]string{"app": "nightly"}),
+		Spec: batchv1.CronJobSpec{
+			Schedule:                   "0 2 * * *",
+			Suspend:                    ptr.To(false),
+			SuccessfulJobsHistoryLimit: ptr.To[int32](3),
+			FailedJobsHistoryLimit:     ptr.To[int32](1),
+			JobTemplate: batchv1.JobTemplateSpec{
+				Spec: batchv1.JobSpec{Template: jobTemplate},
+			},
+		},
+	}
+
+	cronJobRun := &batchv1.Job{
+		ObjectMeta: objectMeta("nightly-28400000", map[string]string{"job-name": "nightly-28400000"}),
+		Spec:       batchv1.JobSpec{Template: jobTemplate},
+		Status:     batchv1.JobStatus{Succeeded: 1},
+	}
+	cronJobRun.OwnerReferences = []metav1.OwnerReference{*metav1.NewControllerRef(cronJob, batchv1.SchemeGroupVersion.WithKind("CronJob"))}
+	cronJobRunPod := jobAttempt("nightly-28400000-9xq2w", "nightly-28400000", corev1.PodSucceeded, fixtureTime.Add(time.Hour), 0, "Completed")
+
+	// api keeps crashing: it is OOM killed and its PVC is not bound.
+	crashingPod := &corev1.Pod{
+		ObjectMeta: objectMeta("api-6c5d-k2x9p", map[string]string{"app": "api"}),
+		Spec:       podTemplate("api:2.1").Spec,
+		Status: corev1.PodStatus{
+			Phase: corev1.PodRunning,
+			Conditions: []corev1.PodCondition{
+				{Type: corev1.PodScheduled, Status: corev1.ConditionTrue, LastTransitionTime: fixtureTime},
+				{Type: corev1.PodReady, Status: corev1.ConditionFalse, Reason: "ContainersNotReady", LastTransitionTime: fixtureTime},
+			},
+			ContainerStatuses: []corev1.ContainerStatus{{
+				Name:         "app",
+				Image:        "api:2.1",
+				RestartCount: 7,
+				State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{
+					Reason:  "CrashLoopBackOff",
+					Message: "back-off 5m0s restarting failed container=app pod=api-6c5d-k2x9p_default",
+				}},
+				LastTerminationState: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{
+					ExitCode:   137,
+					Reason:     "OOMKilled",
+					StartedAt:  fixtureTime,
+					FinishedAt: metav1.NewTime(fixtureTime.Add(time.Minute)),
+				}},
+			}},
+		},
+	}
+	crashingPod.Spec.NodeName = "node-1"
+	crashingPod.Spec.Containers[0].Resources.Limits = corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("128Mi")}
+	crashingPod.Spec.Volumes = []corev1.Volume{{
+		Name:         "data",
+		VolumeSource: corev1.VolumeSource{PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "api-data"}},
+	}}
+
+	// report requests more memory than any node has.
+	pendingPod := &corev1.Pod{
+		ObjectMeta: objectMeta("report-q8z7w", map[string]string{"app": "report"}),
+		Spec:       podTemplate("report:1.0").Spec,
+		Status: corev1.PodStatus{
+			Phase: corev1.PodPending,
+			Conditions: []corev1.PodCondition{{
+				Type:               corev1.PodScheduled,
+				Status:             corev1.ConditionFalse,
+				Reason:             corev1.PodReasonUnschedulable,
+				Message:            "0/1 nodes are available: 1 Insufficient memory.",
+				LastTransitionTime: fixtureTime,
+			}},
+		},
+	}
+	pendingPod.Spec.Containers[0].Resources.Requests = corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("64Gi")}
+
+	ingressClass := "nginx"
+	pathType := networkingv1.PathTypePrefix
+
+	return []runtime.Object{
+		&corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{Name: "default", ResourceVersion: "100", CreationTimestamp: fixtureTime},
+			Status:     corev1.NamespaceStatus{Phase: corev1.NamespaceActive},
+		},
+		&corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              "node-1",
+				ResourceVersion:   "100",
+				CreationTimestamp: fixtureTime,
+				Labels:            map[string]string{"kubernetes.io/hostname": "node-1"},
+			},
+			Status: corev1.NodeStatus{
+				Capacity: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("4"),
+					corev1.ResourceMemory: resource.MustParse("16Gi"),
+					corev1.ResourcePods:   resource.MustParse("110"),
+				},
+				Allocatable: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("3800m"),
+					corev1.ResourceMemory: resource.MustParse("15Gi"),
+					corev1.ResourcePods:   resource.MustParse("110"),
+				},
+				Conditions: []corev1.NodeCondition{{Type: corev1.NodeReady, Status: corev1.ConditionTrue}},
+				NodeInfo:   corev1.NodeSystemInfo{KubeletVersion: "v1.30.2", OSImage: "Ubuntu 22.04"},
+				Addresses:  []corev1.NodeAddress{{Type: corev1.NodeInternalIP, Address: "192.168.1.10"}},
+			},
+		},
+		deployment,
+		replicaSet("web-6b8f", "1", "nginx:1.24", 0),
+		currentRS,
+		pod,
+		statefulSet,
+		daemonSet,
+		job,
+		failedJobPod,
+		jobPod,
+		cronJob,
+		cronJobRun,
+		cronJobRunPod,
+		crashingPod,
+		pendingPod,
+		&corev1.PersistentVolumeClaim{
+			ObjectMeta: objectMeta("api-data", map[string]string{"app": "api"}),
+			Spec: corev1.PersistentVolumeClaimSpec{
+				AccessModes:      []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+				StorageClassName: ptr.To("standard"),
+				Resources:        corev1.VolumeResourceRequirements{Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("5Gi")}},
+			},
+			Status: corev1.PersistentVolumeClaimStatus{Phase: corev1.ClaimPending},
+		},
+		&corev1.ConfigMap{
+			ObjectMeta: objectMeta("app-config", map[string]string{"app": "web"}),
+			Data:       map[string]string{"LOG_LEVEL": "info", "FEATURES": "search,export"},
+		},
+		&corev1.Secret{
+			ObjectMeta: objectMeta("app-secret", map[string]string{"app": "web"}),
+			Type:       corev1.SecretTypeOpaque,
+			Data:       map[string][]byte{"password": []byte("hunter2")},
+		},
+		&corev1.Service{
+			ObjectMeta: objectMeta("web", webLabels),
+			Spec: corev1.ServiceSpec{
+				Type:      corev1.ServiceTypeClusterIP,
+				ClusterIP: "10.96.0.20",
+				Selector:  webLabels,
+				Ports:     []corev1.ServicePort{{Name: "http", Port: 80, Protocol: corev1.ProtocolTCP}},
+			},
+		},
+		&networkingv1.Ingress{
+			ObjectMeta: objectMeta("web", webLabels),
+			Spec: networkingv1.IngressSpec{
+				IngressClassName: &ingressClass,
+				Rules: []networkingv1.IngressRule{{
+					Host: "web.example.com",
+					IngressRuleValue: networkingv1.IngressRuleValue{HTTP: &networkingv1.HTTPIngressRuleValue{
+						Paths: []networkingv1.HTTPIngressPath{{
+							Path:     "/",
+							PathType: &pathType,
+							Backend: networkingv1.IngressBackend{Service: &networkingv1.IngressServiceBackend{
+								Name: "web",
+								Port: networkingv1.ServiceBackendPort{Number: 80},
+							}},
+						}},
+					}},
+				}},
+			},
+		},
+		&autoscalingv2.HorizontalPodAutoscaler{
+			ObjectMeta: objectMeta("web", webLabels),
+			Spec: autoscalingv2.HorizontalPodAutoscalerSpec{
+				ScaleTargetRef: autoscalingv2.CrossVersionObjectReference{APIVersion: "apps/v1", Kind: "Deployment", Name: "web"},
+				MinReplicas:    ptr.To[int32](2),
+				MaxReplicas:    5,
+			},
+			Status: autoscalingv2.HorizontalPodAutoscalerStatus{CurrentReplicas: 2, DesiredReplicas: 2},
+		},
+		&corev1.ResourceQuota{
+			ObjectMeta: objectMeta("compute", nil),
+			Spec:       corev1.ResourceQuotaSpec{Hard: corev1.ResourceList{corev1.ResourcePods: resource.MustParse("20")}},
+			Status: corev1.ResourceQuotaStatus{
+				Hard: corev1.ResourceList{corev1.ResourcePods: resource.MustParse("20")},
+				Used: corev1.ResourceList{corev1.ResourcePods: resource.MustParse("4")},
+			},
+		},
+		&corev1.LimitRange{
+			ObjectMeta: objectMeta("defaults", nil),
+			Spec: corev1.LimitRangeSpec{Limits: []corev1.LimitRangeItem{{
+				Type:           corev1.LimitTypeContainer,
+				Default:        corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("500m")},
+				DefaultRequest: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("100m")},
+			}}},
+		},
+		&corev1.PersistentVolume{
+			ObjectMeta: metav1.ObjectMeta{Name: "pv-1", ResourceVersion: "100", CreationTimestamp: fixtureTime},
+			Spec: corev1.PersistentVolumeSpec{
+				Capacity:                      corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("10Gi")},
+				AccessModes:                   []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+				PersistentVolumeReclaimPolicy: corev1.PersistentVolumeReclaimRetain,
+				StorageClassName:              "standard",
+			},
+			Status: corev1.PersistentVolumeStatus{Phase: corev1.VolumeBound},
+		},
+		&storagev1.StorageClass{
+			ObjectMeta:  metav1.ObjectMeta{Name: "standard", ResourceVersion: "100", CreationTimestamp: fixtureTime},
+			Provisioner: "kubernetes.io/no-provisioner",
+		},
+		&admissionregistrationv1.MutatingWebhookConfiguration{
+			ObjectMeta: metav1.ObjectMeta{Name: "policy-webhook", ResourceVersion: "100", CreationTimestamp: fixtureTime},
+			Webhooks: []admissionregistrationv1.MutatingWebhook{{
+				Name:                    "mutate.policy.example.com",
+				AdmissionReviewVersions: []string{"v1"},
+				FailurePolicy:           ptr.To(admissionregistrationv1.Fail),
+				ClientConfig:            admissionregistrationv1.WebhookClientConfig{URL: ptr.To("https://policy.example.com/mutate")},
+			}},
+		},
+		&admissionregistrationv1.ValidatingWebhookConfiguration{
+			ObjectMeta: metav1.ObjectMeta{Name: "policy-webhook", ResourceVersion: "100", CreationTimestamp: fixtureTime},
+			Webhooks: []admissionregistrationv1.ValidatingWebhook{{
+				Name:                    "validate.policy.example.com",
+				AdmissionReviewVersions: []string{"v1"},
+				FailurePolicy:           ptr.To(admissionregistrationv1.Ignore),
+				ClientConfig: admissionregistrationv1.WebhookClientConfig{Service: &admissionregistrationv1.ServiceReference{
+					Namespace: "policy",
+					Name:      "validator",
+				}},
+			}},
+		},
+		&rbacv1.ClusterRole{
+			ObjectMeta: metav1.ObjectMeta{Name: "viewer", ResourceVersion: "100", CreationTimestamp: fixtureTime},
+			Rules: []rbacv1.PolicyRule{{
+				APIGroups: []string{""},
+				Resources: []string{"pods"},
+				Verbs:     []string{"get", "list"},
+			}},
+		},
+		&corev1.Event{
+			ObjectMeta:     objectMeta("web-7d9c-abcde.17a", nil),
+			InvolvedObject: corev1.ObjectReference{Kind: "Pod", Namespace: "default", Name: "web-7d9c-abcde"},
+			Reason:         "Pulled",
+			Message:        "Container image \"nginx:1.25\" already present on machine",
+			Type:           corev1.EventTypeNormal,
+			Count:          1,
+			FirstTimestamp: fixtureTime,
+			LastTimestamp:  fixtureTime,
+		},
+		&corev1.Event{
+			ObjectMeta:     objectMeta("web-7d9c-abcde.17b", nil),
+			InvolvedObject: corev1.ObjectReference{Kind: "Pod", Namespace: "default", Name: "web-7d9c-abcde"},
+			Reason:         "Unhealthy",
+			Message:        "Readiness probe failed: HTTP probe failed with statuscode: 503",
+			Type:           corev1.EventTypeWarning,
+			Count:          3,
+			FirstTimestamp: metav1.NewTime(fixtureTime.Add(time.Minute)),
+			LastTimestamp:  metav1.NewTime(fixtureTime.Add(2 * time.Minute)),
+		},
+		&corev1.Event{
+			ObjectMeta:     objectMeta("web.17c", nil),
+			InvolvedObject: corev1.ObjectReference{Kind: "Deployment", Namespace: "default", Name: "web"},
+			Reason:         "ScalingReplicaSet",
+			Message:        "Scaled up replica set web-7d9c to 2",
+			Type:           corev1.EventTypeNormal,
+			Count:          1,
+			FirstTimestamp: metav1.NewTime(fixtureTime.Add(-time.Minute)),
+			LastTimestamp:  metav1.NewTime(fixtureTime.Add(-time.Minute)),
+		},
+		&corev1.Event{
+			ObjectMeta:     objectMeta("report-q8z7w.17e", nil),
+			InvolvedObject: corev1.ObjectReference{Kind: "Pod", Namespace: "default", Name: "report-q8z7w"},
+			Reason:         "FailedScheduling",
+			Message:        "0/1 nodes are available: 1 Insufficient memory.",
+			Type:           corev1.EventTypeWarning,
+			Count:          4,
+			FirstTimestamp: fixtureTime,
+			LastTimestamp:  metav1.NewTime(fixtureTime.Add(5 * time.Minute)),
+		},
+		&metricsv1beta1.NodeMetrics{
+			ObjectMeta: metav1.ObjectMeta{Name: "node-1", CreationTimestamp: fixtureTime},
+			Timestamp:  fixtureTime,
+			Window:     metav1.Duration{Duration: 30 * time.Second},
+			Usage: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("1200m"),
+				corev1.ResourceMemory: resource.MustParse("6Gi"),
+			},
+		},
+		podMetrics("web-7d9c-abcde", "150m", "96Mi"),
+		podMetrics("api-6c5d-k2x9p", "20m", "120Mi"),
+		&unstructured.Unstructured{Object: map[string]any{
+			"apiVersion": "cert-manager.io/v1",
+			"kind":       "Certificate",
+			"metadata": map[string]any{
+				"name":              "web-tls",
+				"namespace":         "default",
+				"resourceVersion":   "100",
+				"creationTimestamp": fixtureTime.UTC().Format(time.RFC3339),
+				"labels":            map[string]any{"app": "web"},
+				"finalizers":        []any{"cert-manager.io/certificate-cleanup"},
+			},
+			"spec": map[string]any{
+				"secretName": "web-tls",
+				"dnsNames":   []any{"web.example.com"},
+				"issuerRef":  map[string]any{"name": "letsencrypt", "kind": "ClusterIssuer"},
+			},
+			"status": map[string]any{
+				"conditions": []any{map[string]any{"type": "Ready", "status": "True", "reason": "Ready"}},
+			},
+		}},
+		&eventsv1.Event{
+			ObjectMeta:          objectMeta("web-7d9c.17d", nil),
+			EventTime:           metav1.NewMicroTime(fixtureTime.Add(-time.Minute)),
+			ReportingController: "replicaset-controller",
+			ReportingInstance:   "replicaset-controller-node-1",
+			Action:              "Create",
+			Reason:              "SuccessfulCreate",
+			Regarding:           corev1.ObjectReference{Kind: "ReplicaSet", Namespace: "default", Name: "web-7d9c"},
+			Note:                "Created pod: web-7d9c-abcde",
+			Type:                corev1.EventTypeNormal,
+		},
+	}
+}
+
+// podMetrics returns the metrics.k8s.io usage of a pod whose container
+// "app" uses cpu and memory.
+func podMetrics(name, cpu, memory string) *metricsv1beta1.PodMetrics {
+	return &metricsv1beta1.PodMetrics{
+		ObjectMeta: objectMeta(name, nil),
+		Timestamp:  fixtureTime,
+		Window:     metav1.Duration{Duration: 30 * time.Second},
+		Containers: []metricsv1beta1.ContainerMetrics{{
+			Name: "app",
+			Usage: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse(cpu),
+				corev1.ResourceMemory: resource.MustParse(memory),
+			},
+		}},
+	}
+}
+
+// jobAttempt returns a finished pod of job whose container "app" exited
+// with exitCode.
+func jobAttempt(name, job string, phase corev1.PodPhase, started time.Time, exitCode int32, reason string) *corev1.Pod {
+	template := podTemplate("migrate:1.0")
+	template.Spec.RestartPolicy = corev1.RestartPolicyNever
+	start := metav1.NewTime(started)
+	return &corev1.Pod{
+		ObjectMeta: objectMeta(name, map[string]string{"job-name": job}),
+		Spec:       template.Spec,
+		Status: corev1.PodStatus{
+			Phase:     phase,
+			StartTime: &start,
+			ContainerStatuses: []corev1.ContainerStatus{{
+				Name:  "app",
+				Image: "migrate:1.0",
+				State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{
+					ExitCode:   exitCode,
+					Reason:     reason,
+					StartedAt:  start,
+					FinishedAt: metav1.NewTime(started.Add(30 * time.Second)),
+				}},
+			}},
+		},
+	}
+}
@@ -0,0 +1,119 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/your-org/mcp-k8s-server/internal/domain"
+)
+
+type apiResourcesArgs struct {
+	ClusterID  string `json:"cluster_id" jsonschema:"ID of the cluster"`
+	APIGroup   string `json:"api_group,omitempty" jsonschema:"Optional: only this API group, e.g. 'apps'; '' is the core group"`
+	Namespaced *bool  `json:"namespaced,omitempty" jsonschema:"Optional: only namespaced (true) or cluster-scoped (false) types"`
+	Verb       string `json:"verb,omitempty" jsonschema:"Optional: only types supporting this verb, e.g. 'list'"`
+}
+
+func (m *MCPServer) handleAPIResources(ctx context.Context, req *mcp.CallToolRequest, args apiResourcesArgs) (*mcp.CallToolResult, *domain.APIResourceList, error) {
+	m.logger.Info("Handling API resources request", "args", args)
+
+	clusterID := args.ClusterID
+	filter := domain.APIResourceFilter{
+		APIGroup:   args.APIGroup,
+		Namespaced: args.Namespaced,
+		Verb:       args.Verb,
+	}
+
+	list, err := m.k8sUC.ListAPIResources(ctx, clusterID, filter)
+	if err != nil {
+		return errorResult(err), nil, nil
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "📚 Found %d API resources in cluster '%s':\n\n", len(list.Resources), clusterID)
+	for _, r := range list.Resources {
+		scope := "cluster"
+		if r.Namespaced {
+			scope = "namespaced"
+		}
+		fmt.Fprintf(&sb, "- %s (%s, %s, %s)", r.Name, r.Kind, r.APIVersion, scope)
+		if len(r.ShortNames) > 0 {
+			fmt.Fprintf(&sb, " short names: %s;", strings.Join(r.ShortNames, ","))
+		}
+		fmt.Fprintf(&sb, " verbs: %s\n", strings.Join(r.Verbs, ","))
+	}
+	if len(list.FailedGroups) > 0 {
+		sb.WriteString("\n⚠️ Discovery failed for:\n")
+		groups := make([]string, 0, len(list.FailedGroups))
+		for gv := range list.FailedGroups {
+			groups = append(groups, gv)
+		}
+		slices.Sort(groups)
+		for _, gv := range groups {
+			fmt.Fprintf(&sb, "- %s: %s\n", gv, list.FailedGroups[gv])
+		}
+	}
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{Text: sb.String()},
+			&mcp.TextContent{Text: string(mustMarshalJSON(list))},
+		},
+	}, list, nil
+}
+
+type explainArgs struct {
+	ClusterID  string `json:"cluster_id" jsonschema:"ID of the cluster"`
+	Path       string `json:"path" jsonschema:"Resource followed by field names, e.g. 'deployment.spec.template.spec.containers.readinessProbe'; the resource may be a kind, resource name or short name"`
+	APIVersion string `json:"api_version,omitempty" jsonschema:"Optional: group/version of the resource, e.g. 'cert-manager.io/v1'"`
+}
+
+func (m *MCPServer) handleExplain(ctx context.Context, req *mcp.CallToolRequest, args explainArgs) (*mcp.CallToolResult, *domain.ExplainResult, error) {
+	m.logger.Info("Handling explain request", "args", args)
+
+	clusterID, path, apiVersion := args.ClusterID, args.Path, args.APIVersion
+
+	result, err := m.k8sUC.Explain(ctx, clusterID, path, apiVersion)
+	if err != nil {
+		return errorResult(err), nil, nil
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "📖 KIND: %s\nVERSION: %s\n\nFIELD: %s <%s>", result.Kind, result.APIVersion, result.Path, result.Field.Type)
+	if result.Field.Required {
+		sb.WriteString(" -required-")
+	}
+	fmt.Fprintf(&sb, "\n\nDESCRIPTION:\n%s\n", result.Field.Description)
+	if len(result.Field.Enum) > 0 {
+		fmt.Fprintf(&sb, "\nENUM: %s\n", strings.Join(result.Field.Enum, ", "))
+	}
+	if len(result.Fields) > 0 {
+		sb.WriteString("\nFIELDS:\n")
+		for _, f := range result.Fields {
+			fmt.Fprintf(&sb, "  %s <%s>", f.Name, f.Type)
+			if f.Required {
+				sb.WriteString(" -required-")
+			}
+			fmt.Fprintf(&sb, "\n    %s\n", firstSentence(f.Description))
+		}
+	}
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{Text: sb.String()},
+			&mcp.TextContent{Text: string(mustMarshalJSON(result))},
+		},
+	}, result, nil
+}
+
+// firstSentence shortens a field description for listings; the full text
+// is in the structured result.
+func firstSentence(description string) string {
+	if i := strings.Index(description, ". "); i >= 0 {
+		return description[:i+1]
+	}
+	return description
+}
@@ -5,17 +5,21 @@ import (
 	"fmt"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
-	// Import domain package
-	// "github.com/your-org/mcp-k8s-server/internal/domain"
+	"github.com/your-org/mcp-k8s-server/internal/domain"
 )
 
-func (m *MCPServer) handleListClusterRoles(ctx context.Context, req *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
-	clusterID, _ := args["cluster_id"].(string)
+type listClusterRolesArgs struct {
+	ClusterID string `json:"cluster_id" jsonschema:"ID of the cluster"`
+}
 
-	if clusterID == "" {
-		err := fmt.Errorf("cluster_id is required")
-		return errorResult(err), nil, err
-	}
+type listClusterRolesResult struct {
+	ClusterID    string               `json:"cluster_id"`
+	Count        int                  `json:"count"`
+	ClusterRoles []domain.ClusterRole `json:"cluster_roles"`
+}
+
+func (m *MCPServer) handleListClusterRoles(ctx context.Context, req *mcp.CallToolRequest, args listClusterRolesArgs) (*mcp.CallToolResult, *listClusterRolesResult, error) {
+	clusterID := args.ClusterID
 
 	clusterRoles, err := m.k8sUC.ListClusterRoles(ctx, clusterID)
 	if err != nil {
@@ -39,10 +43,10 @@ func (m *MCPServer) handleListClusterRoles(ctx context.Context, req *mcp.CallToo
 		}
 	}
 
-	resultData := map[string]any{
-		"cluster_id":    clusterID,
-		"count":         len(clusterRoles),
-		"cluster_roles": clusterRoles,
+	resultData := &listClusterRolesResult{
+		ClusterID:    clusterID,
+		Count:        len(clusterRoles),
+		ClusterRoles: clusterRoles,
 	}
 
 	return &mcp.CallToolResult{
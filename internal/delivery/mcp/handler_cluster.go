@@ -0,0 +1,168 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/your-org/mcp-k8s-server/internal/domain"
+)
+
+// clusterInfo describes a registered cluster without exposing its
+// kubeconfig data.
+type clusterInfo struct {
+	ClusterID domain.ClusterID     `json:"cluster_id"`
+	Name      string               `json:"name,omitempty"`
+	Status    domain.ClusterStatus `json:"status"`
+	CreatedAt *time.Time           `json:"created_at,omitempty"`
+	Config    clusterConfigInfo    `json:"config"`
+}
+
+type clusterConfigInfo struct {
+	KubeconfigPath    string `json:"kubeconfig_path"`
+	HasKubeconfigData bool   `json:"has_kubeconfig_data"`
+	InCluster         bool   `json:"in_cluster"`
+	Context           string `json:"context"`
+}
+
+func clusterSummary(c domain.Cluster) clusterInfo {
+	summary := clusterInfo{
+		ClusterID: c.ID,
+		Name:      c.Name,
+		Status:    c.Status,
+		Config: clusterConfigInfo{
+			KubeconfigPath:    c.Config.KubeconfigPath,
+			HasKubeconfigData: len(c.Config.KubeconfigData) > 0,
+			InCluster:         c.Config.InCluster,
+			Context:           c.Config.Context,
+		},
+	}
+	if !c.CreatedAt.IsZero() {
+		summary.CreatedAt = &c.CreatedAt
+	}
+	return summary
+}
+
+type clusterListArgs struct{}
+
+type clusterListResult struct {
+	Count    int           `json:"count"`
+	Clusters []clusterInfo `json:"clusters"`
+}
+
+func (m *MCPServer) handleClusterList(ctx context.Context, req *mcp.CallToolRequest, args clusterListArgs) (*mcp.CallToolResult, *clusterListResult, error) {
+	m.logger.Info("Handling cluster list request")
+
+	clusters, err := m.clusterUC.ListClusters(ctx)
+	if err != nil {
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{Text: fmt.Sprintf("Failed to list clusters: %v", err)},
+			},
+			IsError: true,
+		}, nil, nil
+	}
+
+	items := make([]clusterInfo, 0, len(clusters))
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Found %d registered cluster(s)\n", len(clusters))
+	for _, c := range clusters {
+		items = append(items, clusterSummary(c))
+
+		icon := "✅"
+		if c.Status != domain.ClusterStatusActive {
+			icon = "❌"
+		}
+		fmt.Fprintf(&sb, "%s %s (%s)\n", icon, c.ID, c.Status)
+	}
+
+	resultData := &clusterListResult{
+		Count:    len(clusters),
+		Clusters: items,
+	}
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{Text: sb.String()},
+			&mcp.TextContent{Text: string(mustMarshalJSON(resultData))},
+		},
+	}, resultData, nil
+}
+
+type clusterStatusArgs struct {
+	ClusterID string `json:"cluster_id" jsonschema:"ID of the cluster"`
+}
+
+type clusterStatusResult struct {
+	ClusterID string               `json:"cluster_id"`
+	Status    domain.ClusterStatus `json:"status"`
+	Error     string               `json:"error,omitempty"`
+}
+
+func (m *MCPServer) handleClusterStatus(ctx context.Context, req *mcp.CallToolRequest, args clusterStatusArgs) (*mcp.CallToolResult, *clusterStatusResult, error) {
+	m.logger.Info("Handling cluster status request", "args", args)
+
+	clusterID := args.ClusterID
+	resultData := &clusterStatusResult{
+		ClusterID: clusterID,
+	}
+
+	status, err := m.clusterUC.GetClusterStatus(ctx, domain.ClusterID(clusterID))
+	if err != nil {
+		resultData.Status = domain.ClusterStatusError
+		resultData.Error = err.Error()
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{Text: fmt.Sprintf("❌ Cluster '%s' is not reachable: %v", clusterID, err)},
+				&mcp.TextContent{Text: string(mustMarshalJSON(resultData))},
+			},
+			IsError: true,
+		}, resultData, nil
+	}
+
+	resultData.Status = *status
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{Text: fmt.Sprintf("✅ Cluster '%s' is %s", clusterID, *status)},
+			&mcp.TextContent{Text: string(mustMarshalJSON(resultData))},
+		},
+	}, resultData, nil
+}
+
+type clusterUnregisterArgs struct {
+	ClusterID string `json:"cluster_id" jsonschema:"ID of the cluster"`
+}
+
+type clusterUnregisterResult struct {
+	ClusterID string `json:"cluster_id"`
+	Status    string `json:"status"`
+}
+
+func (m *MCPServer) handleClusterUnregister(ctx context.Context, req *mcp.CallToolRequest, args clusterUnregisterArgs) (*mcp.CallToolResult, *clusterUnregisterResult, error) {
+	m.logger.Info("Handling cluster unregister request", "args", args)
+
+	clusterID := args.ClusterID
+	if err := m.clusterUC.DeleteCluster(ctx, domain.ClusterID(clusterID)); err != nil {
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{Text: fmt.Sprintf("Failed to unregister cluster: %v", err)},
+			},
+			IsError: true,
+		}, nil, nil
+	}
+
+	resultData := &clusterUnregisterResult{
+		ClusterID: clusterID,
+		Status:    "unregistered",
+	}
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{Text: fmt.Sprintf("Cluster '%s' unregistered successfully", clusterID)},
+			&mcp.TextContent{Text: string(mustMarshalJSON(resultData))},
+		},
+	}, resultData, nil
+}
@@ -3,6 +3,8 @@ package mcp
 import (
 	"context"
 	"fmt"
+	"maps"
+	"slices"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 	"github.com/your-org/mcp-k8s-server/internal/domain"
@@ -10,24 +12,22 @@ import (
 
 // ==================== ConfigMap Handlers ====================
 
-func (m *MCPServer) handleListConfigMaps(ctx context.Context, req *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
-	m.logger.Info("Handling list configmaps request", "args", args)
+type listConfigMapsArgs struct {
+	ClusterID string `json:"cluster_id" jsonschema:"ID of the cluster"`
+	Namespace string `json:"namespace,omitempty" jsonschema:"Namespace to list ConfigMaps from" default:"default"`
+}
 
-	clusterID, _ := args["cluster_id"].(string)
-	namespace, _ := args["namespace"].(string)
+type listConfigMapsResult struct {
+	ClusterID  string                    `json:"cluster_id"`
+	Namespace  string                    `json:"namespace"`
+	Count      int                       `json:"count"`
+	ConfigMaps []domain.ConfigMapSummary `json:"configmaps"`
+}
 
-	if clusterID == "" {
-		return &mcp.CallToolResult{
-			Content: []mcp.Content{
-				&mcp.TextContent{Text: "Error: cluster_id is required"},
-			},
-			IsError: true,
-		}, nil, nil
-	}
+func (m *MCPServer) handleListConfigMaps(ctx context.Context, req *mcp.CallToolRequest, args listConfigMapsArgs) (*mcp.CallToolResult, *listConfigMapsResult, error) {
+	m.logger.Info("Handling list configmaps request", "args", args)
 
-	if namespace == "" {
-		namespace = "default"
-	}
+	clusterID, namespace := args.ClusterID, args.Namespace
 
 	configMaps, err := m.k8sUC.ListConfigMaps(ctx, clusterID, namespace)
 	if err != nil {
@@ -42,14 +42,14 @@ func (m *MCPServer) handleListConfigMaps(ctx context.Context, req *mcp.CallToolR
 	summary := fmt.Sprintf("📋 Found %d ConfigMaps in namespace '%s':\n\n", len(configMaps), namespace)
 	for i, cm := range configMaps {
 		summary += fmt.Sprintf("%d. %s - Data keys: %d, Created: %s\n",
-			i+1, cm["name"], cm["data_count"], cm["created"])
+			i+1, cm.Name, cm.DataCount, cm.Created)
 	}
 
-	resultData := map[string]any{
-		"cluster_id": clusterID,
-		"namespace":  namespace,
-		"count":      len(configMaps),
-		"configmaps": configMaps,
+	resultData := &listConfigMapsResult{
+		ClusterID:  clusterID,
+		Namespace:  namespace,
+		Count:      len(configMaps),
+		ConfigMaps: configMaps,
 	}
 
 	return &mcp.CallToolResult{
@@ -60,25 +60,16 @@ func (m *MCPServer) handleListConfigMaps(ctx context.Context, req *mcp.CallToolR
 	}, resultData, nil
 }
 
-func (m *MCPServer) handleGetConfigMap(ctx context.Context, req *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
-	m.logger.Info("Handling get configmap request", "args", args)
-
-	clusterID, _ := args["cluster_id"].(string)
-	namespace, _ := args["namespace"].(string)
-	configMapName, _ := args["configmap_name"].(string)
+type getConfigMapArgs struct {
+	ClusterID     string `json:"cluster_id" jsonschema:"ID of the cluster"`
+	Namespace     string `json:"namespace,omitempty" jsonschema:"Namespace of the ConfigMap" default:"default"`
+	ConfigMapName string `json:"configmap_name" jsonschema:"Name of the ConfigMap"`
+}
 
-	if clusterID == "" || configMapName == "" {
-		return &mcp.CallToolResult{
-			Content: []mcp.Content{
-				&mcp.TextContent{Text: "Error: cluster_id and configmap_name are required"},
-			},
-			IsError: true,
-		}, nil, nil
-	}
+func (m *MCPServer) handleGetConfigMap(ctx context.Context, req *mcp.CallToolRequest, args getConfigMapArgs) (*mcp.CallToolResult, *domain.ConfigMapDetail, error) {
+	m.logger.Info("Handling get configmap request", "args", args)
 
-	if namespace == "" {
-		namespace = "default"
-	}
+	clusterID, namespace, configMapName := args.ClusterID, args.Namespace, args.ConfigMapName
 
 	configMapInfo, err := m.k8sUC.GetConfigMap(ctx, clusterID, namespace, configMapName)
 	if err != nil {
@@ -91,12 +82,13 @@ func (m *MCPServer) handleGetConfigMap(ctx context.Context, req *mcp.CallToolReq
 	}
 
 	summary := fmt.Sprintf("📋 ConfigMap '%s' in namespace '%s':\n\n", configMapName, namespace)
-	summary += fmt.Sprintf("Created: %s\n\n", configMapInfo["created"])
+	summary += fmt.Sprintf("Created: %s\n\n", configMapInfo.Created)
 
-	if data, ok := configMapInfo["data"].(map[string]string); ok && len(data) > 0 {
+	if data := configMapInfo.Data; len(data) > 0 {
 		summary += fmt.Sprintf("Data (%d keys):\n", len(data))
-		for key, value := range data {
+		for _, key := range slices.Sorted(maps.Keys(data)) {
 			// Truncate long values
+			value := data[key]
 			displayValue := value
 			if len(value) > 100 {
 				displayValue = value[:100] + "... (truncated)"
@@ -105,10 +97,10 @@ func (m *MCPServer) handleGetConfigMap(ctx context.Context, req *mcp.CallToolReq
 		}
 	}
 
-	if labels, ok := configMapInfo["labels"].(map[string]string); ok && len(labels) > 0 {
+	if labels := configMapInfo.Labels; len(labels) > 0 {
 		summary += "\nLabels:\n"
-		for k, v := range labels {
-			summary += fmt.Sprintf("  %s: %s\n", k, v)
+		for _, k := range slices.Sorted(maps.Keys(labels)) {
+			summary += fmt.Sprintf("  %s: %s\n", k, labels[k])
 		}
 	}
 
@@ -120,36 +112,28 @@ func (m *MCPServer) handleGetConfigMap(ctx context.Context, req *mcp.CallToolReq
 	}, configMapInfo, nil
 }
 
-func (m *MCPServer) handleCreateConfigMap(ctx context.Context, req *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
-	m.logger.Info("Handling create configmap request", "args", args)
-
-	clusterID, _ := args["cluster_id"].(string)
-	namespace, _ := args["namespace"].(string)
-	configMapName, _ := args["configmap_name"].(string)
+type createConfigMapArgs struct {
+	ClusterID     string            `json:"cluster_id" jsonschema:"ID of the cluster"`
+	Namespace     string            `json:"namespace,omitempty" jsonschema:"Namespace to create the ConfigMap in" default:"default"`
+	ConfigMapName string            `json:"configmap_name" jsonschema:"Name of the ConfigMap"`
+	Data          map[string]string `json:"data" jsonschema:"Key-value pairs for the ConfigMap data"`
+	Labels        map[string]string `json:"labels,omitempty" jsonschema:"Labels to apply to the ConfigMap"`
+}
 
-	if clusterID == "" || configMapName == "" {
-		return &mcp.CallToolResult{
-			Content: []mcp.Content{
-				&mcp.TextContent{Text: "Error: cluster_id and configmap_name are required"},
-			},
-			IsError: true,
-		}, nil, nil
-	}
+type createConfigMapResult struct {
+	ClusterID     string `json:"cluster_id"`
+	Namespace     string `json:"namespace"`
+	ConfigMapName string `json:"configmap_name"`
+	DataKeys      int    `json:"data_keys"`
+	Status        string `json:"status"`
+}
 
-	if namespace == "" {
-		namespace = "default"
-	}
+func (m *MCPServer) handleCreateConfigMap(ctx context.Context, req *mcp.CallToolRequest, args createConfigMapArgs) (*mcp.CallToolResult, *createConfigMapResult, error) {
+	m.logger.Info("Handling create configmap request", "args", args)
 
-	// Parse data
-	data := make(map[string]string)
-	if dataRaw, ok := args["data"].(map[string]any); ok {
-		for k, v := range dataRaw {
-			if strVal, ok := v.(string); ok {
-				data[k] = strVal
-			}
-		}
-	}
+	clusterID, namespace, configMapName := args.ClusterID, args.Namespace, args.ConfigMapName
 
+	data := args.Data
 	if len(data) == 0 {
 		return &mcp.CallToolResult{
 			Content: []mcp.Content{
@@ -159,21 +143,11 @@ func (m *MCPServer) handleCreateConfigMap(ctx context.Context, req *mcp.CallTool
 		}, nil, nil
 	}
 
-	// Parse labels
-	labels := make(map[string]string)
-	if labelsRaw, ok := args["labels"].(map[string]any); ok {
-		for k, v := range labelsRaw {
-			if strVal, ok := v.(string); ok {
-				labels[k] = strVal
-			}
-		}
-	}
-
 	options := domain.ConfigMapCreateOptions{
 		Name:      configMapName,
 		Namespace: namespace,
 		Data:      data,
-		Labels:    labels,
+		Labels:    args.Labels,
 	}
 
 	err := m.k8sUC.CreateConfigMap(ctx, clusterID, options)
@@ -186,12 +160,12 @@ func (m *MCPServer) handleCreateConfigMap(ctx context.Context, req *mcp.CallTool
 		}, nil, nil
 	}
 
-	resultData := map[string]any{
-		"cluster_id":     clusterID,
-		"namespace":      namespace,
-		"configmap_name": configMapName,
-		"data_keys":      len(data),
-		"status":         "created",
+	resultData := &createConfigMapResult{
+		ClusterID:     clusterID,
+		Namespace:     namespace,
+		ConfigMapName: configMapName,
+		DataKeys:      len(data),
+		Status:        "created",
 	}
 
 	return &mcp.CallToolResult{
@@ -203,25 +177,23 @@ func (m *MCPServer) handleCreateConfigMap(ctx context.Context, req *mcp.CallTool
 	}, resultData, nil
 }
 
-func (m *MCPServer) handleDeleteConfigMap(ctx context.Context, req *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
-	m.logger.Info("Handling delete configmap request", "args", args)
+type deleteConfigMapArgs struct {
+	ClusterID     string `json:"cluster_id" jsonschema:"ID of the cluster"`
+	Namespace     string `json:"namespace,omitempty" jsonschema:"Namespace of the ConfigMap" default:"default"`
+	ConfigMapName string `json:"configmap_name" jsonschema:"Name of the ConfigMap to delete"`
+}
 
-	clusterID, _ := args["cluster_id"].(string)
-	namespace, _ := args["namespace"].(string)
-	configMapName, _ := args["configmap_name"].(string)
+type deleteConfigMapResult struct {
+	ClusterID     string `json:"cluster_id"`
+	Namespace     string `json:"namespace"`
+	ConfigMapName string `json:"configmap_name"`
+	Status        string `json:"status"`
+}
 
-	if clusterID == "" || configMapName == "" {
-		return &mcp.CallToolResult{
-			Content: []mcp.Content{
-				&mcp.TextContent{Text: "Error: cluster_id and configmap_name are required"},
-			},
-			IsError: true,
-		}, nil, nil
-	}
+func (m *MCPServer) handleDeleteConfigMap(ctx context.Context, req *mcp.CallToolRequest, args deleteConfigMapArgs) (*mcp.CallToolResult, *deleteConfigMapResult, error) {
+	m.logger.Info("Handling delete configmap request", "args", args)
 
-	if namespace == "" {
-		namespace = "default"
-	}
+	clusterID, namespace, configMapName := args.ClusterID, args.Namespace, args.ConfigMapName
 
 	err := m.k8sUC.DeleteConfigMap(ctx, clusterID, namespace, configMapName)
 	if err != nil {
@@ -233,11 +205,11 @@ func (m *MCPServer) handleDeleteConfigMap(ctx context.Context, req *mcp.CallTool
 		}, nil, nil
 	}
 
-	resultData := map[string]any{
-		"cluster_id":     clusterID,
-		"namespace":      namespace,
-		"configmap_name": configMapName,
-		"status":         "deleted",
+	resultData := &deleteConfigMapResult{
+		ClusterID:     clusterID,
+		Namespace:     namespace,
+		ConfigMapName: configMapName,
+		Status:        "deleted",
 	}
 
 	return &mcp.CallToolResult{
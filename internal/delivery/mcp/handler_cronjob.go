@@ -3,31 +3,32 @@ package mcp
 import (
 	"context"
 	"fmt"
+	"strings"
 
+	"github.com/google/jsonschema-go/jsonschema"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 	"github.com/your-org/mcp-k8s-server/internal/domain"
+	"github.com/your-org/mcp-k8s-server/internal/usecase"
 )
 
 // ==================== CronJob Handlers ====================
 
-func (m *MCPServer) handleListCronJobs(ctx context.Context, req *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
-	m.logger.Info("Handling list cronjobs request", "args", args)
+type listCronJobsArgs struct {
+	ClusterID string `json:"cluster_id" jsonschema:"ID of the cluster"`
+	Namespace string `json:"namespace,omitempty" jsonschema:"Namespace to list CronJobs from" default:"default"`
+}
 
-	clusterID, _ := args["cluster_id"].(string)
-	namespace, _ := args["namespace"].(string)
+type listCronJobsResult struct {
+	ClusterID string                  `json:"cluster_id"`
+	Namespace string                  `json:"namespace"`
+	Count     int                     `json:"count"`
+	CronJobs  []domain.CronJobSummary `json:"cronjobs"`
+}
 
-	if clusterID == "" {
-		return &mcp.CallToolResult{
-			Content: []mcp.Content{
-				&mcp.TextContent{Text: "Error: cluster_id is required"},
-			},
-			IsError: true,
-		}, nil, nil
-	}
+func (m *MCPServer) handleListCronJobs(ctx context.Context, req *mcp.CallToolRequest, args listCronJobsArgs) (*mcp.CallToolResult, *listCronJobsResult, error) {
+	m.logger.Info("Handling list cronjobs request", "args", args)
 
-	if namespace == "" {
-		namespace = "default"
-	}
+	clusterID, namespace := args.ClusterID, args.Namespace
 
 	cronJobs, err := m.k8sUC.ListCronJobs(ctx, clusterID, namespace)
 	if err != nil {
@@ -42,18 +43,18 @@ func (m *MCPServer) handleListCronJobs(ctx context.Context, req *mcp.CallToolReq
 	summary := fmt.Sprintf("⏰ Found %d CronJobs in namespace '%s':\n\n", len(cronJobs), namespace)
 	for i, cj := range cronJobs {
 		statusIcon := ""
-		if cj["suspend"].(bool) {
+		if cj.Suspend {
 			statusIcon = "⏸️ "
 		}
 		summary += fmt.Sprintf("%d. %s %s - Schedule: %s, Active: %d, Last: %s\n",
-			i+1, statusIcon, cj["name"], cj["schedule"], cj["active"], cj["last_schedule"])
+			i+1, statusIcon, cj.Name, cj.Schedule, cj.Active, cj.LastSchedule)
 	}
 
-	resultData := map[string]any{
-		"cluster_id": clusterID,
-		"namespace":  namespace,
-		"count":      len(cronJobs),
-		"cronjobs":   cronJobs,
+	resultData := &listCronJobsResult{
+		ClusterID: clusterID,
+		Namespace: namespace,
+		Count:     len(cronJobs),
+		CronJobs:  cronJobs,
 	}
 
 	return &mcp.CallToolResult{
@@ -64,25 +65,16 @@ func (m *MCPServer) handleListCronJobs(ctx context.Context, req *mcp.CallToolReq
 	}, resultData, nil
 }
 
-func (m *MCPServer) handleGetCronJob(ctx context.Context, req *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
-	m.logger.Info("Handling get cronjob request", "args", args)
-
-	clusterID, _ := args["cluster_id"].(string)
-	namespace, _ := args["namespace"].(string)
-	cronJobName, _ := args["cronjob_name"].(string)
+type getCronJobArgs struct {
+	ClusterID   string `json:"cluster_id" jsonschema:"ID of the cluster"`
+	Namespace   string `json:"namespace,omitempty" jsonschema:"Namespace of the CronJob" default:"default"`
+	CronJobName string `json:"cronjob_name" jsonschema:"Name of the CronJob"`
+}
 
-	if clusterID == "" || cronJobName == "" {
-		return &mcp.CallToolResult{
-			Content: []mcp.Content{
-				&mcp.TextContent{Text: "Error: cluster_id and cronjob_name are required"},
-			},
-			IsError: true,
-		}, nil, nil
-	}
+func (m *MCPServer) handleGetCronJob(ctx context.Context, req *mcp.CallToolRequest, args getCronJobArgs) (*mcp.CallToolResult, *domain.CronJobDetail, error) {
+	m.logger.Info("Handling get cronjob request", "args", args)
 
-	if namespace == "" {
-		namespace = "default"
-	}
+	clusterID, namespace, cronJobName := args.ClusterID, args.Namespace, args.CronJobName
 
 	cronJobInfo, err := m.k8sUC.GetCronJob(ctx, clusterID, namespace, cronJobName)
 	if err != nil {
@@ -95,45 +87,37 @@ func (m *MCPServer) handleGetCronJob(ctx context.Context, req *mcp.CallToolReque
 	}
 
 	statusIcon := " Active"
-	if cronJobInfo["suspend"].(bool) {
+	if cronJobInfo.Suspend {
 		statusIcon = "⏸️  Suspended"
 	}
 
 	summary := fmt.Sprintf("⏰ CronJob '%s' in namespace '%s':\n\n", cronJobName, namespace)
 	summary += fmt.Sprintf("Status: %s\n", statusIcon)
-	summary += fmt.Sprintf("Schedule: %s\n", cronJobInfo["schedule"])
-	summary += fmt.Sprintf("Concurrency Policy: %s\n", cronJobInfo["concurrency_policy"])
-	summary += fmt.Sprintf("Successful Jobs History Limit: %d\n", cronJobInfo["successful_jobs_history_limit"])
-	summary += fmt.Sprintf("Failed Jobs History Limit: %d\n", cronJobInfo["failed_jobs_history_limit"])
+	summary += fmt.Sprintf("Schedule: %s\n", cronJobInfo.Schedule)
+	summary += fmt.Sprintf("Concurrency Policy: %s\n", cronJobInfo.ConcurrencyPolicy)
+	summary += fmt.Sprintf("Successful Jobs History Limit: %d\n", cronJobInfo.SuccessfulJobsHistoryLimit)
+	summary += fmt.Sprintf("Failed Jobs History Limit: %d\n", cronJobInfo.FailedJobsHistoryLimit)
 
-	if lastSchedule, ok := cronJobInfo["last_schedule_time"].(string); ok && lastSchedule != "" {
+	if lastSchedule := cronJobInfo.LastScheduleTime; lastSchedule != "" {
 		summary += fmt.Sprintf("Last Schedule Time: %s\n", lastSchedule)
 	}
-	if lastSuccessful, ok := cronJobInfo["last_successful_time"].(string); ok && lastSuccessful != "" {
+	if lastSuccessful := cronJobInfo.LastSuccessfulTime; lastSuccessful != "" {
 		summary += fmt.Sprintf("Last Successful Time: %s\n", lastSuccessful)
 	}
 
-	summary += fmt.Sprintf("Active Jobs: %d\n", cronJobInfo["active_count"])
-	if activeJobs, ok := cronJobInfo["active_jobs"].([]string); ok && len(activeJobs) > 0 {
+	summary += fmt.Sprintf("Active Jobs: %d\n", cronJobInfo.ActiveCount)
+	if activeJobs := cronJobInfo.ActiveJobs; len(activeJobs) > 0 {
 		summary += "  Running:\n"
 		for _, job := range activeJobs {
 			summary += fmt.Sprintf("  - %s\n", job)
 		}
 	}
 
-	summary += fmt.Sprintf("Created: %s\n", cronJobInfo["created"])
+	summary += fmt.Sprintf("Created: %s\n", cronJobInfo.Created)
 
-	if containers, ok := cronJobInfo["containers"].([]map[string]any); ok && len(containers) > 0 {
+	if containers := cronJobInfo.Containers; len(containers) > 0 {
 		summary += fmt.Sprintf("\nJob Template - Containers (%d):\n", len(containers))
-		for i, container := range containers {
-			summary += fmt.Sprintf("%d. %s - Image: %s\n", i+1, container["name"], container["image"])
-			if cmd, ok := container["command"].([]string); ok && len(cmd) > 0 {
-				summary += fmt.Sprintf("   Command: %v\n", cmd)
-			}
-			if args, ok := container["args"].([]string); ok && len(args) > 0 {
-				summary += fmt.Sprintf("   Args: %v\n", args)
-			}
-		}
+		summary += formatContainerCommands(containers)
 	}
 
 	return &mcp.CallToolResult{
@@ -144,96 +128,51 @@ func (m *MCPServer) handleGetCronJob(ctx context.Context, req *mcp.CallToolReque
 	}, cronJobInfo, nil
 }
 
-func (m *MCPServer) handleCreateCronJob(ctx context.Context, req *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
-	m.logger.Info("Handling create cronjob request", "args", args)
-
-	clusterID, _ := args["cluster_id"].(string)
-	namespace, _ := args["namespace"].(string)
-	cronJobName, _ := args["cronjob_name"].(string)
-	schedule, _ := args["schedule"].(string)
-	image, _ := args["image"].(string)
-
-	if clusterID == "" || cronJobName == "" || schedule == "" || image == "" {
-		return &mcp.CallToolResult{
-			Content: []mcp.Content{
-				&mcp.TextContent{Text: "Error: cluster_id, cronjob_name, schedule, and image are required"},
-			},
-			IsError: true,
-		}, nil, nil
-	}
-
-	if namespace == "" {
-		namespace = "default"
-	}
-
-	// Parse command
-	var command []string
-	if cmdRaw, ok := args["command"].([]any); ok {
-		for _, c := range cmdRaw {
-			if str, ok := c.(string); ok {
-				command = append(command, str)
-			}
-		}
-	}
+type createCronJobArgs struct {
+	ClusterID                  string            `json:"cluster_id" jsonschema:"ID of the cluster"`
+	Namespace                  string            `json:"namespace,omitempty" jsonschema:"Namespace to create the CronJob in" default:"default"`
+	CronJobName                string            `json:"cronjob_name" jsonschema:"Name of the CronJob"`
+	Schedule                   string            `json:"schedule" jsonschema:"Cron schedule expression (e.g., '0 * * * *' for hourly)"`
+	Image                      string            `json:"image" jsonschema:"Container image to run"`
+	Command                    []string          `json:"command,omitempty" jsonschema:"Command to run (optional)"`
+	Args                       []string          `json:"args,omitempty" jsonschema:"Arguments for the command (optional)"`
+	Suspend                    bool              `json:"suspend,omitempty" jsonschema:"Whether the CronJob is suspended" default:"false"`
+	ConcurrencyPolicy          string            `json:"concurrency_policy,omitempty" jsonschema:"Allow, Forbid, or Replace" default:"Allow"`
+	SuccessfulJobsHistoryLimit int32             `json:"successful_jobs_history_limit,omitempty" jsonschema:"Number of successful jobs to keep" default:"3" minimum:"0"`
+	FailedJobsHistoryLimit     int32             `json:"failed_jobs_history_limit,omitempty" jsonschema:"Number of failed jobs to keep" default:"1" minimum:"0"`
+	RestartPolicy              string            `json:"restart_policy,omitempty" jsonschema:"Restart policy (OnFailure, Never)" default:"OnFailure"`
+	Env                        map[string]string `json:"env,omitempty" jsonschema:"Environment variables"`
+	Labels                     map[string]string `json:"labels,omitempty" jsonschema:"Labels to apply to the CronJob"`
+}
 
-	// Parse args
-	var jobArgs []string
-	if argsRaw, ok := args["args"].([]any); ok {
-		for _, a := range argsRaw {
-			if str, ok := a.(string); ok {
-				jobArgs = append(jobArgs, str)
-			}
-		}
-	}
+type createCronJobResult struct {
+	ClusterID   string `json:"cluster_id"`
+	Namespace   string `json:"namespace"`
+	CronJobName string `json:"cronjob_name"`
+	Schedule    string `json:"schedule"`
+	Image       string `json:"image"`
+	Status      string `json:"status"`
+}
 
-	// Parse env
-	env := make(map[string]string)
-	if envRaw, ok := args["env"].(map[string]any); ok {
-		for k, v := range envRaw {
-			if strVal, ok := v.(string); ok {
-				env[k] = strVal
-			}
-		}
-	}
+func (m *MCPServer) handleCreateCronJob(ctx context.Context, req *mcp.CallToolRequest, args createCronJobArgs) (*mcp.CallToolResult, *createCronJobResult, error) {
+	m.logger.Info("Handling create cronjob request", "args", args)
 
-	// Parse labels
-	labels := make(map[string]string)
-	if labelsRaw, ok := args["labels"].(map[string]any); ok {
-		for k, v := range labelsRaw {
-			if strVal, ok := v.(string); ok {
-				labels[k] = strVal
-			}
-		}
-	}
+	clusterID, namespace, cronJobName, schedule, image := args.ClusterID, args.Namespace, args.CronJobName, args.Schedule, args.Image
 
 	options := domain.CronJobCreateOptions{
-		Name:      cronJobName,
-		Namespace: namespace,
-		Schedule:  schedule,
-		Image:     image,
-		Command:   command,
-		Args:      jobArgs,
-		Env:       env,
-		Labels:    labels,
-	}
-
-	// Parse optional fields
-	if suspend, ok := args["suspend"].(bool); ok {
-		options.Suspend = suspend
-	}
-	if concurrencyPolicy, ok := args["concurrency_policy"].(string); ok {
-		options.ConcurrencyPolicy = concurrencyPolicy
-	}
-	if successLimit, ok := args["successful_jobs_history_limit"].(float64); ok {
-		s := int32(successLimit)
-		options.SuccessfulJobsHistoryLimit = &s
-	}
-	if failLimit, ok := args["failed_jobs_history_limit"].(float64); ok {
-		f := int32(failLimit)
-		options.FailedJobsHistoryLimit = &f
-	}
-	if restartPolicy, ok := args["restart_policy"].(string); ok {
-		options.RestartPolicy = restartPolicy
+		Name:                       cronJobName,
+		Namespace:                  namespace,
+		Schedule:                   schedule,
+		Image:                      image,
+		Command:                    args.Command,
+		Args:                       args.Args,
+		Suspend:                    args.Suspend,
+		ConcurrencyPolicy:          args.ConcurrencyPolicy,
+		SuccessfulJobsHistoryLimit: &args.SuccessfulJobsHistoryLimit,
+		FailedJobsHistoryLimit:     &args.FailedJobsHistoryLimit,
+		RestartPolicy:              args.RestartPolicy,
+		Env:                        args.Env,
+		Labels:                     args.Labels,
 	}
 
 	err := m.k8sUC.CreateCronJob(ctx, clusterID, options)
@@ -246,13 +185,13 @@ func (m *MCPServer) handleCreateCronJob(ctx context.Context, req *mcp.CallToolRe
 		}, nil, nil
 	}
 
-	resultData := map[string]any{
-		"cluster_id":   clusterID,
-		"namespace":    namespace,
-		"cronjob_name": cronJobName,
-		"schedule":     schedule,
-		"image":        image,
-		"status":       "created",
+	resultData := &createCronJobResult{
+		ClusterID:   clusterID,
+		Namespace:   namespace,
+		CronJobName: cronJobName,
+		Schedule:    schedule,
+		Image:       image,
+		Status:      "created",
 	}
 
 	return &mcp.CallToolResult{
@@ -264,25 +203,24 @@ func (m *MCPServer) handleCreateCronJob(ctx context.Context, req *mcp.CallToolRe
 	}, resultData, nil
 }
 
-func (m *MCPServer) handleDeleteCronJob(ctx context.Context, req *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
-	m.logger.Info("Handling delete cronjob request", "args", args)
+type deleteCronJobArgs struct {
+	ClusterID   string `json:"cluster_id" jsonschema:"ID of the cluster"`
+	Namespace   string `json:"namespace,omitempty" jsonschema:"Namespace of the CronJob" default:"default"`
+	CronJobName string `json:"cronjob_name" jsonschema:"Name of the CronJob to delete"`
+}
 
-	clusterID, _ := args["cluster_id"].(string)
-	namespace, _ := args["namespace"].(string)
-	cronJobName, _ := args["cronjob_name"].(string)
+// cronJobActionResult is the result of deleting or triggering a CronJob.
+type cronJobActionResult struct {
+	ClusterID   string `json:"cluster_id"`
+	Namespace   string `json:"namespace"`
+	CronJobName string `json:"cronjob_name"`
+	Status      string `json:"status"`
+}
 
-	if clusterID == "" || cronJobName == "" {
-		return &mcp.CallToolResult{
-			Content: []mcp.Content{
-				&mcp.TextContent{Text: "Error: cluster_id and cronjob_name are required"},
-			},
-			IsError: true,
-		}, nil, nil
-	}
+func (m *MCPServer) handleDeleteCronJob(ctx context.Context, req *mcp.CallToolRequest, args deleteCronJobArgs) (*mcp.CallToolResult, *cronJobActionResult, error) {
+	m.logger.Info("Handling delete cronjob request", "args", args)
 
-	if namespace == "" {
-		namespace = "default"
-	}
+	clusterID, namespace, cronJobName := args.ClusterID, args.Namespace, args.CronJobName
 
 	err := m.k8sUC.DeleteCronJob(ctx, clusterID, namespace, cronJobName)
 	if err != nil {
@@ -294,11 +232,11 @@ func (m *MCPServer) handleDeleteCronJob(ctx context.Context, req *mcp.CallToolRe
 		}, nil, nil
 	}
 
-	resultData := map[string]any{
-		"cluster_id":   clusterID,
-		"namespace":    namespace,
-		"cronjob_name": cronJobName,
-		"status":       "deleted",
+	resultData := &cronJobActionResult{
+		ClusterID:   clusterID,
+		Namespace:   namespace,
+		CronJobName: cronJobName,
+		Status:      "deleted",
 	}
 
 	return &mcp.CallToolResult{
@@ -310,26 +248,25 @@ func (m *MCPServer) handleDeleteCronJob(ctx context.Context, req *mcp.CallToolRe
 	}, resultData, nil
 }
 
-func (m *MCPServer) handleSuspendCronJob(ctx context.Context, req *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
-	m.logger.Info("Handling suspend cronjob request", "args", args)
+type suspendCronJobArgs struct {
+	ClusterID   string `json:"cluster_id" jsonschema:"ID of the cluster"`
+	Namespace   string `json:"namespace,omitempty" jsonschema:"Namespace of the CronJob" default:"default"`
+	CronJobName string `json:"cronjob_name" jsonschema:"Name of the CronJob"`
+	Suspend     bool   `json:"suspend" jsonschema:"True to suspend, false to resume"`
+}
 
-	clusterID, _ := args["cluster_id"].(string)
-	namespace, _ := args["namespace"].(string)
-	cronJobName, _ := args["cronjob_name"].(string)
-	suspend, _ := args["suspend"].(bool)
+type suspendCronJobResult struct {
+	ClusterID   string `json:"cluster_id"`
+	Namespace   string `json:"namespace"`
+	CronJobName string `json:"cronjob_name"`
+	Suspend     bool   `json:"suspend"`
+	Status      string `json:"status"`
+}
 
-	if clusterID == "" || cronJobName == "" {
-		return &mcp.CallToolResult{
-			Content: []mcp.Content{
-				&mcp.TextContent{Text: "Error: cluster_id, cronjob_name, and suspend are required"},
-			},
-			IsError: true,
-		}, nil, nil
-	}
+func (m *MCPServer) handleSuspendCronJob(ctx context.Context, req *mcp.CallToolRequest, args suspendCronJobArgs) (*mcp.CallToolResult, *suspendCronJobResult, error) {
+	m.logger.Info("Handling suspend cronjob request", "args", args)
 
-	if namespace == "" {
-		namespace = "default"
-	}
+	clusterID, namespace, cronJobName, suspend := args.ClusterID, args.Namespace, args.CronJobName, args.Suspend
 
 	err := m.k8sUC.SuspendCronJob(ctx, clusterID, namespace, cronJobName, suspend)
 	if err != nil {
@@ -348,12 +285,12 @@ func (m *MCPServer) handleSuspendCronJob(ctx context.Context, req *mcp.CallToolR
 		icon = "⏸️ "
 	}
 
-	resultData := map[string]any{
-		"cluster_id":   clusterID,
-		"namespace":    namespace,
-		"cronjob_name": cronJobName,
-		"suspend":      suspend,
-		"status":       action,
+	resultData := &suspendCronJobResult{
+		ClusterID:   clusterID,
+		Namespace:   namespace,
+		CronJobName: cronJobName,
+		Suspend:     suspend,
+		Status:      action,
 	}
 
 	return &mcp.CallToolResult{
@@ -365,25 +302,16 @@ func (m *MCPServer) handleSuspendCronJob(ctx context.Context, req *mcp.CallToolR
 	}, resultData, nil
 }
 
-func (m *MCPServer) handleTriggerCronJob(ctx context.Context, req *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
-	m.logger.Info("Handling trigger cronjob request", "args", args)
-
-	clusterID, _ := args["cluster_id"].(string)
-	namespace, _ := args["namespace"].(string)
-	cronJobName, _ := args["cronjob_name"].(string)
+type triggerCronJobArgs struct {
+	ClusterID   string `json:"cluster_id" jsonschema:"ID of the cluster"`
+	Namespace   string `json:"namespace,omitempty" jsonschema:"Namespace of the CronJob" default:"default"`
+	CronJobName string `json:"cronjob_name" jsonschema:"Name of the CronJob to trigger"`
+}
 
-	if clusterID == "" || cronJobName == "" {
-		return &mcp.CallToolResult{
-			Content: []mcp.Content{
-				&mcp.TextContent{Text: "Error: cluster_id and cronjob_name are required"},
-			},
-			IsError: true,
-		}, nil, nil
-	}
+func (m *MCPServer) handleTriggerCronJob(ctx context.Context, req *mcp.CallToolRequest, args triggerCronJobArgs) (*mcp.CallToolResult, *cronJobActionResult, error) {
+	m.logger.Info("Handling trigger cronjob request", "args", args)
 
-	if namespace == "" {
-		namespace = "default"
-	}
+	clusterID, namespace, cronJobName := args.ClusterID, args.Namespace, args.CronJobName
 
 	err := m.k8sUC.TriggerCronJob(ctx, clusterID, namespace, cronJobName)
 	if err != nil {
@@ -395,11 +323,11 @@ func (m *MCPServer) handleTriggerCronJob(ctx context.Context, req *mcp.CallToolR
 		}, nil, nil
 	}
 
-	resultData := map[string]any{
-		"cluster_id":   clusterID,
-		"namespace":    namespace,
-		"cronjob_name": cronJobName,
-		"status":       "triggered",
+	resultData := &cronJobActionResult{
+		ClusterID:   clusterID,
+		Namespace:   namespace,
+		CronJobName: cronJobName,
+		Status:      "triggered",
 	}
 
 	return &mcp.CallToolResult{
@@ -410,3 +338,54 @@ func (m *MCPServer) handleTriggerCronJob(ctx context.Context, req *mcp.CallToolR
 		},
 	}, resultData, nil
 }
+
+type getCronJobLogsArgs struct {
+	ClusterID   string `json:"cluster_id" jsonschema:"ID of the cluster"`
+	Namespace   string `json:"namespace,omitempty" jsonschema:"Namespace of the CronJob" default:"default"`
+	CronJobName string `json:"cronjob_name" jsonschema:"Name of the CronJob"`
+	Jobs        int    `json:"jobs,omitempty" minimum:"0"`
+	logOptionArgs
+}
+
+func (getCronJobLogsArgs) adjustSchema(s *jsonschema.Schema) {
+	s.Properties["jobs"].Description = fmt.Sprintf("Number of most recent Jobs to read (at most %d)", usecase.MaxCronJobLogJobs)
+	s.Properties["jobs"].Default = defaultOf(usecase.DefaultCronJobLogJobs)
+}
+
+func (m *MCPServer) handleGetCronJobLogs(ctx context.Context, req *mcp.CallToolRequest, args getCronJobLogsArgs) (*mcp.CallToolResult, *domain.CronJobLogs, error) {
+	m.logger.Info("Handling get cronjob logs request", "args", args)
+
+	clusterID, namespace, cronJobName := args.ClusterID, args.Namespace, args.CronJobName
+
+	maxJobs := usecase.DefaultCronJobLogJobs
+	if args.Jobs > 0 {
+		maxJobs = min(args.Jobs, usecase.MaxCronJobLogJobs)
+	}
+	opts, err := args.logOptions()
+	if err != nil {
+		return errorResult(err), nil, nil
+	}
+
+	logs, err := m.k8sUC.GetCronJobLogs(ctx, clusterID, namespace, cronJobName, maxJobs, opts)
+	if err != nil {
+		return errorResult(fmt.Errorf("failed to get cronjob logs: %w", err)), nil, nil
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "📋 Logs from the %d most recent Job(s) of CronJob '%s' in namespace '%s'", len(logs.Jobs), cronJobName, namespace)
+	if logs.JobsOmitted > 0 {
+		fmt.Fprintf(&sb, " (%d older Job(s) omitted)", logs.JobsOmitted)
+	}
+	sb.WriteString("\n")
+	if len(logs.Jobs) == 0 {
+		sb.WriteString("No Jobs found for this cronjob\n")
+	}
+	for i := range logs.Jobs {
+		sb.WriteString("\n")
+		writeJobLogs(&sb, &logs.Jobs[i])
+	}
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{&mcp.TextContent{Text: sb.String()}},
+	}, logs, nil
+}
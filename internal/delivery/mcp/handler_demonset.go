@@ -5,28 +5,27 @@ import (
 	"fmt"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/your-org/mcp-k8s-server/internal/domain"
 )
 
 // ==================== DaemonSet Handlers ====================
 
-func (m *MCPServer) handleListDaemonSets(ctx context.Context, req *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
-	m.logger.Info("Handling list daemonsets request", "args", args)
+type listDaemonSetsArgs struct {
+	ClusterID string `json:"cluster_id" jsonschema:"ID of the cluster"`
+	Namespace string `json:"namespace,omitempty" jsonschema:"Namespace to list DaemonSets from" default:"default"`
+}
 
-	clusterID, _ := args["cluster_id"].(string)
-	namespace, _ := args["namespace"].(string)
+type listDaemonSetsResult struct {
+	ClusterID  string                    `json:"cluster_id"`
+	Namespace  string                    `json:"namespace"`
+	Count      int                       `json:"count"`
+	DaemonSets []domain.DaemonSetSummary `json:"daemonsets"`
+}
 
-	if clusterID == "" {
-		return &mcp.CallToolResult{
-			Content: []mcp.Content{
-				&mcp.TextContent{Text: "Error: cluster_id is required"},
-			},
-			IsError: true,
-		}, nil, nil
-	}
+func (m *MCPServer) handleListDaemonSets(ctx context.Context, req *mcp.CallToolRequest, args listDaemonSetsArgs) (*mcp.CallToolResult, *listDaemonSetsResult, error) {
+	m.logger.Info("Handling list daemonsets request", "args", args)
 
-	if namespace == "" {
-		namespace = "default"
-	}
+	clusterID, namespace := args.ClusterID, args.Namespace
 
 	daemonSets, err := m.k8sUC.ListDaemonSets(ctx, clusterID, namespace)
 	if err != nil {
@@ -41,16 +40,16 @@ func (m *MCPServer) handleListDaemonSets(ctx context.Context, req *mcp.CallToolR
 	summary := fmt.Sprintf("⚙️ Found %d DaemonSets in namespace '%s':\n\n", len(daemonSets), namespace)
 	for i, ds := range daemonSets {
 		summary += fmt.Sprintf("%d. %s - Ready: %d/%d, Available: %d/%d\n",
-			i+1, ds["name"],
-			ds["number_ready"], ds["current_number_scheduled"],
-			ds["number_available"], ds["desired_number_scheduled"])
+			i+1, ds.Name,
+			ds.NumberReady, ds.CurrentNumberScheduled,
+			ds.NumberAvailable, ds.DesiredNumberScheduled)
 	}
 
-	resultData := map[string]any{
-		"cluster_id": clusterID,
-		"namespace":  namespace,
-		"count":      len(daemonSets),
-		"daemonsets": daemonSets,
+	resultData := &listDaemonSetsResult{
+		ClusterID:  clusterID,
+		Namespace:  namespace,
+		Count:      len(daemonSets),
+		DaemonSets: daemonSets,
 	}
 
 	return &mcp.CallToolResult{
@@ -61,25 +60,16 @@ func (m *MCPServer) handleListDaemonSets(ctx context.Context, req *mcp.CallToolR
 	}, resultData, nil
 }
 
-func (m *MCPServer) handleGetDaemonSet(ctx context.Context, req *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
-	m.logger.Info("Handling get daemonset request", "args", args)
-
-	clusterID, _ := args["cluster_id"].(string)
-	namespace, _ := args["namespace"].(string)
-	daemonSetName, _ := args["daemonset_name"].(string)
+type getDaemonSetArgs struct {
+	ClusterID     string `json:"cluster_id" jsonschema:"ID of the cluster"`
+	Namespace     string `json:"namespace,omitempty" jsonschema:"Namespace of the DaemonSet" default:"default"`
+	DaemonSetName string `json:"daemonset_name" jsonschema:"Name of the DaemonSet"`
+}
 
-	if clusterID == "" || daemonSetName == "" {
-		return &mcp.CallToolResult{
-			Content: []mcp.Content{
-				&mcp.TextContent{Text: "Error: cluster_id and daemonset_name are required"},
-			},
-			IsError: true,
-		}, nil, nil
-	}
+func (m *MCPServer) handleGetDaemonSet(ctx context.Context, req *mcp.CallToolRequest, args getDaemonSetArgs) (*mcp.CallToolResult, *domain.DaemonSetDetail, error) {
+	m.logger.Info("Handling get daemonset request", "args", args)
 
-	if namespace == "" {
-		namespace = "default"
-	}
+	clusterID, namespace, daemonSetName := args.ClusterID, args.Namespace, args.DaemonSetName
 
 	daemonSetInfo, err := m.k8sUC.GetDaemonSet(ctx, clusterID, namespace, daemonSetName)
 	if err != nil {
@@ -92,27 +82,27 @@ func (m *MCPServer) handleGetDaemonSet(ctx context.Context, req *mcp.CallToolReq
 	}
 
 	summary := fmt.Sprintf("⚙️ DaemonSet '%s' in namespace '%s':\n\n", daemonSetName, namespace)
-	summary += fmt.Sprintf("Desired: %d nodes\n", daemonSetInfo["desired_number_scheduled"])
-	summary += fmt.Sprintf("Current: %d scheduled\n", daemonSetInfo["current_number_scheduled"])
-	summary += fmt.Sprintf("Ready: %d\n", daemonSetInfo["number_ready"])
-	summary += fmt.Sprintf("Available: %d\n", daemonSetInfo["number_available"])
-	summary += fmt.Sprintf("Updated: %d\n", daemonSetInfo["updated_number_scheduled"])
+	summary += fmt.Sprintf("Desired: %d nodes\n", daemonSetInfo.DesiredNumberScheduled)
+	summary += fmt.Sprintf("Current: %d scheduled\n", daemonSetInfo.CurrentNumberScheduled)
+	summary += fmt.Sprintf("Ready: %d\n", daemonSetInfo.NumberReady)
+	summary += fmt.Sprintf("Available: %d\n", daemonSetInfo.NumberAvailable)
+	summary += fmt.Sprintf("Updated: %d\n", daemonSetInfo.UpdatedNumberScheduled)
 
-	if misscheduled, ok := daemonSetInfo["number_misscheduled"].(int32); ok && misscheduled > 0 {
+	if misscheduled := daemonSetInfo.NumberMisscheduled; misscheduled > 0 {
 		summary += fmt.Sprintf("⚠️  Misscheduled: %d\n", misscheduled)
 	}
 
-	summary += fmt.Sprintf("Update Strategy: %s\n", daemonSetInfo["update_strategy"])
-	summary += fmt.Sprintf("Created: %s\n", daemonSetInfo["created"])
+	summary += fmt.Sprintf("Update Strategy: %s\n", daemonSetInfo.UpdateStrategy)
+	summary += fmt.Sprintf("Created: %s\n", daemonSetInfo.Created)
 
-	if containers, ok := daemonSetInfo["containers"].([]map[string]any); ok && len(containers) > 0 {
+	if containers := daemonSetInfo.Containers; len(containers) > 0 {
 		summary += fmt.Sprintf("\nContainers (%d):\n", len(containers))
 		for i, container := range containers {
-			summary += fmt.Sprintf("%d. %s - Image: %s\n", i+1, container["name"], container["image"])
+			summary += fmt.Sprintf("%d. %s - Image: %s\n", i+1, container.Name, container.Image)
 		}
 	}
 
-	if nodeSelector, ok := daemonSetInfo["node_selector"].(map[string]string); ok && len(nodeSelector) > 0 {
+	if nodeSelector := daemonSetInfo.NodeSelector; len(nodeSelector) > 0 {
 		summary += "\nNode Selector:\n"
 		for k, v := range nodeSelector {
 			summary += fmt.Sprintf("  %s: %s\n", k, v)
@@ -127,25 +117,25 @@ func (m *MCPServer) handleGetDaemonSet(ctx context.Context, req *mcp.CallToolReq
 	}, daemonSetInfo, nil
 }
 
-func (m *MCPServer) handleRestartDaemonSet(ctx context.Context, req *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
-	m.logger.Info("Handling restart daemonset request", "args", args)
+// daemonSetStatusResult reports the new status of a DaemonSet after a
+// change.
+type daemonSetStatusResult struct {
+	ClusterID     string `json:"cluster_id"`
+	Namespace     string `json:"namespace"`
+	DaemonSetName string `json:"daemonset_name"`
+	Status        string `json:"status"`
+}
 
-	clusterID, _ := args["cluster_id"].(string)
-	namespace, _ := args["namespace"].(string)
-	daemonSetName, _ := args["daemonset_name"].(string)
+type restartDaemonSetArgs struct {
+	ClusterID     string `json:"cluster_id" jsonschema:"ID of the cluster"`
+	Namespace     string `json:"namespace,omitempty" jsonschema:"Namespace of the DaemonSet" default:"default"`
+	DaemonSetName string `json:"daemonset_name" jsonschema:"Name of the DaemonSet"`
+}
 
-	if clusterID == "" || daemonSetName == "" {
-		return &mcp.CallToolResult{
-			Content: []mcp.Content{
-				&mcp.TextContent{Text: "Error: cluster_id and daemonset_name are required"},
-			},
-			IsError: true,
-		}, nil, nil
-	}
+func (m *MCPServer) handleRestartDaemonSet(ctx context.Context, req *mcp.CallToolRequest, args restartDaemonSetArgs) (*mcp.CallToolResult, *daemonSetStatusResult, error) {
+	m.logger.Info("Handling restart daemonset request", "args", args)
 
-	if namespace == "" {
-		namespace = "default"
-	}
+	clusterID, namespace, daemonSetName := args.ClusterID, args.Namespace, args.DaemonSetName
 
 	err := m.k8sUC.RestartDaemonSet(ctx, clusterID, namespace, daemonSetName)
 	if err != nil {
@@ -157,11 +147,11 @@ func (m *MCPServer) handleRestartDaemonSet(ctx context.Context, req *mcp.CallToo
 		}, nil, nil
 	}
 
-	resultData := map[string]any{
-		"cluster_id":     clusterID,
-		"namespace":      namespace,
-		"daemonset_name": daemonSetName,
-		"status":         "restarting",
+	resultData := &daemonSetStatusResult{
+		ClusterID:     clusterID,
+		Namespace:     namespace,
+		DaemonSetName: daemonSetName,
+		Status:        "restarting",
 	}
 
 	return &mcp.CallToolResult{
@@ -173,25 +163,16 @@ func (m *MCPServer) handleRestartDaemonSet(ctx context.Context, req *mcp.CallToo
 	}, resultData, nil
 }
 
-func (m *MCPServer) handleDeleteDaemonSet(ctx context.Context, req *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
-	m.logger.Info("Handling delete daemonset request", "args", args)
-
-	clusterID, _ := args["cluster_id"].(string)
-	namespace, _ := args["namespace"].(string)
-	daemonSetName, _ := args["daemonset_name"].(string)
+type deleteDaemonSetArgs struct {
+	ClusterID     string `json:"cluster_id" jsonschema:"ID of the cluster"`
+	Namespace     string `json:"namespace,omitempty" jsonschema:"Namespace of the DaemonSet" default:"default"`
+	DaemonSetName string `json:"daemonset_name" jsonschema:"Name of the DaemonSet to delete"`
+}
 
-	if clusterID == "" || daemonSetName == "" {
-		return &mcp.CallToolResult{
-			Content: []mcp.Content{
-				&mcp.TextContent{Text: "Error: cluster_id and daemonset_name are required"},
-			},
-			IsError: true,
-		}, nil, nil
-	}
+func (m *MCPServer) handleDeleteDaemonSet(ctx context.Context, req *mcp.CallToolRequest, args deleteDaemonSetArgs) (*mcp.CallToolResult, *daemonSetStatusResult, error) {
+	m.logger.Info("Handling delete daemonset request", "args", args)
 
-	if namespace == "" {
-		namespace = "default"
-	}
+	clusterID, namespace, daemonSetName := args.ClusterID, args.Namespace, args.DaemonSetName
 
 	err := m.k8sUC.DeleteDaemonSet(ctx, clusterID, namespace, daemonSetName)
 	if err != nil {
@@ -203,11 +184,11 @@ func (m *MCPServer) handleDeleteDaemonSet(ctx context.Context, req *mcp.CallTool
 		}, nil, nil
 	}
 
-	resultData := map[string]any{
-		"cluster_id":     clusterID,
-		"namespace":      namespace,
-		"daemonset_name": daemonSetName,
-		"status":         "deleted",
+	resultData := &daemonSetStatusResult{
+		ClusterID:     clusterID,
+		Namespace:     namespace,
+		DaemonSetName: daemonSetName,
+		Status:        "deleted",
 	}
 
 	return &mcp.CallToolResult{
@@ -219,25 +200,24 @@ func (m *MCPServer) handleDeleteDaemonSet(ctx context.Context, req *mcp.CallTool
 	}, resultData, nil
 }
 
-func (m *MCPServer) handleGetDaemonSetPods(ctx context.Context, req *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
-	m.logger.Info("Handling get daemonset pods request", "args", args)
+type getDaemonSetPodsArgs struct {
+	ClusterID     string `json:"cluster_id" jsonschema:"ID of the cluster"`
+	Namespace     string `json:"namespace,omitempty" jsonschema:"Namespace of the DaemonSet" default:"default"`
+	DaemonSetName string `json:"daemonset_name" jsonschema:"Name of the DaemonSet"`
+}
 
-	clusterID, _ := args["cluster_id"].(string)
-	namespace, _ := args["namespace"].(string)
-	daemonSetName, _ := args["daemonset_name"].(string)
+type getDaemonSetPodsResult struct {
+	ClusterID     string                `json:"cluster_id"`
+	Namespace     string                `json:"namespace"`
+	DaemonSetName string                `json:"daemonset_name"`
+	PodCount      int                   `json:"pod_count"`
+	Pods          []domain.DaemonSetPod `json:"pods"`
+}
 
-	if clusterID == "" || daemonSetName == "" {
-		return &mcp.CallToolResult{
-			Content: []mcp.Content{
-				&mcp.TextContent{Text: "Error: cluster_id and daemonset_name are required"},
-			},
-			IsError: true,
-		}, nil, nil
-	}
+func (m *MCPServer) handleGetDaemonSetPods(ctx context.Context, req *mcp.CallToolRequest, args getDaemonSetPodsArgs) (*mcp.CallToolResult, *getDaemonSetPodsResult, error) {
+	m.logger.Info("Handling get daemonset pods request", "args", args)
 
-	if namespace == "" {
-		namespace = "default"
-	}
+	clusterID, namespace, daemonSetName := args.ClusterID, args.Namespace, args.DaemonSetName
 
 	pods, err := m.k8sUC.GetDaemonSetPods(ctx, clusterID, namespace, daemonSetName)
 	if err != nil {
@@ -253,24 +233,24 @@ func (m *MCPServer) handleGetDaemonSetPods(ctx context.Context, req *mcp.CallToo
 		len(pods), daemonSetName, namespace)
 
 	for i, pod := range pods {
-		summary += fmt.Sprintf("%d. %s\n", i+1, pod["name"])
-		summary += fmt.Sprintf("   Node: %s, Status: %s\n", pod["node"], pod["status"])
-		if hostIP, ok := pod["host_ip"].(string); ok && hostIP != "" {
-			summary += fmt.Sprintf("   Host IP: %s", hostIP)
-			if podIP, ok := pod["pod_ip"].(string); ok && podIP != "" {
-				summary += fmt.Sprintf(", Pod IP: %s", podIP)
+		summary += fmt.Sprintf("%d. %s\n", i+1, pod.Name)
+		summary += fmt.Sprintf("   Node: %s, Status: %s\n", pod.Node, pod.Status)
+		if pod.HostIP != "" {
+			summary += fmt.Sprintf("   Host IP: %s", pod.HostIP)
+			if pod.PodIP != "" {
+				summary += fmt.Sprintf(", Pod IP: %s", pod.PodIP)
 			}
 			summary += "\n"
 		}
 		summary += "\n"
 	}
 
-	resultData := map[string]any{
-		"cluster_id":     clusterID,
-		"namespace":      namespace,
-		"daemonset_name": daemonSetName,
-		"pod_count":      len(pods),
-		"pods":           pods,
+	resultData := &getDaemonSetPodsResult{
+		ClusterID:     clusterID,
+		Namespace:     namespace,
+		DaemonSetName: daemonSetName,
+		PodCount:      len(pods),
+		Pods:          pods,
 	}
 
 	return &mcp.CallToolResult{
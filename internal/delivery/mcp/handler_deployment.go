@@ -0,0 +1,325 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/your-org/mcp-k8s-server/internal/domain"
+)
+
+const (
+	defaultRolloutTimeout = 5 * time.Minute
+	maxRolloutTimeout     = 30 * time.Minute
+)
+
+// ==================== Deployment Handlers ====================
+
+// deploymentArgs are the arguments shared by every deployment tool.
+type deploymentArgs struct {
+	ClusterID      string `json:"cluster_id" jsonschema:"ID of the cluster"`
+	Namespace      string `json:"namespace,omitempty" jsonschema:"Namespace of the deployment" default:"default"`
+	DeploymentName string `json:"deployment_name" jsonschema:"Name of the deployment"`
+}
+
+// deploymentResult identifies the deployment a tool acted on.
+type deploymentResult struct {
+	ClusterID      string `json:"cluster_id"`
+	Namespace      string `json:"namespace"`
+	DeploymentName string `json:"deployment_name"`
+}
+
+type listDeploymentsArgs struct {
+	ClusterID string `json:"cluster_id" jsonschema:"ID of the cluster"`
+	Namespace string `json:"namespace,omitempty" jsonschema:"Namespace to list Deployments from" default:"default"`
+}
+
+type listDeploymentsResult struct {
+	ClusterID   string                     `json:"cluster_id"`
+	Namespace   string                     `json:"namespace"`
+	Count       int                        `json:"count"`
+	Deployments []domain.DeploymentSummary `json:"deployments"`
+}
+
+func (m *MCPServer) handleListDeployments(ctx context.Context, req *mcp.CallToolRequest, args listDeploymentsArgs) (*mcp.CallToolResult, *listDeploymentsResult, error) {
+	m.logger.Info("Handling list deployments request", "args", args)
+
+	clusterID, namespace := args.ClusterID, args.Namespace
+
+	deployments, err := m.k8sUC.ListDeployments(ctx, clusterID, namespace)
+	if err != nil {
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{Text: fmt.Sprintf("Failed to list deployments: %v", err)},
+			},
+			IsError: true,
+		}, nil, nil
+	}
+
+	summary := fmt.Sprintf("📊 Found %d Deployments in namespace '%s':\n\n", len(deployments), namespace)
+	for i, d := range deployments {
+		summary += fmt.Sprintf("%d. %s - Ready: %d/%d, Up-to-date: %d, Available: %d, Revision: %s",
+			i+1, d.Name, d.ReadyReplicas, d.Replicas, d.UpdatedReplicas, d.AvailableReplicas, d.Revision)
+		if d.Paused {
+			summary += " (paused)"
+		}
+		summary += "\n"
+	}
+
+	resultData := &listDeploymentsResult{
+		ClusterID:   clusterID,
+		Namespace:   namespace,
+		Count:       len(deployments),
+		Deployments: deployments,
+	}
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{Text: summary},
+			&mcp.TextContent{Text: string(mustMarshalJSON(resultData))},
+		},
+	}, resultData, nil
+}
+
+type restartDeploymentResult struct {
+	deploymentResult
+	Status string `json:"status"`
+}
+
+func (m *MCPServer) handleRestartDeployment(ctx context.Context, req *mcp.CallToolRequest, args deploymentArgs) (*mcp.CallToolResult, *restartDeploymentResult, error) {
+	m.logger.Info("Handling restart deployment request", "args", args)
+
+	clusterID, namespace, deploymentName := args.ClusterID, args.Namespace, args.DeploymentName
+
+	if err := m.k8sUC.RestartDeployment(ctx, clusterID, namespace, deploymentName); err != nil {
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{Text: fmt.Sprintf("Failed to restart deployment: %v", err)},
+			},
+			IsError: true,
+		}, nil, nil
+	}
+
+	resultData := &restartDeploymentResult{
+		deploymentResult: deploymentResult{ClusterID: clusterID, Namespace: namespace, DeploymentName: deploymentName},
+		Status:           "restarting",
+	}
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{Text: fmt.Sprintf(" Deployment '%s/%s' restart initiated. Pods will be replaced according to the rollout strategy; follow it with k8s_deployment_rollout_status.",
+				namespace, deploymentName)},
+			&mcp.TextContent{Text: string(mustMarshalJSON(resultData))},
+		},
+	}, resultData, nil
+}
+
+type deploymentRolloutStatusArgs struct {
+	deploymentArgs
+	TimeoutSeconds int `json:"timeout_seconds,omitempty" minimum:"0"`
+}
+
+func (deploymentRolloutStatusArgs) adjustSchema(s *jsonschema.Schema) {
+	s.Properties["timeout_seconds"].Description = fmt.Sprintf("How long to wait for the rollout (max %d). 0 returns the current state immediately.", int(maxRolloutTimeout.Seconds()))
+	s.Properties["timeout_seconds"].Default = defaultOf(int(defaultRolloutTimeout.Seconds()))
+}
+
+type deploymentRolloutStatusResult struct {
+	ClusterID string                `json:"cluster_id"`
+	Status    *domain.RolloutStatus `json:"status"`
+}
+
+func (m *MCPServer) handleDeploymentRolloutStatus(ctx context.Context, req *mcp.CallToolRequest, args deploymentRolloutStatusArgs) (*mcp.CallToolResult, *deploymentRolloutStatusResult, error) {
+	m.logger.Info("Handling deployment rollout status request", "args", args)
+
+	clusterID, namespace, deploymentName := args.ClusterID, args.Namespace, args.DeploymentName
+
+	// timeout_seconds defaults to defaultRolloutTimeout in the schema; an
+	// explicit 0 only reports the current state.
+	timeout := min(time.Duration(args.TimeoutSeconds)*time.Second, maxRolloutTimeout)
+
+	status, err := m.k8sUC.DeploymentRolloutStatus(ctx, clusterID, namespace, deploymentName, timeout)
+	if err != nil {
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{Text: fmt.Sprintf("Failed to get rollout status: %v", err)},
+			},
+			IsError: true,
+		}, nil, nil
+	}
+
+	icon := "⏳"
+	switch {
+	case status.Complete:
+		icon = "✅"
+	case status.Stuck:
+		icon = "❌"
+	case status.Paused:
+		icon = "⏸️"
+	}
+	summary := fmt.Sprintf("%s %s\nRevision: %s, Updated: %d/%d, Ready: %d, Available: %d\n",
+		icon, status.Message, status.Revision, status.UpdatedReplicas, status.Replicas, status.ReadyReplicas, status.AvailableReplicas)
+
+	resultData := &deploymentRolloutStatusResult{
+		ClusterID: clusterID,
+		Status:    status,
+	}
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{Text: summary},
+			&mcp.TextContent{Text: string(mustMarshalJSON(resultData))},
+		},
+		IsError: status.Stuck,
+	}, resultData, nil
+}
+
+type deploymentHistoryResult struct {
+	deploymentResult
+	Count     int                         `json:"count"`
+	Revisions []domain.DeploymentRevision `json:"revisions"`
+}
+
+func (m *MCPServer) handleDeploymentHistory(ctx context.Context, req *mcp.CallToolRequest, args deploymentArgs) (*mcp.CallToolResult, *deploymentHistoryResult, error) {
+	m.logger.Info("Handling deployment history request", "args", args)
+
+	clusterID, namespace, deploymentName := args.ClusterID, args.Namespace, args.DeploymentName
+
+	revisions, err := m.k8sUC.DeploymentHistory(ctx, clusterID, namespace, deploymentName)
+	if err != nil {
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{Text: fmt.Sprintf("Failed to get deployment history: %v", err)},
+			},
+			IsError: true,
+		}, nil, nil
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "📜 Rollout history of deployment '%s/%s' (%d revisions):\n\n", namespace, deploymentName, len(revisions))
+	for _, r := range revisions {
+		current := ""
+		if r.Current {
+			current = " (current)"
+		}
+		cause := r.ChangeCause
+		if cause == "" {
+			cause = "<none>"
+		}
+		fmt.Fprintf(&sb, "Revision %d%s - %s, change-cause: %s\n", r.Revision, current, r.ReplicaSet, cause)
+		for _, c := range r.ImageChanges {
+			fmt.Fprintf(&sb, "    %s\n", c)
+		}
+	}
+
+	resultData := &deploymentHistoryResult{
+		deploymentResult: deploymentResult{ClusterID: clusterID, Namespace: namespace, DeploymentName: deploymentName},
+		Count:            len(revisions),
+		Revisions:        revisions,
+	}
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{Text: sb.String()},
+			&mcp.TextContent{Text: string(mustMarshalJSON(resultData))},
+		},
+	}, resultData, nil
+}
+
+type undoDeploymentArgs struct {
+	deploymentArgs
+	ToRevision int64 `json:"to_revision,omitempty" jsonschema:"Revision to roll back to (see k8s_deployment_history). Omit or 0 for the previous revision." minimum:"0"`
+}
+
+type undoDeploymentResult struct {
+	deploymentResult
+	RolledBack bool                       `json:"rolled_back"`
+	Revision   *domain.DeploymentRevision `json:"revision"`
+}
+
+func (m *MCPServer) handleUndoDeployment(ctx context.Context, req *mcp.CallToolRequest, args undoDeploymentArgs) (*mcp.CallToolResult, *undoDeploymentResult, error) {
+	m.logger.Info("Handling undo deployment request", "args", args)
+
+	clusterID, namespace, deploymentName := args.ClusterID, args.Namespace, args.DeploymentName
+
+	revision, changed, err := m.k8sUC.UndoDeployment(ctx, clusterID, namespace, deploymentName, args.ToRevision)
+	if err != nil {
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{Text: fmt.Sprintf("Failed to roll back deployment: %v", err)},
+			},
+			IsError: true,
+		}, nil, nil
+	}
+
+	text := fmt.Sprintf(" Deployment '%s/%s' rolled back to revision %d. Follow it with k8s_deployment_rollout_status.", namespace, deploymentName, revision.Revision)
+	if !changed {
+		text = fmt.Sprintf(" Skipped rollback: deployment '%s/%s' already runs the pod template of revision %d.", namespace, deploymentName, revision.Revision)
+	}
+
+	resultData := &undoDeploymentResult{
+		deploymentResult: deploymentResult{ClusterID: clusterID, Namespace: namespace, DeploymentName: deploymentName},
+		RolledBack:       changed,
+		Revision:         revision,
+	}
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{Text: text},
+			&mcp.TextContent{Text: string(mustMarshalJSON(resultData))},
+		},
+	}, resultData, nil
+}
+
+// pauseDeploymentResult is the result of pausing or resuming a deployment.
+type pauseDeploymentResult struct {
+	deploymentResult
+	Paused bool `json:"paused"`
+}
+
+func (m *MCPServer) handlePauseDeployment(ctx context.Context, req *mcp.CallToolRequest, args deploymentArgs) (*mcp.CallToolResult, *pauseDeploymentResult, error) {
+	return m.setDeploymentPaused(ctx, args, true)
+}
+
+func (m *MCPServer) handleResumeDeployment(ctx context.Context, req *mcp.CallToolRequest, args deploymentArgs) (*mcp.CallToolResult, *pauseDeploymentResult, error) {
+	return m.setDeploymentPaused(ctx, args, false)
+}
+
+func (m *MCPServer) setDeploymentPaused(ctx context.Context, args deploymentArgs, paused bool) (*mcp.CallToolResult, *pauseDeploymentResult, error) {
+	verb, state := "resume", "resumed"
+	if paused {
+		verb, state = "pause", "paused"
+	}
+	m.logger.Info("Handling "+verb+" deployment request", "args", args)
+
+	clusterID, namespace, deploymentName := args.ClusterID, args.Namespace, args.DeploymentName
+
+	if err := m.k8sUC.SetDeploymentPaused(ctx, clusterID, namespace, deploymentName, paused); err != nil {
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{Text: fmt.Sprintf("Failed to %s deployment: %v", verb, err)},
+			},
+			IsError: true,
+		}, nil, nil
+	}
+
+	resultData := &pauseDeploymentResult{
+		deploymentResult: deploymentResult{ClusterID: clusterID, Namespace: namespace, DeploymentName: deploymentName},
+		Paused:           paused,
+	}
+
+	text := fmt.Sprintf(" Deployment '%s/%s' %s. Changes to its pod template will not roll out until it is resumed.", namespace, deploymentName, state)
+	if !paused {
+		text = fmt.Sprintf(" Deployment '%s/%s' %s. Pending changes to its pod template roll out now.", namespace, deploymentName, state)
+	}
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{Text: text},
+			&mcp.TextContent{Text: string(mustMarshalJSON(resultData))},
+		},
+	}, resultData, nil
+}
@@ -3,26 +3,32 @@ package mcp
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
+	"github.com/google/jsonschema-go/jsonschema"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 	"github.com/your-org/mcp-k8s-server/internal/domain"
-	// Import domain package
-	// "github.com/your-org/mcp-k8s-server/internal/domain"
+	"github.com/your-org/mcp-k8s-server/internal/usecase"
 )
 
-func (m *MCPServer) handleListEvents(ctx context.Context, req *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
-	clusterID, _ := args["cluster_id"].(string)
-	namespace, _ := args["namespace"].(string)
-	involvedKind, _ := args["involved_kind"].(string)
-	involvedName, _ := args["involved_name"].(string)
+type listEventsArgs struct {
+	ClusterID    string `json:"cluster_id" jsonschema:"ID of the cluster"`
+	Namespace    string `json:"namespace,omitempty" jsonschema:"Namespace to list events from" default:"default"`
+	InvolvedKind string `json:"involved_kind,omitempty" jsonschema:"Optional: Kind of the object (e.g., 'Pod', 'Deployment') to filter events for."`
+	InvolvedName string `json:"involved_name,omitempty" jsonschema:"Optional: Name of the object to filter events for."`
+}
 
-	if clusterID == "" {
-		return errorResult(fmt.Errorf("cluster_id is required")), nil, nil
-	}
-	if namespace == "" {
-		namespace = "default" // Default namespace if not specified
-	}
+type listEventsResult struct {
+	ClusterID string           `json:"cluster_id"`
+	Namespace string           `json:"namespace"`
+	Filter    domain.ObjectRef `json:"filter"`
+	Events    []domain.Event   `json:"events"`
+}
+
+func (m *MCPServer) handleListEvents(ctx context.Context, req *mcp.CallToolRequest, args listEventsArgs) (*mcp.CallToolResult, *listEventsResult, error) {
+	clusterID, namespace := args.ClusterID, args.Namespace
+	involvedKind, involvedName := args.InvolvedKind, args.InvolvedName
 
 	events, err := m.k8sUC.ListEvents(ctx, clusterID, namespace, involvedKind, involvedName)
 	if err != nil {
@@ -49,7 +55,7 @@ func (m *MCPServer) handleListEvents(ctx context.Context, req *mcp.CallToolReque
 			prefix = "⚠️"
 		}
 
-		summary += fmt.Sprintf("%s [%s, %s, %dx] %s: %s (on %s/%s) - %s ago\n",
+		summary += fmt.Sprintf("%s [%s, %s, %dx] %s (on %s/%s) - %s ago\n",
 			prefix,
 			event.Type,
 			event.Reason,
@@ -67,11 +73,11 @@ func (m *MCPServer) handleListEvents(ctx context.Context, req *mcp.CallToolReque
 		}
 	}
 
-	resultData := map[string]any{
-		"cluster_id": clusterID,
-		"namespace":  namespace,
-		"filter":     map[string]string{"kind": involvedKind, "name": involvedName},
-		"events":     events,
+	resultData := &listEventsResult{
+		ClusterID: clusterID,
+		Namespace: namespace,
+		Filter:    domain.ObjectRef{Kind: involvedKind, Name: involvedName},
+		Events:    events,
 	}
 
 	return &mcp.CallToolResult{
@@ -81,3 +87,182 @@ func (m *MCPServer) handleListEvents(ctx context.Context, req *mcp.CallToolReque
 		},
 	}, resultData, nil
 }
+
+// eventLine formats an event as one timeline line.
+func eventLine(event domain.Event) string {
+	prefix := "🔸"
+	if event.Type == domain.EventTypeWarning {
+		prefix = "⚠️"
+	}
+	return fmt.Sprintf("%s %s [%s, %s, %dx] %s (on %s/%s)",
+		event.LastTimestamp.Format(time.RFC3339), prefix, event.Type, event.Reason, event.Count, event.Message, event.InvolvedKind, event.InvolvedName)
+}
+
+// setEventAPIs completes the schema of the api argument of the event tools.
+func setEventAPIs(prop *jsonschema.Schema) {
+	prop.Enum = enumOf(domain.EventAPICoreV1, domain.EventAPIEventsV1)
+	prop.Default = defaultOf(domain.EventAPICoreV1)
+}
+
+type eventsForWorkloadArgs struct {
+	ClusterID string `json:"cluster_id" jsonschema:"ID of the cluster"`
+	Namespace string `json:"namespace,omitempty" jsonschema:"Namespace of the workload" default:"default"`
+	Kind      string `json:"kind" jsonschema:"Workload kind"`
+	Name      string `json:"name" jsonschema:"Workload name"`
+	API       string `json:"api,omitempty" jsonschema:"Event API to read"`
+	MaxEvents int    `json:"max_events,omitempty"`
+}
+
+func (eventsForWorkloadArgs) adjustSchema(s *jsonschema.Schema) {
+	s.Properties["kind"].Enum = enumOf(usecase.EventWorkloadKinds...)
+	setEventAPIs(s.Properties["api"])
+	s.Properties["max_events"].Description = fmt.Sprintf("Maximum number of events, newest kept (at most %d)", usecase.MaxWorkloadEvents)
+	s.Properties["max_events"].Default = defaultOf(usecase.DefaultWorkloadEvents)
+}
+
+func (m *MCPServer) handleEventsForWorkload(ctx context.Context, req *mcp.CallToolRequest, args eventsForWorkloadArgs) (*mcp.CallToolResult, *domain.WorkloadEvents, error) {
+	m.logger.Info("Handling events for workload request", "args", args)
+
+	clusterID, namespace, kind, name, api := args.ClusterID, args.Namespace, args.Kind, args.Name, args.API
+
+	maxEvents := usecase.DefaultWorkloadEvents
+	if args.MaxEvents > 0 {
+		maxEvents = min(args.MaxEvents, usecase.MaxWorkloadEvents)
+	}
+
+	timeline, err := m.k8sUC.WorkloadEvents(ctx, clusterID, namespace, kind, name, api, maxEvents)
+	if err != nil {
+		return errorResult(err), nil, nil
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "📢 %d event(s) for %s '%s/%s' and %d related object(s) from %s", len(timeline.Events), kind, namespace, name, len(timeline.Objects)-1, timeline.API)
+	if timeline.EventsOmitted > 0 {
+		fmt.Fprintf(&sb, " (%d older event(s) omitted, raise max_events)", timeline.EventsOmitted)
+	}
+	sb.WriteString("\nObjects:")
+	for _, ref := range timeline.Objects {
+		fmt.Fprintf(&sb, " %s/%s", ref.Kind, ref.Name)
+	}
+	sb.WriteString("\n\n")
+	for _, event := range timeline.Events {
+		sb.WriteString(eventLine(event))
+		sb.WriteString("\n")
+	}
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{&mcp.TextContent{Text: sb.String()}},
+	}, timeline, nil
+}
+
+type eventsWatchArgs struct {
+	ClusterID       string `json:"cluster_id" jsonschema:"ID of the cluster"`
+	Namespace       string `json:"namespace,omitempty" jsonschema:"Namespace to watch" default:"default"`
+	AllNamespaces   bool   `json:"all_namespaces,omitempty" jsonschema:"Watch every namespace instead of namespace"`
+	Type            string `json:"type,omitempty" jsonschema:"Event type to report"`
+	InvolvedKind    string `json:"involved_kind,omitempty" jsonschema:"Optional: only events on objects of this kind, e.g. 'Pod'"`
+	InvolvedName    string `json:"involved_name,omitempty" jsonschema:"Optional: only events on objects with this name"`
+	API             string `json:"api,omitempty" jsonschema:"Event API to watch"`
+	DurationSeconds int    `json:"duration_seconds,omitempty"`
+	MaxEvents       int    `json:"max_events,omitempty"`
+}
+
+func (eventsWatchArgs) adjustSchema(s *jsonschema.Schema) {
+	s.Properties["type"].Enum = enumOf(string(domain.EventTypeWarning), string(domain.EventTypeNormal), "all")
+	s.Properties["type"].Default = defaultOf(domain.EventTypeWarning)
+	setEventAPIs(s.Properties["api"])
+	s.Properties["duration_seconds"].Description = fmt.Sprintf("Stop watching after this many seconds (at most %d)", int(usecase.MaxEventWatchDuration.Seconds()))
+	s.Properties["duration_seconds"].Default = defaultOf(int(usecase.DefaultEventWatchDuration.Seconds()))
+	s.Properties["max_events"].Description = fmt.Sprintf("Stop watching after this many events (at most %d)", usecase.MaxEventWatchEvents)
+	s.Properties["max_events"].Default = defaultOf(usecase.DefaultEventWatchEvents)
+}
+
+func (m *MCPServer) handleEventsWatch(ctx context.Context, req *mcp.CallToolRequest, args eventsWatchArgs) (*mcp.CallToolResult, *domain.EventWatchResult, error) {
+	m.logger.Info("Handling events watch request", "args", args)
+
+	clusterID, namespace, allNamespaces := args.ClusterID, args.Namespace, args.AllNamespaces
+	filter := domain.EventWatchFilter{
+		API:          args.API,
+		Type:         domain.EventType(args.Type),
+		InvolvedKind: args.InvolvedKind,
+		InvolvedName: args.InvolvedName,
+	}
+	switch args.Type {
+	case "":
+		filter.Type = domain.EventTypeWarning
+	case "all":
+		filter.Type = ""
+	}
+
+	if allNamespaces {
+		namespace = string(domain.NamespaceAll)
+	}
+	duration := usecase.DefaultEventWatchDuration
+	if args.DurationSeconds > 0 {
+		duration = min(time.Duration(args.DurationSeconds)*time.Second, usecase.MaxEventWatchDuration)
+	}
+	maxEvents := usecase.DefaultEventWatchEvents
+	if args.MaxEvents > 0 {
+		maxEvents = min(args.MaxEvents, usecase.MaxEventWatchEvents)
+	}
+
+	// Each event is sent as a progress notification when the client asked
+	// for progress, and as a log message at the level the client set.
+	progressToken := req.Params.GetProgressToken()
+	onEvent := func(event domain.Event, n int) {
+		line := eventLine(event)
+		if progressToken != nil {
+			if err := req.Session.NotifyProgress(ctx, &mcp.ProgressNotificationParams{
+				ProgressToken: progressToken,
+				Message:       line,
+				Progress:      float64(n),
+				Total:         float64(maxEvents),
+			}); err != nil {
+				m.logger.Debug("Failed to send event progress", "error", err)
+			}
+		}
+		level := mcp.LoggingLevel("info")
+		if event.Type == domain.EventTypeWarning {
+			level = "warning"
+		}
+		if err := req.Session.Log(ctx, &mcp.LoggingMessageParams{Level: level, Logger: "events", Data: event}); err != nil {
+			m.logger.Debug("Failed to send event message", "error", err)
+		}
+	}
+
+	result, err := m.k8sUC.WatchEvents(ctx, clusterID, namespace, filter, maxEvents, duration, onEvent)
+	if err != nil {
+		return errorResult(err), nil, nil
+	}
+
+	scope := fmt.Sprintf("namespace '%s'", namespace)
+	if allNamespaces {
+		scope = "all namespaces"
+	}
+	kind := "event(s)"
+	if filter.Type != "" {
+		kind = fmt.Sprintf("%s event(s)", filter.Type)
+	}
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "👀 Watched %s: %d new %s, %s\n\n", scope, len(result.Events), kind, eventWatchStopText(result.StopReason, maxEvents, duration))
+	for _, event := range result.Events {
+		sb.WriteString(eventLine(event))
+		sb.WriteString("\n")
+	}
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{&mcp.TextContent{Text: sb.String()}},
+	}, result, nil
+}
+
+func eventWatchStopText(reason string, maxEvents int, duration time.Duration) string {
+	switch reason {
+	case domain.EventWatchStopMaxEvents:
+		return fmt.Sprintf("stopped at max_events (%d)", maxEvents)
+	case domain.EventWatchStopDuration:
+		return fmt.Sprintf("stopped after %s", duration)
+	case domain.EventWatchStopCancelled:
+		return "stopped: the request was cancelled"
+	}
+	return "stopped: the watch was closed by the API server"
+}
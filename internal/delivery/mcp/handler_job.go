@@ -3,6 +3,8 @@ package mcp
 import (
 	"context"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 	"github.com/your-org/mcp-k8s-server/internal/domain"
@@ -10,24 +12,22 @@ import (
 
 // ==================== Job Handlers ====================
 
-func (m *MCPServer) handleListJobs(ctx context.Context, req *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
-	m.logger.Info("Handling list jobs request", "args", args)
+type listJobsArgs struct {
+	ClusterID string `json:"cluster_id" jsonschema:"ID of the cluster"`
+	Namespace string `json:"namespace,omitempty" jsonschema:"Namespace to list Jobs from" default:"default"`
+}
 
-	clusterID, _ := args["cluster_id"].(string)
-	namespace, _ := args["namespace"].(string)
+type listJobsResult struct {
+	ClusterID string              `json:"cluster_id"`
+	Namespace string              `json:"namespace"`
+	Count     int                 `json:"count"`
+	Jobs      []domain.JobSummary `json:"jobs"`
+}
 
-	if clusterID == "" {
-		return &mcp.CallToolResult{
-			Content: []mcp.Content{
-				&mcp.TextContent{Text: "Error: cluster_id is required"},
-			},
-			IsError: true,
-		}, nil, nil
-	}
+func (m *MCPServer) handleListJobs(ctx context.Context, req *mcp.CallToolRequest, args listJobsArgs) (*mcp.CallToolResult, *listJobsResult, error) {
+	m.logger.Info("Handling list jobs request", "args", args)
 
-	if namespace == "" {
-		namespace = "default"
-	}
+	clusterID, namespace := args.ClusterID, args.Namespace
 
 	jobs, err := m.k8sUC.ListJobs(ctx, clusterID, namespace)
 	if err != nil {
@@ -42,14 +42,14 @@ func (m *MCPServer) handleListJobs(ctx context.Context, req *mcp.CallToolRequest
 	summary := fmt.Sprintf("⚡ Found %d Jobs in namespace '%s':\n\n", len(jobs), namespace)
 	for i, job := range jobs {
 		summary += fmt.Sprintf("%d. %s - Status: %s, Completions: %s, Duration: %s\n",
-			i+1, job["name"], job["status"], job["completions"], job["duration"])
+			i+1, job.Name, job.Status, job.Completions, job.Duration)
 	}
 
-	resultData := map[string]any{
-		"cluster_id": clusterID,
-		"namespace":  namespace,
-		"count":      len(jobs),
-		"jobs":       jobs,
+	resultData := &listJobsResult{
+		ClusterID: clusterID,
+		Namespace: namespace,
+		Count:     len(jobs),
+		Jobs:      jobs,
 	}
 
 	return &mcp.CallToolResult{
@@ -60,25 +60,16 @@ func (m *MCPServer) handleListJobs(ctx context.Context, req *mcp.CallToolRequest
 	}, resultData, nil
 }
 
-func (m *MCPServer) handleGetJob(ctx context.Context, req *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
-	m.logger.Info("Handling get job request", "args", args)
-
-	clusterID, _ := args["cluster_id"].(string)
-	namespace, _ := args["namespace"].(string)
-	jobName, _ := args["job_name"].(string)
+type getJobArgs struct {
+	ClusterID string `json:"cluster_id" jsonschema:"ID of the cluster"`
+	Namespace string `json:"namespace,omitempty" jsonschema:"Namespace of the Job" default:"default"`
+	JobName   string `json:"job_name" jsonschema:"Name of the Job"`
+}
 
-	if clusterID == "" || jobName == "" {
-		return &mcp.CallToolResult{
-			Content: []mcp.Content{
-				&mcp.TextContent{Text: "Error: cluster_id and job_name are required"},
-			},
-			IsError: true,
-		}, nil, nil
-	}
+func (m *MCPServer) handleGetJob(ctx context.Context, req *mcp.CallToolRequest, args getJobArgs) (*mcp.CallToolResult, *domain.JobDetail, error) {
+	m.logger.Info("Handling get job request", "args", args)
 
-	if namespace == "" {
-		namespace = "default"
-	}
+	clusterID, namespace, jobName := args.ClusterID, args.Namespace, args.JobName
 
 	jobInfo, err := m.k8sUC.GetJob(ctx, clusterID, namespace, jobName)
 	if err != nil {
@@ -91,41 +82,33 @@ func (m *MCPServer) handleGetJob(ctx context.Context, req *mcp.CallToolRequest,
 	}
 
 	summary := fmt.Sprintf("⚡ Job '%s' in namespace '%s':\n\n", jobName, namespace)
-	summary += fmt.Sprintf("Completions: %d (desired)\n", jobInfo["completions"])
-	summary += fmt.Sprintf("Parallelism: %d\n", jobInfo["parallelism"])
-	summary += fmt.Sprintf("Backoff Limit: %d\n", jobInfo["backoff_limit"])
+	summary += fmt.Sprintf("Completions: %d (desired)\n", jobInfo.Completions)
+	summary += fmt.Sprintf("Parallelism: %d\n", jobInfo.Parallelism)
+	summary += fmt.Sprintf("Backoff Limit: %d\n", jobInfo.BackoffLimit)
 	summary += fmt.Sprintf("Active: %d, Succeeded: %d, Failed: %d\n",
-		jobInfo["active"], jobInfo["succeeded"], jobInfo["failed"])
+		jobInfo.Active, jobInfo.Succeeded, jobInfo.Failed)
 
-	if startTime, ok := jobInfo["start_time"].(string); ok && startTime != "" {
+	if startTime := jobInfo.StartTime; startTime != "" {
 		summary += fmt.Sprintf("Start Time: %s\n", startTime)
 	}
-	if completionTime, ok := jobInfo["completion_time"].(string); ok && completionTime != "" {
+	if completionTime := jobInfo.CompletionTime; completionTime != "" {
 		summary += fmt.Sprintf("Completion Time: %s\n", completionTime)
 	}
-	if duration, ok := jobInfo["duration"].(string); ok && duration != "" {
+	if duration := jobInfo.Duration; duration != "" {
 		summary += fmt.Sprintf("Duration: %s\n", duration)
 	}
-	summary += fmt.Sprintf("Created: %s\n", jobInfo["created"])
+	summary += fmt.Sprintf("Created: %s\n", jobInfo.Created)
 
-	if containers, ok := jobInfo["containers"].([]map[string]any); ok && len(containers) > 0 {
+	if containers := jobInfo.Containers; len(containers) > 0 {
 		summary += fmt.Sprintf("\nContainers (%d):\n", len(containers))
-		for i, container := range containers {
-			summary += fmt.Sprintf("%d. %s - Image: %s\n", i+1, container["name"], container["image"])
-			if cmd, ok := container["command"].([]string); ok && len(cmd) > 0 {
-				summary += fmt.Sprintf("   Command: %v\n", cmd)
-			}
-			if args, ok := container["args"].([]string); ok && len(args) > 0 {
-				summary += fmt.Sprintf("   Args: %v\n", args)
-			}
-		}
+		summary += formatContainerCommands(containers)
 	}
 
-	if conditions, ok := jobInfo["conditions"].([]map[string]any); ok && len(conditions) > 0 {
+	if conditions := jobInfo.Conditions; len(conditions) > 0 {
 		summary += "\nConditions:\n"
 		for _, condition := range conditions {
-			summary += fmt.Sprintf("  %s: %s", condition["type"], condition["status"])
-			if reason, ok := condition["reason"].(string); ok && reason != "" {
+			summary += fmt.Sprintf("  %s: %s", condition.Type, condition.Status)
+			if reason := condition.Reason; reason != "" {
 				summary += fmt.Sprintf(" - %s", reason)
 			}
 			summary += "\n"
@@ -140,92 +123,46 @@ func (m *MCPServer) handleGetJob(ctx context.Context, req *mcp.CallToolRequest,
 	}, jobInfo, nil
 }
 
-func (m *MCPServer) handleCreateJob(ctx context.Context, req *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
-	m.logger.Info("Handling create job request", "args", args)
-
-	clusterID, _ := args["cluster_id"].(string)
-	namespace, _ := args["namespace"].(string)
-	jobName, _ := args["job_name"].(string)
-	image, _ := args["image"].(string)
-
-	if clusterID == "" || jobName == "" || image == "" {
-		return &mcp.CallToolResult{
-			Content: []mcp.Content{
-				&mcp.TextContent{Text: "Error: cluster_id, job_name, and image are required"},
-			},
-			IsError: true,
-		}, nil, nil
-	}
-
-	if namespace == "" {
-		namespace = "default"
-	}
-
-	// Parse command
-	var command []string
-	if cmdRaw, ok := args["command"].([]any); ok {
-		for _, c := range cmdRaw {
-			if str, ok := c.(string); ok {
-				command = append(command, str)
-			}
-		}
-	}
+type createJobArgs struct {
+	ClusterID     string            `json:"cluster_id" jsonschema:"ID of the cluster"`
+	Namespace     string            `json:"namespace,omitempty" jsonschema:"Namespace to create the Job in" default:"default"`
+	JobName       string            `json:"job_name" jsonschema:"Name of the Job"`
+	Image         string            `json:"image" jsonschema:"Container image to run"`
+	Command       []string          `json:"command,omitempty" jsonschema:"Command to run (optional)"`
+	Args          []string          `json:"args,omitempty" jsonschema:"Arguments for the command (optional)"`
+	Completions   int32             `json:"completions,omitempty" jsonschema:"Number of successful completions required" default:"1" minimum:"0"`
+	Parallelism   int32             `json:"parallelism,omitempty" jsonschema:"Number of pods to run in parallel" default:"1" minimum:"0"`
+	BackoffLimit  int32             `json:"backoff_limit,omitempty" jsonschema:"Number of retries before considering Job failed" default:"6" minimum:"0"`
+	RestartPolicy string            `json:"restart_policy,omitempty" jsonschema:"Restart policy (OnFailure, Never)" default:"OnFailure"`
+	Env           map[string]string `json:"env,omitempty" jsonschema:"Environment variables"`
+	Labels        map[string]string `json:"labels,omitempty" jsonschema:"Labels to apply to the Job"`
+}
 
-	// Parse args
-	var jobArgs []string
-	if argsRaw, ok := args["args"].([]any); ok {
-		for _, a := range argsRaw {
-			if str, ok := a.(string); ok {
-				jobArgs = append(jobArgs, str)
-			}
-		}
-	}
+type createJobResult struct {
+	ClusterID string `json:"cluster_id"`
+	Namespace string `json:"namespace"`
+	JobName   string `json:"job_name"`
+	Image     string `json:"image"`
+	Status    string `json:"status"`
+}
 
-	// Parse env
-	env := make(map[string]string)
-	if envRaw, ok := args["env"].(map[string]any); ok {
-		for k, v := range envRaw {
-			if strVal, ok := v.(string); ok {
-				env[k] = strVal
-			}
-		}
-	}
+func (m *MCPServer) handleCreateJob(ctx context.Context, req *mcp.CallToolRequest, args createJobArgs) (*mcp.CallToolResult, *createJobResult, error) {
+	m.logger.Info("Handling create job request", "args", args)
 
-	// Parse labels
-	labels := make(map[string]string)
-	if labelsRaw, ok := args["labels"].(map[string]any); ok {
-		for k, v := range labelsRaw {
-			if strVal, ok := v.(string); ok {
-				labels[k] = strVal
-			}
-		}
-	}
+	clusterID, namespace, jobName, image := args.ClusterID, args.Namespace, args.JobName, args.Image
 
 	options := domain.JobCreateOptions{
-		Name:      jobName,
-		Namespace: namespace,
-		Image:     image,
-		Command:   command,
-		Args:      jobArgs,
-		Env:       env,
-		Labels:    labels,
-	}
-
-	// Parse optional fields
-	if completions, ok := args["completions"].(float64); ok {
-		c := int32(completions)
-		options.Completions = &c
-	}
-	if parallelism, ok := args["parallelism"].(float64); ok {
-		p := int32(parallelism)
-		options.Parallelism = &p
-	}
-	if backoffLimit, ok := args["backoff_limit"].(float64); ok {
-		b := int32(backoffLimit)
-		options.BackoffLimit = &b
-	}
-	if restartPolicy, ok := args["restart_policy"].(string); ok {
-		options.RestartPolicy = restartPolicy
+		Name:          jobName,
+		Namespace:     namespace,
+		Image:         image,
+		Command:       args.Command,
+		Args:          args.Args,
+		Completions:   &args.Completions,
+		Parallelism:   &args.Parallelism,
+		BackoffLimit:  &args.BackoffLimit,
+		RestartPolicy: args.RestartPolicy,
+		Env:           args.Env,
+		Labels:        args.Labels,
 	}
 
 	err := m.k8sUC.CreateJob(ctx, clusterID, options)
@@ -238,12 +175,12 @@ func (m *MCPServer) handleCreateJob(ctx context.Context, req *mcp.CallToolReques
 		}, nil, nil
 	}
 
-	resultData := map[string]any{
-		"cluster_id": clusterID,
-		"namespace":  namespace,
-		"job_name":   jobName,
-		"image":      image,
-		"status":     "created",
+	resultData := &createJobResult{
+		ClusterID: clusterID,
+		Namespace: namespace,
+		JobName:   jobName,
+		Image:     image,
+		Status:    "created",
 	}
 
 	return &mcp.CallToolResult{
@@ -255,25 +192,23 @@ func (m *MCPServer) handleCreateJob(ctx context.Context, req *mcp.CallToolReques
 	}, resultData, nil
 }
 
-func (m *MCPServer) handleDeleteJob(ctx context.Context, req *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
-	m.logger.Info("Handling delete job request", "args", args)
+type deleteJobArgs struct {
+	ClusterID string `json:"cluster_id" jsonschema:"ID of the cluster"`
+	Namespace string `json:"namespace,omitempty" jsonschema:"Namespace of the Job" default:"default"`
+	JobName   string `json:"job_name" jsonschema:"Name of the Job to delete"`
+}
 
-	clusterID, _ := args["cluster_id"].(string)
-	namespace, _ := args["namespace"].(string)
-	jobName, _ := args["job_name"].(string)
+type deleteJobResult struct {
+	ClusterID string `json:"cluster_id"`
+	Namespace string `json:"namespace"`
+	JobName   string `json:"job_name"`
+	Status    string `json:"status"`
+}
 
-	if clusterID == "" || jobName == "" {
-		return &mcp.CallToolResult{
-			Content: []mcp.Content{
-				&mcp.TextContent{Text: "Error: cluster_id and job_name are required"},
-			},
-			IsError: true,
-		}, nil, nil
-	}
+func (m *MCPServer) handleDeleteJob(ctx context.Context, req *mcp.CallToolRequest, args deleteJobArgs) (*mcp.CallToolResult, *deleteJobResult, error) {
+	m.logger.Info("Handling delete job request", "args", args)
 
-	if namespace == "" {
-		namespace = "default"
-	}
+	clusterID, namespace, jobName := args.ClusterID, args.Namespace, args.JobName
 
 	err := m.k8sUC.DeleteJob(ctx, clusterID, namespace, jobName)
 	if err != nil {
@@ -285,11 +220,11 @@ func (m *MCPServer) handleDeleteJob(ctx context.Context, req *mcp.CallToolReques
 		}, nil, nil
 	}
 
-	resultData := map[string]any{
-		"cluster_id": clusterID,
-		"namespace":  namespace,
-		"job_name":   jobName,
-		"status":     "deleted",
+	resultData := &deleteJobResult{
+		ClusterID: clusterID,
+		Namespace: namespace,
+		JobName:   jobName,
+		Status:    "deleted",
 	}
 
 	return &mcp.CallToolResult{
@@ -301,32 +236,24 @@ func (m *MCPServer) handleDeleteJob(ctx context.Context, req *mcp.CallToolReques
 	}, resultData, nil
 }
 
-func (m *MCPServer) handleGetJobLogs(ctx context.Context, req *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
-	m.logger.Info("Handling get job logs request", "args", args)
-
-	clusterID, _ := args["cluster_id"].(string)
-	namespace, _ := args["namespace"].(string)
-	jobName, _ := args["job_name"].(string)
+type getJobLogsArgs struct {
+	ClusterID string `json:"cluster_id" jsonschema:"ID of the cluster"`
+	Namespace string `json:"namespace,omitempty" jsonschema:"Namespace of the Job" default:"default"`
+	JobName   string `json:"job_name" jsonschema:"Name of the Job"`
+	logOptionArgs
+}
 
-	var tailLines int64 = 100
-	if tl, ok := args["tail_lines"].(float64); ok {
-		tailLines = int64(tl)
-	}
+func (m *MCPServer) handleGetJobLogs(ctx context.Context, req *mcp.CallToolRequest, args getJobLogsArgs) (*mcp.CallToolResult, *domain.JobLogs, error) {
+	m.logger.Info("Handling get job logs request", "args", args)
 
-	if clusterID == "" || jobName == "" {
-		return &mcp.CallToolResult{
-			Content: []mcp.Content{
-				&mcp.TextContent{Text: "Error: cluster_id and job_name are required"},
-			},
-			IsError: true,
-		}, nil, nil
-	}
+	clusterID, namespace, jobName := args.ClusterID, args.Namespace, args.JobName
 
-	if namespace == "" {
-		namespace = "default"
+	opts, err := args.logOptions()
+	if err != nil {
+		return errorResult(err), nil, nil
 	}
 
-	logs, err := m.k8sUC.GetJobLogs(ctx, clusterID, namespace, jobName, tailLines)
+	logs, err := m.k8sUC.GetJobLogs(ctx, clusterID, namespace, jobName, opts)
 	if err != nil {
 		return &mcp.CallToolResult{
 			Content: []mcp.Content{
@@ -336,10 +263,74 @@ func (m *MCPServer) handleGetJobLogs(ctx context.Context, req *mcp.CallToolReque
 		}, nil, nil
 	}
 
+	var sb strings.Builder
+	writeJobLogs(&sb, logs)
 	return &mcp.CallToolResult{
-		Content: []mcp.Content{
-			&mcp.TextContent{Text: fmt.Sprintf("📋 Logs from Job '%s' in namespace '%s' (last %d lines):\n\n%s",
-				jobName, namespace, tailLines, logs)},
-		},
+		Content: []mcp.Content{&mcp.TextContent{Text: sb.String()}},
 	}, logs, nil
 }
+
+// formatContainerCommands lists containers with their image, command and
+// args, one per line.
+func formatContainerCommands(containers []domain.ContainerCommand) string {
+	var out string
+	for i, container := range containers {
+		out += fmt.Sprintf("%d. %s - Image: %s\n", i+1, container.Name, container.Image)
+		if len(container.Command) > 0 {
+			out += fmt.Sprintf("   Command: %v\n", container.Command)
+		}
+		if len(container.Args) > 0 {
+			out += fmt.Sprintf("   Args: %v\n", container.Args)
+		}
+	}
+	return out
+}
+
+// writeJobLogs renders the logs of a Job grouped by pod, oldest attempt
+// first, with failed attempts marked.
+func writeJobLogs(sb *strings.Builder, logs *domain.JobLogs) {
+	fmt.Fprintf(sb, "📋 Logs from Job '%s' in namespace '%s' [%s]: %d pod(s), %d failed\n",
+		logs.Job, logs.Namespace, logs.Status, len(logs.Pods), logs.Failed)
+	if len(logs.Pods) == 0 {
+		sb.WriteString("No pods found for this job\n")
+	}
+	for _, pod := range logs.Pods {
+		marker := "✅"
+		if pod.Failed {
+			marker = "❌ FAILED"
+		} else if pod.Phase != "Succeeded" {
+			marker = "⏳"
+		}
+		fmt.Fprintf(sb, "\n=== %s pod %s [%s]", marker, pod.Pod, pod.Phase)
+		if pod.StartTime != nil {
+			fmt.Fprintf(sb, " started %s", pod.StartTime.Format(time.RFC3339))
+		}
+		if pod.Reason != "" {
+			fmt.Fprintf(sb, " reason=%s", pod.Reason)
+		}
+		sb.WriteString("\n")
+		if pod.Message != "" {
+			fmt.Fprintf(sb, "%s\n", pod.Message)
+		}
+		for _, c := range pod.Containers {
+			fmt.Fprintf(sb, "--- container %s", c.Name)
+			if c.ExitCode != nil {
+				fmt.Fprintf(sb, ": exit code %d", *c.ExitCode)
+				if c.Reason != "" {
+					fmt.Fprintf(sb, " (%s)", c.Reason)
+				}
+			}
+			sb.WriteString("\n")
+			if c.Message != "" {
+				fmt.Fprintf(sb, "termination message: %s\n", c.Message)
+			}
+			if c.Error != "" {
+				fmt.Fprintf(sb, "⚠️ %s\n", c.Error)
+			}
+			sb.WriteString(c.Logs)
+			if c.Logs != "" && !strings.HasSuffix(c.Logs, "\n") {
+				sb.WriteString("\n")
+			}
+		}
+	}
+}
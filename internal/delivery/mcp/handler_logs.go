@@ -0,0 +1,318 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/your-org/mcp-k8s-server/internal/domain"
+	"github.com/your-org/mcp-k8s-server/internal/infrastructure"
+	"github.com/your-org/mcp-k8s-server/internal/usecase"
+)
+
+// logOptionArgs are the log options the log tools take. Tools whose
+// tail_lines means something else describe it in adjustSchema.
+type logOptionArgs struct {
+	Container    string `json:"container,omitempty" jsonschema:"Only read this container. Required for a single pod with several containers; selectors read every container by default"`
+	TailLines    int64  `json:"tail_lines,omitempty" jsonschema:"Number of lines to tail per container" default:"100"`
+	Previous     bool   `json:"previous,omitempty" jsonschema:"Read the logs of the previous, terminated container instance"`
+	SinceSeconds int64  `json:"since_seconds,omitempty" jsonschema:"Only return logs newer than this many seconds" minimum:"0"`
+	SinceTime    string `json:"since_time,omitempty" jsonschema:"Only return logs after this RFC 3339 time; exclusive with since_seconds"`
+	Timestamps   bool   `json:"timestamps,omitempty" jsonschema:"Prefix each line with its RFC 3339 timestamp"`
+	LimitBytes   int64  `json:"limit_bytes,omitempty" jsonschema:"Maximum bytes of logs to read per container" minimum:"0"`
+	Grep         string `json:"grep,omitempty" jsonschema:"Regular expression (RE2); only matching lines are returned"`
+}
+
+// logOptions converts the arguments to log options. Zero tail_lines,
+// since_seconds and limit_bytes are unset.
+func (a logOptionArgs) logOptions() (domain.LogOptions, error) {
+	opts := domain.LogOptions{
+		Container:  a.Container,
+		Previous:   a.Previous,
+		Timestamps: a.Timestamps,
+		Grep:       a.Grep,
+	}
+	if a.TailLines > 0 {
+		opts.TailLines = &a.TailLines
+	}
+	if a.SinceSeconds > 0 {
+		opts.SinceSeconds = &a.SinceSeconds
+	}
+	if a.SinceTime != "" {
+		if opts.SinceSeconds != nil {
+			return opts, fmt.Errorf("since_seconds and since_time are mutually exclusive")
+		}
+		t, err := time.Parse(time.RFC3339, a.SinceTime)
+		if err != nil {
+			return opts, fmt.Errorf("invalid since_time %q: want an RFC 3339 time", a.SinceTime)
+		}
+		opts.SinceTime = &t
+	}
+	if a.LimitBytes > 0 {
+		opts.LimitBytes = &a.LimitBytes
+	}
+	return opts, nil
+}
+
+// describeTailLines replaces the description of tail_lines, which then has
+// no default.
+func describeTailLines(s *jsonschema.Schema, description string) {
+	s.Properties["tail_lines"].Description = description
+	s.Properties["tail_lines"].Default = nil
+}
+
+type logsBySelectorArgs struct {
+	ClusterID     string `json:"cluster_id" jsonschema:"ID of the cluster"`
+	Namespace     string `json:"namespace,omitempty" jsonschema:"Namespace of the pods" default:"default"`
+	LabelSelector string `json:"label_selector,omitempty" jsonschema:"Label selector, e.g. app=web,tier!=cache"`
+	Kind          string `json:"kind,omitempty" jsonschema:"Workload kind whose pods to read, instead of label_selector" enum:"deployment,statefulset,daemonset,replicaset,job"`
+	Name          string `json:"name,omitempty" jsonschema:"Workload name"`
+	MaxPods       int    `json:"max_pods,omitempty" minimum:"0"`
+	logOptionArgs
+}
+
+func (logsBySelectorArgs) adjustSchema(s *jsonschema.Schema) {
+	s.Properties["max_pods"].Description = fmt.Sprintf("Maximum number of pods to read, by name (at most %d)", usecase.MaxLogPods)
+	s.Properties["max_pods"].Default = defaultOf(usecase.DefaultLogPods)
+}
+
+func (m *MCPServer) handleLogsBySelector(ctx context.Context, req *mcp.CallToolRequest, args logsBySelectorArgs) (*mcp.CallToolResult, *domain.AggregatedLogs, error) {
+	m.logger.Info("Handling logs by selector request", "args", args)
+
+	clusterID, namespace := args.ClusterID, args.Namespace
+	target := domain.LogTarget{LabelSelector: args.LabelSelector, Kind: args.Kind, Name: args.Name}
+
+	maxPods := usecase.DefaultLogPods
+	if args.MaxPods > 0 {
+		maxPods = min(args.MaxPods, usecase.MaxLogPods)
+	}
+	opts, err := args.logOptions()
+	if err != nil {
+		return errorResult(err), nil, nil
+	}
+
+	logs, err := m.k8sUC.GetLogsBySelector(ctx, clusterID, namespace, target, opts, maxPods)
+	if err != nil {
+		return errorResult(err), nil, nil
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "📋 %d line(s) from %d pod(s) matching %s in namespace '%s'", len(logs.Lines), len(logs.Pods), logs.Selector, namespace)
+	if logs.PodsOmitted > 0 {
+		fmt.Fprintf(&sb, " (%d more pod(s) omitted, raise max_pods)", logs.PodsOmitted)
+	}
+	sb.WriteString("\n")
+	for _, e := range logs.Errors {
+		fmt.Fprintf(&sb, "⚠️ %s/%s: %s\n", e.Pod, e.Container, e.Error)
+	}
+	sb.WriteString("\n")
+	for _, l := range logs.Lines {
+		fmt.Fprintf(&sb, "[%s/%s] ", l.Pod, l.Container)
+		if opts.Timestamps && l.Time != nil {
+			sb.WriteString(l.Time.Format(time.RFC3339Nano) + " ")
+		}
+		sb.WriteString(l.Message)
+		sb.WriteString("\n")
+	}
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{&mcp.TextContent{Text: sb.String()}},
+	}, logs, nil
+}
+
+type logsExportArgs struct {
+	ClusterID     string `json:"cluster_id" jsonschema:"ID of the cluster"`
+	Namespace     string `json:"namespace,omitempty" jsonschema:"Namespace of the pods" default:"default"`
+	LabelSelector string `json:"label_selector,omitempty" jsonschema:"Label selector, e.g. app=web,tier!=cache. Omit it and kind to export every pod in the namespace"`
+	Kind          string `json:"kind,omitempty" jsonschema:"Workload kind whose pods to export, instead of label_selector" enum:"deployment,statefulset,daemonset,replicaset,job"`
+	Name          string `json:"name,omitempty" jsonschema:"Workload name"`
+	MaxPods       int    `json:"max_pods,omitempty" minimum:"0"`
+	Format        string `json:"format,omitempty"`
+	ErrorPattern  string `json:"error_pattern,omitempty" jsonschema:"Regular expression (RE2) counting error lines in the summary"`
+	logOptionArgs
+}
+
+func (logsExportArgs) adjustSchema(s *jsonschema.Schema) {
+	s.Properties["max_pods"].Description = fmt.Sprintf("Maximum number of pods to export, by name (at most %d)", usecase.MaxLogPods)
+	s.Properties["max_pods"].Default = defaultOf(usecase.DefaultLogPods)
+	s.Properties["format"].Enum = enumOf(infrastructure.ArchiveFormatTarGz, infrastructure.ArchiveFormatZip)
+	s.Properties["format"].Default = defaultOf(infrastructure.ArchiveFormatTarGz)
+	s.Properties["error_pattern"].Default = defaultOf(usecase.DefaultLogErrorPattern)
+	describeTailLines(s, "Number of lines to tail per container; all lines by default")
+}
+
+func (m *MCPServer) handleLogsExport(ctx context.Context, req *mcp.CallToolRequest, args logsExportArgs) (*mcp.CallToolResult, *domain.LogExport, error) {
+	m.logger.Info("Handling logs export request", "args", args)
+
+	exportReq := domain.LogExportRequest{
+		ClusterID:    args.ClusterID,
+		Namespace:    args.Namespace,
+		Target:       domain.LogTarget{LabelSelector: args.LabelSelector, Kind: args.Kind, Name: args.Name},
+		Format:       args.Format,
+		ErrorPattern: args.ErrorPattern,
+	}
+
+	if m.exportDir == "" {
+		return errorResult(fmt.Errorf("log export is disabled: no export directory is configured")), nil, nil
+	}
+	exportReq.MaxPods = usecase.DefaultLogPods
+	if args.MaxPods > 0 {
+		exportReq.MaxPods = min(args.MaxPods, usecase.MaxLogPods)
+	}
+	// Exports keep whole logs unless the caller asks for a tail, as
+	// tail_lines has no default here.
+	opts, err := args.logOptions()
+	if err != nil {
+		return errorResult(err), nil, nil
+	}
+	exportReq.Options = opts
+
+	export, err := m.k8sUC.ExportLogs(ctx, exportReq, m.exportDir)
+	if err != nil {
+		return errorResult(err), nil, nil
+	}
+
+	mimeType := "application/gzip"
+	if export.Format == infrastructure.ArchiveFormatZip {
+		mimeType = "application/zip"
+	}
+	uri := logExportURIPrefix + export.Name
+	if expires := m.logExports.add(export.Name, export.Path, mimeType, req.Session); !expires.IsZero() {
+		export.ExpiresAt = &expires
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "📦 Exported %d line(s), %d error line(s), from %d container(s)", export.Lines, export.Errors, len(export.Files))
+	if export.Selector != "" {
+		fmt.Fprintf(&sb, " matching %s", export.Selector)
+	}
+	fmt.Fprintf(&sb, " in namespace '%s'", export.Namespace)
+	if export.PodsOmitted > 0 {
+		fmt.Fprintf(&sb, " (%d more pod(s) omitted, raise max_pods)", export.PodsOmitted)
+	}
+	fmt.Fprintf(&sb, "\nArchive: %s (%d bytes)", export.Path, export.Size)
+	if export.ExpiresAt != nil {
+		fmt.Fprintf(&sb, ", deleted at %s", export.ExpiresAt.UTC().Format(time.RFC3339))
+	}
+	sb.WriteString("\n\n")
+	for _, f := range export.Files {
+		if f.Error != "" {
+			fmt.Fprintf(&sb, "⚠️ %s: %s\n", f.Name, f.Error)
+			continue
+		}
+		fmt.Fprintf(&sb, "- %s: %d line(s), %d error(s)", f.Name, f.Lines, f.ErrorLines)
+		if f.Truncated {
+			sb.WriteString(" (truncated at limit_bytes)")
+		}
+		sb.WriteString("\n")
+	}
+
+	size := export.Size
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{Text: sb.String()},
+			&mcp.ResourceLink{
+				URI:         uri,
+				Name:        export.Name,
+				Description: fmt.Sprintf("Logs of %d container(s) in namespace %s", len(export.Files), export.Namespace),
+				MIMEType:    mimeType,
+				Size:        &size,
+			},
+		},
+	}, export, nil
+}
+
+type podLogsFollowArgs struct {
+	ClusterID       string `json:"cluster_id" jsonschema:"ID of the cluster"`
+	Namespace       string `json:"namespace,omitempty" jsonschema:"Namespace of the pod" default:"default"`
+	PodName         string `json:"pod_name" jsonschema:"Name of the pod"`
+	DurationSeconds int    `json:"duration_seconds,omitempty" minimum:"0"`
+	MaxLines        int    `json:"max_lines,omitempty" minimum:"0"`
+	logOptionArgs
+}
+
+func (podLogsFollowArgs) adjustSchema(s *jsonschema.Schema) {
+	s.Properties["duration_seconds"].Description = fmt.Sprintf("Stop following after this many seconds (at most %d)", int(usecase.MaxLogFollowDuration.Seconds()))
+	s.Properties["duration_seconds"].Default = defaultOf(int(usecase.DefaultLogFollowDuration.Seconds()))
+	s.Properties["max_lines"].Description = fmt.Sprintf("Stop following after this many lines (at most %d)", usecase.MaxLogFollowLines)
+	s.Properties["max_lines"].Default = defaultOf(usecase.DefaultLogFollowLines)
+	describeTailLines(s, "Number of existing lines to include before following; only new lines by default")
+}
+
+func (m *MCPServer) handlePodLogsFollow(ctx context.Context, req *mcp.CallToolRequest, args podLogsFollowArgs) (*mcp.CallToolResult, *domain.LogFollowResult, error) {
+	m.logger.Info("Handling pod logs follow request", "args", args)
+
+	clusterID, namespace, podName := args.ClusterID, args.Namespace, args.PodName
+
+	opts, err := args.logOptions()
+	if err != nil {
+		return errorResult(err), nil, nil
+	}
+	// Only new lines are followed unless the caller asks for some history.
+	if opts.TailLines == nil {
+		zero := int64(0)
+		opts.TailLines = &zero
+	}
+	duration := usecase.DefaultLogFollowDuration
+	if args.DurationSeconds > 0 {
+		duration = min(time.Duration(args.DurationSeconds)*time.Second, usecase.MaxLogFollowDuration)
+	}
+	maxLines := usecase.DefaultLogFollowLines
+	if args.MaxLines > 0 {
+		maxLines = min(args.MaxLines, usecase.MaxLogFollowLines)
+	}
+
+	// Each line is sent as a progress notification when the client asked
+	// for progress, and as a log message at the level the client set.
+	source := podName
+	if opts.Container != "" {
+		source += "/" + opts.Container
+	}
+	progressToken := req.Params.GetProgressToken()
+	onLine := func(line string, n int) {
+		if progressToken != nil {
+			if err := req.Session.NotifyProgress(ctx, &mcp.ProgressNotificationParams{
+				ProgressToken: progressToken,
+				Message:       line,
+				Progress:      float64(n),
+				Total:         float64(maxLines),
+			}); err != nil {
+				m.logger.Debug("Failed to send log progress", "error", err)
+			}
+		}
+		if err := req.Session.Log(ctx, &mcp.LoggingMessageParams{Level: "info", Logger: source, Data: line}); err != nil {
+			m.logger.Debug("Failed to send log message", "error", err)
+		}
+	}
+
+	result, err := m.k8sUC.FollowPodLogs(ctx, clusterID, namespace, podName, opts, maxLines, duration, onLine)
+	if err != nil {
+		return errorResult(err), nil, nil
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "📡 Followed logs of pod '%s/%s': %d line(s), %s\n\n", namespace, source, len(result.Lines), logFollowStopText(result.StopReason, maxLines, duration))
+	for _, line := range result.Lines {
+		sb.WriteString(line)
+		sb.WriteString("\n")
+	}
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{&mcp.TextContent{Text: sb.String()}},
+	}, result, nil
+}
+
+func logFollowStopText(reason string, maxLines int, duration time.Duration) string {
+	switch reason {
+	case domain.LogFollowStopMaxLines:
+		return fmt.Sprintf("stopped at max_lines (%d)", maxLines)
+	case domain.LogFollowStopDuration:
+		return fmt.Sprintf("stopped after %s", duration)
+	case domain.LogFollowStopCancelled:
+		return "stopped: the request was cancelled"
+	}
+	return "stopped: the log stream ended"
+}
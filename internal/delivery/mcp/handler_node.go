@@ -3,22 +3,27 @@ package mcp
 import (
 	"context"
 	"fmt"
-	"strings"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
-	// Import domain package
-	// Giả định mustMarshalJSON và logger được định nghĩa
+	"github.com/your-org/mcp-k8s-server/internal/domain"
 )
 
-func (m *MCPServer) handleListNodes(ctx context.Context, req *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+type listNodesArgs struct {
+	ClusterID string `json:"cluster_id" jsonschema:"ID of the cluster"`
+	Namespace string `json:"namespace,omitempty" jsonschema:"Namespace (mặc dù Node là tài nguyên Cluster-scoped, namespace vẫn có thể được dùng cho ngữ cảnh)" default:"default"`
+}
+
+type listNodesResult struct {
+	ClusterID string        `json:"cluster_id"`
+	Count     int           `json:"count"`
+	Nodes     []domain.Node `json:"nodes"`
+}
+
+func (m *MCPServer) handleListNodes(ctx context.Context, req *mcp.CallToolRequest, args listNodesArgs) (*mcp.CallToolResult, *listNodesResult, error) {
 	// Logger is assumed to be available
 	// m.logger.Info("Handling list nodes request", "args", args)
 
-	clusterID, _ := args["cluster_id"].(string)
-
-	if clusterID == "" {
-		return errorResultNode("cluster_id is required"), nil, nil
-	}
+	clusterID := args.ClusterID
 
 	// CHÚ Ý: Use Case giờ trả về []domain.Node
 	nodes, err := m.k8sUC.ListNodes(ctx, clusterID)
@@ -35,10 +40,10 @@ func (m *MCPServer) handleListNodes(ctx context.Context, req *mcp.CallToolReques
 	}
 
 	// Gửi struct domain.Node đi, nó sẽ được marshal thành JSON
-	resultData := map[string]any{
-		"cluster_id": clusterID,
-		"count":      len(nodes),
-		"nodes":      nodes, // Trả về slice of domain.Node
+	resultData := &listNodesResult{
+		ClusterID: clusterID,
+		Count:     len(nodes),
+		Nodes:     nodes,
 	}
 
 	return &mcp.CallToolResult{
@@ -49,15 +54,15 @@ func (m *MCPServer) handleListNodes(ctx context.Context, req *mcp.CallToolReques
 	}, resultData, nil
 }
 
-func (m *MCPServer) handleGetNodeMetrics(ctx context.Context, req *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
-	// m.logger.Info("Handling get node metrics request", "args", args)
+type getNodeMetricsArgs struct {
+	ClusterID string `json:"cluster_id" jsonschema:"ID of the cluster"`
+	NodeName  string `json:"node_name" jsonschema:"Name of the Node"`
+}
 
-	clusterID, _ := args["cluster_id"].(string)
-	nodeName, _ := args["node_name"].(string)
+func (m *MCPServer) handleGetNodeMetrics(ctx context.Context, req *mcp.CallToolRequest, args getNodeMetricsArgs) (*mcp.CallToolResult, *domain.NodeMetrics, error) {
+	// m.logger.Info("Handling get node metrics request", "args", args)
 
-	if clusterID == "" || nodeName == "" {
-		return errorResultNode("cluster_id and node_name are required"), nil, nil
-	}
+	clusterID, nodeName := args.ClusterID, args.NodeName
 
 	// CHÚ Ý: Use Case giờ trả về domain.NodeMetrics
 	metrics, err := m.k8sUC.GetNodeMetrics(ctx, clusterID, nodeName)
@@ -78,12 +83,20 @@ func (m *MCPServer) handleGetNodeMetrics(ctx context.Context, req *mcp.CallToolR
 	summary += fmt.Sprintf("Memory: %s\n", metrics.Allocatable["memory"])
 	summary += fmt.Sprintf("Max Pods: %s\n", metrics.Allocatable["pods"])
 
+	summary += fmt.Sprintf("\n--- Usage (metrics.k8s.io) ---\n")
+	if metrics.Usage != nil {
+		summary += fmt.Sprintf("CPU: %s\n", metrics.Usage["cpu"])
+		summary += fmt.Sprintf("Memory: %s\n", metrics.Usage["memory"])
+	} else {
+		summary += fmt.Sprintf("Not available: %s\n", metrics.UsageError)
+	}
+
 	return &mcp.CallToolResult{
 		Content: []mcp.Content{
 			&mcp.TextContent{Text: summary},
 			&mcp.TextContent{Text: string(mustMarshalJSON(metrics))}, // Marshal domain.NodeMetrics
 		},
-	}, metrics, nil
+	}, &metrics, nil
 }
 
 func errorResultNode(errMsg string) *mcp.CallToolResult {
@@ -94,22 +107,15 @@ func errorResultNode(errMsg string) *mcp.CallToolResult {
 }
 
 // handleApplyTaintToNode adds or removes a taint on a Kubernetes Node.
-func (m *MCPServer) handleApplyTaintToNode(ctx context.Context, req *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
-	clusterID, _ := args["cluster_id"].(string)
-	nodeName, _ := args["node_name"].(string)
-	taintKey, _ := args["taint_key"].(string)
-	action, _ := args["action"].(string) // "add" or "remove"
-
-	if clusterID == "" || nodeName == "" || taintKey == "" || action == "" {
-		err := fmt.Errorf("cluster_id, node_name, taint_key (e.g., 'key=value:NoSchedule'), and action ('add' or 'remove') are required")
-		return errorResult(err), nil, err
-	}
+type applyTaintToNodeArgs struct {
+	ClusterID string `json:"cluster_id" jsonschema:"ID of the cluster."`
+	NodeName  string `json:"node_name" jsonschema:"Name of the Node to modify."`
+	TaintKey  string `json:"taint_key" jsonschema:"The Taint to apply/remove, in 'key=value:Effect' or 'key:Effect' format (Effect: NoSchedule, PreferNoSchedule, or NoExecute)."`
+	Action    string `json:"action" jsonschema:"Action to perform: 'add' or 'remove'." enum:"add,remove"`
+}
 
-	action = strings.ToLower(action)
-	if action != "add" && action != "remove" {
-		err := fmt.Errorf("invalid action '%s'. Must be 'add' or 'remove'", action)
-		return errorResult(err), nil, err
-	}
+func (m *MCPServer) handleApplyTaintToNode(ctx context.Context, req *mcp.CallToolRequest, args applyTaintToNodeArgs) (*mcp.CallToolResult, *domain.Node, error) {
+	clusterID, nodeName, taintKey, action := args.ClusterID, args.NodeName, args.TaintKey, args.Action
 
 	updatedNode, err := m.k8sUC.ApplyTaintToNode(ctx, clusterID, nodeName, taintKey, action)
 	if err != nil {
@@ -142,5 +148,5 @@ func (m *MCPServer) handleApplyTaintToNode(ctx context.Context, req *mcp.CallToo
 			&mcp.TextContent{Text: summary},
 			&mcp.TextContent{Text: string(mustMarshalJSON(updatedNode))},
 		},
-	}, updatedNode, nil
+	}, &updatedNode, nil
 }
@@ -3,74 +3,444 @@ package mcp
 import (
 	"context"
 	"fmt"
+	"strings"
+	"time"
 
+	"github.com/google/jsonschema-go/jsonschema"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/your-org/mcp-k8s-server/internal/domain"
+	"github.com/your-org/mcp-k8s-server/internal/usecase"
 )
 
-func (m *MCPServer) handleApplyYAML(ctx context.Context, req *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
-	clusterID, _ := args["cluster_id"].(string)
-	yamlBody, _ := args["yaml_body"].(string)
+type applyYAMLArgs struct {
+	ClusterID    string `json:"cluster_id"`
+	YAMLBody     string `json:"yaml_body,omitempty" jsonschema:"YAML or JSON manifests. Multiple documents may be separated by '---'."`
+	Path         string `json:"path,omitempty" jsonschema:"Optional: manifest file, directory (*.yaml, *.yml, *.json) or glob pattern such as 'deploy/*.yaml', relative to the server's manifest root. Only available when a manifest root is configured."`
+	Recursive    bool   `json:"recursive,omitempty" jsonschema:"When path is a directory, also read manifests from its subdirectories." default:"false"`
+	FieldManager string `json:"field_manager,omitempty"`
+	DryRun       bool   `json:"dry_run,omitempty" jsonschema:"If true, only validate the object without persisting it."`
+}
+
+type applyYAMLResult struct {
+	ClusterID string               `json:"cluster_id"`
+	DryRun    bool                 `json:"dry_run"`
+	Count     int                  `json:"count"`
+	Failed    int                  `json:"failed"`
+	Results   []domain.ApplyResult `json:"results"`
+}
 
-	dryRun, _ := args["dry_run"].(bool)
+func (m *MCPServer) handleApplyYAML(ctx context.Context, req *mcp.CallToolRequest, args applyYAMLArgs) (*mcp.CallToolResult, *applyYAMLResult, error) {
+	clusterID, yamlBody, path := args.ClusterID, args.YAMLBody, args.Path
+	recursive, dryRun := args.Recursive, args.DryRun
 
-	manager, ok := args["field_manager"].(string)
-	if !ok || manager == "" {
+	manager := args.FieldManager
+	if manager == "" {
 		manager = "mcp-k8s-assistant"
 	}
 
-	result, err := m.k8sUC.ApplyYAML(ctx, clusterID, yamlBody, manager, dryRun)
+	if strings.TrimSpace(yamlBody) == "" && path == "" {
+		return errorResult(fmt.Errorf("either yaml_body or path is required")), nil, nil
+	}
+
+	files := usecase.ManifestFiles{Root: m.manifestRoot, Path: path, Recursive: recursive}
+	results, err := m.k8sUC.ApplyYAML(ctx, clusterID, yamlBody, files, manager, dryRun)
 	if err != nil {
 		return errorResult(err), nil, err
 	}
 
-	statusEmoji := "✅"
 	actionText := "Applied"
 	if dryRun {
-		statusEmoji = " [DRY-RUN]"
-		actionText = "validated (no changes made)"
+		actionText = "[DRY-RUN] Validated (no changes made)"
+	}
+
+	failed := 0
+	summary := fmt.Sprintf("%s %d object(s):\n", actionText, len(results))
+	for i, r := range results {
+		target := r.Name
+		if r.Namespace != "" {
+			target = r.Namespace + "/" + r.Name
+		}
+		if r.Error != "" {
+			failed++
+			summary += fmt.Sprintf("%d. ❌ %s %s - %s\n", i+1, r.Kind, target, r.Error)
+			continue
+		}
+		summary += fmt.Sprintf("%d. ✅ %s %s %s\n", i+1, r.Kind, target, r.Action)
+	}
+	if failed > 0 {
+		summary += fmt.Sprintf("\n%d of %d object(s) failed.", failed, len(results))
 	}
 
-	summary := fmt.Sprintf("%s %s %s: %s in namespace %s",
-		statusEmoji, actionText, result.Kind, result.Name, result.Namespace)
+	resultData := &applyYAMLResult{
+		ClusterID: clusterID,
+		DryRun:    dryRun,
+		Count:     len(results),
+		Failed:    failed,
+		Results:   results,
+	}
 
 	return &mcp.CallToolResult{
 		Content: []mcp.Content{
 			&mcp.TextContent{Text: summary},
+			&mcp.TextContent{Text: string(mustMarshalJSON(resultData))},
 		},
-	}, result, nil
+		IsError: failed > 0,
+	}, resultData, nil
 }
 
-func (m *MCPServer) handlePortForward(ctx context.Context, req *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
-	clusterID, _ := args["cluster_id"].(string)
-	ns, _ := args["namespace"].(string)
-	pod, _ := args["pod_name"].(string)
+type diffYAMLArgs struct {
+	ClusterID    string `json:"cluster_id" jsonschema:"ID of the cluster"`
+	YAMLBody     string `json:"yaml_body,omitempty" jsonschema:"YAML or JSON manifests. Multiple documents may be separated by '---'."`
+	Path         string `json:"path,omitempty" jsonschema:"Optional: manifest file, directory (*.yaml, *.yml, *.json) or glob pattern relative to the server's manifest root. Only available when a manifest root is configured."`
+	Recursive    bool   `json:"recursive,omitempty" jsonschema:"When path is a directory, also read manifests from its subdirectories." default:"false"`
+	FieldManager string `json:"field_manager,omitempty"`
+}
 
-	action, _ := args["action"].(string)
-	if action == "" {
-		action = "start"
-	}
+type diffYAMLResult struct {
+	ClusterID string              `json:"cluster_id"`
+	Count     int                 `json:"count"`
+	Changed   int                 `json:"changed"`
+	Failed    int                 `json:"failed"`
+	Results   []domain.DiffResult `json:"results"`
+}
 
-	var lPort, rPort int
-	if val, ok := args["local_port"].(float64); ok {
-		lPort = int(val)
+func (m *MCPServer) handleDiffYAML(ctx context.Context, req *mcp.CallToolRequest, args diffYAMLArgs) (*mcp.CallToolResult, *diffYAMLResult, error) {
+	clusterID, yamlBody, path, recursive := args.ClusterID, args.YAMLBody, args.Path, args.Recursive
+
+	manager := args.FieldManager
+	if manager == "" {
+		manager = "mcp-k8s-assistant"
 	}
-	if val, ok := args["remote_port"].(float64); ok {
-		rPort = int(val)
+
+	if strings.TrimSpace(yamlBody) == "" && path == "" {
+		return errorResult(fmt.Errorf("either yaml_body or path is required")), nil, nil
 	}
 
-	res, err := m.k8sUC.PortForward(ctx, clusterID, ns, pod, lPort, rPort, action)
+	files := usecase.ManifestFiles{Root: m.manifestRoot, Path: path, Recursive: recursive}
+	results, err := m.k8sUC.DiffYAML(ctx, clusterID, yamlBody, files, manager)
 	if err != nil {
 		return errorResult(err), nil, err
 	}
 
-	var summary string
-	if action == "stop" {
-		summary = fmt.Sprintf(" Port-forward for pod %s has been terminated.", pod)
+	changed, failed := 0, 0
+	summary := fmt.Sprintf("Diff of %d object(s) against the live cluster:\n", len(results))
+	diffs := ""
+	for i, r := range results {
+		target := r.Name
+		if r.Namespace != "" {
+			target = r.Namespace + "/" + r.Name
+		}
+		switch {
+		case r.Error != "":
+			failed++
+			summary += fmt.Sprintf("%d. ❌ %s %s - %s\n", i+1, r.Kind, target, r.Error)
+		case !r.Exists:
+			changed++
+			summary += fmt.Sprintf("%d. ➕ %s %s (new object)\n", i+1, r.Kind, target)
+		case r.Changed:
+			changed++
+			summary += fmt.Sprintf("%d. ✏️ %s %s (changed)\n", i+1, r.Kind, target)
+		default:
+			summary += fmt.Sprintf("%d. %s %s (no changes)\n", i+1, r.Kind, target)
+		}
+		diffs += r.Diff
+	}
+	summary += fmt.Sprintf("\n%d object(s) would change, %d failed.", changed, failed)
+
+	resultData := &diffYAMLResult{
+		ClusterID: clusterID,
+		Count:     len(results),
+		Changed:   changed,
+		Failed:    failed,
+		Results:   results,
+	}
+
+	content := []mcp.Content{&mcp.TextContent{Text: summary}}
+	if diffs != "" {
+		content = append(content, &mcp.TextContent{Text: "```diff\n" + diffs + "```"})
+	}
+	content = append(content, &mcp.TextContent{Text: string(mustMarshalJSON(resultData))})
+
+	return &mcp.CallToolResult{
+		Content: content,
+		IsError: failed > 0,
+	}, resultData, nil
+}
+
+type portForwardArgs struct {
+	ClusterID      string `json:"cluster_id"`
+	Namespace      string `json:"namespace,omitempty"`
+	PodName        string `json:"pod_name,omitempty" jsonschema:"Forward to this pod."`
+	ServiceName    string `json:"service_name,omitempty" jsonschema:"Forward to a ready pod selected by this service; remote_port is a service port."`
+	DeploymentName string `json:"deployment_name,omitempty" jsonschema:"Forward to a ready pod of this deployment."`
+	RemotePort     int    `json:"remote_port,omitempty" jsonschema:"Port on the pod, or on the service for service_name. Optional for single-port services."`
+	LocalPort      int    `json:"local_port,omitempty" jsonschema:"Local port to listen on; 0 or omitted picks a free port." default:"0"`
+	TTLSeconds     int    `json:"ttl_seconds,omitempty" jsonschema:"Close the tunnel after this many seconds; capped by the server's maximum lifetime."`
+	ID             string `json:"id,omitempty" jsonschema:"Tunnel ID to stop."`
+	Action         string `json:"action,omitempty" default:"start" enum:"start,stop"`
+}
+
+// portForwardResult holds the tunnel started, or those stopped.
+type portForwardResult struct {
+	Action  string                      `json:"action"`
+	Session *domain.PortForwardSession  `json:"session,omitempty"`
+	Stopped []domain.PortForwardSession `json:"stopped,omitempty"`
+}
+
+func (m *MCPServer) handlePortForward(ctx context.Context, req *mcp.CallToolRequest, args portForwardArgs) (*mcp.CallToolResult, *portForwardResult, error) {
+	id, action := args.ID, args.Action
+	pfReq := domain.PortForwardRequest{
+		ClusterID:  args.ClusterID,
+		Namespace:  args.Namespace,
+		LocalPort:  args.LocalPort,
+		RemotePort: args.RemotePort,
+	}
+	if pfReq.Namespace == "" {
+		pfReq.Namespace = "default"
+	}
+	if args.TTLSeconds > 0 {
+		pfReq.TTL = time.Duration(args.TTLSeconds) * time.Second
+	}
+
+	var targets []string
+	for _, t := range []struct{ kind, key, name string }{
+		{domain.PortForwardTargetPod, "pod_name", args.PodName},
+		{domain.PortForwardTargetService, "service_name", args.ServiceName},
+		{domain.PortForwardTargetDeployment, "deployment_name", args.DeploymentName},
+	} {
+		if t.name != "" {
+			pfReq.TargetKind, pfReq.TargetName = t.kind, t.name
+			targets = append(targets, t.key)
+		}
+	}
+	if len(targets) > 1 {
+		return errorResult(fmt.Errorf("only one of pod_name, service_name or deployment_name may be set")), nil, nil
+	}
+
+	switch action {
+	case "stop":
+		stopped, err := m.k8sUC.StopPortForward(id, pfReq)
+		if err != nil {
+			return errorResult(err), nil, nil
+		}
+		var sb strings.Builder
+		fmt.Fprintf(&sb, "Stopped %d port-forward(s):\n", len(stopped))
+		for _, s := range stopped {
+			fmt.Fprintf(&sb, "- %s\n", s.ID)
+		}
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{&mcp.TextContent{Text: sb.String()}},
+		}, &portForwardResult{Action: action, Stopped: stopped}, nil
+
+	case "start":
+		if pfReq.TargetName == "" {
+			return errorResult(fmt.Errorf("one of pod_name, service_name or deployment_name is required")), nil, nil
+		}
+		session, err := m.k8sUC.StartPortForward(ctx, pfReq)
+		if err != nil {
+			return errorResult(err), nil, nil
+		}
+		summary := fmt.Sprintf("Tunnel %s active: %s -> pod %s port %d", session.ID, session.URL, session.PodName, session.PodPort)
+		if session.ExpiresAt != nil {
+			summary += fmt.Sprintf(" (expires %s)", session.ExpiresAt.Format(time.RFC3339))
+		}
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{&mcp.TextContent{Text: summary}},
+		}, &portForwardResult{Action: action, Session: session}, nil
+	}
+	return errorResult(fmt.Errorf("unknown action %q: want start or stop", action)), nil, nil
+}
+
+type listPortForwardsArgs struct {
+	ClusterID string `json:"cluster_id,omitempty" jsonschema:"Only list tunnels to this cluster."`
+}
+
+type listPortForwardsResult struct {
+	Count        int                         `json:"count"`
+	PortForwards []domain.PortForwardSession `json:"port_forwards"`
+}
+
+func (m *MCPServer) handleListPortForwards(ctx context.Context, req *mcp.CallToolRequest, args listPortForwardsArgs) (*mcp.CallToolResult, *listPortForwardsResult, error) {
+	clusterID := args.ClusterID
+
+	sessions := m.k8sUC.ListPortForwards(clusterID)
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Found %d active port-forward(s)\n", len(sessions))
+	for _, s := range sessions {
+		fmt.Fprintf(&sb, "- %s %s (%s %s, %d connection(s), last active %s)\n",
+			s.ID, s.URL, s.TargetKind, s.TargetName, s.Connections, s.LastActive.Format(time.RFC3339))
+	}
+
+	resultData := &listPortForwardsResult{
+		Count:        len(sessions),
+		PortForwards: sessions,
+	}
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{Text: sb.String()},
+			&mcp.TextContent{Text: string(mustMarshalJSON(resultData))},
+		},
+	}, resultData, nil
+}
+
+const (
+	defaultExecTimeout   = 30 * time.Second
+	defaultExecMaxOutput = 64 << 10
+)
+
+type podExecArgs struct {
+	ClusterID      string   `json:"cluster_id"`
+	Namespace      string   `json:"namespace"`
+	PodName        string   `json:"pod_name"`
+	Container      string   `json:"container,omitempty" jsonschema:"Container to run in; defaults to the pod's default container."`
+	Command        []string `json:"command" jsonschema:"Command and arguments, e.g. [\"cat\", \"/etc/resolv.conf\"]. No shell is involved." minItems:"1"`
+	TimeoutSeconds int      `json:"timeout_seconds,omitempty" jsonschema:"Stop waiting after this many seconds; capped by the server."`
+}
+
+func (podExecArgs) adjustSchema(s *jsonschema.Schema) {
+	s.Properties["timeout_seconds"].Default = defaultOf(defaultExecTimeout.Seconds())
+}
+
+func (m *MCPServer) handlePodExec(ctx context.Context, req *mcp.CallToolRequest, args podExecArgs) (*mcp.CallToolResult, *domain.PodExecResult, error) {
+	execReq := domain.PodExecRequest{
+		ClusterID:      args.ClusterID,
+		Namespace:      args.Namespace,
+		PodName:        args.PodName,
+		Container:      args.Container,
+		Command:        args.Command,
+		Timeout:        defaultExecTimeout,
+		MaxOutputBytes: m.execPolicy.MaxOutputBytes,
+	}
+	if args.TimeoutSeconds > 0 {
+		execReq.Timeout = time.Duration(args.TimeoutSeconds) * time.Second
+	}
+	if m.execPolicy.MaxTimeout > 0 {
+		execReq.Timeout = min(execReq.Timeout, m.execPolicy.MaxTimeout)
+	}
+	if execReq.MaxOutputBytes <= 0 {
+		execReq.MaxOutputBytes = defaultExecMaxOutput
+	}
+
+	if err := usecase.CheckExecCommand(m.execPolicy, execReq.Command); err != nil {
+		return errorResult(err), nil, nil
+	}
+
+	res, err := m.k8sUC.ExecPod(ctx, execReq)
+	if err != nil {
+		return errorResult(err), nil, nil
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "$ %s (pod %s/%s, container %s)\n", strings.Join(res.Command, " "), res.Namespace, res.PodName, res.Container)
+	if res.TimedOut {
+		fmt.Fprintf(&sb, "Timed out after %s\n", execReq.Timeout)
 	} else {
-		summary = fmt.Sprintf(" Tunnel Active! Access here: %s", res.URL)
+		fmt.Fprintf(&sb, "Exit code: %d\n", res.ExitCode)
+	}
+	for _, stream := range []struct {
+		name, out string
+		truncated bool
+	}{{"stdout", res.Stdout, res.StdoutTruncated}, {"stderr", res.Stderr, res.StderrTruncated}} {
+		if stream.out == "" {
+			continue
+		}
+		fmt.Fprintf(&sb, "--- %s", stream.name)
+		if stream.truncated {
+			fmt.Fprintf(&sb, " (truncated at %d bytes)", execReq.MaxOutputBytes)
+		}
+		sb.WriteString("\n" + stream.out)
+		if !strings.HasSuffix(stream.out, "\n") {
+			sb.WriteString("\n")
+		}
 	}
 
 	return &mcp.CallToolResult{
-		Content: []mcp.Content{&mcp.TextContent{Text: summary}},
+		Content: []mcp.Content{&mcp.TextContent{Text: sb.String()}},
+		IsError: res.ExitCode != 0,
 	}, res, nil
 }
+
+type podDiagnoseArgs struct {
+	ClusterID    string `json:"cluster_id" jsonschema:"ID of the cluster"`
+	Namespace    string `json:"namespace,omitempty" jsonschema:"Namespace of the pod" default:"default"`
+	PodName      string `json:"pod_name" jsonschema:"Name of the pod"`
+	LogTailLines int64  `json:"log_tail_lines,omitempty" jsonschema:"Lines of previous logs to read per restarted container"`
+}
+
+func (podDiagnoseArgs) adjustSchema(s *jsonschema.Schema) {
+	s.Properties["log_tail_lines"].Default = defaultOf(usecase.DefaultDiagnoseLogLines)
+}
+
+func (m *MCPServer) handlePodDiagnose(ctx context.Context, req *mcp.CallToolRequest, args podDiagnoseArgs) (*mcp.CallToolResult, *domain.PodDiagnosis, error) {
+	m.logger.Info("Handling pod diagnose request", "args", args)
+
+	clusterID, namespace, podName := args.ClusterID, args.Namespace, args.PodName
+
+	logLines := args.LogTailLines
+	if logLines <= 0 {
+		logLines = usecase.DefaultDiagnoseLogLines
+	}
+
+	d, err := m.k8sUC.DiagnosePod(ctx, clusterID, namespace, podName, logLines)
+	if err != nil {
+		return errorResult(err), nil, nil
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "🩺 Pod '%s/%s' is %s", d.Namespace, d.Pod, d.Phase)
+	if d.Node != "" {
+		fmt.Fprintf(&sb, " on node %s", d.Node)
+	}
+	if len(d.Problems) == 0 {
+		sb.WriteString(": no problems detected\n")
+	} else {
+		fmt.Fprintf(&sb, ": %d problem(s) detected\n", len(d.Problems))
+	}
+	for i, p := range d.Problems {
+		icon := "⚠️"
+		if p.Severity == domain.ProblemSeverityCritical {
+			icon = "❌"
+		}
+		fmt.Fprintf(&sb, "\n%d. %s %s", i+1, icon, p.Reason)
+		if p.Container != "" {
+			fmt.Fprintf(&sb, " (container %s)", p.Container)
+		}
+		fmt.Fprintf(&sb, ": %s\n", p.Message)
+		for _, cause := range p.LikelyCauses {
+			fmt.Fprintf(&sb, "   - %s\n", cause)
+		}
+	}
+
+	sb.WriteString("\nContainers:\n")
+	for _, c := range d.Containers {
+		state := c.State
+		if c.Reason != "" {
+			state += " (" + c.Reason + ")"
+		}
+		fmt.Fprintf(&sb, "- %s [%s]: %s, ready=%t, restarts=%d\n", c.Name, c.Image, state, c.Ready, c.RestartCount)
+		if t := c.LastTermination; t != nil {
+			fmt.Fprintf(&sb, "  last terminated: %s, exit code %d\n", t.Reason, t.ExitCode)
+		}
+		if c.PreviousLogs != "" {
+			sb.WriteString("  previous logs:\n")
+			for _, line := range strings.Split(strings.TrimRight(c.PreviousLogs, "\n"), "\n") {
+				fmt.Fprintf(&sb, "    %s\n", line)
+			}
+		}
+	}
+	if len(d.Events) > 0 {
+		sb.WriteString("\nEvents:\n")
+		for _, e := range d.Events {
+			sb.WriteString(eventLine(e))
+			sb.WriteString("\n")
+		}
+	}
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{Text: sb.String()},
+			&mcp.TextContent{Text: string(mustMarshalJSON(d))},
+		},
+	}, d, nil
+}
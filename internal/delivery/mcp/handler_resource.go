@@ -0,0 +1,169 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/your-org/mcp-k8s-server/internal/domain"
+	"github.com/your-org/mcp-k8s-server/internal/usecase"
+	"sigs.k8s.io/yaml"
+)
+
+// resourceTypeArgs select the resource type of the generic resource tools.
+type resourceTypeArgs struct {
+	ClusterID  string `json:"cluster_id" jsonschema:"ID of the cluster"`
+	Kind       string `json:"kind" jsonschema:"Kind, resource name or short name, e.g. 'Deployment', 'pvc', 'networkpolicies' or 'certificates.cert-manager.io'"`
+	APIVersion string `json:"api_version,omitempty" jsonschema:"Optional: group/version to use when a kind is served by several groups or versions, e.g. 'cert-manager.io/v1'"`
+	Namespace  string `json:"namespace,omitempty" jsonschema:"Namespace of the objects; ignored for cluster-scoped kinds" default:"default"`
+}
+
+type resourceGetArgs struct {
+	resourceTypeArgs
+	Name   string `json:"name" jsonschema:"Name of the object"`
+	Output string `json:"output,omitempty" jsonschema:"Format of the text output" enum:"yaml,json" default:"yaml"`
+}
+
+func (m *MCPServer) handleResourceGet(ctx context.Context, req *mcp.CallToolRequest, args resourceGetArgs) (*mcp.CallToolResult, *domain.ResourceObject, error) {
+	m.logger.Info("Handling resource get request", "args", args)
+
+	clusterID, kind, apiVersion, namespace := args.ClusterID, args.Kind, args.APIVersion, args.Namespace
+	name, output := args.Name, args.Output
+
+	obj, err := m.k8sUC.GetResource(ctx, clusterID, kind, apiVersion, namespace, name)
+	if err != nil {
+		return errorResult(err), nil, nil
+	}
+
+	var body []byte
+	if output == "json" {
+		body, err = json.MarshalIndent(obj.Object, "", "  ")
+	} else {
+		body, err = yaml.Marshal(obj.Object)
+	}
+	if err != nil {
+		return errorResult(fmt.Errorf("failed to encode %s: %w", obj.Kind, err)), nil, nil
+	}
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{Text: fmt.Sprintf("📄 %s '%s' (%s):\n\n%s", obj.Kind, objectPath(obj.Namespace, obj.Name), obj.APIVersion, body)},
+		},
+	}, obj, nil
+}
+
+type resourceListArgs struct {
+	resourceTypeArgs
+	AllNamespaces bool   `json:"all_namespaces,omitempty" jsonschema:"List every namespace instead of namespace"`
+	LabelSelector string `json:"label_selector,omitempty" jsonschema:"Optional label selector, e.g. app=web,tier!=cache"`
+	FieldSelector string `json:"field_selector,omitempty" jsonschema:"Optional field selector, e.g. status.phase=Running"`
+	Limit         int64  `json:"limit,omitempty"`
+	Continue      string `json:"continue,omitempty" jsonschema:"Continue token from the previous page"`
+}
+
+func (resourceListArgs) adjustSchema(s *jsonschema.Schema) {
+	s.Properties["limit"].Description = fmt.Sprintf("Objects per page (at most %d)", usecase.MaxResourceListLimit)
+	s.Properties["limit"].Default = defaultOf(usecase.DefaultResourceListLimit)
+}
+
+func (m *MCPServer) handleResourceList(ctx context.Context, req *mcp.CallToolRequest, args resourceListArgs) (*mcp.CallToolResult, *domain.ResourceList, error) {
+	m.logger.Info("Handling resource list request", "args", args)
+
+	clusterID, namespace, allNamespaces := args.ClusterID, args.Namespace, args.AllNamespaces
+	listReq := domain.ResourceListRequest{
+		Kind:          args.Kind,
+		APIVersion:    args.APIVersion,
+		Namespace:     namespace,
+		LabelSelector: args.LabelSelector,
+		FieldSelector: args.FieldSelector,
+		Limit:         usecase.DefaultResourceListLimit,
+		Continue:      args.Continue,
+	}
+	if args.Limit > 0 {
+		listReq.Limit = min(args.Limit, usecase.MaxResourceListLimit)
+	}
+	if allNamespaces {
+		listReq.Namespace = string(domain.NamespaceAll)
+	}
+
+	list, err := m.k8sUC.ListResources(ctx, clusterID, listReq)
+	if err != nil {
+		return errorResult(err), nil, nil
+	}
+
+	scope := "the cluster"
+	switch {
+	case list.Namespaced && allNamespaces:
+		scope = "all namespaces"
+	case list.Namespaced:
+		scope = fmt.Sprintf("namespace '%s'", namespace)
+	}
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "📦 Found %d %s (%s) in %s:\n\n", len(list.Items), list.Resource, list.APIVersion, scope)
+	for i, item := range list.Items {
+		name := item.Name
+		if allNamespaces && item.Namespace != "" {
+			name = item.Namespace + "/" + item.Name
+		}
+		fmt.Fprintf(&sb, "%d. %s", i+1, name)
+		if item.Status != "" {
+			fmt.Fprintf(&sb, " - Status: %s", item.Status)
+		}
+		sb.WriteString("\n")
+	}
+	if list.Continue != "" {
+		fmt.Fprintf(&sb, "\nMore results are available: call again with continue=%q.\n", list.Continue)
+	}
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{Text: sb.String()},
+			&mcp.TextContent{Text: string(mustMarshalJSON(list))},
+		},
+	}, list, nil
+}
+
+type resourceDeleteArgs struct {
+	resourceTypeArgs
+	Name        string `json:"name" jsonschema:"Name of the object"`
+	Propagation string `json:"propagation,omitempty" jsonschema:"How dependents are deleted" default:"background"`
+	DryRun      bool   `json:"dry_run,omitempty" jsonschema:"Let the API server validate the deletion without deleting anything" default:"false"`
+}
+
+func (resourceDeleteArgs) adjustSchema(s *jsonschema.Schema) {
+	s.Properties["propagation"].Enum = enumOf(usecase.DeletePropagationPolicies...)
+}
+
+func (m *MCPServer) handleResourceDelete(ctx context.Context, req *mcp.CallToolRequest, args resourceDeleteArgs) (*mcp.CallToolResult, *domain.ResourceDeleteResult, error) {
+	m.logger.Info("Handling resource delete request", "args", args)
+
+	clusterID, kind, apiVersion, namespace := args.ClusterID, args.Kind, args.APIVersion, args.Namespace
+	name, propagation, dryRun := args.Name, args.Propagation, args.DryRun
+
+	result, err := m.k8sUC.DeleteResource(ctx, clusterID, kind, apiVersion, namespace, name, propagation, dryRun)
+	if err != nil {
+		return errorResult(err), nil, nil
+	}
+
+	text := fmt.Sprintf("🗑️ %s '%s' deleted (propagation: %s)", result.Kind, objectPath(result.Namespace, result.Name), result.Propagation)
+	if result.DryRun {
+		text = fmt.Sprintf("🧪 Dry run: %s '%s' would be deleted (propagation: %s); nothing was changed", result.Kind, objectPath(result.Namespace, result.Name), result.Propagation)
+	}
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{Text: text},
+			&mcp.TextContent{Text: string(mustMarshalJSON(result))},
+		},
+	}, result, nil
+}
+
+// objectPath is namespace/name, or name for cluster-scoped objects.
+func objectPath(namespace, name string) string {
+	if namespace == "" {
+		return name
+	}
+	return namespace + "/" + name
+}
@@ -5,13 +5,24 @@ import (
 	"fmt"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/your-org/mcp-k8s-server/internal/domain"
 )
 
 // ... imports
 
-func (m *MCPServer) handleListResourceQuotas(ctx context.Context, req *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, map[string]any, error) {
-	clusterID, _ := args["cluster_id"].(string)
-	namespace, _ := args["namespace"].(string)
+type listResourceQuotasArgs struct {
+	ClusterID string `json:"cluster_id" jsonschema:"ID of the cluster"`
+	Namespace string `json:"namespace" jsonschema:"Namespace to list ResourceQuotas from" default:"default"`
+}
+
+type listResourceQuotasResult struct {
+	ClusterID string                        `json:"cluster_id"`
+	Namespace string                        `json:"namespace"`
+	Quotas    []domain.ResourceQuotaSummary `json:"quotas"`
+}
+
+func (m *MCPServer) handleListResourceQuotas(ctx context.Context, req *mcp.CallToolRequest, args listResourceQuotasArgs) (*mcp.CallToolResult, *listResourceQuotasResult, error) {
+	clusterID, namespace := args.ClusterID, args.Namespace
 
 	quotas, err := m.k8sUC.ListResourceQuotas(ctx, clusterID, namespace)
 	if err != nil {
@@ -20,13 +31,13 @@ func (m *MCPServer) handleListResourceQuotas(ctx context.Context, req *mcp.CallT
 
 	summary := fmt.Sprintf(" Found %d ResourceQuota(s) in %s/%s:\n", len(quotas), clusterID, namespace)
 	for i, q := range quotas {
-		summary += fmt.Sprintf("%d. Name: %s\n", i+1, q["name"])
+		summary += fmt.Sprintf("%d. Name: %s\n", i+1, q.Name)
 	}
 
-	resultData := map[string]any{
-		"cluster_id": clusterID,
-		"namespace":  namespace,
-		"quotas":     quotas,
+	resultData := &listResourceQuotasResult{
+		ClusterID: clusterID,
+		Namespace: namespace,
+		Quotas:    quotas,
 	}
 
 	return &mcp.CallToolResult{
@@ -37,10 +48,14 @@ func (m *MCPServer) handleListResourceQuotas(ctx context.Context, req *mcp.CallT
 	}, resultData, nil
 }
 
-func (m *MCPServer) handleGetResourceQuota(ctx context.Context, req *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, map[string]any, error) {
-	clusterID, _ := args["cluster_id"].(string)
-	namespace, _ := args["namespace"].(string)
-	name, _ := args["quota_name"].(string)
+type getResourceQuotaArgs struct {
+	ClusterID string `json:"cluster_id" jsonschema:"ID of the cluster"`
+	Namespace string `json:"namespace" jsonschema:"Namespace of the ResourceQuota" default:"default"`
+	QuotaName string `json:"quota_name" jsonschema:"Name of the ResourceQuota"`
+}
+
+func (m *MCPServer) handleGetResourceQuota(ctx context.Context, req *mcp.CallToolRequest, args getResourceQuotaArgs) (*mcp.CallToolResult, *domain.ResourceQuotaDetail, error) {
+	clusterID, namespace, name := args.ClusterID, args.Namespace, args.QuotaName
 
 	quota, err := m.k8sUC.GetResourceQuotaDetail(ctx, clusterID, namespace, name)
 	if err != nil {
@@ -49,14 +64,12 @@ func (m *MCPServer) handleGetResourceQuota(ctx context.Context, req *mcp.CallToo
 
 	summary := fmt.Sprintf("📈 Resource Quota Details (%s/%s):\n", namespace, name)
 	summary += "--- Limits (Hard) ---\n"
-	hardLimits, _ := quota["hard_limits"].(map[string]string)
-	for res, limit := range hardLimits {
+	for res, limit := range quota.HardLimits {
 		summary += fmt.Sprintf("%s: %s\n", res, limit)
 	}
 
 	summary += "\n--- Used ---\n"
-	used, _ := quota["used"].(map[string]string)
-	for res, usage := range used {
+	for res, usage := range quota.Used {
 		summary += fmt.Sprintf("%s: %s\n", res, usage)
 	}
 
@@ -68,9 +81,19 @@ func (m *MCPServer) handleGetResourceQuota(ctx context.Context, req *mcp.CallToo
 	}, quota, nil
 }
 
-func (m *MCPServer) handleListLimitRanges(ctx context.Context, req *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, map[string]any, error) {
-	clusterID, _ := args["cluster_id"].(string)
-	namespace, _ := args["namespace"].(string)
+type listLimitRangesArgs struct {
+	ClusterID string `json:"cluster_id" jsonschema:"ID of the cluster"`
+	Namespace string `json:"namespace" jsonschema:"Namespace to list LimitRanges from" default:"default"`
+}
+
+type listLimitRangesResult struct {
+	ClusterID   string                     `json:"cluster_id"`
+	Namespace   string                     `json:"namespace"`
+	LimitRanges []domain.LimitRangeSummary `json:"limit_ranges"`
+}
+
+func (m *MCPServer) handleListLimitRanges(ctx context.Context, req *mcp.CallToolRequest, args listLimitRangesArgs) (*mcp.CallToolResult, *listLimitRangesResult, error) {
+	clusterID, namespace := args.ClusterID, args.Namespace
 
 	limitRanges, err := m.k8sUC.ListLimitRanges(ctx, clusterID, namespace)
 	if err != nil {
@@ -79,13 +102,13 @@ func (m *MCPServer) handleListLimitRanges(ctx context.Context, req *mcp.CallTool
 
 	summary := fmt.Sprintf("⚖️ Found %d LimitRange(s) in %s/%s:\n", len(limitRanges), clusterID, namespace)
 	for i, lr := range limitRanges {
-		summary += fmt.Sprintf("%d. Name: %s (Limits: %d)\n", i+1, lr["name"], lr["limits_count"])
+		summary += fmt.Sprintf("%d. Name: %s (Limits: %d)\n", i+1, lr.Name, lr.LimitsCount)
 	}
 
-	resultData := map[string]any{
-		"cluster_id":   clusterID,
-		"namespace":    namespace,
-		"limit_ranges": limitRanges,
+	resultData := &listLimitRangesResult{
+		ClusterID:   clusterID,
+		Namespace:   namespace,
+		LimitRanges: limitRanges,
 	}
 
 	return &mcp.CallToolResult{
@@ -96,10 +119,14 @@ func (m *MCPServer) handleListLimitRanges(ctx context.Context, req *mcp.CallTool
 	}, resultData, nil
 }
 
-func (m *MCPServer) handleGetLimitRange(ctx context.Context, req *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, map[string]any, error) {
-	clusterID, _ := args["cluster_id"].(string)
-	namespace, _ := args["namespace"].(string)
-	name, _ := args["limit_range_name"].(string)
+type getLimitRangeArgs struct {
+	ClusterID      string `json:"cluster_id" jsonschema:"ID of the cluster"`
+	Namespace      string `json:"namespace" jsonschema:"Namespace of the LimitRange" default:"default"`
+	LimitRangeName string `json:"limit_range_name" jsonschema:"Name of the LimitRange"`
+}
+
+func (m *MCPServer) handleGetLimitRange(ctx context.Context, req *mcp.CallToolRequest, args getLimitRangeArgs) (*mcp.CallToolResult, *domain.LimitRangeDetail, error) {
+	clusterID, namespace, name := args.ClusterID, args.Namespace, args.LimitRangeName
 
 	limitRange, err := m.k8sUC.GetLimitRangeDetail(ctx, clusterID, namespace, name)
 	if err != nil {
@@ -108,23 +135,20 @@ func (m *MCPServer) handleGetLimitRange(ctx context.Context, req *mcp.CallToolRe
 
 	summary := fmt.Sprintf(" Limit Range Details (%s/%s):\n\n", namespace, name)
 
-	limits, _ := limitRange["limits"].([]map[string]any)
-	for i, limit := range limits {
-		summary += fmt.Sprintf("--- Limit Set %d (Type: %s) ---\n", i+1, limit["type"])
+	for i, limit := range limitRange.Limits {
+		summary += fmt.Sprintf("--- Limit Set %d (Type: %s) ---\n", i+1, limit.Type)
 
-		max, _ := limit["max"].(map[string]string)
-		if len(max) > 0 {
+		if len(limit.Max) > 0 {
 			summary += "Max: "
-			for res, val := range max {
+			for res, val := range limit.Max {
 				summary += fmt.Sprintf("%s=%s ", res, val)
 			}
 			summary += "\n"
 		}
 
-		def, _ := limit["default"].(map[string]string)
-		if len(def) > 0 {
+		if len(limit.Default) > 0 {
 			summary += "Default: "
-			for res, val := range def {
+			for res, val := range limit.Default {
 				summary += fmt.Sprintf("%s=%s ", res, val)
 			}
 			summary += "\n"
@@ -5,11 +5,22 @@ import (
 	"fmt"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/your-org/mcp-k8s-server/internal/domain"
 )
 
-func (m *MCPServer) handleListHPAs(ctx context.Context, req *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
-	clusterID, _ := args["cluster_id"].(string)
-	namespace, _ := args["namespace"].(string)
+type listHPAsArgs struct {
+	ClusterID string `json:"cluster_id" jsonschema:"ID of the cluster"`
+	Namespace string `json:"namespace" jsonschema:"Namespace to list HPAs from" default:"default"`
+}
+
+type listHPAsResult struct {
+	ClusterID string       `json:"cluster_id"`
+	Namespace string       `json:"namespace"`
+	HPAs      []domain.HPA `json:"hpas"`
+}
+
+func (m *MCPServer) handleListHPAs(ctx context.Context, req *mcp.CallToolRequest, args listHPAsArgs) (*mcp.CallToolResult, *listHPAsResult, error) {
+	clusterID, namespace := args.ClusterID, args.Namespace
 
 	nodes, err := m.k8sUC.ListHPAs(ctx, clusterID, namespace)
 	if err != nil {
@@ -29,10 +40,10 @@ func (m *MCPServer) handleListHPAs(ctx context.Context, req *mcp.CallToolRequest
 		)
 	}
 
-	resultData := map[string]any{
-		"cluster_id": clusterID,
-		"namespace":  namespace,
-		"hpas":       nodes, // nodes là []domain.HPA
+	resultData := &listHPAsResult{
+		ClusterID: clusterID,
+		Namespace: namespace,
+		HPAs:      nodes,
 	}
 
 	return &mcp.CallToolResult{
@@ -43,10 +54,14 @@ func (m *MCPServer) handleListHPAs(ctx context.Context, req *mcp.CallToolRequest
 	}, resultData, nil
 }
 
-func (m *MCPServer) handleGetHPA(ctx context.Context, req *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
-	clusterID, _ := args["cluster_id"].(string)
-	namespace, _ := args["namespace"].(string)
-	name, _ := args["hpa_name"].(string)
+type getHPAArgs struct {
+	ClusterID string `json:"cluster_id" jsonschema:"ID of the cluster"`
+	Namespace string `json:"namespace" jsonschema:"Namespace of the HPA" default:"default"`
+	HPAName   string `json:"hpa_name" jsonschema:"Name of the Horizontal Pod Autoscaler"`
+}
+
+func (m *MCPServer) handleGetHPA(ctx context.Context, req *mcp.CallToolRequest, args getHPAArgs) (*mcp.CallToolResult, *domain.HPA, error) {
+	clusterID, namespace, name := args.ClusterID, args.Namespace, args.HPAName
 
 	hpa, err := m.k8sUC.GetHPADetail(ctx, clusterID, namespace, name)
 	if err != nil {
@@ -82,13 +97,22 @@ func (m *MCPServer) handleGetHPA(ctx context.Context, req *mcp.CallToolRequest,
 			&mcp.TextContent{Text: summary},
 			&mcp.TextContent{Text: string(mustMarshalJSON(hpa))},
 		},
-	}, hpa, nil
+	}, &hpa, nil
+}
+
+type deleteHPAArgs struct {
+	ClusterID string `json:"cluster_id" jsonschema:"ID of the cluster"`
+	Namespace string `json:"namespace" jsonschema:"Namespace of the HPA to delete" default:"default"`
+	HPAName   string `json:"hpa_name" jsonschema:"Name of the Horizontal Pod Autoscaler to delete"`
+}
+
+type deleteHPAResult struct {
+	Status  string `json:"status"`
+	Message string `json:"message"`
 }
 
-func (m *MCPServer) handleDeleteHPA(ctx context.Context, req *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, map[string]any, error) {
-	clusterID, _ := args["cluster_id"].(string)
-	namespace, _ := args["namespace"].(string)
-	name, _ := args["hpa_name"].(string)
+func (m *MCPServer) handleDeleteHPA(ctx context.Context, req *mcp.CallToolRequest, args deleteHPAArgs) (*mcp.CallToolResult, *deleteHPAResult, error) {
+	clusterID, namespace, name := args.ClusterID, args.Namespace, args.HPAName
 
 	err := m.k8sUC.DeleteHPA(ctx, clusterID, namespace, name)
 	if err != nil {
@@ -101,5 +125,5 @@ func (m *MCPServer) handleDeleteHPA(ctx context.Context, req *mcp.CallToolReques
 		Content: []mcp.Content{
 			&mcp.TextContent{Text: successMsg},
 		},
-	}, map[string]any{"status": "success", "message": successMsg}, nil
+	}, &deleteHPAResult{Status: "success", Message: successMsg}, nil
 }
@@ -3,6 +3,8 @@ package mcp
 import (
 	"context"
 	"fmt"
+	"maps"
+	"slices"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 	"github.com/your-org/mcp-k8s-server/internal/domain"
@@ -10,24 +12,22 @@ import (
 
 // ==================== Secret Handlers ====================
 
-func (m *MCPServer) handleListSecrets(ctx context.Context, req *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
-	m.logger.Info("Handling list secrets request", "args", args)
+type listSecretsArgs struct {
+	ClusterID string `json:"cluster_id" jsonschema:"ID of the cluster"`
+	Namespace string `json:"namespace,omitempty" jsonschema:"Namespace to list Secrets from" default:"default"`
+}
 
-	clusterID, _ := args["cluster_id"].(string)
-	namespace, _ := args["namespace"].(string)
+type listSecretsResult struct {
+	ClusterID string                 `json:"cluster_id"`
+	Namespace string                 `json:"namespace"`
+	Count     int                    `json:"count"`
+	Secrets   []domain.SecretSummary `json:"secrets"`
+}
 
-	if clusterID == "" {
-		return &mcp.CallToolResult{
-			Content: []mcp.Content{
-				&mcp.TextContent{Text: "Error: cluster_id is required"},
-			},
-			IsError: true,
-		}, nil, nil
-	}
+func (m *MCPServer) handleListSecrets(ctx context.Context, req *mcp.CallToolRequest, args listSecretsArgs) (*mcp.CallToolResult, *listSecretsResult, error) {
+	m.logger.Info("Handling list secrets request", "args", args)
 
-	if namespace == "" {
-		namespace = "default"
-	}
+	clusterID, namespace := args.ClusterID, args.Namespace
 
 	secrets, err := m.k8sUC.ListSecrets(ctx, clusterID, namespace)
 	if err != nil {
@@ -42,14 +42,14 @@ func (m *MCPServer) handleListSecrets(ctx context.Context, req *mcp.CallToolRequ
 	summary := fmt.Sprintf(" Found %d Secrets in namespace '%s':\n\n", len(secrets), namespace)
 	for i, secret := range secrets {
 		summary += fmt.Sprintf("%d. %s - Type: %s, Keys: %d, Created: %s\n",
-			i+1, secret["name"], secret["type"], secret["data_count"], secret["created"])
+			i+1, secret.Name, secret.Type, secret.DataCount, secret.Created)
 	}
 
-	resultData := map[string]any{
-		"cluster_id": clusterID,
-		"namespace":  namespace,
-		"count":      len(secrets),
-		"secrets":    secrets,
+	resultData := &listSecretsResult{
+		ClusterID: clusterID,
+		Namespace: namespace,
+		Count:     len(secrets),
+		Secrets:   secrets,
 	}
 
 	return &mcp.CallToolResult{
@@ -60,25 +60,16 @@ func (m *MCPServer) handleListSecrets(ctx context.Context, req *mcp.CallToolRequ
 	}, resultData, nil
 }
 
-func (m *MCPServer) handleGetSecret(ctx context.Context, req *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
-	m.logger.Info("Handling get secret request", "args", args)
-
-	clusterID, _ := args["cluster_id"].(string)
-	namespace, _ := args["namespace"].(string)
-	secretName, _ := args["secret_name"].(string)
+type getSecretArgs struct {
+	ClusterID  string `json:"cluster_id" jsonschema:"ID of the cluster"`
+	Namespace  string `json:"namespace,omitempty" jsonschema:"Namespace of the Secret" default:"default"`
+	SecretName string `json:"secret_name" jsonschema:"Name of the Secret"`
+}
 
-	if clusterID == "" || secretName == "" {
-		return &mcp.CallToolResult{
-			Content: []mcp.Content{
-				&mcp.TextContent{Text: "Error: cluster_id and secret_name are required"},
-			},
-			IsError: true,
-		}, nil, nil
-	}
+func (m *MCPServer) handleGetSecret(ctx context.Context, req *mcp.CallToolRequest, args getSecretArgs) (*mcp.CallToolResult, *domain.SecretDetail, error) {
+	m.logger.Info("Handling get secret request", "args", args)
 
-	if namespace == "" {
-		namespace = "default"
-	}
+	clusterID, namespace, secretName := args.ClusterID, args.Namespace, args.SecretName
 
 	secretInfo, err := m.k8sUC.GetSecret(ctx, clusterID, namespace, secretName)
 	if err != nil {
@@ -91,10 +82,10 @@ func (m *MCPServer) handleGetSecret(ctx context.Context, req *mcp.CallToolReques
 	}
 
 	summary := fmt.Sprintf(" Secret '%s' in namespace '%s':\n\n", secretName, namespace)
-	summary += fmt.Sprintf("Type: %s\n", secretInfo["type"])
-	summary += fmt.Sprintf("Created: %s\n\n", secretInfo["created"])
+	summary += fmt.Sprintf("Type: %s\n", secretInfo.Type)
+	summary += fmt.Sprintf("Created: %s\n\n", secretInfo.Created)
 
-	if dataKeys, ok := secretInfo["data_keys"].([]string); ok && len(dataKeys) > 0 {
+	if dataKeys := secretInfo.DataKeys; len(dataKeys) > 0 {
 		summary += fmt.Sprintf("Data Keys (%d):\n", len(dataKeys))
 		for i, key := range dataKeys {
 			summary += fmt.Sprintf("  %d. %s\n", i+1, key)
@@ -102,10 +93,10 @@ func (m *MCPServer) handleGetSecret(ctx context.Context, req *mcp.CallToolReques
 		summary += "\n  Note: Secret values are not displayed for security reasons\n"
 	}
 
-	if labels, ok := secretInfo["labels"].(map[string]string); ok && len(labels) > 0 {
+	if labels := secretInfo.Labels; len(labels) > 0 {
 		summary += "\nLabels:\n"
-		for k, v := range labels {
-			summary += fmt.Sprintf("  %s: %s\n", k, v)
+		for _, k := range slices.Sorted(maps.Keys(labels)) {
+			summary += fmt.Sprintf("  %s: %s\n", k, labels[k])
 		}
 	}
 
@@ -117,41 +108,30 @@ func (m *MCPServer) handleGetSecret(ctx context.Context, req *mcp.CallToolReques
 	}, secretInfo, nil
 }
 
-func (m *MCPServer) handleCreateSecret(ctx context.Context, req *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
-	m.logger.Info("Handling create secret request", "args", args)
-
-	clusterID, _ := args["cluster_id"].(string)
-	namespace, _ := args["namespace"].(string)
-	secretName, _ := args["secret_name"].(string)
-	secretType, _ := args["type"].(string)
-
-	if clusterID == "" || secretName == "" {
-		return &mcp.CallToolResult{
-			Content: []mcp.Content{
-				&mcp.TextContent{Text: "Error: cluster_id and secret_name are required"},
-			},
-			IsError: true,
-		}, nil, nil
-	}
+type createSecretArgs struct {
+	ClusterID  string            `json:"cluster_id" jsonschema:"ID of the cluster"`
+	Namespace  string            `json:"namespace,omitempty" jsonschema:"Namespace to create the Secret in" default:"default"`
+	SecretName string            `json:"secret_name" jsonschema:"Name of the Secret"`
+	Type       string            `json:"type,omitempty" jsonschema:"Type of the Secret (Opaque, kubernetes.io/tls, etc.)" default:"Opaque"`
+	StringData map[string]string `json:"string_data" jsonschema:"Key-value pairs for the Secret data (will be base64 encoded)"`
+	Labels     map[string]string `json:"labels,omitempty" jsonschema:"Labels to apply to the Secret"`
+}
 
-	if namespace == "" {
-		namespace = "default"
-	}
+type createSecretResult struct {
+	ClusterID  string `json:"cluster_id"`
+	Namespace  string `json:"namespace"`
+	SecretName string `json:"secret_name"`
+	Type       string `json:"type"`
+	DataKeys   int    `json:"data_keys"`
+	Status     string `json:"status"`
+}
 
-	if secretType == "" {
-		secretType = "Opaque"
-	}
+func (m *MCPServer) handleCreateSecret(ctx context.Context, req *mcp.CallToolRequest, args createSecretArgs) (*mcp.CallToolResult, *createSecretResult, error) {
+	m.logger.Info("Handling create secret request", "args", args)
 
-	// Parse string_data
-	stringData := make(map[string]string)
-	if dataRaw, ok := args["string_data"].(map[string]any); ok {
-		for k, v := range dataRaw {
-			if strVal, ok := v.(string); ok {
-				stringData[k] = strVal
-			}
-		}
-	}
+	clusterID, namespace, secretName, secretType := args.ClusterID, args.Namespace, args.SecretName, args.Type
 
+	stringData := args.StringData
 	if len(stringData) == 0 {
 		return &mcp.CallToolResult{
 			Content: []mcp.Content{
@@ -161,22 +141,12 @@ func (m *MCPServer) handleCreateSecret(ctx context.Context, req *mcp.CallToolReq
 		}, nil, nil
 	}
 
-	// Parse labels
-	labels := make(map[string]string)
-	if labelsRaw, ok := args["labels"].(map[string]any); ok {
-		for k, v := range labelsRaw {
-			if strVal, ok := v.(string); ok {
-				labels[k] = strVal
-			}
-		}
-	}
-
 	options := domain.SecretCreateOptions{
 		Name:       secretName,
 		Namespace:  namespace,
 		Type:       secretType,
 		StringData: stringData,
-		Labels:     labels,
+		Labels:     args.Labels,
 	}
 
 	err := m.k8sUC.CreateSecret(ctx, clusterID, options)
@@ -189,13 +159,13 @@ func (m *MCPServer) handleCreateSecret(ctx context.Context, req *mcp.CallToolReq
 		}, nil, nil
 	}
 
-	resultData := map[string]any{
-		"cluster_id":  clusterID,
-		"namespace":   namespace,
-		"secret_name": secretName,
-		"type":        secretType,
-		"data_keys":   len(stringData),
-		"status":      "created",
+	resultData := &createSecretResult{
+		ClusterID:  clusterID,
+		Namespace:  namespace,
+		SecretName: secretName,
+		Type:       secretType,
+		DataKeys:   len(stringData),
+		Status:     "created",
 	}
 
 	return &mcp.CallToolResult{
@@ -207,25 +177,23 @@ func (m *MCPServer) handleCreateSecret(ctx context.Context, req *mcp.CallToolReq
 	}, resultData, nil
 }
 
-func (m *MCPServer) handleDeleteSecret(ctx context.Context, req *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
-	m.logger.Info("Handling delete secret request", "args", args)
+type deleteSecretArgs struct {
+	ClusterID  string `json:"cluster_id" jsonschema:"ID of the cluster"`
+	Namespace  string `json:"namespace,omitempty" jsonschema:"Namespace of the Secret" default:"default"`
+	SecretName string `json:"secret_name" jsonschema:"Name of the Secret to delete"`
+}
 
-	clusterID, _ := args["cluster_id"].(string)
-	namespace, _ := args["namespace"].(string)
-	secretName, _ := args["secret_name"].(string)
+type deleteSecretResult struct {
+	ClusterID  string `json:"cluster_id"`
+	Namespace  string `json:"namespace"`
+	SecretName string `json:"secret_name"`
+	Status     string `json:"status"`
+}
 
-	if clusterID == "" || secretName == "" {
-		return &mcp.CallToolResult{
-			Content: []mcp.Content{
-				&mcp.TextContent{Text: "Error: cluster_id and secret_name are required"},
-			},
-			IsError: true,
-		}, nil, nil
-	}
+func (m *MCPServer) handleDeleteSecret(ctx context.Context, req *mcp.CallToolRequest, args deleteSecretArgs) (*mcp.CallToolResult, *deleteSecretResult, error) {
+	m.logger.Info("Handling delete secret request", "args", args)
 
-	if namespace == "" {
-		namespace = "default"
-	}
+	clusterID, namespace, secretName := args.ClusterID, args.Namespace, args.SecretName
 
 	err := m.k8sUC.DeleteSecret(ctx, clusterID, namespace, secretName)
 	if err != nil {
@@ -237,11 +205,11 @@ func (m *MCPServer) handleDeleteSecret(ctx context.Context, req *mcp.CallToolReq
 		}, nil, nil
 	}
 
-	resultData := map[string]any{
-		"cluster_id":  clusterID,
-		"namespace":   namespace,
-		"secret_name": secretName,
-		"status":      "deleted",
+	resultData := &deleteSecretResult{
+		ClusterID:  clusterID,
+		Namespace:  namespace,
+		SecretName: secretName,
+		Status:     "deleted",
 	}
 
 	return &mcp.CallToolResult{
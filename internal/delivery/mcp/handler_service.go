@@ -5,26 +5,25 @@ import (
 	"fmt"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/your-org/mcp-k8s-server/internal/domain"
 )
 
-func (m *MCPServer) handleListServices(ctx context.Context, req *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
-	m.logger.Info("Handling list services request", "args", args)
+type listServicesArgs struct {
+	ClusterID string `json:"cluster_id" jsonschema:"ID of the cluster"`
+	Namespace string `json:"namespace,omitempty" jsonschema:"Namespace to list services from" default:"default"`
+}
 
-	clusterID, _ := args["cluster_id"].(string)
-	namespace, _ := args["namespace"].(string)
+type listServicesResult struct {
+	ClusterID string                  `json:"cluster_id"`
+	Namespace string                  `json:"namespace"`
+	Count     int                     `json:"count"`
+	Services  []domain.ServiceSummary `json:"services"`
+}
 
-	if clusterID == "" {
-		return &mcp.CallToolResult{
-			Content: []mcp.Content{
-				&mcp.TextContent{Text: "Error: cluster_id is required"},
-			},
-			IsError: true,
-		}, nil, nil
-	}
+func (m *MCPServer) handleListServices(ctx context.Context, req *mcp.CallToolRequest, args listServicesArgs) (*mcp.CallToolResult, *listServicesResult, error) {
+	m.logger.Info("Handling list services request", "args", args)
 
-	if namespace == "" {
-		namespace = "default"
-	}
+	clusterID, namespace := args.ClusterID, args.Namespace
 
 	services, err := m.k8sUC.ListServices(ctx, clusterID, namespace)
 	if err != nil {
@@ -39,14 +38,14 @@ func (m *MCPServer) handleListServices(ctx context.Context, req *mcp.CallToolReq
 	summary := fmt.Sprintf("🌐 Found %d services in namespace '%s':\n\n", len(services), namespace)
 	for i, svc := range services {
 		summary += fmt.Sprintf("%d. %s - Type: %s, ClusterIP: %s\n",
-			i+1, svc["name"], svc["type"], svc["cluster_ip"])
+			i+1, svc.Name, svc.Type, svc.ClusterIP)
 	}
 
-	resultData := map[string]any{
-		"cluster_id": clusterID,
-		"namespace":  namespace,
-		"count":      len(services),
-		"services":   services,
+	resultData := &listServicesResult{
+		ClusterID: clusterID,
+		Namespace: namespace,
+		Count:     len(services),
+		Services:  services,
 	}
 
 	return &mcp.CallToolResult{
@@ -57,25 +56,16 @@ func (m *MCPServer) handleListServices(ctx context.Context, req *mcp.CallToolReq
 	}, resultData, nil
 }
 
-func (m *MCPServer) handleGetService(ctx context.Context, req *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
-	m.logger.Info("Handling get service request", "args", args)
-
-	clusterID, _ := args["cluster_id"].(string)
-	namespace, _ := args["namespace"].(string)
-	serviceName, _ := args["service_name"].(string)
+type getServiceArgs struct {
+	ClusterID   string `json:"cluster_id" jsonschema:"ID of the cluster"`
+	Namespace   string `json:"namespace,omitempty" jsonschema:"Namespace of the service" default:"default"`
+	ServiceName string `json:"service_name" jsonschema:"Name of the service"`
+}
 
-	if clusterID == "" || serviceName == "" {
-		return &mcp.CallToolResult{
-			Content: []mcp.Content{
-				&mcp.TextContent{Text: "Error: cluster_id and service_name are required"},
-			},
-			IsError: true,
-		}, nil, nil
-	}
+func (m *MCPServer) handleGetService(ctx context.Context, req *mcp.CallToolRequest, args getServiceArgs) (*mcp.CallToolResult, *domain.Service, error) {
+	m.logger.Info("Handling get service request", "args", args)
 
-	if namespace == "" {
-		namespace = "default"
-	}
+	clusterID, namespace, serviceName := args.ClusterID, args.Namespace, args.ServiceName
 
 	serviceInfo, err := m.k8sUC.GetService(ctx, clusterID, namespace, serviceName)
 	if err != nil {
@@ -88,15 +78,13 @@ func (m *MCPServer) handleGetService(ctx context.Context, req *mcp.CallToolReque
 	}
 
 	summary := fmt.Sprintf("🌐 Service '%s' in namespace '%s':\n\n", serviceName, namespace)
-	summary += fmt.Sprintf("Type: %s\n", serviceInfo["type"])
-	summary += fmt.Sprintf("ClusterIP: %s\n", serviceInfo["cluster_ip"])
-
-	if ports, ok := serviceInfo["ports"].([]map[string]any); ok {
-		summary += fmt.Sprintf("\nPorts (%d):\n", len(ports))
-		for i, port := range ports {
-			summary += fmt.Sprintf("%d. %s: %v -> %v\n",
-				i+1, port["name"], port["port"], port["target_port"])
-		}
+	summary += fmt.Sprintf("Type: %s\n", serviceInfo.Type)
+	summary += fmt.Sprintf("ClusterIP: %s\n", serviceInfo.ClusterIP)
+
+	summary += fmt.Sprintf("\nPorts (%d):\n", len(serviceInfo.Ports))
+	for i, port := range serviceInfo.Ports {
+		summary += fmt.Sprintf("%d. %s: %v -> %v\n",
+			i+1, port.Name, port.Port, port.TargetPort)
 	}
 
 	return &mcp.CallToolResult{
@@ -107,25 +95,23 @@ func (m *MCPServer) handleGetService(ctx context.Context, req *mcp.CallToolReque
 	}, serviceInfo, nil
 }
 
-func (m *MCPServer) handleDeleteService(ctx context.Context, req *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
-	m.logger.Info("Handling delete service request", "args", args)
+type deleteServiceArgs struct {
+	ClusterID   string `json:"cluster_id" jsonschema:"ID of the cluster"`
+	Namespace   string `json:"namespace,omitempty" jsonschema:"Namespace of the service" default:"default"`
+	ServiceName string `json:"service_name" jsonschema:"Name of the service to delete"`
+}
 
-	clusterID, _ := args["cluster_id"].(string)
-	namespace, _ := args["namespace"].(string)
-	serviceName, _ := args["service_name"].(string)
+type deleteServiceResult struct {
+	ClusterID   string `json:"cluster_id"`
+	Namespace   string `json:"namespace"`
+	ServiceName string `json:"service_name"`
+	Status      string `json:"status"`
+}
 
-	if clusterID == "" || serviceName == "" {
-		return &mcp.CallToolResult{
-			Content: []mcp.Content{
-				&mcp.TextContent{Text: "Error: cluster_id and service_name are required"},
-			},
-			IsError: true,
-		}, nil, nil
-	}
+func (m *MCPServer) handleDeleteService(ctx context.Context, req *mcp.CallToolRequest, args deleteServiceArgs) (*mcp.CallToolResult, *deleteServiceResult, error) {
+	m.logger.Info("Handling delete service request", "args", args)
 
-	if namespace == "" {
-		namespace = "default"
-	}
+	clusterID, namespace, serviceName := args.ClusterID, args.Namespace, args.ServiceName
 
 	// m.k8sUC.DeleteService is assumed to be implemented
 	err := m.k8sUC.DeleteService(ctx, clusterID, namespace, serviceName)
@@ -140,11 +126,11 @@ func (m *MCPServer) handleDeleteService(ctx context.Context, req *mcp.CallToolRe
 
 	summary := fmt.Sprintf(" Service '%s' in namespace '%s' on cluster '%s' deleted successfully.", serviceName, namespace, clusterID)
 
-	resultData := map[string]any{
-		"cluster_id":   clusterID,
-		"namespace":    namespace,
-		"service_name": serviceName,
-		"status":       "deleted",
+	resultData := &deleteServiceResult{
+		ClusterID:   clusterID,
+		Namespace:   namespace,
+		ServiceName: serviceName,
+		Status:      "deleted",
 	}
 
 	return &mcp.CallToolResult{
@@ -158,24 +144,22 @@ func (m *MCPServer) handleDeleteService(ctx context.Context, req *mcp.CallToolRe
 // --- Ingress Handlers ---
 // Ingress is an API object that manages external access to the services in a cluster, typically HTTP.
 
-func (m *MCPServer) handleListIngresses(ctx context.Context, req *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
-	m.logger.Info("Handling list ingresses request", "args", args)
+type listIngressesArgs struct {
+	ClusterID string `json:"cluster_id" jsonschema:"ID of the cluster"`
+	Namespace string `json:"namespace,omitempty" jsonschema:"Namespace to list ingresses from" default:"default"`
+}
 
-	clusterID, _ := args["cluster_id"].(string)
-	namespace, _ := args["namespace"].(string)
+type listIngressesResult struct {
+	ClusterID string                  `json:"cluster_id"`
+	Namespace string                  `json:"namespace"`
+	Count     int                     `json:"count"`
+	Ingresses []domain.IngressSummary `json:"ingresses"`
+}
 
-	if clusterID == "" {
-		return &mcp.CallToolResult{
-			Content: []mcp.Content{
-				&mcp.TextContent{Text: "Error: cluster_id is required"},
-			},
-			IsError: true,
-		}, nil, nil
-	}
+func (m *MCPServer) handleListIngresses(ctx context.Context, req *mcp.CallToolRequest, args listIngressesArgs) (*mcp.CallToolResult, *listIngressesResult, error) {
+	m.logger.Info("Handling list ingresses request", "args", args)
 
-	if namespace == "" {
-		namespace = "default"
-	}
+	clusterID, namespace := args.ClusterID, args.Namespace
 
 	// m.k8sUC.ListIngresses is assumed to be implemented
 	ingresses, err := m.k8sUC.ListIngresses(ctx, clusterID, namespace)
@@ -190,16 +174,15 @@ func (m *MCPServer) handleListIngresses(ctx context.Context, req *mcp.CallToolRe
 
 	summary := fmt.Sprintf("🚪 Found %d ingresses in namespace '%s':\n\n", len(ingresses), namespace)
 	for i, ing := range ingresses {
-		// Assuming ing is a map[string]any containing name and host
 		summary += fmt.Sprintf("%d. %s - Host: %s\n",
-			i+1, ing["name"], ing["host"])
+			i+1, ing.Name, ing.Host)
 	}
 
-	resultData := map[string]any{
-		"cluster_id": clusterID,
-		"namespace":  namespace,
-		"count":      len(ingresses),
-		"ingresses":  ingresses,
+	resultData := &listIngressesResult{
+		ClusterID: clusterID,
+		Namespace: namespace,
+		Count:     len(ingresses),
+		Ingresses: ingresses,
 	}
 
 	return &mcp.CallToolResult{
@@ -210,25 +193,16 @@ func (m *MCPServer) handleListIngresses(ctx context.Context, req *mcp.CallToolRe
 	}, resultData, nil
 }
 
-func (m *MCPServer) handleGetIngress(ctx context.Context, req *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
-	m.logger.Info("Handling get ingress request", "args", args)
-
-	clusterID, _ := args["cluster_id"].(string)
-	namespace, _ := args["namespace"].(string)
-	ingressName, _ := args["ingress_name"].(string)
+type getIngressArgs struct {
+	ClusterID   string `json:"cluster_id" jsonschema:"ID of the cluster"`
+	Namespace   string `json:"namespace,omitempty" jsonschema:"Namespace of the ingress" default:"default"`
+	IngressName string `json:"ingress_name" jsonschema:"Name of the ingress"`
+}
 
-	if clusterID == "" || ingressName == "" {
-		return &mcp.CallToolResult{
-			Content: []mcp.Content{
-				&mcp.TextContent{Text: "Error: cluster_id and ingress_name are required"},
-			},
-			IsError: true,
-		}, nil, nil
-	}
+func (m *MCPServer) handleGetIngress(ctx context.Context, req *mcp.CallToolRequest, args getIngressArgs) (*mcp.CallToolResult, *domain.IngressDetail, error) {
+	m.logger.Info("Handling get ingress request", "args", args)
 
-	if namespace == "" {
-		namespace = "default"
-	}
+	clusterID, namespace, ingressName := args.ClusterID, args.Namespace, args.IngressName
 
 	// m.k8sUC.GetIngress is assumed to be implemented
 	ingressInfo, err := m.k8sUC.GetIngress(ctx, clusterID, namespace, ingressName)
@@ -242,20 +216,15 @@ func (m *MCPServer) handleGetIngress(ctx context.Context, req *mcp.CallToolReque
 	}
 
 	summary := fmt.Sprintf("🚪 Ingress '%s' in namespace '%s':\n\n", ingressName, namespace)
-	summary += fmt.Sprintf("Class: %s\n", ingressInfo["ingress_class"])
-	summary += fmt.Sprintf("Default Backend: %s:%v\n", ingressInfo["default_backend_service"], ingressInfo["default_backend_port"])
-
-	if rules, ok := ingressInfo["rules"].([]map[string]any); ok {
-		summary += fmt.Sprintf("\nRules (%d):\n", len(rules))
-		for i, rule := range rules {
-			summary += fmt.Sprintf("%d. Host: %s\n", i+1, rule["host"])
-			if paths, ok := rule["paths"].([]map[string]any); ok {
-				for _, path := range paths {
-					// Assuming path is map[string]any with path and backend_service
-					summary += fmt.Sprintf("   Path: %s -> Service: %s:%v\n",
-						path["path"], path["backend_service"], path["backend_port"])
-				}
-			}
+	summary += fmt.Sprintf("Class: %s\n", ingressInfo.IngressClass)
+	summary += fmt.Sprintf("Default Backend: %s:%v\n", ingressInfo.DefaultBackendService, ingressInfo.DefaultBackendPort)
+
+	summary += fmt.Sprintf("\nRules (%d):\n", len(ingressInfo.Rules))
+	for i, rule := range ingressInfo.Rules {
+		summary += fmt.Sprintf("%d. Host: %s\n", i+1, rule.Host)
+		for _, path := range rule.Paths {
+			summary += fmt.Sprintf("   Path: %s -> Service: %s:%v\n",
+				path.Path, path.BackendService, path.BackendPort)
 		}
 	}
 
@@ -267,25 +236,23 @@ func (m *MCPServer) handleGetIngress(ctx context.Context, req *mcp.CallToolReque
 	}, ingressInfo, nil
 }
 
-func (m *MCPServer) handleDeleteIngress(ctx context.Context, req *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
-	m.logger.Info("Handling delete ingress request", "args", args)
+type deleteIngressArgs struct {
+	ClusterID   string `json:"cluster_id" jsonschema:"ID of the cluster"`
+	Namespace   string `json:"namespace,omitempty" jsonschema:"Namespace of the ingress" default:"default"`
+	IngressName string `json:"ingress_name" jsonschema:"Name of the ingress to delete"`
+}
 
-	clusterID, _ := args["cluster_id"].(string)
-	namespace, _ := args["namespace"].(string)
-	ingressName, _ := args["ingress_name"].(string)
+type deleteIngressResult struct {
+	ClusterID   string `json:"cluster_id"`
+	Namespace   string `json:"namespace"`
+	IngressName string `json:"ingress_name"`
+	Status      string `json:"status"`
+}
 
-	if clusterID == "" || ingressName == "" {
-		return &mcp.CallToolResult{
-			Content: []mcp.Content{
-				&mcp.TextContent{Text: "Error: cluster_id and ingress_name are required"},
-			},
-			IsError: true,
-		}, nil, nil
-	}
+func (m *MCPServer) handleDeleteIngress(ctx context.Context, req *mcp.CallToolRequest, args deleteIngressArgs) (*mcp.CallToolResult, *deleteIngressResult, error) {
+	m.logger.Info("Handling delete ingress request", "args", args)
 
-	if namespace == "" {
-		namespace = "default"
-	}
+	clusterID, namespace, ingressName := args.ClusterID, args.Namespace, args.IngressName
 
 	// m.k8sUC.DeleteIngress is assumed to be implemented
 	err := m.k8sUC.DeleteIngress(ctx, clusterID, namespace, ingressName)
@@ -300,11 +267,11 @@ func (m *MCPServer) handleDeleteIngress(ctx context.Context, req *mcp.CallToolRe
 
 	summary := fmt.Sprintf(" Ingress '%s' in namespace '%s' on cluster '%s' deleted successfully.", ingressName, namespace, clusterID)
 
-	resultData := map[string]any{
-		"cluster_id":   clusterID,
-		"namespace":    namespace,
-		"ingress_name": ingressName,
-		"status":       "deleted",
+	resultData := &deleteIngressResult{
+		ClusterID:   clusterID,
+		Namespace:   namespace,
+		IngressName: ingressName,
+		Status:      "deleted",
 	}
 
 	return &mcp.CallToolResult{
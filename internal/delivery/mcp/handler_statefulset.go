@@ -5,28 +5,27 @@ import (
 	"fmt"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/your-org/mcp-k8s-server/internal/domain"
 )
 
 // ==================== StatefulSet Handlers ====================
 
-func (m *MCPServer) handleListStatefulSets(ctx context.Context, req *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
-	m.logger.Info("Handling list statefulsets request", "args", args)
+type listStatefulSetsArgs struct {
+	ClusterID string `json:"cluster_id" jsonschema:"ID of the cluster"`
+	Namespace string `json:"namespace,omitempty" jsonschema:"Namespace to list StatefulSets from" default:"default"`
+}
 
-	clusterID, _ := args["cluster_id"].(string)
-	namespace, _ := args["namespace"].(string)
+type listStatefulSetsResult struct {
+	ClusterID    string                      `json:"cluster_id"`
+	Namespace    string                      `json:"namespace"`
+	Count        int                         `json:"count"`
+	StatefulSets []domain.StatefulSetSummary `json:"statefulsets"`
+}
 
-	if clusterID == "" {
-		return &mcp.CallToolResult{
-			Content: []mcp.Content{
-				&mcp.TextContent{Text: "Error: cluster_id is required"},
-			},
-			IsError: true,
-		}, nil, nil
-	}
+func (m *MCPServer) handleListStatefulSets(ctx context.Context, req *mcp.CallToolRequest, args listStatefulSetsArgs) (*mcp.CallToolResult, *listStatefulSetsResult, error) {
+	m.logger.Info("Handling list statefulsets request", "args", args)
 
-	if namespace == "" {
-		namespace = "default"
-	}
+	clusterID, namespace := args.ClusterID, args.Namespace
 
 	statefulSets, err := m.k8sUC.ListStatefulSets(ctx, clusterID, namespace)
 	if err != nil {
@@ -41,14 +40,14 @@ func (m *MCPServer) handleListStatefulSets(ctx context.Context, req *mcp.CallToo
 	summary := fmt.Sprintf("📊 Found %d StatefulSets in namespace '%s':\n\n", len(statefulSets), namespace)
 	for i, sts := range statefulSets {
 		summary += fmt.Sprintf("%d. %s - Replicas: %d/%d (ready/desired), Service: %s\n",
-			i+1, sts["name"], sts["ready_replicas"], sts["replicas"], sts["service_name"])
+			i+1, sts.Name, sts.ReadyReplicas, sts.Replicas, sts.ServiceName)
 	}
 
-	resultData := map[string]any{
-		"cluster_id":   clusterID,
-		"namespace":    namespace,
-		"count":        len(statefulSets),
-		"statefulsets": statefulSets,
+	resultData := &listStatefulSetsResult{
+		ClusterID:    clusterID,
+		Namespace:    namespace,
+		Count:        len(statefulSets),
+		StatefulSets: statefulSets,
 	}
 
 	return &mcp.CallToolResult{
@@ -59,25 +58,16 @@ func (m *MCPServer) handleListStatefulSets(ctx context.Context, req *mcp.CallToo
 	}, resultData, nil
 }
 
-func (m *MCPServer) handleGetStatefulSet(ctx context.Context, req *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
-	m.logger.Info("Handling get statefulset request", "args", args)
-
-	clusterID, _ := args["cluster_id"].(string)
-	namespace, _ := args["namespace"].(string)
-	statefulSetName, _ := args["statefulset_name"].(string)
+type getStatefulSetArgs struct {
+	ClusterID       string `json:"cluster_id" jsonschema:"ID of the cluster"`
+	Namespace       string `json:"namespace,omitempty" jsonschema:"Namespace of the StatefulSet" default:"default"`
+	StatefulSetName string `json:"statefulset_name" jsonschema:"Name of the StatefulSet"`
+}
 
-	if clusterID == "" || statefulSetName == "" {
-		return &mcp.CallToolResult{
-			Content: []mcp.Content{
-				&mcp.TextContent{Text: "Error: cluster_id and statefulset_name are required"},
-			},
-			IsError: true,
-		}, nil, nil
-	}
+func (m *MCPServer) handleGetStatefulSet(ctx context.Context, req *mcp.CallToolRequest, args getStatefulSetArgs) (*mcp.CallToolResult, *domain.StatefulSetDetail, error) {
+	m.logger.Info("Handling get statefulset request", "args", args)
 
-	if namespace == "" {
-		namespace = "default"
-	}
+	clusterID, namespace, statefulSetName := args.ClusterID, args.Namespace, args.StatefulSetName
 
 	statefulSetInfo, err := m.k8sUC.GetStatefulSet(ctx, clusterID, namespace, statefulSetName)
 	if err != nil {
@@ -91,26 +81,26 @@ func (m *MCPServer) handleGetStatefulSet(ctx context.Context, req *mcp.CallToolR
 
 	summary := fmt.Sprintf("📊 StatefulSet '%s' in namespace '%s':\n\n", statefulSetName, namespace)
 	summary += fmt.Sprintf("Replicas: %d/%d (ready/desired)\n",
-		statefulSetInfo["replicas_ready"], statefulSetInfo["replicas_desired"])
+		statefulSetInfo.ReplicasReady, statefulSetInfo.ReplicasDesired)
 	summary += fmt.Sprintf("Current: %d, Updated: %d\n",
-		statefulSetInfo["replicas_current"], statefulSetInfo["replicas_updated"])
-	summary += fmt.Sprintf("Service Name: %s\n", statefulSetInfo["service_name"])
-	summary += fmt.Sprintf("Update Strategy: %s\n", statefulSetInfo["update_strategy"])
-	summary += fmt.Sprintf("Created: %s\n", statefulSetInfo["created"])
+		statefulSetInfo.ReplicasCurrent, statefulSetInfo.ReplicasUpdated)
+	summary += fmt.Sprintf("Service Name: %s\n", statefulSetInfo.ServiceName)
+	summary += fmt.Sprintf("Update Strategy: %s\n", statefulSetInfo.UpdateStrategy)
+	summary += fmt.Sprintf("Created: %s\n", statefulSetInfo.Created)
 
-	if containers, ok := statefulSetInfo["containers"].([]map[string]any); ok && len(containers) > 0 {
+	if containers := statefulSetInfo.Containers; len(containers) > 0 {
 		summary += fmt.Sprintf("\nContainers (%d):\n", len(containers))
 		for i, container := range containers {
-			summary += fmt.Sprintf("%d. %s - Image: %s\n", i+1, container["name"], container["image"])
+			summary += fmt.Sprintf("%d. %s - Image: %s\n", i+1, container.Name, container.Image)
 		}
 	}
 
-	if volumeClaims, ok := statefulSetInfo["volume_claims"].([]map[string]any); ok && len(volumeClaims) > 0 {
+	if volumeClaims := statefulSetInfo.VolumeClaims; len(volumeClaims) > 0 {
 		summary += fmt.Sprintf("\nVolume Claim Templates (%d):\n", len(volumeClaims))
 		for i, pvc := range volumeClaims {
-			summary += fmt.Sprintf("%d. %s - Storage: %s", i+1, pvc["name"], pvc["storage"])
-			if sc, ok := pvc["storage_class"].(string); ok && sc != "" {
-				summary += fmt.Sprintf(", StorageClass: %s", sc)
+			summary += fmt.Sprintf("%d. %s - Storage: %s", i+1, pvc.Name, pvc.Storage)
+			if pvc.StorageClass != "" {
+				summary += fmt.Sprintf(", StorageClass: %s", pvc.StorageClass)
 			}
 			summary += "\n"
 		}
@@ -124,39 +114,25 @@ func (m *MCPServer) handleGetStatefulSet(ctx context.Context, req *mcp.CallToolR
 	}, statefulSetInfo, nil
 }
 
-func (m *MCPServer) handleScaleStatefulSet(ctx context.Context, req *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
-	m.logger.Info("Handling scale statefulset request", "args", args)
-
-	clusterID, _ := args["cluster_id"].(string)
-	namespace, _ := args["namespace"].(string)
-	statefulSetName, _ := args["statefulset_name"].(string)
-
-	var replicas int32 = 1
-	if r, ok := args["replicas"].(float64); ok {
-		replicas = int32(r)
-	}
+type scaleStatefulSetArgs struct {
+	ClusterID       string `json:"cluster_id" jsonschema:"ID of the cluster"`
+	Namespace       string `json:"namespace,omitempty" jsonschema:"Namespace of the StatefulSet" default:"default"`
+	StatefulSetName string `json:"statefulset_name" jsonschema:"Name of the StatefulSet"`
+	Replicas        int32  `json:"replicas" jsonschema:"Desired number of replicas" minimum:"0"`
+}
 
-	if clusterID == "" || statefulSetName == "" {
-		return &mcp.CallToolResult{
-			Content: []mcp.Content{
-				&mcp.TextContent{Text: "Error: cluster_id and statefulset_name are required"},
-			},
-			IsError: true,
-		}, nil, nil
-	}
+type scaleStatefulSetResult struct {
+	ClusterID       string `json:"cluster_id"`
+	Namespace       string `json:"namespace"`
+	StatefulSetName string `json:"statefulset_name"`
+	Replicas        int32  `json:"replicas"`
+	Status          string `json:"status"`
+}
 
-	if namespace == "" {
-		namespace = "default"
-	}
+func (m *MCPServer) handleScaleStatefulSet(ctx context.Context, req *mcp.CallToolRequest, args scaleStatefulSetArgs) (*mcp.CallToolResult, *scaleStatefulSetResult, error) {
+	m.logger.Info("Handling scale statefulset request", "args", args)
 
-	if replicas < 0 {
-		return &mcp.CallToolResult{
-			Content: []mcp.Content{
-				&mcp.TextContent{Text: fmt.Sprintf("Error: replicas must be non-negative, got %d", replicas)},
-			},
-			IsError: true,
-		}, nil, nil
-	}
+	clusterID, namespace, statefulSetName, replicas := args.ClusterID, args.Namespace, args.StatefulSetName, args.Replicas
 
 	err := m.k8sUC.ScaleStatefulSet(ctx, clusterID, namespace, statefulSetName, replicas)
 	if err != nil {
@@ -168,12 +144,12 @@ func (m *MCPServer) handleScaleStatefulSet(ctx context.Context, req *mcp.CallToo
 		}, nil, nil
 	}
 
-	resultData := map[string]any{
-		"cluster_id":       clusterID,
-		"namespace":        namespace,
-		"statefulset_name": statefulSetName,
-		"replicas":         replicas,
-		"status":           "scaled",
+	resultData := &scaleStatefulSetResult{
+		ClusterID:       clusterID,
+		Namespace:       namespace,
+		StatefulSetName: statefulSetName,
+		Replicas:        replicas,
+		Status:          "scaled",
 	}
 
 	return &mcp.CallToolResult{
@@ -185,25 +161,25 @@ func (m *MCPServer) handleScaleStatefulSet(ctx context.Context, req *mcp.CallToo
 	}, resultData, nil
 }
 
-func (m *MCPServer) handleRestartStatefulSet(ctx context.Context, req *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
-	m.logger.Info("Handling restart statefulset request", "args", args)
+// statefulSetStatusResult reports the new status of a StatefulSet after a
+// change.
+type statefulSetStatusResult struct {
+	ClusterID       string `json:"cluster_id"`
+	Namespace       string `json:"namespace"`
+	StatefulSetName string `json:"statefulset_name"`
+	Status          string `json:"status"`
+}
 
-	clusterID, _ := args["cluster_id"].(string)
-	namespace, _ := args["namespace"].(string)
-	statefulSetName, _ := args["statefulset_name"].(string)
+type restartStatefulSetArgs struct {
+	ClusterID       string `json:"cluster_id" jsonschema:"ID of the cluster"`
+	Namespace       string `json:"namespace,omitempty" jsonschema:"Namespace of the StatefulSet" default:"default"`
+	StatefulSetName string `json:"statefulset_name" jsonschema:"Name of the StatefulSet"`
+}
 
-	if clusterID == "" || statefulSetName == "" {
-		return &mcp.CallToolResult{
-			Content: []mcp.Content{
-				&mcp.TextContent{Text: "Error: cluster_id and statefulset_name are required"},
-			},
-			IsError: true,
-		}, nil, nil
-	}
+func (m *MCPServer) handleRestartStatefulSet(ctx context.Context, req *mcp.CallToolRequest, args restartStatefulSetArgs) (*mcp.CallToolResult, *statefulSetStatusResult, error) {
+	m.logger.Info("Handling restart statefulset request", "args", args)
 
-	if namespace == "" {
-		namespace = "default"
-	}
+	clusterID, namespace, statefulSetName := args.ClusterID, args.Namespace, args.StatefulSetName
 
 	err := m.k8sUC.RestartStatefulSet(ctx, clusterID, namespace, statefulSetName)
 	if err != nil {
@@ -215,11 +191,11 @@ func (m *MCPServer) handleRestartStatefulSet(ctx context.Context, req *mcp.CallT
 		}, nil, nil
 	}
 
-	resultData := map[string]any{
-		"cluster_id":       clusterID,
-		"namespace":        namespace,
-		"statefulset_name": statefulSetName,
-		"status":           "restarting",
+	resultData := &statefulSetStatusResult{
+		ClusterID:       clusterID,
+		Namespace:       namespace,
+		StatefulSetName: statefulSetName,
+		Status:          "restarting",
 	}
 
 	return &mcp.CallToolResult{
@@ -231,25 +207,16 @@ func (m *MCPServer) handleRestartStatefulSet(ctx context.Context, req *mcp.CallT
 	}, resultData, nil
 }
 
-func (m *MCPServer) handleDeleteStatefulSet(ctx context.Context, req *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
-	m.logger.Info("Handling delete statefulset request", "args", args)
-
-	clusterID, _ := args["cluster_id"].(string)
-	namespace, _ := args["namespace"].(string)
-	statefulSetName, _ := args["statefulset_name"].(string)
+type deleteStatefulSetArgs struct {
+	ClusterID       string `json:"cluster_id" jsonschema:"ID of the cluster"`
+	Namespace       string `json:"namespace,omitempty" jsonschema:"Namespace of the StatefulSet" default:"default"`
+	StatefulSetName string `json:"statefulset_name" jsonschema:"Name of the StatefulSet to delete"`
+}
 
-	if clusterID == "" || statefulSetName == "" {
-		return &mcp.CallToolResult{
-			Content: []mcp.Content{
-				&mcp.TextContent{Text: "Error: cluster_id and statefulset_name are required"},
-			},
-			IsError: true,
-		}, nil, nil
-	}
+func (m *MCPServer) handleDeleteStatefulSet(ctx context.Context, req *mcp.CallToolRequest, args deleteStatefulSetArgs) (*mcp.CallToolResult, *statefulSetStatusResult, error) {
+	m.logger.Info("Handling delete statefulset request", "args", args)
 
-	if namespace == "" {
-		namespace = "default"
-	}
+	clusterID, namespace, statefulSetName := args.ClusterID, args.Namespace, args.StatefulSetName
 
 	err := m.k8sUC.DeleteStatefulSet(ctx, clusterID, namespace, statefulSetName)
 	if err != nil {
@@ -261,11 +228,11 @@ func (m *MCPServer) handleDeleteStatefulSet(ctx context.Context, req *mcp.CallTo
 		}, nil, nil
 	}
 
-	resultData := map[string]any{
-		"cluster_id":       clusterID,
-		"namespace":        namespace,
-		"statefulset_name": statefulSetName,
-		"status":           "deleted",
+	resultData := &statefulSetStatusResult{
+		ClusterID:       clusterID,
+		Namespace:       namespace,
+		StatefulSetName: statefulSetName,
+		Status:          "deleted",
 	}
 
 	return &mcp.CallToolResult{
@@ -0,0 +1,185 @@
+package mcp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/your-org/mcp-k8s-server/internal/domain"
+	"github.com/your-org/mcp-k8s-server/internal/usecase"
+)
+
+// setTopSortKeys completes the schema of the sort_by argument of the top
+// tools.
+func setTopSortKeys(prop *jsonschema.Schema) {
+	prop.Enum = enumOf(usecase.TopSortKeys...)
+	prop.Default = defaultOf(usecase.TopSortCPU)
+}
+
+// metricsAvailability says whether usage metrics could be read, and why
+// not.
+type metricsAvailability struct {
+	MetricsAvailable bool   `json:"metrics_available"`
+	Reason           string `json:"reason,omitempty"`
+}
+
+type topNodesArgs struct {
+	ClusterID string `json:"cluster_id" jsonschema:"ID of the cluster"`
+	SortBy    string `json:"sort_by,omitempty" jsonschema:"Sort by CPU or memory usage, highest first, or by name"`
+}
+
+func (topNodesArgs) adjustSchema(s *jsonschema.Schema) {
+	setTopSortKeys(s.Properties["sort_by"])
+}
+
+type topNodesResult struct {
+	ClusterID string `json:"cluster_id"`
+	metricsAvailability
+	Count int                `json:"count"`
+	Nodes []domain.NodeUsage `json:"nodes"`
+}
+
+func (m *MCPServer) handleTopNodes(ctx context.Context, req *mcp.CallToolRequest, args topNodesArgs) (*mcp.CallToolResult, *topNodesResult, error) {
+	m.logger.Info("Handling top nodes request", "args", args)
+
+	clusterID, sortBy := args.ClusterID, args.SortBy
+
+	nodes, err := m.k8sUC.TopNodes(ctx, clusterID, sortBy)
+	if errors.Is(err, domain.ErrMetricsUnavailable) {
+		return metricsUnavailableResult(clusterID, err), &topNodesResult{ClusterID: clusterID, metricsAvailability: metricsAvailability{Reason: err.Error()}}, nil
+	}
+	if err != nil {
+		return errorResult(err), nil, nil
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "📊 Usage of %d node(s) in cluster '%s':\n\n", len(nodes), clusterID)
+	for i, n := range nodes {
+		fmt.Fprintf(&sb, "%d. %s (%d pods)\n", i+1, n.Name, n.Pods)
+		fmt.Fprintf(&sb, "   CPU: %s of %s allocatable%s, requested %s%s\n",
+			n.CPU, n.AllocatableCPU, percentText(n.CPUPercent), n.RequestedCPU, percentText(n.RequestedCPUPercent))
+		fmt.Fprintf(&sb, "   Memory: %s of %s allocatable%s, requested %s%s\n",
+			n.Memory, n.AllocatableMemory, percentText(n.MemoryPercent), n.RequestedMemory, percentText(n.RequestedMemoryPercent))
+	}
+
+	resultData := &topNodesResult{
+		ClusterID:           clusterID,
+		metricsAvailability: metricsAvailability{MetricsAvailable: true},
+		Count:               len(nodes),
+		Nodes:               nodes,
+	}
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{Text: sb.String()},
+			&mcp.TextContent{Text: string(mustMarshalJSON(resultData))},
+		},
+	}, resultData, nil
+}
+
+type topPodsArgs struct {
+	ClusterID     string `json:"cluster_id" jsonschema:"ID of the cluster"`
+	Namespace     string `json:"namespace,omitempty" jsonschema:"Namespace of the pods" default:"default"`
+	AllNamespaces bool   `json:"all_namespaces,omitempty" jsonschema:"Show pods of every namespace instead of namespace"`
+	LabelSelector string `json:"label_selector,omitempty" jsonschema:"Optional label selector, e.g. app=web"`
+	SortBy        string `json:"sort_by,omitempty" jsonschema:"Sort by CPU or memory usage, highest first, or by name"`
+	Containers    bool   `json:"containers,omitempty" jsonschema:"List the usage of each container in the text output"`
+}
+
+func (topPodsArgs) adjustSchema(s *jsonschema.Schema) {
+	setTopSortKeys(s.Properties["sort_by"])
+}
+
+type topPodsResult struct {
+	ClusterID string `json:"cluster_id"`
+	Namespace string `json:"namespace"`
+	metricsAvailability
+	Count int               `json:"count"`
+	Pods  []domain.PodUsage `json:"pods"`
+}
+
+func (m *MCPServer) handleTopPods(ctx context.Context, req *mcp.CallToolRequest, args topPodsArgs) (*mcp.CallToolResult, *topPodsResult, error) {
+	m.logger.Info("Handling top pods request", "args", args)
+
+	clusterID, namespace, allNamespaces := args.ClusterID, args.Namespace, args.AllNamespaces
+	labelSelector, sortBy, showContainers := args.LabelSelector, args.SortBy, args.Containers
+
+	if allNamespaces {
+		namespace = string(domain.NamespaceAll)
+	}
+
+	pods, err := m.k8sUC.TopPods(ctx, clusterID, namespace, labelSelector, sortBy)
+	if errors.Is(err, domain.ErrMetricsUnavailable) {
+		return metricsUnavailableResult(clusterID, err), &topPodsResult{ClusterID: clusterID, Namespace: namespace, metricsAvailability: metricsAvailability{Reason: err.Error()}}, nil
+	}
+	if err != nil {
+		return errorResult(err), nil, nil
+	}
+
+	scope := fmt.Sprintf("namespace '%s'", namespace)
+	if allNamespaces {
+		scope = "all namespaces"
+	}
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "📊 Usage of %d pod(s) in %s:\n\n", len(pods), scope)
+	for i, p := range pods {
+		name := p.Name
+		if allNamespaces {
+			name = p.Namespace + "/" + p.Name
+		}
+		fmt.Fprintf(&sb, "%d. %s: CPU %s%s, memory %s%s\n", i+1, name,
+			p.CPU, requestText(p.CPURequest, p.CPURequestPercent), p.Memory, requestText(p.MemoryRequest, p.MemoryRequestPercent))
+		if !showContainers {
+			continue
+		}
+		for _, c := range p.Containers {
+			fmt.Fprintf(&sb, "   - %s: CPU %s%s, memory %s%s", c.Name,
+				c.CPU, requestText(c.CPURequest, c.CPURequestPercent), c.Memory, requestText(c.MemoryRequest, c.MemoryRequestPercent))
+			if c.MemoryLimitPercent != nil {
+				fmt.Fprintf(&sb, ", %.1f%% of memory limit %s", *c.MemoryLimitPercent, c.MemoryLimit)
+			}
+			sb.WriteString("\n")
+		}
+	}
+
+	resultData := &topPodsResult{
+		ClusterID:           clusterID,
+		Namespace:           namespace,
+		metricsAvailability: metricsAvailability{MetricsAvailable: true},
+		Count:               len(pods),
+		Pods:                pods,
+	}
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{Text: sb.String()},
+			&mcp.TextContent{Text: string(mustMarshalJSON(resultData))},
+		},
+	}, resultData, nil
+}
+
+// metricsUnavailableResult explains that usage cannot be shown; it is not
+// an error of the call, as capacity and requests remain available through
+// other tools.
+func metricsUnavailableResult(clusterID string, err error) *mcp.CallToolResult {
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{Text: fmt.Sprintf("ℹ️ No usage metrics for cluster '%s': %v\n\nCapacity, allocatable and requests are still available through k8s_node_get_metrics and k8s_pod_diagnose.", clusterID, err)},
+		},
+	}
+}
+
+func percentText(p *float64) string {
+	if p == nil {
+		return ""
+	}
+	return fmt.Sprintf(" (%.1f%%)", *p)
+}
+
+func requestText(request string, p *float64) string {
+	if p == nil {
+		return " (no request)"
+	}
+	return fmt.Sprintf(" (%.1f%% of request %s)", *p, request)
+}
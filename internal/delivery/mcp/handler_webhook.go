@@ -5,18 +5,32 @@ import (
 	"fmt"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
-	// Import domain package
+	"github.com/your-org/mcp-k8s-server/internal/domain"
 )
 
+type listWebhooksArgs struct {
+	ClusterID string `json:"cluster_id" jsonschema:"ID of the cluster"`
+}
+
+type getMutatingWebhookArgs struct {
+	ClusterID   string `json:"cluster_id" jsonschema:"ID of the cluster"`
+	WebhookName string `json:"webhook_name" jsonschema:"Name of the Mutating Webhook Configuration."`
+}
+
+type getValidatingWebhookArgs struct {
+	ClusterID   string `json:"cluster_id" jsonschema:"ID of the cluster"`
+	WebhookName string `json:"webhook_name" jsonschema:"Name of the Validating Webhook Configuration."`
+}
+
 // --- Mutating Webhooks Handler ---
 
-func (m *MCPServer) handleListMutatingWebhooks(ctx context.Context, req *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
-	clusterID, _ := args["cluster_id"].(string)
+type listMutatingWebhooksResult struct {
+	ClusterID string                   `json:"cluster_id"`
+	Webhooks  []domain.MutatingWebhook `json:"webhooks"`
+}
 
-	if clusterID == "" {
-		err := fmt.Errorf("cluster_id is required")
-		return errorResult(err), nil, err
-	}
+func (m *MCPServer) handleListMutatingWebhooks(ctx context.Context, req *mcp.CallToolRequest, args listWebhooksArgs) (*mcp.CallToolResult, *listMutatingWebhooksResult, error) {
+	clusterID := args.ClusterID
 
 	webhooks, err := m.k8sUC.ListMutatingWebhooks(ctx, clusterID)
 	if err != nil {
@@ -30,9 +44,9 @@ func (m *MCPServer) handleListMutatingWebhooks(ctx context.Context, req *mcp.Cal
 			i+1, wh.Name, wh.WebhooksCount, wh.FailurePolicy, wh.ClientConfig.Service)
 	}
 
-	resultData := map[string]any{
-		"cluster_id": clusterID,
-		"webhooks":   webhooks,
+	resultData := &listMutatingWebhooksResult{
+		ClusterID: clusterID,
+		Webhooks:  webhooks,
 	}
 
 	return &mcp.CallToolResult{
@@ -45,13 +59,13 @@ func (m *MCPServer) handleListMutatingWebhooks(ctx context.Context, req *mcp.Cal
 
 // --- Validating Webhooks Handler ---
 
-func (m *MCPServer) handleListValidatingWebhooks(ctx context.Context, req *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
-	clusterID, _ := args["cluster_id"].(string)
+type listValidatingWebhooksResult struct {
+	ClusterID string                     `json:"cluster_id"`
+	Webhooks  []domain.ValidatingWebhook `json:"webhooks"`
+}
 
-	if clusterID == "" {
-		err := fmt.Errorf("cluster_id is required")
-		return errorResult(err), nil, err
-	}
+func (m *MCPServer) handleListValidatingWebhooks(ctx context.Context, req *mcp.CallToolRequest, args listWebhooksArgs) (*mcp.CallToolResult, *listValidatingWebhooksResult, error) {
+	clusterID := args.ClusterID
 
 	webhooks, err := m.k8sUC.ListValidatingWebhooks(ctx, clusterID)
 	if err != nil {
@@ -65,9 +79,9 @@ func (m *MCPServer) handleListValidatingWebhooks(ctx context.Context, req *mcp.C
 			i+1, wh.Name, wh.WebhooksCount, wh.FailurePolicy, wh.ClientConfig.Service)
 	}
 
-	resultData := map[string]any{
-		"cluster_id": clusterID,
-		"webhooks":   webhooks,
+	resultData := &listValidatingWebhooksResult{
+		ClusterID: clusterID,
+		Webhooks:  webhooks,
 	}
 
 	return &mcp.CallToolResult{
@@ -78,14 +92,8 @@ func (m *MCPServer) handleListValidatingWebhooks(ctx context.Context, req *mcp.C
 	}, resultData, nil
 }
 
-func (m *MCPServer) handleGetMutatingWebhook(ctx context.Context, req *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
-	clusterID, _ := args["cluster_id"].(string)
-	name, _ := args["webhook_name"].(string)
-
-	if clusterID == "" || name == "" {
-		err := fmt.Errorf("cluster_id and webhook_name are required")
-		return errorResult(err), nil, err
-	}
+func (m *MCPServer) handleGetMutatingWebhook(ctx context.Context, req *mcp.CallToolRequest, args getMutatingWebhookArgs) (*mcp.CallToolResult, *domain.MutatingWebhook, error) {
+	clusterID, name := args.ClusterID, args.WebhookName
 
 	webhook, err := m.k8sUC.GetMutatingWebhook(ctx, clusterID, name)
 	if err != nil {
@@ -111,18 +119,12 @@ func (m *MCPServer) handleGetMutatingWebhook(ctx context.Context, req *mcp.CallT
 			&mcp.TextContent{Text: summary},
 			&mcp.TextContent{Text: string(mustMarshalJSON(webhook))},
 		},
-	}, webhook, nil
+	}, &webhook, nil
 }
 
 // handleGetValidatingWebhook retrieves and displays detailed information for a specific ValidatingWebhookConfiguration.
-func (m *MCPServer) handleGetValidatingWebhook(ctx context.Context, req *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
-	clusterID, _ := args["cluster_id"].(string)
-	name, _ := args["webhook_name"].(string)
-
-	if clusterID == "" || name == "" {
-		err := fmt.Errorf("cluster_id and webhook_name are required")
-		return errorResult(err), nil, err
-	}
+func (m *MCPServer) handleGetValidatingWebhook(ctx context.Context, req *mcp.CallToolRequest, args getValidatingWebhookArgs) (*mcp.CallToolResult, *domain.ValidatingWebhook, error) {
+	clusterID, name := args.ClusterID, args.WebhookName
 
 	webhook, err := m.k8sUC.GetValidatingWebhook(ctx, clusterID, name)
 	if err != nil {
@@ -148,5 +150,5 @@ func (m *MCPServer) handleGetValidatingWebhook(ctx context.Context, req *mcp.Cal
 			&mcp.TextContent{Text: summary},
 			&mcp.TextContent{Text: string(mustMarshalJSON(webhook))},
 		},
-	}, webhook, nil
+	}, &webhook, nil
 }
@@ -0,0 +1,140 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/your-org/mcp-k8s-server/internal/domain"
+)
+
+// ==================== Workload Handlers ====================
+
+// workloadArgs are the arguments shared by the k8s_workload_* tools.
+type workloadArgs struct {
+	ClusterID string `json:"cluster_id" jsonschema:"ID of the cluster"`
+	Namespace string `json:"namespace,omitempty" jsonschema:"Namespace of the workload" default:"default"`
+	Kind      string `json:"kind" jsonschema:"Kind of the workload"`
+	Name      string `json:"name" jsonschema:"Name of the workload"`
+}
+
+func (workloadArgs) adjustSchema(s *jsonschema.Schema) {
+	s.Properties["kind"].Enum = enumOf(domain.WorkloadKinds...)
+}
+
+type setWorkloadImageArgs struct {
+	workloadArgs
+	Images map[string]string `json:"images" jsonschema:"Container name to image, e.g. {\"app\": \"nginx:1.27\"}. Init containers can be named too; the key \"*\" sets every container."`
+}
+
+// workloadUpdateResult is the result of the k8s_workload_* tools.
+type workloadUpdateResult struct {
+	ClusterID string                 `json:"cluster_id"`
+	Update    *domain.WorkloadUpdate `json:"update"`
+}
+
+func (m *MCPServer) handleSetWorkloadImage(ctx context.Context, req *mcp.CallToolRequest, args setWorkloadImageArgs) (*mcp.CallToolResult, *workloadUpdateResult, error) {
+	m.logger.Info("Handling set workload image request", "args", args)
+
+	clusterID, kind, namespace, name := args.ClusterID, args.Kind, args.Namespace, args.Name
+
+	update, err := m.k8sUC.SetWorkloadImage(ctx, clusterID, kind, namespace, name, args.Images)
+	if err != nil {
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{Text: fmt.Sprintf("Failed to set image: %v", err)},
+			},
+			IsError: true,
+		}, nil, nil
+	}
+	return workloadUpdated(clusterID, "Image updated", update)
+}
+
+type setWorkloadEnvArgs struct {
+	workloadArgs
+	Container string               `json:"container,omitempty" jsonschema:"Container to change. Omit to change every (non-init) container."`
+	Set       []domain.EnvVarSpec  `json:"set,omitempty" jsonschema:"Variables to add or update. Use value for a literal, or configmap/secret together with key to reference a key."`
+	Remove    []string             `json:"remove,omitempty" jsonschema:"Names of variables to remove"`
+	EnvFrom   []domain.EnvFromSpec `json:"env_from,omitempty" jsonschema:"ConfigMaps or Secrets whose keys all become variables (envFrom), optionally with a name prefix"`
+}
+
+func (a setWorkloadEnvArgs) adjustSchema(s *jsonschema.Schema) {
+	a.workloadArgs.adjustSchema(s)
+	set := s.Properties["set"].Items.Properties
+	set["configmap"].Description = "ConfigMap to read key from"
+	set["secret"].Description = "Secret to read key from"
+}
+
+func (m *MCPServer) handleSetWorkloadEnv(ctx context.Context, req *mcp.CallToolRequest, args setWorkloadEnvArgs) (*mcp.CallToolResult, *workloadUpdateResult, error) {
+	m.logger.Info("Handling set workload env request", "tool", "k8s_workload_set_env")
+
+	clusterID, kind, namespace, name := args.ClusterID, args.Kind, args.Namespace, args.Name
+	change := domain.EnvChange{Set: args.Set, Remove: args.Remove, EnvFrom: args.EnvFrom}
+
+	update, err := m.k8sUC.SetWorkloadEnv(ctx, clusterID, kind, namespace, name, args.Container, change)
+	if err != nil {
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{Text: fmt.Sprintf("Failed to set environment: %v", err)},
+			},
+			IsError: true,
+		}, nil, nil
+	}
+	return workloadUpdated(clusterID, "Environment updated", update)
+}
+
+type setWorkloadResourcesArgs struct {
+	workloadArgs
+	Container string            `json:"container,omitempty" jsonschema:"Container to change. Omit to change every (non-init) container."`
+	Requests  map[string]string `json:"requests,omitempty" jsonschema:"Resource name to quantity, e.g. {\"cpu\": \"250m\", \"memory\": \"256Mi\"}. An empty quantity removes the entry."`
+	Limits    map[string]string `json:"limits,omitempty" jsonschema:"Resource name to quantity, e.g. {\"memory\": \"512Mi\"}. An empty quantity removes the entry."`
+}
+
+func (m *MCPServer) handleSetWorkloadResources(ctx context.Context, req *mcp.CallToolRequest, args setWorkloadResourcesArgs) (*mcp.CallToolResult, *workloadUpdateResult, error) {
+	m.logger.Info("Handling set workload resources request", "args", args)
+
+	clusterID, kind, namespace, name := args.ClusterID, args.Kind, args.Namespace, args.Name
+	change := domain.ResourceChange{Requests: args.Requests, Limits: args.Limits}
+
+	update, err := m.k8sUC.SetWorkloadResources(ctx, clusterID, kind, namespace, name, args.Container, change)
+	if err != nil {
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{Text: fmt.Sprintf("Failed to set resources: %v", err)},
+			},
+			IsError: true,
+		}, nil, nil
+	}
+	return workloadUpdated(clusterID, "Resources updated", update)
+}
+
+func workloadUpdated(clusterID, action string, update *domain.WorkloadUpdate) (*mcp.CallToolResult, *workloadUpdateResult, error) {
+	text := fmt.Sprintf(" %s on %s '%s/%s' (containers: %s).", action, update.Kind, update.Namespace, update.Name, strings.Join(update.Containers, ", "))
+	switch {
+	case !update.Changed:
+		text += " Nothing changed; the pod template already had these values."
+	case update.Kind == domain.WorkloadKindCronJob:
+		text += " Jobs created from now on use the new template."
+	case update.Revision != "":
+		text += fmt.Sprintf(" Rolling out as revision %s.", update.Revision)
+	default:
+		text += " The controller has not reported the new revision yet."
+	}
+	if update.Changed && update.Kind == domain.WorkloadKindDeployment {
+		text += " Follow it with k8s_deployment_rollout_status."
+	}
+
+	resultData := &workloadUpdateResult{
+		ClusterID: clusterID,
+		Update:    update,
+	}
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{Text: text},
+			&mcp.TextContent{Text: string(mustMarshalJSON(resultData))},
+		},
+	}, resultData, nil
+}
@@ -0,0 +1,339 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/your-org/mcp-k8s-server/internal/domain"
+	"github.com/your-org/mcp-k8s-server/internal/infrastructure"
+	"github.com/your-org/mcp-k8s-server/internal/usecase"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/watch"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	fakedynamic "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/openapi"
+	"k8s.io/client-go/openapi/openapitest"
+	clienttesting "k8s.io/client-go/testing"
+	metricsv1beta1 "k8s.io/metrics/pkg/apis/metrics/v1beta1"
+	fakemetrics "k8s.io/metrics/pkg/client/clientset/versioned/fake"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files in testdata/golden")
+
+// testClusterID is the cluster the harness registers its fakes under.
+const testClusterID = "test"
+
+// testHarness is an MCP server wired to fake clientsets and an in-process
+// client connected to it.
+type testHarness struct {
+	server    *MCPServer
+	session   *mcp.ClientSession
+	clientset *fake.Clientset
+	dynamic   *fakedynamic.FakeDynamicClient
+	metrics   *fakemetrics.Clientset
+	// updated receives the URIs of resources/updated notifications.
+	updated chan string
+}
+
+// newTestHarness starts a server whose cluster "test" serves objects from
+// fake typed, dynamic and discovery clients sharing one object tracker.
+// NodeMetrics and PodMetrics objects are served by a fake metrics client,
+// and unstructured custom resources by the dynamic client only.
+func newTestHarness(t *testing.T, opts ServerOptions, objects ...runtime.Object) *testHarness {
+	t.Helper()
+
+	logger := infrastructure.NewLeveledLogger(infrastructure.LogLevelError)
+	var metricsObjects, customObjects []runtime.Object
+	objects = slices.DeleteFunc(slices.Clone(objects), func(obj runtime.Object) bool {
+		switch obj.(type) {
+		case *metricsv1beta1.NodeMetrics, *metricsv1beta1.PodMetrics:
+			metricsObjects = append(metricsObjects, obj)
+			return true
+		case *unstructured.Unstructured:
+			customObjects = append(customObjects, obj)
+			return true
+		}
+		return false
+	})
+	clientset := fake.NewClientset(objects...)
+	disc := fakeDiscovery{clientset.Discovery().(*fakediscovery.FakeDiscovery)}
+	disc.Resources = testAPIResources
+	dyn := newFakeDynamicClient(clientset, customObjects...)
+	metrics := newFakeMetricsClient(t, metricsObjects)
+
+	config := domain.ClusterConfig{Context: testClusterID}
+	clusterManager := infrastructure.NewClusterManager(logger)
+	clusterManager.RegisterClusterClients(domain.ClusterID(testClusterID), config, infrastructure.ClusterClients{
+		Clientset: clientset,
+		Dynamic:   dyn,
+		Discovery: disc,
+		Metrics:   metrics,
+	})
+	clusterRepo := infrastructure.NewInMemoryClusterRepository()
+	if err := clusterRepo.Save(&domain.Cluster{ID: testClusterID, Config: config, Status: domain.ClusterStatusActive}); err != nil {
+		t.Fatalf("save cluster: %v", err)
+	}
+
+	clusterUC := usecase.NewClusterUseCase(clusterManager, clusterRepo, logger)
+	k8sUC := usecase.NewK8sUseCase(clusterRepo, clusterManager, logger)
+	server, err := NewMCPServer(clusterUC, k8sUC, logger, opts)
+	if err != nil {
+		t.Fatalf("NewMCPServer: %v", err)
+	}
+
+	h := &testHarness{server: server, clientset: clientset, dynamic: dyn, metrics: metrics, updated: make(chan string, 16)}
+	h.session = h.connect(t, &mcp.ClientOptions{
+		ResourceUpdatedHandler: func(_ context.Context, req *mcp.ResourceUpdatedNotificationRequest) {
+			h.updated <- req.Params.URI
+		},
+	})
+	return h
+}
+
+// connect opens another client session to the harness server.
+func (h *testHarness) connect(t *testing.T, opts *mcp.ClientOptions) *mcp.ClientSession {
+	t.Helper()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	serverTransport, clientTransport := mcp.NewInMemoryTransports()
+	serverSession, err := h.server.server.Connect(ctx, serverTransport, nil)
+	if err != nil {
+		t.Fatalf("server connect: %v", err)
+	}
+	client := mcp.NewClient(&mcp.Implementation{Name: "harness", Version: "v0.0.0"}, opts)
+	session, err := client.Connect(ctx, clientTransport, nil)
+	if err != nil {
+		t.Fatalf("client connect: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = session.Close()
+		_ = serverSession.Wait()
+	})
+	return session
+}
+
+// fakeDiscovery adds to the fake discovery client the OpenAPI v3 documents
+// client-go embeds for tests (core, apps, batch and a few more groups).
+type fakeDiscovery struct {
+	*fakediscovery.FakeDiscovery
+}
+
+func (fakeDiscovery) OpenAPIV3() openapi.Client {
+	return openapitest.NewEmbeddedFileClient()
+}
+
+// customListKinds are the list kinds of the custom resources in
+// testAPIResources, which the typed clientset's scheme does not know.
+var customListKinds = map[schema.GroupVersionResource]string{
+	{Group: "cert-manager.io", Version: "v1", Resource: "certificates"}: "CertificateList",
+}
+
+// newFakeDynamicClient returns a dynamic client backed by the object
+// tracker of clientset, so typed and dynamic calls see the same objects. The
+// tracker manages fields, which server-side apply needs. The fake client does
+// not pass patch options on, so applies are neither forced nor dry runs.
+// Custom resources live in a tracker of their own, seeded with custom, as
+// the typed tracker cannot list them.
+func newFakeDynamicClient(clientset *fake.Clientset, custom ...runtime.Object) *fakedynamic.FakeDynamicClient {
+	tracker := clientset.Tracker()
+	dyn := fakedynamic.NewSimpleDynamicClientWithCustomListKinds(scheme.Scheme, customListKinds, custom...)
+	customTracker := dyn.Tracker()
+	trackerFor := func(action clienttesting.Action) clienttesting.ObjectTracker {
+		if _, ok := customListKinds[action.GetResource()]; ok {
+			return customTracker
+		}
+		return tracker
+	}
+	dyn.ReactionChain = nil
+	dyn.AddReactor("*", "*", func(action clienttesting.Action) (bool, runtime.Object, error) {
+		return clienttesting.ObjectReaction(trackerFor(action))(action)
+	})
+	dyn.WatchReactionChain = nil
+	dyn.AddWatchReactor("*", func(action clienttesting.Action) (bool, watch.Interface, error) {
+		w, err := trackerFor(action).Watch(action.GetResource(), action.GetNamespace())
+		return true, w, err
+	})
+	return dyn
+}
+
+// newFakeMetricsClient returns a metrics client serving objects. They are
+// added under the resources the client reads ("nodes" and "pods"), which
+// the tracker cannot guess from the kinds.
+func newFakeMetricsClient(t *testing.T, objects []runtime.Object) *fakemetrics.Clientset {
+	t.Helper()
+
+	client := fakemetrics.NewSimpleClientset()
+	for _, obj := range objects {
+		resource := metricsv1beta1.SchemeGroupVersion.WithResource("nodes")
+		ns := ""
+		if pm, ok := obj.(*metricsv1beta1.PodMetrics); ok {
+			resource = metricsv1beta1.SchemeGroupVersion.WithResource("pods")
+			ns = pm.Namespace
+		}
+		if err := client.Tracker().Create(resource, obj, ns); err != nil {
+			t.Fatalf("add metrics %T: %v", obj, err)
+		}
+	}
+	return client
+}
+
+// call invokes a tool and fails the test on protocol errors. Tool errors are
+// returned in the result.
+func (h *testHarness) call(t *testing.T, tool string, args map[string]any) *mcp.CallToolResult {
+	t.Helper()
+	return callTool(t, h.session, tool, args)
+}
+
+// callTool is like testHarness.call on another session.
+func callTool(t *testing.T, session *mcp.ClientSession, tool string, args map[string]any) *mcp.CallToolResult {
+	t.Helper()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	res, err := session.CallTool(ctx, &mcp.CallToolParams{Name: tool, Arguments: args})
+	if err != nil {
+		t.Fatalf("call %s: %v", tool, err)
+	}
+	return res
+}
+
+// toolNames lists the tools the server advertises.
+func (h *testHarness) toolNames(t *testing.T) []string {
+	t.Helper()
+
+	var names []string
+	for tool, err := range h.session.Tools(context.Background(), nil) {
+		if err != nil {
+			t.Fatalf("list tools: %v", err)
+		}
+		names = append(names, tool.Name)
+	}
+	return names
+}
+
+// Patterns for output that depends on when or how fast the test runs: RFC
+// 3339 times, ages such as "1h2m3s ago", audited call durations, and the
+// paths and sizes of log export archives.
+var (
+	timestampPattern  = regexp.MustCompile(`\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}(\.\d+)?(Z|[+-]\d{2}:\d{2})`)
+	agePattern        = regexp.MustCompile(`\b[\dhms.]+ ago\b`)
+	durationPattern   = regexp.MustCompile(`("duration_ms": |\] )\d+(ms)?`)
+	exportPattern     = regexp.MustCompile(`(file://|[\s"])/[^\s"]*/(logs-[\w.-]+)-\d{8}-\d{6}-[0-9a-f]{8}\.`)
+	exportNamePattern = regexp.MustCompile(`(logs-[\w.-]+)-\d{8}-\d{6}-[0-9a-f]{8}\.`)
+	exportSizePattern = regexp.MustCompile(`("size": )\d+|(\()\d+( bytes\))`)
+)
+
+// renderResult formats a tool result for comparison with a golden file:
+// every content block, then the structured content, with JSON indented.
+func renderResult(res *mcp.CallToolResult) string {
+	var sb strings.Builder
+	sb.WriteString("isError: ")
+	if res.IsError {
+		sb.WriteString("true\n")
+	} else {
+		sb.WriteString("false\n")
+	}
+	for i, c := range res.Content {
+		switch c := c.(type) {
+		case *mcp.TextContent:
+			fmt.Fprintf(&sb, "--- content[%d] text\n", i)
+			sb.WriteString(indentJSON(c.Text))
+		default:
+			data, _ := json.Marshal(c)
+			fmt.Fprintf(&sb, "--- content[%d]\n", i)
+			sb.WriteString(indentJSON(string(data)))
+		}
+		sb.WriteString("\n")
+	}
+	if res.StructuredContent != nil {
+		data, _ := json.Marshal(res.StructuredContent)
+		sb.WriteString("--- structuredContent\n")
+		sb.WriteString(indentJSON(string(data)))
+		sb.WriteString("\n")
+	}
+	return normalizeOutput(sb.String())
+}
+
+// renderPrompt formats a prompt result for comparison with a golden file.
+func renderPrompt(res *mcp.GetPromptResult) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "description: %s\n", res.Description)
+	for i, msg := range res.Messages {
+		fmt.Fprintf(&sb, "--- messages[%d] %s\n", i, msg.Role)
+		if text, ok := msg.Content.(*mcp.TextContent); ok {
+			sb.WriteString(text.Text)
+		}
+		sb.WriteString("\n")
+	}
+	return normalizeOutput(sb.String())
+}
+
+// normalizeOutput replaces the timestamps, ages, durations and export names
+// that change between runs.
+func normalizeOutput(out string) string {
+	out = timestampPattern.ReplaceAllString(out, "<timestamp>")
+	out = agePattern.ReplaceAllString(out, "<age> ago")
+	out = exportPattern.ReplaceAllString(out, "${1}<exports>/${2}-<id>.")
+	out = exportNamePattern.ReplaceAllString(out, "${1}-<id>.")
+	out = exportSizePattern.ReplaceAllString(out, "${1}${2}<size>${3}")
+	return durationPattern.ReplaceAllString(out, "${1}<duration>${2}")
+}
+
+func indentJSON(s string) string {
+	trimmed := strings.TrimSpace(s)
+	if !strings.HasPrefix(trimmed, "{") && !strings.HasPrefix(trimmed, "[") {
+		return s
+	}
+	var v any
+	if err := json.Unmarshal([]byte(trimmed), &v); err != nil {
+		return s
+	}
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return s
+	}
+	return string(data)
+}
+
+// assertGolden compares got with testdata/golden/<name>.golden, or rewrites
+// the file when the test runs with -update.
+func assertGolden(t *testing.T, name, got string) {
+	t.Helper()
+
+	path := filepath.Join("testdata", "golden", name+".golden")
+	if *update {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(got), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read golden file (run go test -update to create it): %v", err)
+	}
+	if string(want) != got {
+		t.Errorf("result differs from %s (run go test -update to accept):\n--- want\n%s\n--- got\n%s", path, want, got)
+	}
+}
+
+// fixtureTime is the creation time of every fixture object.
+var fixtureTime = metav1.NewTime(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC))
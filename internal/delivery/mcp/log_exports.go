@@ -0,0 +1,130 @@
+package mcp
+
+import (
+	"context"
+	"errors"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/your-org/mcp-k8s-server/internal/infrastructure"
+)
+
+// Archives written by k8s_logs_export are exposed under this URI template,
+// registered once, to the session that created them.
+const (
+	logExportURIPrefix = "k8s-logs://exports/"
+	logExportTemplate  = logExportURIPrefix + "{name}"
+)
+
+// logExports tracks the archives of k8s_logs_export by name. An archive is
+// readable by the session that created it until it is older than ttl or
+// maxCount newer archives exist; then it is forgotten and its file deleted.
+// Zero ttl or maxCount disables that limit.
+type logExports struct {
+	mu       sync.Mutex
+	ttl      time.Duration
+	maxCount int
+	logger   infrastructure.Logger
+	exports  map[string]*logExport
+	// order holds the names of the archives, oldest first.
+	order []string
+}
+
+type logExport struct {
+	path     string
+	mimeType string
+	session  *mcp.ServerSession
+	timer    *time.Timer
+}
+
+func newLogExports(ttl time.Duration, maxCount int, logger infrastructure.Logger) *logExports {
+	return &logExports{
+		ttl:      ttl,
+		maxCount: maxCount,
+		logger:   logger,
+		exports:  make(map[string]*logExport),
+	}
+}
+
+// add registers the archive at path for session and returns when it
+// expires, zero without ttl. The oldest archives beyond maxCount are
+// removed.
+func (l *logExports) add(name, path, mimeType string, session *mcp.ServerSession) time.Time {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	export := &logExport{path: path, mimeType: mimeType, session: session}
+	var expires time.Time
+	if l.ttl > 0 {
+		expires = time.Now().Add(l.ttl)
+		export.timer = time.AfterFunc(l.ttl, func() {
+			l.mu.Lock()
+			defer l.mu.Unlock()
+			l.remove(name)
+		})
+	}
+	l.exports[name] = export
+	l.order = append(l.order, name)
+
+	for l.maxCount > 0 && len(l.order) > l.maxCount {
+		l.remove(l.order[0])
+	}
+	return expires
+}
+
+// get returns the archive name if session created it.
+func (l *logExports) get(name string, session *mcp.ServerSession) (*logExport, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	export, ok := l.exports[name]
+	if !ok || export.session != session {
+		return nil, false
+	}
+	return export, true
+}
+
+// remove forgets the archive name and deletes its file. l.mu must be held.
+func (l *logExports) remove(name string) {
+	export, ok := l.exports[name]
+	if !ok {
+		return
+	}
+	if export.timer != nil {
+		export.timer.Stop()
+	}
+	delete(l.exports, name)
+	for i, n := range l.order {
+		if n == name {
+			l.order = append(l.order[:i], l.order[i+1:]...)
+			break
+		}
+	}
+	if err := os.Remove(export.path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		l.logger.Warn("Failed to delete log export", "path", export.path, "error", err)
+		return
+	}
+	l.logger.Debug("Deleted log export", "name", name)
+}
+
+func (m *MCPServer) readLogExport(ctx context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+	m.logger.Info("Handling log export read", "uri", req.Params.URI)
+
+	name, _ := strings.CutPrefix(req.Params.URI, logExportURIPrefix)
+	export, ok := m.logExports.get(name, req.Session)
+	if !ok {
+		return nil, mcp.ResourceNotFoundError(req.Params.URI)
+	}
+	data, err := os.ReadFile(export.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, mcp.ResourceNotFoundError(req.Params.URI)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &mcp.ReadResourceResult{
+		Contents: []*mcp.ResourceContents{{URI: req.Params.URI, MIMEType: export.mimeType, Blob: data}},
+	}, nil
+}
@@ -0,0 +1,220 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/your-org/mcp-k8s-server/internal/domain"
+	"github.com/your-org/mcp-k8s-server/internal/usecase"
+)
+
+// policyMiddleware rejects tool calls to hidden tools and calls denied by the
+// policy rules before they reach the handlers. Reading and subscribing to
+// object resources are checked as calls to k8s_resource_get, and built-in
+// prompts as calls to the tools whose data they gather.
+func (m *MCPServer) policyMiddleware(opts ServerOptions, policy *usecase.PolicyUseCase) mcp.Middleware {
+	return func(next mcp.MethodHandler) mcp.MethodHandler {
+		return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+			if uri, ok := objectResourceURI(method, req); ok {
+				if err := m.checkObjectResource(opts, policy, uri); err != nil {
+					return nil, err
+				}
+				return next(ctx, method, req)
+			}
+			if params, ok := req.GetParams().(*mcp.GetPromptParams); ok && method == "prompts/get" {
+				if err := m.checkPrompt(opts, policy, params); err != nil {
+					return nil, err
+				}
+				return next(ctx, method, req)
+			}
+			if method != "tools/call" {
+				return next(ctx, method, req)
+			}
+			params, ok := req.GetParams().(*mcp.CallToolParamsRaw)
+			if !ok {
+				return next(ctx, method, req)
+			}
+
+			// Clients may still call a tool from a stale tools/list.
+			if reason := opts.disabledReason(params.Name); reason != "" {
+				return policyDeniedResult(domain.PolicyDecision{
+					Tool:   params.Name,
+					Rule:   "tool_filter",
+					Reason: reason,
+				}), nil
+			}
+
+			if !policy.HasRules() {
+				return next(ctx, method, req)
+			}
+
+			decision := policy.Evaluate(m.policyRequest(params))
+			if !decision.Allowed {
+				m.logger.Warn("Tool call denied by policy", "tool", params.Name, "rule", decision.Rule, "cluster", decision.ClusterID, "namespace", decision.Namespace)
+				return policyDeniedResult(decision), nil
+			}
+			return next(ctx, method, req)
+		}
+	}
+}
+
+// policyRequest extracts the cluster, namespaces and access type of a call.
+func (m *MCPServer) policyRequest(params *mcp.CallToolParamsRaw) domain.PolicyRequest {
+	var args map[string]any
+	_ = json.Unmarshal(params.Arguments, &args)
+
+	req := domain.PolicyRequest{
+		Tool:  params.Name,
+		Write: !isReadOnlyTool(params.Name),
+	}
+	req.ClusterID, _ = args["cluster_id"].(string)
+	if ns, _ := args["namespace"].(string); ns != "" {
+		req.Namespaces = []string{ns}
+	}
+	if all, _ := args["all_namespaces"].(bool); all {
+		req.AllNamespaces = true
+	}
+
+	switch params.Name {
+	case "k8s_resource_get", "k8s_resource_list", "k8s_resource_delete":
+		kind, _ := args["kind"].(string)
+		apiVersion, _ := args["api_version"].(string)
+		name, _ := args["name"].(string)
+		m.scopeResourceRequest(&req, kind, apiVersion, name)
+	case "k8s_apply_yaml":
+		if dryRun, _ := args["dry_run"].(bool); dryRun {
+			req.Write = false
+		}
+		yamlBody, _ := args["yaml_body"].(string)
+		files := usecase.ManifestFiles{Root: m.manifestRoot}
+		files.Path, _ = args["path"].(string)
+		files.Recursive, _ = args["recursive"].(bool)
+		// Unreadable manifests fail in the handler without being applied.
+		if namespaces, err := usecase.ManifestNamespaces(yamlBody, files); err == nil {
+			req.Namespaces = namespaces
+		}
+	}
+
+	return req
+}
+
+// scopeResourceRequest sets the namespaces of a call on objects of any
+// kind. Cluster-scoped kinds ignore the namespace argument: a Namespace is
+// checked as the namespace it names, other cluster-scoped objects and lists
+// of Namespaces as every namespace. Unknown kinds fail in the handler.
+func (m *MCPServer) scopeResourceRequest(req *domain.PolicyRequest, kind, apiVersion, name string) {
+	rt, err := m.k8sUC.ResolveResourceType(req.ClusterID, kind, apiVersion)
+	if err != nil || rt.Namespaced {
+		return
+	}
+	req.Namespaces = nil
+	if isNamespaceType(rt) && name != "" {
+		req.Namespaces = []string{name}
+		return
+	}
+	req.AllNamespaces = true
+}
+
+func isNamespaceType(rt domain.ResourceType) bool {
+	return rt.APIVersion == "v1" && rt.Kind == "Namespace"
+}
+
+// objectResourceURI returns the URI of resources/read and
+// resources/subscribe requests for k8s:// object resources.
+func objectResourceURI(method string, req mcp.Request) (string, bool) {
+	var uri string
+	switch params := req.GetParams().(type) {
+	case *mcp.ReadResourceParams:
+		uri = params.URI
+	case *mcp.SubscribeParams:
+		uri = params.URI
+	default:
+		return "", false
+	}
+	return uri, strings.HasPrefix(uri, objectURIScheme+"://")
+}
+
+// checkObjectResource applies the tool filters and policy rules of
+// k8s_resource_get to an object resource.
+func (m *MCPServer) checkObjectResource(opts ServerOptions, policy *usecase.PolicyUseCase, uri string) error {
+	if reason := opts.disabledReason(resourceReadTool); reason != "" {
+		return fmt.Errorf("%s", policyDeniedMessage(domain.PolicyDecision{Tool: resourceReadTool, Rule: "tool_filter", Reason: reason}))
+	}
+	if !policy.HasRules() {
+		return nil
+	}
+	req, err := m.objectPolicyRequest(uri)
+	if err != nil {
+		return err
+	}
+	decision := policy.Evaluate(req)
+	if !decision.Allowed {
+		m.logger.Warn("Resource access denied by policy", "uri", uri, "rule", decision.Rule, "cluster", decision.ClusterID, "namespace", decision.Namespace)
+		return fmt.Errorf("%s", policyDeniedMessage(decision))
+	}
+	return nil
+}
+
+// checkPrompt applies the policy rules of the tools a built-in prompt reads
+// on the cluster and namespace it is requested for. Prompts of hidden tools
+// are not registered, and custom prompts read nothing.
+func (m *MCPServer) checkPrompt(opts ServerOptions, policy *usecase.PolicyUseCase, params *mcp.GetPromptParams) error {
+	p, ok := m.prompts[params.Name]
+	if !ok || !policy.HasRules() {
+		return nil
+	}
+	// Missing required arguments are reported by the handler.
+	args, err := promptArguments(p.prompt, params.Arguments, opts)
+	if err != nil {
+		return nil
+	}
+	for _, tool := range p.tools {
+		req := domain.PolicyRequest{Tool: tool, ClusterID: args["cluster_id"]}
+		if ns := args["namespace"]; ns != "" {
+			req.Namespaces = []string{ns}
+		}
+		decision := policy.Evaluate(req)
+		if !decision.Allowed {
+			m.logger.Warn("Prompt denied by policy", "prompt", params.Name, "tool", tool, "rule", decision.Rule, "cluster", decision.ClusterID, "namespace", decision.Namespace)
+			return fmt.Errorf("%s", policyDeniedMessage(decision))
+		}
+	}
+	return nil
+}
+
+func policyDeniedMessage(decision domain.PolicyDecision) string {
+	msg := fmt.Sprintf("Denied by policy: %s is not allowed", decision.Tool)
+	if decision.ClusterID != "" {
+		msg += fmt.Sprintf(" on cluster '%s'", decision.ClusterID)
+	}
+	if decision.Namespace == domain.PolicyAllNamespaces {
+		msg += " in all namespaces"
+	} else if decision.Namespace != "" {
+		msg += fmt.Sprintf(" in namespace '%s'", decision.Namespace)
+	}
+	if decision.Reason != "" {
+		msg += ": " + decision.Reason
+	}
+	return msg
+}
+
+func policyDeniedResult(decision domain.PolicyDecision) *mcp.CallToolResult {
+	msg := policyDeniedMessage(decision)
+
+	resultData := map[string]any{
+		"error":    "policy_denied",
+		"decision": decision,
+	}
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{Text: msg},
+			&mcp.TextContent{Text: string(mustMarshalJSON(resultData))},
+		},
+		StructuredContent: resultData,
+		IsError:           true,
+	}
+}
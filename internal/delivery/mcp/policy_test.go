@@ -0,0 +1,110 @@
+package mcp
+
+import (
+	"encoding/json"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/your-org/mcp-k8s-server/internal/domain"
+)
+
+// TestPolicyRequest checks how calls are classified for the policy rules.
+func TestPolicyRequest(t *testing.T) {
+	h := newTestHarness(t, ServerOptions{}, fixtureObjects()...)
+	manifest := "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: a\n  namespace: web\n---\napiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: b\n"
+
+	for _, tc := range []struct {
+		name string
+		toolCall
+		want domain.PolicyRequest
+	}{
+		{"list", toolCall{"k8s_pod_list", map[string]any{"cluster_id": "dev", "namespace": "web"}}, domain.PolicyRequest{Tool: "k8s_pod_list", ClusterID: "dev", Namespaces: []string{"web"}}},
+		{"explain", toolCall{"k8s_explain", map[string]any{"cluster_id": "dev", "path": "pod.spec"}}, domain.PolicyRequest{Tool: "k8s_explain", ClusterID: "dev"}},
+		{"api resources", toolCall{"k8s_api_resources", map[string]any{"cluster_id": "dev"}}, domain.PolicyRequest{Tool: "k8s_api_resources", ClusterID: "dev"}},
+		{"set resources", toolCall{"k8s_workload_set_resources", map[string]any{"cluster_id": "dev", "namespace": "web"}}, domain.PolicyRequest{Tool: "k8s_workload_set_resources", ClusterID: "dev", Namespaces: []string{"web"}, Write: true}},
+		{"delete", toolCall{"k8s_secret_delete", map[string]any{"cluster_id": "dev", "namespace": "web"}}, domain.PolicyRequest{Tool: "k8s_secret_delete", ClusterID: "dev", Namespaces: []string{"web"}, Write: true}},
+		{"exec", toolCall{"k8s_pod_exec", map[string]any{"cluster_id": "dev", "namespace": "web"}}, domain.PolicyRequest{Tool: "k8s_pod_exec", ClusterID: "dev", Namespaces: []string{"web"}, Write: true}},
+		{"apply", toolCall{"k8s_apply_yaml", map[string]any{"cluster_id": "dev", "yaml_body": manifest}}, domain.PolicyRequest{Tool: "k8s_apply_yaml", ClusterID: "dev", Namespaces: []string{"web", "default"}, Write: true}},
+		{"apply dry run", toolCall{"k8s_apply_yaml", map[string]any{"cluster_id": "dev", "yaml_body": manifest, "dry_run": true}}, domain.PolicyRequest{Tool: "k8s_apply_yaml", ClusterID: "dev", Namespaces: []string{"web", "default"}}},
+		{"apply invalid", toolCall{"k8s_apply_yaml", map[string]any{"cluster_id": "dev", "namespace": "web", "yaml_body": "metadata: ["}}, domain.PolicyRequest{Tool: "k8s_apply_yaml", ClusterID: "dev", Namespaces: []string{"web"}, Write: true}},
+		{"resource get", toolCall{"k8s_resource_get", map[string]any{"cluster_id": testClusterID, "kind": "cm", "namespace": "web", "name": "a"}}, domain.PolicyRequest{Tool: "k8s_resource_get", ClusterID: testClusterID, Namespaces: []string{"web"}}},
+		{"resource get cluster-scoped", toolCall{"k8s_resource_get", map[string]any{"cluster_id": testClusterID, "kind": "nodes", "namespace": "default", "name": "node-1"}}, domain.PolicyRequest{Tool: "k8s_resource_get", ClusterID: testClusterID, AllNamespaces: true}},
+		{"resource delete namespace", toolCall{"k8s_resource_delete", map[string]any{"cluster_id": testClusterID, "kind": "Namespace", "namespace": "default", "name": "kube-system"}}, domain.PolicyRequest{Tool: "k8s_resource_delete", ClusterID: testClusterID, Namespaces: []string{"kube-system"}, Write: true}},
+		{"resource list namespaces", toolCall{"k8s_resource_list", map[string]any{"cluster_id": testClusterID, "kind": "namespaces", "namespace": "default"}}, domain.PolicyRequest{Tool: "k8s_resource_list", ClusterID: testClusterID, AllNamespaces: true}},
+		{"resource list all namespaces", toolCall{"k8s_resource_list", map[string]any{"cluster_id": testClusterID, "kind": "pods", "namespace": "default", "all_namespaces": true}}, domain.PolicyRequest{Tool: "k8s_resource_list", ClusterID: testClusterID, Namespaces: []string{"default"}, AllNamespaces: true}},
+		{"events watch all namespaces", toolCall{"k8s_events_watch", map[string]any{"cluster_id": testClusterID, "namespace": "default", "all_namespaces": true}}, domain.PolicyRequest{Tool: "k8s_events_watch", ClusterID: testClusterID, Namespaces: []string{"default"}, AllNamespaces: true}},
+		{"top pods all namespaces", toolCall{"k8s_top_pods", map[string]any{"cluster_id": testClusterID, "namespace": "default", "all_namespaces": true}}, domain.PolicyRequest{Tool: "k8s_top_pods", ClusterID: testClusterID, Namespaces: []string{"default"}, AllNamespaces: true}},
+		{"resource unknown kind", toolCall{"k8s_resource_delete", map[string]any{"cluster_id": testClusterID, "kind": "widgets", "namespace": "web", "name": "w"}}, domain.PolicyRequest{Tool: "k8s_resource_delete", ClusterID: testClusterID, Namespaces: []string{"web"}, Write: true}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			args, _ := json.Marshal(tc.args)
+			got := h.server.policyRequest(&mcp.CallToolParamsRaw{Name: tc.tool, Arguments: args})
+			slices.Sort(got.Namespaces)
+			slices.Sort(tc.want.Namespaces)
+			if got.Tool != tc.want.Tool || got.ClusterID != tc.want.ClusterID || got.Write != tc.want.Write || got.AllNamespaces != tc.want.AllNamespaces || !slices.Equal(got.Namespaces, tc.want.Namespaces) {
+				t.Errorf("policyRequest = %+v, want %+v", got, tc.want)
+			}
+		})
+	}
+}
+
+// TestToolPolicy runs calls through a server with policy rules and tool
+// filters.
+func TestToolPolicy(t *testing.T) {
+	opts := ServerOptions{
+		DenyTools: []string{"k8s_configmap_*"},
+		PolicyRules: []domain.ToolPolicyRule{
+			{Name: "allow-web-restart", Namespaces: []string{"default"}, Tools: []string{"k8s_deployment_restart"}, Effect: domain.PolicyEffectAllow},
+			{Name: "default-read-only", Namespaces: []string{"default"}, Effect: domain.PolicyEffectDeny},
+			{Name: "no-secrets", Tools: []string{"k8s_secret_*"}, Access: domain.PolicyAccessAny, Effect: domain.PolicyEffectDeny},
+			{Name: "allow-kube-system-list", Namespaces: []string{"kube-system"}, Tools: []string{"k8s_resource_list"}, Access: domain.PolicyAccessAny, Effect: domain.PolicyEffectAllow},
+			{Name: "kube-system-private", Namespaces: []string{"kube-*"}, Access: domain.PolicyAccessAny, Effect: domain.PolicyEffectDeny},
+		},
+	}
+	h := newTestHarness(t, opts, fixtureObjects()...)
+
+	for _, tc := range []struct {
+		name string
+		toolCall
+		wantRule string // empty when allowed
+	}{
+		{"read in protected namespace", toolCall{"k8s_pod_list", map[string]any{"cluster_id": testClusterID, "namespace": "default"}}, ""},
+		{"allowed before denied", toolCall{"k8s_deployment_restart", map[string]any{"cluster_id": testClusterID, "namespace": "default", "deployment_name": "web"}}, ""},
+		{"write in protected namespace", toolCall{"k8s_deployment_scale", map[string]any{"cluster_id": testClusterID, "namespace": "default", "deployment_name": "web", "replicas": 1}}, "default-read-only"},
+		{"set resources is a write", toolCall{"k8s_workload_set_resources", map[string]any{
+			"cluster_id": testClusterID, "namespace": "default", "kind": "deployment", "name": "web", "resources": map[string]any{"web": map[string]any{"limits": map[string]any{"cpu": "1"}}},
+		}}, "default-read-only"},
+		{"apply of namespace-less object", toolCall{"k8s_apply_yaml", map[string]any{
+			"cluster_id": testClusterID, "yaml_body": "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: flags\n",
+		}}, "default-read-only"},
+		{"apply dry run is a read", toolCall{"k8s_apply_yaml", map[string]any{
+			"cluster_id": testClusterID, "dry_run": true, "yaml_body": "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: flags\n",
+		}}, ""},
+		{"read denied by any access", toolCall{"k8s_secret_list", map[string]any{"cluster_id": testClusterID, "namespace": "kube-system"}}, "no-secrets"},
+		{"hidden tool", toolCall{"k8s_configmap_delete", map[string]any{"cluster_id": testClusterID, "namespace": "kube-system", "configmap_name": "coredns"}}, "tool_filter"},
+		{"list in other namespace", toolCall{"k8s_resource_list", map[string]any{"cluster_id": testClusterID, "kind": "pods", "namespace": "web"}}, ""},
+		{"list of all namespaces", toolCall{"k8s_resource_list", map[string]any{"cluster_id": testClusterID, "kind": "pods", "all_namespaces": true}}, "kube-system-private"},
+		{"list of namespaces", toolCall{"k8s_resource_list", map[string]any{"cluster_id": testClusterID, "kind": "namespaces"}}, "kube-system-private"},
+		{"get of cluster-scoped object", toolCall{"k8s_resource_get", map[string]any{"cluster_id": testClusterID, "kind": "node", "name": "node-1"}}, "kube-system-private"},
+		{"events watch of all namespaces", toolCall{"k8s_events_watch", map[string]any{"cluster_id": testClusterID, "all_namespaces": true, "duration_seconds": 1}}, "kube-system-private"},
+		{"top pods in other namespace", toolCall{"k8s_top_pods", map[string]any{"cluster_id": testClusterID, "namespace": "web"}}, ""},
+		{"top pods of all namespaces", toolCall{"k8s_top_pods", map[string]any{"cluster_id": testClusterID, "all_namespaces": true}}, "kube-system-private"},
+		{"delete of protected namespace", toolCall{"k8s_resource_delete", map[string]any{"cluster_id": testClusterID, "kind": "Namespace", "name": "kube-system"}}, "kube-system-private"},
+		{"delete of protected namespace by resource name", toolCall{"k8s_resource_delete", map[string]any{"cluster_id": testClusterID, "kind": "namespaces", "name": "kube-public", "namespace": "web"}}, "kube-system-private"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			res := h.call(t, tc.tool, tc.args)
+			content, _ := res.StructuredContent.(map[string]any)
+			decision, _ := content["decision"].(map[string]any)
+			rule, _ := decision["rule"].(string)
+			if rule != tc.wantRule {
+				t.Errorf("denied by %q, want %q:\n%s", rule, tc.wantRule, renderResult(res))
+			}
+			if tc.wantRule == "" && res.IsError && strings.Contains(renderResult(res), "policy_denied") {
+				t.Errorf("call denied:\n%s", renderResult(res))
+			}
+		})
+	}
+}
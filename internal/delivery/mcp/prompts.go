@@ -0,0 +1,346 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/your-org/mcp-k8s-server/internal/domain"
+	"github.com/your-org/mcp-k8s-server/internal/usecase"
+)
+
+// maintenanceTaint is the taint plan_node_maintenance suggests for keeping
+// pods off a node under maintenance.
+const maintenanceTaint = "maintenance=true"
+
+// promptMaxEvents bounds the events a prompt includes; the model can read
+// more with the event tools.
+const promptMaxEvents = 25
+
+// builtinPrompt is a prompt that gathers its context from the cluster when
+// it is requested. tools are the tools whose data it reads: the prompt is
+// only offered when all of them are exposed, and their policy rules apply
+// to it.
+type builtinPrompt struct {
+	prompt *mcp.Prompt
+	tools  []string
+	render func(ctx context.Context, args map[string]string) (string, error)
+}
+
+func (m *MCPServer) builtinPrompts(opts ServerOptions) []builtinPrompt {
+	return []builtinPrompt{
+		{
+			prompt: &mcp.Prompt{
+				Name:        "investigate_failing_pod",
+				Title:       "Investigate a failing pod",
+				Description: "Find the root cause of a pod that crashes, does not start or is not ready, starting from its diagnosis, events and previous logs.",
+				Arguments: []*mcp.PromptArgument{
+					clusterArgument(opts),
+					{Name: "namespace", Description: "Namespace of the pod"},
+					{Name: "pod", Description: "Name of the pod", Required: true},
+				},
+			},
+			tools:  []string{"k8s_pod_diagnose"},
+			render: m.renderInvestigateFailingPod,
+		},
+		{
+			prompt: &mcp.Prompt{
+				Name:        "review_namespace_security",
+				Title:       "Review namespace security",
+				Description: "Review the pod security, network exposure, access and resource limits of a namespace and rank the findings.",
+				Arguments: []*mcp.PromptArgument{
+					clusterArgument(opts),
+					{Name: "namespace", Description: "Namespace to review"},
+				},
+			},
+			tools: []string{
+				"k8s_namespace_get", "k8s_pod_list", "k8s_service_list", "k8s_secret_list",
+				"k8s_quota_list", "k8s_limitrange_list", "k8s_resource_list",
+			},
+			render: m.renderReviewNamespaceSecurity,
+		},
+		{
+			prompt: &mcp.Prompt{
+				Name:        "plan_node_maintenance",
+				Title:       "Plan node maintenance",
+				Description: "Plan how to take a node out of service: where its pods go, which disruption budgets and single replicas are at risk, and the taints to apply and remove.",
+				Arguments: []*mcp.PromptArgument{
+					clusterArgument(opts),
+					{Name: "node", Description: "Name of the node", Required: true},
+				},
+			},
+			tools:  []string{"k8s_node_get_metrics", "k8s_node_list", "k8s_resource_list"},
+			render: m.renderPlanNodeMaintenance,
+		},
+		{
+			prompt: &mcp.Prompt{
+				Name:        "prepare_rollout",
+				Title:       "Prepare a deployment rollout",
+				Description: "Check that a deployment is ready for a rollout and plan the change, how to watch it and how to roll it back.",
+				Arguments: []*mcp.PromptArgument{
+					clusterArgument(opts),
+					{Name: "namespace", Description: "Namespace of the deployment"},
+					{Name: "deployment", Description: "Name of the deployment", Required: true},
+					{Name: "image", Description: "Optional: new image to roll out, e.g. nginx:1.27"},
+				},
+			},
+			tools:  []string{"k8s_deployment_get_info", "k8s_deployment_history", "k8s_events_for_workload"},
+			render: m.renderPrepareRollout,
+		},
+	}
+}
+
+// clusterArgument is only required when no default cluster is configured.
+func clusterArgument(opts ServerOptions) *mcp.PromptArgument {
+	return &mcp.PromptArgument{Name: "cluster_id", Description: "ID of the cluster", Required: opts.DefaultCluster == ""}
+}
+
+// setupPrompts registers the built-in prompts whose tools are exposed and
+// the custom prompts of opts. Custom prompts may not reuse a built-in name.
+func (m *MCPServer) setupPrompts(opts ServerOptions) error {
+	m.prompts = make(map[string]builtinPrompt)
+	builtin := make(map[string]bool)
+	for _, p := range m.builtinPrompts(opts) {
+		builtin[p.prompt.Name] = true
+		hidden := false
+		for _, tool := range p.tools {
+			hidden = hidden || opts.disabledReason(tool) != ""
+		}
+		if hidden {
+			m.logger.Info("Hidden prompt by configuration", "prompt", p.prompt.Name)
+			continue
+		}
+		m.prompts[p.prompt.Name] = p
+		m.server.AddPrompt(p.prompt, m.builtinPromptHandler(p, opts))
+	}
+
+	for _, p := range opts.CustomPrompts {
+		if builtin[p.Name] {
+			return fmt.Errorf("custom prompt %s has the name of a built-in prompt", p.Name)
+		}
+		prompt := &mcp.Prompt{Name: p.Name, Title: p.Title, Description: p.Description}
+		for _, arg := range p.Arguments {
+			prompt.Arguments = append(prompt.Arguments, &mcp.PromptArgument{Name: arg.Name, Description: arg.Description, Required: arg.Required})
+		}
+		m.server.AddPrompt(prompt, m.customPromptHandler(p, prompt, opts))
+	}
+	if len(opts.CustomPrompts) > 0 {
+		m.logger.Info("Registered custom prompts", "count", len(opts.CustomPrompts))
+	}
+	return nil
+}
+
+func (m *MCPServer) builtinPromptHandler(p builtinPrompt, opts ServerOptions) mcp.PromptHandler {
+	return func(ctx context.Context, req *mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+		m.logger.Info("Handling prompt request", "prompt", req.Params.Name, "args", req.Params.Arguments)
+
+		args, err := promptArguments(p.prompt, req.Params.Arguments, opts)
+		if err != nil {
+			return nil, err
+		}
+		text, err := p.render(ctx, args)
+		if err != nil {
+			return nil, err
+		}
+		return promptResult(p.prompt, text), nil
+	}
+}
+
+func (m *MCPServer) customPromptHandler(p domain.PromptTemplate, prompt *mcp.Prompt, opts ServerOptions) mcp.PromptHandler {
+	return func(ctx context.Context, req *mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+		m.logger.Info("Handling prompt request", "prompt", req.Params.Name, "args", req.Params.Arguments)
+
+		args, err := promptArguments(prompt, req.Params.Arguments, opts)
+		if err != nil {
+			return nil, err
+		}
+		text, err := p.Render(args)
+		if err != nil {
+			return nil, err
+		}
+		return promptResult(prompt, text), nil
+	}
+}
+
+// promptArguments fills in cluster_id and namespace, when the prompt takes
+// them, like the default arguments of tool calls, and checks that required
+// arguments are set. The SDK does not check them.
+func promptArguments(prompt *mcp.Prompt, given map[string]string, opts ServerOptions) (map[string]string, error) {
+	args := make(map[string]string, len(prompt.Arguments))
+	for _, arg := range prompt.Arguments {
+		value := strings.TrimSpace(given[arg.Name])
+		switch {
+		case value == "" && arg.Name == "cluster_id":
+			value = opts.DefaultCluster
+		case value == "" && arg.Name == "namespace":
+			value = opts.DefaultNamespace
+			if value == "" {
+				value = "default"
+			}
+		}
+		if value == "" && arg.Required {
+			return nil, fmt.Errorf("prompt %s: argument %s is required", prompt.Name, arg.Name)
+		}
+		args[arg.Name] = value
+	}
+	return args, nil
+}
+
+func promptResult(prompt *mcp.Prompt, text string) *mcp.GetPromptResult {
+	return &mcp.GetPromptResult{
+		Description: prompt.Description,
+		Messages:    []*mcp.PromptMessage{{Role: "user", Content: &mcp.TextContent{Text: text}}},
+	}
+}
+
+// promptContext collects the cluster state a prompt hands to the model.
+// Sections that cannot be read say so instead of failing the prompt.
+type promptContext struct {
+	sb strings.Builder
+}
+
+func newPromptContext(instructions string) *promptContext {
+	c := &promptContext{}
+	c.sb.WriteString(instructions)
+	c.sb.WriteString("\n# Context gathered from the cluster\n")
+	return c
+}
+
+func (c *promptContext) add(title string, data any, err error) {
+	fmt.Fprintf(&c.sb, "\n## %s\n\n", title)
+	if err != nil {
+		fmt.Fprintf(&c.sb, "Not available: %v\n", err)
+		return
+	}
+	fmt.Fprintf(&c.sb, "```json\n%s\n```\n", mustMarshalJSON(data))
+}
+
+// addResourceList adds the objects of any resource type, which the cluster
+// may not serve, such as NetworkPolicies without a network plugin.
+func (m *MCPServer) addResourceList(ctx context.Context, c *promptContext, title, clusterID string, req domain.ResourceListRequest) {
+	list, err := m.k8sUC.ListResources(ctx, clusterID, req)
+	if err != nil {
+		c.add(title, nil, err)
+		return
+	}
+	c.add(title, list.Items, nil)
+}
+
+func (c *promptContext) String() string {
+	return c.sb.String()
+}
+
+func (m *MCPServer) renderInvestigateFailingPod(ctx context.Context, args map[string]string) (string, error) {
+	cluster, namespace, pod := args["cluster_id"], args["namespace"], args["pod"]
+	diagnosis, err := m.k8sUC.DiagnosePod(ctx, cluster, namespace, pod, usecase.DefaultDiagnoseLogLines)
+	if err != nil {
+		return "", err
+	}
+
+	c := newPromptContext(fmt.Sprintf(`Investigate why pod %[3]s in namespace %[2]s on cluster %[1]s is failing, find the root cause and propose a fix.
+
+Its diagnosis is below. Call the tools with cluster_id %[1]q and namespace %[2]q and work through these steps:
+1. Start from the problems in the diagnosis. Each comes with likely causes; confirm or rule them out with evidence instead of guessing.
+2. If a container restarted, read the logs of its previous run with k8s_pod_get_logs (pod_name %[3]q, previous: true, container as needed).
+3. If the pod belongs to a workload, read k8s_events_for_workload for it to see scheduling, image pull, volume and probe problems across its pods.
+4. Check the objects the pod depends on, such as ConfigMaps, Secrets, PersistentVolumeClaims and Services, with k8s_resource_get.
+5. Report the root cause, the evidence for it and the smallest change that fixes it. Do not change the cluster without asking first.
+`, cluster, namespace, pod))
+	c.add("Diagnosis of pod "+pod, diagnosis, nil)
+	return c.String(), nil
+}
+
+func (m *MCPServer) renderReviewNamespaceSecurity(ctx context.Context, args map[string]string) (string, error) {
+	cluster, namespace := args["cluster_id"], args["namespace"]
+	ns, err := m.k8sUC.GetNamespace(ctx, cluster, namespace)
+	if err != nil {
+		return "", err
+	}
+
+	c := newPromptContext(fmt.Sprintf(`Review the security of namespace %[2]s on cluster %[1]s. This is a read-only review: do not change anything.
+
+The namespace and what runs in it are below. Call the tools with cluster_id %[1]q and namespace %[2]q and check:
+1. Pod Security Admission: the pod-security.kubernetes.io/enforce label of the namespace. Without it, or at level privileged, any pod may run.
+2. Pods: read each workload with k8s_resource_get and look at securityContext (runAsNonRoot, privileged, allowPrivilegeEscalation, readOnlyRootFilesystem, added capabilities), hostNetwork, hostPID, hostPath volumes, and whether service account tokens are mounted without need.
+3. Network: without NetworkPolicies every pod accepts traffic from the whole cluster. Note Services of type NodePort or LoadBalancer, which are reachable from outside.
+4. Access: RoleBindings that grant admin, edit or wildcard roles, or grant anything to the default service account. k8s_rbac_clusterrole_list shows what cluster roles allow.
+5. Secrets: unexpected types and secrets no pod uses. Never print secret values.
+6. Resources: without ResourceQuotas and LimitRanges a single workload can starve the namespace.
+
+Report the findings ranked by severity, each with the object, the risk and a concrete fix.
+`, cluster, namespace))
+	c.add("Namespace", ns, nil)
+	pods, err := m.k8sUC.ListPods(ctx, cluster, namespace)
+	c.add("Pods", pods, err)
+	services, err := m.k8sUC.ListServices(ctx, cluster, namespace)
+	c.add("Services", services, err)
+	secrets, err := m.k8sUC.ListSecrets(ctx, cluster, namespace)
+	c.add("Secrets (metadata only)", secrets, err)
+	m.addResourceList(ctx, c, "NetworkPolicies", cluster, domain.ResourceListRequest{Kind: "networkpolicies", Namespace: namespace})
+	m.addResourceList(ctx, c, "RoleBindings", cluster, domain.ResourceListRequest{Kind: "rolebindings", Namespace: namespace})
+	m.addResourceList(ctx, c, "ServiceAccounts", cluster, domain.ResourceListRequest{Kind: "serviceaccounts", Namespace: namespace})
+	quotas, err := m.k8sUC.ListResourceQuotas(ctx, cluster, namespace)
+	c.add("ResourceQuotas", quotas, err)
+	limitRanges, err := m.k8sUC.ListLimitRanges(ctx, cluster, namespace)
+	c.add("LimitRanges", limitRanges, err)
+	return c.String(), nil
+}
+
+func (m *MCPServer) renderPlanNodeMaintenance(ctx context.Context, args map[string]string) (string, error) {
+	cluster, node := args["cluster_id"], args["node"]
+	metrics, err := m.k8sUC.GetNodeMetrics(ctx, cluster, node)
+	if err != nil {
+		return "", err
+	}
+
+	c := newPromptContext(fmt.Sprintf(`Plan maintenance of node %[2]s on cluster %[1]s: move its pods elsewhere without an outage, then bring it back.
+
+The node, the pods on it and the other nodes are below. Call the tools with cluster_id %[1]q and work out:
+1. Capacity: whether the other nodes can take the node's pods. Compare their allocatable resources and k8s_top_nodes usage with what the pods request.
+2. Risk: workloads with a single replica on this node, pods without a controller, which are not recreated, and local or node-bound volumes.
+3. Disruption budgets: evictions caused by NoExecute taints do not respect PodDisruptionBudgets, so budgets that would be violated need the workload moved first, e.g. with k8s_deployment_restart after the node stops taking new pods.
+4. The steps: k8s_node_taint_apply with node_name %[2]q, taint_key "%[3]s:NoSchedule" and action add keeps new pods off; "%[3]s:NoExecute" then evicts the remaining pods without a matching toleration. Check with k8s_resource_list (kind pods, all_namespaces, field_selector "spec.nodeName=%[2]s") that only DaemonSet pods are left.
+5. Afterwards: remove both taints with action remove and check the node is Ready.
+
+Present the plan step by step and wait for approval before applying any taint.
+`, cluster, node, maintenanceTaint))
+	c.add("Node "+node, metrics, nil)
+	m.addResourceList(ctx, c, "Pods on the node", cluster, domain.ResourceListRequest{Kind: "pods", FieldSelector: "spec.nodeName=" + node})
+	impact, err := m.k8sUC.TaintImpact(ctx, cluster, node, maintenanceTaint+":NoExecute")
+	c.add("Impact of a NoExecute taint", impact, err)
+	nodes, err := m.k8sUC.ListNodes(ctx, cluster)
+	c.add("Nodes", nodes, err)
+	m.addResourceList(ctx, c, "PodDisruptionBudgets", cluster, domain.ResourceListRequest{Kind: "poddisruptionbudgets"})
+	return c.String(), nil
+}
+
+func (m *MCPServer) renderPrepareRollout(ctx context.Context, args map[string]string) (string, error) {
+	cluster, namespace, deployment, image := args["cluster_id"], args["namespace"], args["deployment"], args["image"]
+	info, err := m.k8sUC.GetDeploymentInfo(ctx, cluster, namespace, deployment)
+	if err != nil {
+		return "", err
+	}
+
+	change := `3. The change: describe what changes. Preview manifest changes with k8s_diff_yaml before applying them with k8s_apply_yaml.`
+	if image != "" {
+		change = fmt.Sprintf(`3. The change: set the image to %q with k8s_workload_set_image (kind deployment, name %q). Name the containers to change in images, or use "*" only if every container runs this image.`, image, deployment)
+	}
+	c := newPromptContext(fmt.Sprintf(`Prepare a rollout of deployment %[3]s in namespace %[2]s on cluster %[1]s.
+
+The deployment, its revisions and recent events are below. Call the tools with cluster_id %[1]q and namespace %[2]q and check:
+1. Health: all replicas are ready and available and there are no recent warning events. Do not roll out on top of a failing rollout; investigate it first.
+2. Safety: the rollout strategy (maxUnavailable, maxSurge), readiness probes that gate traffic to new pods, and whether an HPA (k8s_hpa_list) scales the deployment.
+%[4]s
+4. Watching: follow the rollout with k8s_deployment_rollout_status. If new pods fail, inspect one with k8s_pod_diagnose.
+5. Rolling back: note the current revision in the history. If the rollout fails, k8s_deployment_undo with deployment_name %[3]q and to_revision set to it restores the deployment.
+
+Present the plan as a checklist and wait for approval before changing anything.
+`, cluster, namespace, deployment, change))
+	c.add("Deployment "+deployment, info, nil)
+	history, err := m.k8sUC.DeploymentHistory(ctx, cluster, namespace, deployment)
+	c.add("Revisions", history, err)
+	events, err := m.k8sUC.WorkloadEvents(ctx, cluster, namespace, "deployment", deployment, "", promptMaxEvents)
+	c.add("Events", events, err)
+	return c.String(), nil
+}
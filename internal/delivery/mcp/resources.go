@@ -0,0 +1,250 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/your-org/mcp-k8s-server/internal/domain"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/yaml"
+)
+
+// Kubernetes objects are exposed as MCP resources under these URI
+// templates. kind is resolved like the kind argument of k8s_resource_get,
+// so "deployment", "deploy" and "certificates.cert-manager.io" all work.
+const (
+	objectURIScheme          = "k8s"
+	namespacedObjectTemplate = "k8s://{cluster}/{namespace}/{kind}/{name}"
+	clusterObjectTemplate    = "k8s://{cluster}/{kind}/{name}"
+)
+
+// resourceReadTool is the tool whose policy and filters govern reading and
+// subscribing to object resources.
+const resourceReadTool = "k8s_resource_get"
+
+// objectRef is an object named by a k8s:// URI. namespace is empty for
+// cluster-scoped objects.
+type objectRef struct {
+	clusterID string
+	namespace string
+	kind      string
+	name      string
+}
+
+// parseObjectURI parses k8s://{cluster}/{namespace}/{kind}/{name} and
+// k8s://{cluster}/{kind}/{name}. Segments may be percent-encoded.
+func parseObjectURI(uri string) (objectRef, error) {
+	u, err := url.Parse(uri)
+	if err != nil {
+		return objectRef{}, fmt.Errorf("invalid resource URI %q: %w", uri, err)
+	}
+	if u.Scheme != objectURIScheme || u.Host == "" {
+		return objectRef{}, fmt.Errorf("invalid resource URI %q: want %s or %s", uri, namespacedObjectTemplate, clusterObjectTemplate)
+	}
+	segments := strings.Split(strings.TrimPrefix(u.EscapedPath(), "/"), "/")
+	for i, s := range segments {
+		if segments[i], err = url.PathUnescape(s); err != nil || segments[i] == "" {
+			return objectRef{}, fmt.Errorf("invalid resource URI %q: empty or malformed path segment", uri)
+		}
+	}
+	ref := objectRef{clusterID: u.Host}
+	switch len(segments) {
+	case 3:
+		ref.namespace, ref.kind, ref.name = segments[0], segments[1], segments[2]
+	case 2:
+		ref.kind, ref.name = segments[0], segments[1]
+	default:
+		return objectRef{}, fmt.Errorf("invalid resource URI %q: want %s or %s", uri, namespacedObjectTemplate, clusterObjectTemplate)
+	}
+	return ref, nil
+}
+
+func (m *MCPServer) setupResources() {
+	m.server.AddResourceTemplate(&mcp.ResourceTemplate{
+		Name:        "k8s_object",
+		Title:       "Kubernetes object",
+		URITemplate: namespacedObjectTemplate,
+		Description: "A namespaced Kubernetes object of any kind as YAML, without managedFields and server-set metadata. Secret values are redacted. Subscribe to be notified when it changes.",
+		MIMEType:    "application/yaml",
+	}, m.readObjectResource)
+	m.server.AddResourceTemplate(&mcp.ResourceTemplate{
+		Name:        "k8s_cluster_object",
+		Title:       "Cluster-scoped Kubernetes object",
+		URITemplate: clusterObjectTemplate,
+		Description: "A cluster-scoped Kubernetes object, such as a Node or Namespace, as YAML without managedFields and server-set metadata. Subscribe to be notified when it changes.",
+		MIMEType:    "application/yaml",
+	}, m.readObjectResource)
+	if m.exportDir != "" {
+		m.server.AddResourceTemplate(&mcp.ResourceTemplate{
+			Name:        "k8s_logs_export",
+			Title:       "Log export archive",
+			URITemplate: logExportTemplate,
+			Description: "A tar.gz or zip archive written by k8s_logs_export, readable by the session that created it until it expires.",
+		}, m.readLogExport)
+	}
+}
+
+func (m *MCPServer) readObjectResource(ctx context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+	m.logger.Info("Handling object resource read", "uri", req.Params.URI)
+
+	ref, err := parseObjectURI(req.Params.URI)
+	if err != nil {
+		return nil, err
+	}
+	obj, err := m.k8sUC.GetResource(ctx, ref.clusterID, ref.kind, "", ref.namespace, ref.name)
+	if apierrors.IsNotFound(err) {
+		return nil, mcp.ResourceNotFoundError(req.Params.URI)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	cleanObject(obj.Object)
+	body, err := yaml.Marshal(obj.Object)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode %s: %w", obj.Kind, err)
+	}
+	return &mcp.ReadResourceResult{
+		Contents: []*mcp.ResourceContents{{URI: req.Params.URI, MIMEType: "application/yaml", Text: string(body)}},
+	}, nil
+}
+
+// cleanObject drops the metadata the API server sets, which is noise when
+// reading an object or reusing it as a manifest. status is kept.
+func cleanObject(obj map[string]any) {
+	metadata, ok := obj["metadata"].(map[string]any)
+	if !ok {
+		return
+	}
+	for _, field := range []string{"uid", "resourceVersion", "generation", "creationTimestamp", "selfLink", "managedFields"} {
+		delete(metadata, field)
+	}
+	if annotations, ok := metadata["annotations"].(map[string]any); ok {
+		delete(annotations, "kubectl.kubernetes.io/last-applied-configuration")
+		if len(annotations) == 0 {
+			delete(metadata, "annotations")
+		}
+	}
+}
+
+// objectWatches runs one watch per subscribed object URI, shared by every
+// session subscribed to it, and stops it when the last one unsubscribes or
+// disconnects.
+type objectWatches struct {
+	mu       sync.Mutex
+	watches  map[string]*objectWatch
+	sessions map[*mcp.ServerSession]bool
+}
+
+type objectWatch struct {
+	cancel      context.CancelFunc
+	subscribers map[*mcp.ServerSession]bool
+}
+
+func newObjectWatches() *objectWatches {
+	return &objectWatches{
+		watches:  make(map[string]*objectWatch),
+		sessions: make(map[*mcp.ServerSession]bool),
+	}
+}
+
+// subscribeResource starts watching the object behind a k8s:// URI and
+// sends resources/updated notifications to its subscribers on each change.
+func (m *MCPServer) subscribeResource(ctx context.Context, req *mcp.SubscribeRequest) error {
+	m.logger.Info("Handling object resource subscribe", "uri", req.Params.URI)
+
+	uri := req.Params.URI
+	ref, err := parseObjectURI(uri)
+	if err != nil {
+		return err
+	}
+
+	w := m.objectWatches
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if existing, ok := w.watches[uri]; ok {
+		existing.subscribers[req.Session] = true
+		w.trackSession(req.Session)
+		return nil
+	}
+
+	// The watch outlives the subscribe request.
+	watchCtx, cancel := context.WithCancel(context.Background())
+	changes, err := m.k8sUC.WatchResource(watchCtx, ref.clusterID, ref.kind, ref.namespace, ref.name)
+	if err != nil {
+		cancel()
+		return err
+	}
+	w.watches[uri] = &objectWatch{cancel: cancel, subscribers: map[*mcp.ServerSession]bool{req.Session: true}}
+	w.trackSession(req.Session)
+
+	go func() {
+		for change := range changes {
+			m.logger.Debug("Subscribed object changed", "uri", uri, "change", change.Type, "resourceVersion", change.ResourceVersion)
+			_ = m.server.ResourceUpdated(watchCtx, &mcp.ResourceUpdatedNotificationParams{URI: uri})
+		}
+	}()
+	return nil
+}
+
+func (m *MCPServer) unsubscribeResource(ctx context.Context, req *mcp.UnsubscribeRequest) error {
+	m.logger.Info("Handling object resource unsubscribe", "uri", req.Params.URI)
+
+	w := m.objectWatches
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.remove(req.Params.URI, req.Session)
+	return nil
+}
+
+// trackSession drops the subscriptions of session once it closes, which
+// the SDK does not report to the unsubscribe handler. w.mu must be held.
+func (w *objectWatches) trackSession(session *mcp.ServerSession) {
+	if session == nil || w.sessions[session] {
+		return
+	}
+	w.sessions[session] = true
+	go func() {
+		_ = session.Wait()
+		w.mu.Lock()
+		defer w.mu.Unlock()
+		delete(w.sessions, session)
+		for uri := range w.watches {
+			w.remove(uri, session)
+		}
+	}()
+}
+
+// remove unsubscribes session from uri and stops the watch when nobody is
+// subscribed any more. w.mu must be held.
+func (w *objectWatches) remove(uri string, session *mcp.ServerSession) {
+	watch, ok := w.watches[uri]
+	if !ok {
+		return
+	}
+	delete(watch.subscribers, session)
+	if len(watch.subscribers) == 0 {
+		watch.cancel()
+		delete(w.watches, uri)
+	}
+}
+
+// objectPolicyRequest is the policy request for reading or subscribing to
+// an object resource: a call to k8s_resource_get on its cluster and
+// namespace.
+func (m *MCPServer) objectPolicyRequest(uri string) (domain.PolicyRequest, error) {
+	ref, err := parseObjectURI(uri)
+	if err != nil {
+		return domain.PolicyRequest{}, err
+	}
+	req := domain.PolicyRequest{Tool: resourceReadTool, ClusterID: ref.clusterID}
+	if ref.namespace != "" {
+		req.Namespaces = []string{ref.namespace}
+	}
+	m.scopeResourceRequest(&req, ref.kind, "", ref.name)
+	return req, nil
+}
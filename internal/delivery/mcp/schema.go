@@ -0,0 +1,226 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// Tool arguments and results are Go structs, and addTool generates their
+// JSON Schemas from them: property names from `json` tags, descriptions
+// from `jsonschema` tags, and fields without omitempty as required. These
+// tags add what the Go types cannot express:
+//
+//	default:"default"  value filled in when the argument is omitted
+//	enum:"yaml,json"   allowed values, comma separated
+//	minimum:"0"        lower bound of a number
+//	minItems:"1"       minimum length of a list
+//
+// Argument types whose enums or defaults come from Go values implement
+// schemaAdjuster instead.
+
+// schemaAdjuster is implemented by argument types that complete their
+// generated schema.
+type schemaAdjuster interface {
+	adjustSchema(s *jsonschema.Schema)
+}
+
+// toolHandler handles a call with typed arguments. A nil result leaves the
+// structured content out, as for errors.
+type toolHandler[In, Out any] func(ctx context.Context, req *mcp.CallToolRequest, args In) (*mcp.CallToolResult, *Out, error)
+
+// addTool registers a tool whose input and output schemas are generated
+// from In and Out. The SDK validates the arguments against the input
+// schema before h runs, and the structured content h returns against the
+// output schema.
+func addTool[In, Out any](s *mcp.Server, t *mcp.Tool, h toolHandler[In, Out]) {
+	tool := *t
+	tool.InputSchema = inputSchemaFor[In]()
+	tool.OutputSchema = outputSchemaFor[Out]()
+	mcp.AddTool(s, &tool, func(ctx context.Context, req *mcp.CallToolRequest, args In) (*mcp.CallToolResult, any, error) {
+		res, out, err := h(ctx, req, args)
+		if out == nil {
+			return res, nil, err
+		}
+		return res, out, err
+	})
+}
+
+// inputSchemaFor generates the schema of the arguments In. Required string
+// arguments may not be empty.
+func inputSchemaFor[In any]() *jsonschema.Schema {
+	s := mustSchemaFor[In]()
+	for _, name := range s.Required {
+		if prop := s.Properties[name]; prop != nil && prop.Type == "string" {
+			prop.MinLength = jsonschema.Ptr(1)
+		}
+	}
+	var in In
+	if a, ok := any(in).(schemaAdjuster); ok {
+		a.adjustSchema(s)
+	}
+	return s
+}
+
+// outputSchemaFor generates the schema of the result Out. Lists and maps
+// may be null, as Go encodes nil ones.
+func outputSchemaFor[Out any]() *jsonschema.Schema {
+	s := mustSchemaFor[Out]()
+	for _, prop := range s.Properties {
+		nullCollections(prop)
+	}
+	return s
+}
+
+// mustSchemaFor generates the schema of T and applies its schema tags.
+// Types that cannot be described are programming errors.
+func mustSchemaFor[T any]() *jsonschema.Schema {
+	s, err := jsonschema.For[T](nil)
+	if err != nil {
+		panic(fmt.Sprintf("schema of %T: %v", *new(T), err))
+	}
+	if err := applySchemaTags(reflect.TypeFor[T](), s); err != nil {
+		panic(fmt.Sprintf("schema of %T: %v", *new(T), err))
+	}
+	return s
+}
+
+// applySchemaTags sets the default, enum, minimum and minItems tags of the
+// fields of t, and of the structs it contains, on the schema s of t.
+func applySchemaTags(t reflect.Type, s *jsonschema.Schema) error {
+	for t.Kind() == reflect.Pointer || t.Kind() == reflect.Slice || t.Kind() == reflect.Map {
+		if t.Kind() == reflect.Map {
+			s = s.AdditionalProperties
+		} else if t.Kind() == reflect.Slice {
+			s = s.Items
+		}
+		t = t.Elem()
+		if s == nil {
+			return nil
+		}
+	}
+	if t.Kind() != reflect.Struct || s.Properties == nil {
+		return nil
+	}
+
+	for _, field := range reflect.VisibleFields(t) {
+		if field.Anonymous || !field.IsExported() {
+			continue
+		}
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "" {
+			name = field.Name
+		}
+		prop, ok := s.Properties[name]
+		if !ok {
+			continue
+		}
+		if err := applyFieldTags(field, prop); err != nil {
+			return fmt.Errorf("field %s: %w", field.Name, err)
+		}
+		if err := applySchemaTags(field.Type, prop); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func applyFieldTags(field reflect.StructField, prop *jsonschema.Schema) error {
+	if v, ok := field.Tag.Lookup("default"); ok {
+		value, err := parseSchemaValue(prop, v)
+		if err != nil {
+			return err
+		}
+		if prop.Default, err = json.Marshal(value); err != nil {
+			return err
+		}
+	}
+	if v, ok := field.Tag.Lookup("enum"); ok {
+		for _, item := range strings.Split(v, ",") {
+			value, err := parseSchemaValue(prop, item)
+			if err != nil {
+				return err
+			}
+			prop.Enum = append(prop.Enum, value)
+		}
+	}
+	if v, ok := field.Tag.Lookup("minimum"); ok {
+		min, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return fmt.Errorf("invalid minimum %q", v)
+		}
+		prop.Minimum = &min
+	}
+	if v, ok := field.Tag.Lookup("minItems"); ok {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return fmt.Errorf("invalid minItems %q", v)
+		}
+		prop.MinItems = &n
+	}
+	return nil
+}
+
+// parseSchemaValue reads a tag value as a value of the type of prop.
+func parseSchemaValue(prop *jsonschema.Schema, v string) (any, error) {
+	typ := prop.Type
+	for _, t := range prop.Types {
+		if t != "null" {
+			typ = t
+		}
+	}
+	switch typ {
+	case "integer":
+		return strconv.ParseInt(v, 10, 64)
+	case "number":
+		return strconv.ParseFloat(v, 64)
+	case "boolean":
+		return strconv.ParseBool(v)
+	case "string":
+		return v, nil
+	}
+	return nil, fmt.Errorf("no tag values for properties of type %q", typ)
+}
+
+// nullCollections allows null for the lists and maps in s.
+func nullCollections(s *jsonschema.Schema) {
+	if s == nil {
+		return
+	}
+	isMap := s.Type == "object" && s.AdditionalProperties != nil && s.AdditionalProperties.Not == nil
+	if s.Type == "array" || isMap {
+		s.Types = []string{"null", s.Type}
+		s.Type = ""
+	}
+	nullCollections(s.Items)
+	if isMap {
+		nullCollections(s.AdditionalProperties)
+	}
+	for _, prop := range s.Properties {
+		nullCollections(prop)
+	}
+}
+
+// enumOf converts Go values to schema enum values.
+func enumOf[T any](values ...T) []any {
+	enum := make([]any, len(values))
+	for i, v := range values {
+		enum[i] = v
+	}
+	return enum
+}
+
+// defaultOf encodes a Go value as a schema default.
+func defaultOf(v any) json.RawMessage {
+	data, err := json.Marshal(v)
+	if err != nil {
+		panic(fmt.Sprintf("schema default %v: %v", v, err))
+	}
+	return data
+}
@@ -0,0 +1,113 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// schemaDir holds the input and output schemas of every tool for client
+// developers.
+var schemaDir = filepath.Join("..", "..", "..", "api", "json_schema")
+
+// TestToolSchemas compares the schemas the server advertises with
+// api/json_schema/<tool>.input.json and <tool>.output.json, or rewrites
+// them when the test runs with -update.
+func TestToolSchemas(t *testing.T) {
+	h := newToolsHarness(t)
+
+	written := make(map[string]bool)
+	for tool, err := range h.session.Tools(context.Background(), nil) {
+		if err != nil {
+			t.Fatalf("list tools: %v", err)
+		}
+		if tool.OutputSchema == nil {
+			t.Errorf("tool %s has no output schema", tool.Name)
+		}
+		for suffix, schema := range map[string]any{"input": tool.InputSchema, "output": tool.OutputSchema} {
+			name := tool.Name + "." + suffix + ".json"
+			written[name] = true
+			data, err := json.MarshalIndent(schema, "", "  ")
+			if err != nil {
+				t.Fatalf("encode %s: %v", name, err)
+			}
+			assertSchemaFile(t, name, string(data)+"\n")
+		}
+	}
+
+	files, err := os.ReadDir(schemaDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, f := range files {
+		if !strings.HasSuffix(f.Name(), ".json") || written[f.Name()] {
+			continue
+		}
+		if *update {
+			if err := os.Remove(filepath.Join(schemaDir, f.Name())); err != nil {
+				t.Fatal(err)
+			}
+			continue
+		}
+		t.Errorf("%s belongs to no tool (run go test -update to remove it)", f.Name())
+	}
+}
+
+func assertSchemaFile(t *testing.T, name, got string) {
+	t.Helper()
+
+	path := filepath.Join(schemaDir, name)
+	if *update {
+		if err := os.WriteFile(path, []byte(got), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read schema file (run go test -update to create it): %v", err)
+	}
+	if string(want) != got {
+		t.Errorf("schema differs from %s (run go test -update to accept):\n--- want\n%s\n--- got\n%s", path, want, got)
+	}
+}
+
+// TestToolArgumentValidation calls tools with arguments their schemas
+// reject.
+func TestToolArgumentValidation(t *testing.T) {
+	h := newToolsHarness(t)
+
+	for _, tc := range []struct {
+		name string
+		toolCall
+		want string
+	}{
+		{"missing required", toolCall{"k8s_secret_get", map[string]any{"cluster_id": testClusterID}}, "secret_name"},
+		{"empty required", toolCall{"k8s_secret_get", map[string]any{"cluster_id": testClusterID, "secret_name": ""}}, "secret_name"},
+		{"wrong type", toolCall{"k8s_pod_get_logs", map[string]any{"cluster_id": testClusterID, "pod_name": "web-7d9c-abcde", "tail_lines": "ten"}}, "tail_lines"},
+		{"not in enum", toolCall{"k8s_resource_get", map[string]any{"cluster_id": testClusterID, "kind": "pod", "name": "web-7d9c-abcde", "output": "xml"}}, "output"},
+		{"below minimum", toolCall{"k8s_deployment_scale", map[string]any{"cluster_id": testClusterID, "deployment_name": "web", "replicas": -1}}, "replicas"},
+		{"unknown argument", toolCall{"k8s_node_list", map[string]any{"cluster_id": testClusterID, "node": "node-1"}}, "node"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			res, err := h.session.CallTool(context.Background(), &mcp.CallToolParams{Name: tc.tool, Arguments: tc.args})
+			var got string
+			switch {
+			case err != nil:
+				got = err.Error()
+			case res.IsError:
+				got = renderResult(res)
+			default:
+				t.Fatalf("%s accepted %v:\n%s", tc.tool, tc.args, renderResult(res))
+			}
+			if !strings.Contains(got, tc.want) {
+				t.Errorf("error does not mention %q:\n%s", tc.want, got)
+			}
+		})
+	}
+}
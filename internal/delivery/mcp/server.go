@@ -5,6 +5,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"log"
+	"maps"
+	"path/filepath"
+	"slices"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 	"github.com/your-org/mcp-k8s-server/internal/domain"
@@ -16,13 +19,23 @@ type MCPServer struct {
 	server    *mcp.Server
 	clusterUC *usecase.ClusterUseCase
 	k8sUC     *usecase.K8sUseCase
+	auditUC   *usecase.AuditUseCase
 	logger    infrastructure.Logger
+
+	execPolicy    domain.ExecPolicy
+	exportDir     string
+	logExports    *logExports
+	manifestRoot  string
+	objectWatches *objectWatches
+	// prompts are the built-in prompts offered, by name.
+	prompts map[string]builtinPrompt
 }
 
 func NewMCPServer(
 	clusterUC *usecase.ClusterUseCase,
 	k8sUC *usecase.K8sUseCase,
 	logger infrastructure.Logger,
+	opts ServerOptions,
 ) (*MCPServer, error) {
 
 	impl := &mcp.Implementation{
@@ -30,1537 +43,655 @@ func NewMCPServer(
 		Version: "1.0.0",
 	}
 
-	server := mcp.NewServer(impl, nil)
 	mcpServer := &MCPServer{
-		server:    server,
 		clusterUC: clusterUC,
 		k8sUC:     k8sUC,
+		auditUC:   opts.Audit,
 		logger:    logger,
+
+		execPolicy:    opts.Exec,
+		logExports:    newLogExports(opts.LogExportTTL, opts.LogExportMaxArchives, logger),
+		objectWatches: newObjectWatches(),
+	}
+	mcpServer.server = mcp.NewServer(impl, &mcp.ServerOptions{
+		SubscribeHandler:   mcpServer.subscribeResource,
+		UnsubscribeHandler: mcpServer.unsubscribeResource,
+	})
+	if opts.LogExportDir != "" {
+		exportDir, err := filepath.Abs(opts.LogExportDir)
+		if err != nil {
+			return nil, fmt.Errorf("invalid log export directory: %w", err)
+		}
+		mcpServer.exportDir = exportDir
+	}
+	if opts.ManifestRoot != "" {
+		root, err := filepath.Abs(opts.ManifestRoot)
+		if err != nil {
+			return nil, fmt.Errorf("invalid manifest root: %w", err)
+		}
+		mcpServer.manifestRoot = root
 	}
 
 	mcpServer.setupTools()
+	mcpServer.setupResources()
+	if err := mcpServer.setupPrompts(opts); err != nil {
+		return nil, err
+	}
+	if err := mcpServer.applyToolOptions(context.Background(), opts); err != nil {
+		return nil, err
+	}
 	return mcpServer, nil
 }
 
 func (m *MCPServer) setupTools() {
 
-	mcp.AddTool(m.server, &mcp.Tool{
+	addTool(m.server, &mcp.Tool{
 		Name:        "k8s_apply_yaml",
-		Description: "Apply K8s resources. Use 'dry_run: true' to validate YAML without creating resources.",
-		InputSchema: map[string]any{
-			"type": "object",
-			"properties": map[string]any{
-				"cluster_id":    map[string]any{"type": "string"},
-				"yaml_body":     map[string]any{"type": "string"},
-				"field_manager": map[string]any{"type": "string"},
-				"dry_run": map[string]any{
-					"type":        "boolean",
-					"description": "If true, only validate the object without persisting it.",
-				},
-			},
-			"required": []string{"cluster_id", "yaml_body"},
-		},
+		Description: "Apply K8s resources with server-side apply. Accepts multi-document YAML ('---' separated) and/or a file, directory or glob path under the server's manifest root. Objects are applied in dependency order (CRDs and Namespaces first) and a result is returned per object. Use 'dry_run: true' to validate YAML without creating resources.",
 	}, m.handleApplyYAML)
 
+	addTool(m.server, &mcp.Tool{
+		Name:        "k8s_diff_yaml",
+		Description: "Show what k8s_apply_yaml would change: performs a server-side dry-run apply and returns a unified diff against the live objects (managedFields, status and resourceVersion are ignored). Nothing is persisted.",
+	}, m.handleDiffYAML)
+
+	// Generic tools for any resource type, custom resources included.
+	addTool(m.server, &mcp.Tool{
+		Name:        "k8s_resource_get",
+		Description: "Get one object of any resource type, including custom resources, as YAML or JSON. managedFields are left out and Secret values are redacted.",
+	}, m.handleResourceGet)
+
+	addTool(m.server, &mcp.Tool{
+		Name:        "k8s_resource_list",
+		Description: "List the objects of any resource type, including custom resources, filtered by label and field selectors. Results are paged: pass the returned continue token to get the next page.",
+	}, m.handleResourceList)
+
+	addTool(m.server, &mcp.Tool{
+		Name:        "k8s_resource_delete",
+		Description: "Delete one object of any resource type, including custom resources. Requires confirmation unless dry_run is set.",
+	}, m.handleResourceDelete)
+
+	addTool(m.server, &mcp.Tool{
+		Name:        "k8s_api_resources",
+		Description: "List the resource types the cluster serves, custom resources included, with their API version, kind, short names, scope and verbs (like kubectl api-resources).",
+	}, m.handleAPIResources)
+
+	addTool(m.server, &mcp.Tool{
+		Name: "k8s_explain",
+		Description: "Describe a field of a resource type from the cluster's OpenAPI v3 schema: its type, whether it is required, its description and the fields below it (like kubectl explain). " +
+			"Use it to check field names before writing manifests for k8s_apply_yaml.",
+	}, m.handleExplain)
+
 	// 2. Tool Port Forward
-	mcp.AddTool(m.server, &mcp.Tool{
-		Name:        "k8s_port_forward",
-		Description: "Manage port forwarding. To STOP, call this tool with pod_name and cluster_id.",
-		InputSchema: map[string]any{
-			"type": "object",
-			"properties": map[string]any{
-				"cluster_id":  map[string]any{"type": "string"},
-				"namespace":   map[string]any{"type": "string"},
-				"pod_name":    map[string]any{"type": "string"},
-				"remote_port": map[string]any{"type": "number"},
-				"local_port":  map[string]any{"type": "number"},
-				"action":      map[string]any{"type": "string", "enum": []string{"start", "stop"}, "default": "start"},
-			},
-			"required": []string{"cluster_id", "namespace", "pod_name"},
-		},
+	addTool(m.server, &mcp.Tool{
+		Name: "k8s_port_forward",
+		Description: "Start or stop a port-forward to a pod, or to a ready pod behind a Service or Deployment. " +
+			"Tunnels are identified by <cluster>/<namespace>/<pod>/<local port>:<pod port> and close after the server's idle timeout or their TTL. " +
+			"To stop, pass the id from k8s_port_forward_list, or the same target with action=stop.",
 	}, m.handlePortForward)
+
+	addTool(m.server, &mcp.Tool{
+		Name:        "k8s_port_forward_list",
+		Description: "List active port-forwards with their local URL, target, connection count and traffic.",
+	}, m.handleListPortForwards)
+
+	addTool(m.server, &mcp.Tool{
+		Name: "k8s_pod_exec",
+		Description: "Run a diagnostic command in a container, without a shell, TTY or stdin, and return its exit code, stdout and stderr. " +
+			"Only commands permitted by the server's exec allowlist run, e.g. `cat /etc/resolv.conf`; output is truncated at the server's limit.",
+	}, m.handlePodExec)
 	//  tool k8s_webhook_mutating_get
-	mcp.AddTool(m.server, &mcp.Tool{
+	addTool(m.server, &mcp.Tool{
 		Name:        "k8s_webhook_mutating_get",
 		Description: "Get detailed configuration of a specific Mutating Webhook Configuration by name.",
-		InputSchema: map[string]any{
-			"type": "object",
-			"properties": map[string]any{
-				"cluster_id":   map[string]any{"type": "string", "description": "ID of the cluster"},
-				"webhook_name": map[string]any{"type": "string", "description": "Name of the Mutating Webhook Configuration."},
-			},
-			"required": []string{"cluster_id", "webhook_name"},
-		},
 	}, m.handleGetMutatingWebhook)
 
 	//  tool k8s_webhook_validating_get
-	mcp.AddTool(m.server, &mcp.Tool{
+	addTool(m.server, &mcp.Tool{
 		Name:        "k8s_webhook_validating_get",
 		Description: "Get detailed configuration of a specific Validating Webhook Configuration by name.",
-		InputSchema: map[string]any{
-			"type": "object",
-			"properties": map[string]any{
-				"cluster_id":   map[string]any{"type": "string", "description": "ID of the cluster"},
-				"webhook_name": map[string]any{"type": "string", "description": "Name of the Validating Webhook Configuration."},
-			},
-			"required": []string{"cluster_id", "webhook_name"},
-		},
 	}, m.handleGetValidatingWebhook)
 	//  tool k8s_webhook_mutating_list
-	mcp.AddTool(m.server, &mcp.Tool{
+	addTool(m.server, &mcp.Tool{
 		Name:        "k8s_webhook_mutating_list",
 		Description: "List all Mutating Webhook Configurations (used to change resources before validation) in the cluster.",
-		InputSchema: map[string]any{
-			"type": "object",
-			"properties": map[string]any{
-				"cluster_id": map[string]any{"type": "string", "description": "ID of the cluster"},
-			},
-			"required": []string{"cluster_id"},
-		},
 	}, m.handleListMutatingWebhooks)
 
 	//  tool k8s_webhook_validating_list
-	mcp.AddTool(m.server, &mcp.Tool{
+	addTool(m.server, &mcp.Tool{
 		Name:        "k8s_webhook_validating_list",
 		Description: "List all Validating Webhook Configurations (used to enforce policy rules) in the cluster.",
-		InputSchema: map[string]any{
-			"type": "object",
-			"properties": map[string]any{
-				"cluster_id": map[string]any{"type": "string", "description": "ID of the cluster"},
-			},
-			"required": []string{"cluster_id"},
-		},
 	}, m.handleListValidatingWebhooks)
 	//  tool k8s_rbac_clusterrole_list
-	mcp.AddTool(m.server, &mcp.Tool{
+	addTool(m.server, &mcp.Tool{
 		Name:        "k8s_rbac_clusterrole_list",
 		Description: "List all ClusterRoles (global authorization policies) in the cluster.",
-		InputSchema: map[string]any{
-			"type": "object",
-			"properties": map[string]any{
-				"cluster_id": map[string]any{"type": "string", "description": "ID of the cluster"},
-			},
-			"required": []string{"cluster_id"},
-		},
 	}, m.handleListClusterRoles)
 
 	//  tool k8s_event_list
-	mcp.AddTool(m.server, &mcp.Tool{
+	addTool(m.server, &mcp.Tool{
 		Name:        "k8s_event_list",
 		Description: "List recent events in a Kubernetes namespace, optionally filtered by a specific involved object (Pod, Deployment, etc.).",
-		InputSchema: map[string]any{
-			"type": "object",
-			"properties": map[string]any{
-				"cluster_id":    map[string]any{"type": "string", "description": "ID of the cluster"},
-				"namespace":     map[string]any{"type": "string", "description": "Namespace to list events from", "default": "default"},
-				"involved_kind": map[string]any{"type": "string", "description": "Optional: Kind of the object (e.g., 'Pod', 'Deployment') to filter events for."},
-				"involved_name": map[string]any{"type": "string", "description": "Optional: Name of the object to filter events for."},
-			},
-			"required": []string{"cluster_id"},
-		},
 	}, m.handleListEvents)
 
+	// tool k8s_events_for_workload
+	addTool(m.server, &mcp.Tool{
+		Name: "k8s_events_for_workload",
+		Description: "One event timeline for a workload: its own events merged with those of the ReplicaSets, Jobs and Pods it controls and of the PVCs and Services its pods use, " +
+			"sorted by last occurrence, oldest first.",
+	}, m.handleEventsForWorkload)
+
+	// tool k8s_events_watch
+	addTool(m.server, &mcp.Tool{
+		Name: "k8s_events_watch",
+		Description: "Watch for new events, Warning events by default, for a bounded time. " +
+			"Each event is sent as a progress notification (when the call has a progress token) and as a log message, and all events are returned when the watch stops: " +
+			"after duration_seconds, after max_events events or when the call is cancelled.",
+	}, m.handleEventsWatch)
+
 	// Register k8s_hpa_list tool
-	mcp.AddTool(m.server, &mcp.Tool{
+	addTool(m.server, &mcp.Tool{
 		Name:        "k8s_hpa_list",
 		Description: "List all Horizontal Pod Autoscalers (HPA) in a Kubernetes namespace",
-		InputSchema: map[string]any{
-			"type": "object",
-			"properties": map[string]any{
-				"cluster_id": map[string]any{"type": "string", "description": "ID of the cluster"},
-				"namespace":  map[string]any{"type": "string", "description": "Namespace to list HPAs from", "default": "default"},
-			},
-			"required": []string{"cluster_id", "namespace"},
-		},
 	}, m.handleListHPAs)
 
 	// Register k8s_hpa_get tool
-	mcp.AddTool(m.server, &mcp.Tool{
+	addTool(m.server, &mcp.Tool{
 		Name:        "k8s_hpa_get",
 		Description: "Get detailed information about a specific HPA, including current status and metrics.",
-		InputSchema: map[string]any{
-			"type": "object",
-			"properties": map[string]any{
-				"cluster_id": map[string]any{"type": "string", "description": "ID of the cluster"},
-				"namespace":  map[string]any{"type": "string", "description": "Namespace of the HPA", "default": "default"},
-				"hpa_name":   map[string]any{"type": "string", "description": "Name of the Horizontal Pod Autoscaler"},
-			},
-			"required": []string{"cluster_id", "namespace", "hpa_name"},
-		},
 	}, m.handleGetHPA)
 
 	// Register k8s_hpa_delete tool
-	mcp.AddTool(m.server, &mcp.Tool{
+	addTool(m.server, &mcp.Tool{
 		Name:        "k8s_hpa_delete",
 		Description: "Delete a Horizontal Pod Autoscaler (HPA) by name and namespace.",
-		InputSchema: map[string]any{
-			"type": "object",
-			"properties": map[string]any{
-				"cluster_id": map[string]any{"type": "string", "description": "ID of the cluster"},
-				"namespace":  map[string]any{"type": "string", "description": "Namespace of the HPA to delete", "default": "default"},
-				"hpa_name":   map[string]any{"type": "string", "description": "Name of the Horizontal Pod Autoscaler to delete"},
-			},
-			"required": []string{"cluster_id", "namespace", "hpa_name"},
-		},
 	}, m.handleDeleteHPA)
 	//  tool k8s_quota_list
-	mcp.AddTool(m.server, &mcp.Tool{
+	addTool(m.server, &mcp.Tool{
 		Name:        "k8s_quota_list",
 		Description: "List all ResourceQuotas in a Kubernetes namespace",
-		InputSchema: map[string]any{
-			"type": "object",
-			"properties": map[string]any{
-				"cluster_id": map[string]any{"type": "string", "description": "ID of the cluster"},
-				"namespace":  map[string]any{"type": "string", "description": "Namespace to list ResourceQuotas from", "default": "default"},
-			},
-			"required": []string{"cluster_id", "namespace"},
-		},
 	}, m.handleListResourceQuotas)
 
 	//  tool k8s_quota_get
-	mcp.AddTool(m.server, &mcp.Tool{
+	addTool(m.server, &mcp.Tool{
 		Name:        "k8s_quota_get",
 		Description: "Get detailed information about a specific ResourceQuota",
-		InputSchema: map[string]any{
-			"type": "object",
-			"properties": map[string]any{
-				"cluster_id": map[string]any{"type": "string", "description": "ID of the cluster"},
-				"namespace":  map[string]any{"type": "string", "description": "Namespace of the ResourceQuota", "default": "default"},
-				"quota_name": map[string]any{"type": "string", "description": "Name of the ResourceQuota"},
-			},
-			"required": []string{"cluster_id", "namespace", "quota_name"},
-		},
 	}, m.handleGetResourceQuota)
 
 	//  tool k8s_limitrange_list
-	mcp.AddTool(m.server, &mcp.Tool{
+	addTool(m.server, &mcp.Tool{
 		Name:        "k8s_limitrange_list",
 		Description: "List all LimitRanges in a Kubernetes namespace",
-		InputSchema: map[string]any{
-			"type": "object",
-			"properties": map[string]any{
-				"cluster_id": map[string]any{"type": "string", "description": "ID of the cluster"},
-				"namespace":  map[string]any{"type": "string", "description": "Namespace to list LimitRanges from", "default": "default"},
-			},
-			"required": []string{"cluster_id", "namespace"},
-		},
 	}, m.handleListLimitRanges)
 
 	//  tool k8s_limitrange_get
-	mcp.AddTool(m.server, &mcp.Tool{
+	addTool(m.server, &mcp.Tool{
 		Name:        "k8s_limitrange_get",
 		Description: "Get detailed information about a specific LimitRange",
-		InputSchema: map[string]any{
-			"type": "object",
-			"properties": map[string]any{
-				"cluster_id":       map[string]any{"type": "string", "description": "ID of the cluster"},
-				"namespace":        map[string]any{"type": "string", "description": "Namespace of the LimitRange", "default": "default"},
-				"limit_range_name": map[string]any{"type": "string", "description": "Name of the LimitRange"},
-			},
-			"required": []string{"cluster_id", "namespace", "limit_range_name"},
-		},
 	}, m.handleGetLimitRange)
 
 	// ==================== Node & Resource Tools ====================
 	// Đăng ký tool k8s_node_taint_apply
-	mcp.AddTool(m.server, &mcp.Tool{
+	addTool(m.server, &mcp.Tool{
 		Name:        "k8s_node_taint_apply",
 		Description: "Add or remove a Taint on a specific Kubernetes Node to control Pod scheduling (Taints must be in 'key=value:effect' format, e.g., 'gpu=true:NoSchedule').",
-		InputSchema: map[string]any{
-			"type": "object",
-			"properties": map[string]any{
-				"cluster_id": map[string]any{"type": "string", "description": "ID of the cluster."},
-				"node_name":  map[string]any{"type": "string", "description": "Name of the Node to modify."},
-				"taint_key":  map[string]any{"type": "string", "description": "The Taint to apply/remove, in 'key=value:Effect' or 'key:Effect' format (Effect: NoSchedule, PreferNoSchedule, or NoExecute)."},
-				"action":     map[string]any{"type": "string", "description": "Action to perform: 'add' or 'remove'."},
-			},
-			"required": []string{"cluster_id", "node_name", "taint_key", "action"},
-		},
 	}, m.handleApplyTaintToNode)
-	mcp.AddTool(m.server, &mcp.Tool{
+	addTool(m.server, &mcp.Tool{
 		Name:        "k8s_node_list",
 		Description: "List all Kubernetes nodes in the cluster and their basic status",
-		InputSchema: map[string]any{
-			"type": "object",
-			"properties": map[string]any{
-				"cluster_id": map[string]any{
-					"type":        "string",
-					"description": "ID of the cluster",
-				},
-				"namespace": map[string]any{
-					"type":        "string",
-					"description": "Namespace (mặc dù Node là tài nguyên Cluster-scoped, namespace vẫn có thể được dùng cho ngữ cảnh)",
-					"default":     "default",
-				},
-			},
-			"required": []string{"cluster_id"},
-		},
 	}, m.handleListNodes)
 
 	// register tool k8s_node_get_metrics
-	mcp.AddTool(m.server, &mcp.Tool{
+	addTool(m.server, &mcp.Tool{
 		Name:        "k8s_node_get_metrics",
-		Description: "Get resource capacity and allocatable metrics (CPU, Memory, Pods) for a specific node",
-		InputSchema: map[string]any{
-			"type": "object",
-			"properties": map[string]any{
-				"cluster_id": map[string]any{
-					"type":        "string",
-					"description": "ID of the cluster",
-				},
-				"node_name": map[string]any{
-					"type":        "string",
-					"description": "Name of the Node",
-				},
-			},
-			"required": []string{"cluster_id", "node_name"},
-		},
+		Description: "Get resource capacity and allocatable metrics (CPU, Memory, Pods) for a specific node, and its current CPU and memory usage when metrics-server is installed",
 	}, m.handleGetNodeMetrics)
+	// register tool k8s_top_nodes
+	addTool(m.server, &mcp.Tool{
+		Name: "k8s_top_nodes",
+		Description: "Show the current CPU and memory usage of every node from the metrics.k8s.io API (metrics-server), " +
+			"with usage and the requests of the node's pods as percentages of allocatable.",
+	}, m.handleTopNodes)
+
+	// register tool k8s_top_pods
+	addTool(m.server, &mcp.Tool{
+		Name: "k8s_top_pods",
+		Description: "Show the current CPU and memory usage of pods from the metrics.k8s.io API (metrics-server), per pod and per container, " +
+			"with usage as a percentage of requests and limits.",
+	}, m.handleTopPods)
+
 	// register tool k8s_job_list
-	mcp.AddTool(m.server, &mcp.Tool{
+	addTool(m.server, &mcp.Tool{
 		Name:        "k8s_job_list",
 		Description: "List all Jobs in a Kubernetes namespace",
-		InputSchema: map[string]any{
-			"type": "object",
-			"properties": map[string]any{
-				"cluster_id": map[string]any{
-					"type":        "string",
-					"description": "ID of the cluster",
-				},
-				"namespace": map[string]any{
-					"type":        "string",
-					"description": "Namespace to list Jobs from",
-					"default":     "default",
-				},
-			},
-			"required": []string{"cluster_id"},
-		},
 	}, m.handleListJobs)
 
 	// register tool k8s_job_get
-	mcp.AddTool(m.server, &mcp.Tool{
+	addTool(m.server, &mcp.Tool{
 		Name:        "k8s_job_get",
 		Description: "Get detailed information about a specific Job",
-		InputSchema: map[string]any{
-			"type": "object",
-			"properties": map[string]any{
-				"cluster_id": map[string]any{
-					"type":        "string",
-					"description": "ID of the cluster",
-				},
-				"namespace": map[string]any{
-					"type":        "string",
-					"description": "Namespace of the Job",
-					"default":     "default",
-				},
-				"job_name": map[string]any{
-					"type":        "string",
-					"description": "Name of the Job",
-				},
-			},
-			"required": []string{"cluster_id", "job_name"},
-		},
 	}, m.handleGetJob)
 
 	// register tool k8s_job_create
-	mcp.AddTool(m.server, &mcp.Tool{
+	addTool(m.server, &mcp.Tool{
 		Name:        "k8s_job_create",
 		Description: "Create a new Job in a Kubernetes namespace",
-		InputSchema: map[string]any{
-			"type": "object",
-			"properties": map[string]any{
-				"cluster_id": map[string]any{
-					"type":        "string",
-					"description": "ID of the cluster",
-				},
-				"namespace": map[string]any{
-					"type":        "string",
-					"description": "Namespace to create the Job in",
-					"default":     "default",
-				},
-				"job_name": map[string]any{
-					"type":        "string",
-					"description": "Name of the Job",
-				},
-				"image": map[string]any{
-					"type":        "string",
-					"description": "Container image to run",
-				},
-				"command": map[string]any{
-					"type":        "array",
-					"description": "Command to run (optional)",
-					"items":       map[string]any{"type": "string"},
-				},
-				"args": map[string]any{
-					"type":        "array",
-					"description": "Arguments for the command (optional)",
-					"items":       map[string]any{"type": "string"},
-				},
-				"completions": map[string]any{
-					"type":        "number",
-					"description": "Number of successful completions required",
-					"default":     1,
-				},
-				"parallelism": map[string]any{
-					"type":        "number",
-					"description": "Number of pods to run in parallel",
-					"default":     1,
-				},
-				"backoff_limit": map[string]any{
-					"type":        "number",
-					"description": "Number of retries before considering Job failed",
-					"default":     6,
-				},
-				"restart_policy": map[string]any{
-					"type":        "string",
-					"description": "Restart policy (OnFailure, Never)",
-					"default":     "OnFailure",
-				},
-				"env": map[string]any{
-					"type":        "object",
-					"description": "Environment variables",
-				},
-				"labels": map[string]any{
-					"type":        "object",
-					"description": "Labels to apply to the Job",
-				},
-			},
-			"required": []string{"cluster_id", "job_name", "image"},
-		},
 	}, m.handleCreateJob)
 
 	// register tool k8s_job_delete
-	mcp.AddTool(m.server, &mcp.Tool{
+	addTool(m.server, &mcp.Tool{
 		Name:        "k8s_job_delete",
 		Description: "Delete a Job from a Kubernetes namespace",
-		InputSchema: map[string]any{
-			"type": "object",
-			"properties": map[string]any{
-				"cluster_id": map[string]any{
-					"type":        "string",
-					"description": "ID of the cluster",
-				},
-				"namespace": map[string]any{
-					"type":        "string",
-					"description": "Namespace of the Job",
-					"default":     "default",
-				},
-				"job_name": map[string]any{
-					"type":        "string",
-					"description": "Name of the Job to delete",
-				},
-			},
-			"required": []string{"cluster_id", "job_name"},
-		},
 	}, m.handleDeleteJob)
 
 	// register tool k8s_job_get_logs
-	mcp.AddTool(m.server, &mcp.Tool{
-		Name:        "k8s_job_get_logs",
-		Description: "Get logs from a Job's pod",
-		InputSchema: map[string]any{
-			"type": "object",
-			"properties": map[string]any{
-				"cluster_id": map[string]any{
-					"type":        "string",
-					"description": "ID of the cluster",
-				},
-				"namespace": map[string]any{
-					"type":        "string",
-					"description": "Namespace of the Job",
-					"default":     "default",
-				},
-				"job_name": map[string]any{
-					"type":        "string",
-					"description": "Name of the Job",
-				},
-				"tail_lines": map[string]any{
-					"type":        "number",
-					"description": "Number of lines to tail",
-					"default":     100,
-				},
-			},
-			"required": []string{"cluster_id", "job_name"},
-		},
+	addTool(m.server, &mcp.Tool{
+		Name: "k8s_job_get_logs",
+		Description: "Get logs from every pod of a Job, including failed attempts and retries, grouped by pod and ordered by start time. " +
+			"Each pod shows its phase and each container its exit code and termination reason; failed attempts are marked.",
 	}, m.handleGetJobLogs)
 
 	// ==================== CronJob Tools ====================
 
 	// register tool k8s_cronjob_list
-	mcp.AddTool(m.server, &mcp.Tool{
+	addTool(m.server, &mcp.Tool{
 		Name:        "k8s_cronjob_list",
 		Description: "List all CronJobs in a Kubernetes namespace",
-		InputSchema: map[string]any{
-			"type": "object",
-			"properties": map[string]any{
-				"cluster_id": map[string]any{
-					"type":        "string",
-					"description": "ID of the cluster",
-				},
-				"namespace": map[string]any{
-					"type":        "string",
-					"description": "Namespace to list CronJobs from",
-					"default":     "default",
-				},
-			},
-			"required": []string{"cluster_id"},
-		},
 	}, m.handleListCronJobs)
 
 	// register tool k8s_cronjob_get
-	mcp.AddTool(m.server, &mcp.Tool{
+	addTool(m.server, &mcp.Tool{
 		Name:        "k8s_cronjob_get",
 		Description: "Get detailed information about a specific CronJob",
-		InputSchema: map[string]any{
-			"type": "object",
-			"properties": map[string]any{
-				"cluster_id": map[string]any{
-					"type":        "string",
-					"description": "ID of the cluster",
-				},
-				"namespace": map[string]any{
-					"type":        "string",
-					"description": "Namespace of the CronJob",
-					"default":     "default",
-				},
-				"cronjob_name": map[string]any{
-					"type":        "string",
-					"description": "Name of the CronJob",
-				},
-			},
-			"required": []string{"cluster_id", "cronjob_name"},
-		},
 	}, m.handleGetCronJob)
 
+	// register tool k8s_cronjob_get_logs
+	addTool(m.server, &mcp.Tool{
+		Name:        "k8s_cronjob_get_logs",
+		Description: "Get logs from the most recent Jobs spawned by a CronJob, newest Job first, each grouped by pod with exit codes and failed attempts marked",
+	}, m.handleGetCronJobLogs)
+
 	// register tool k8s_cronjob_create
-	mcp.AddTool(m.server, &mcp.Tool{
+	addTool(m.server, &mcp.Tool{
 		Name:        "k8s_cronjob_create",
 		Description: "Create a new CronJob in a Kubernetes namespace",
-		InputSchema: map[string]any{
-			"type": "object",
-			"properties": map[string]any{
-				"cluster_id": map[string]any{
-					"type":        "string",
-					"description": "ID of the cluster",
-				},
-				"namespace": map[string]any{
-					"type":        "string",
-					"description": "Namespace to create the CronJob in",
-					"default":     "default",
-				},
-				"cronjob_name": map[string]any{
-					"type":        "string",
-					"description": "Name of the CronJob",
-				},
-				"schedule": map[string]any{
-					"type":        "string",
-					"description": "Cron schedule expression (e.g., '0 * * * *' for hourly)",
-				},
-				"image": map[string]any{
-					"type":        "string",
-					"description": "Container image to run",
-				},
-				"command": map[string]any{
-					"type":        "array",
-					"description": "Command to run (optional)",
-					"items":       map[string]any{"type": "string"},
-				},
-				"args": map[string]any{
-					"type":        "array",
-					"description": "Arguments for the command (optional)",
-					"items":       map[string]any{"type": "string"},
-				},
-				"suspend": map[string]any{
-					"type":        "boolean",
-					"description": "Whether the CronJob is suspended",
-					"default":     false,
-				},
-				"concurrency_policy": map[string]any{
-					"type":        "string",
-					"description": "Allow, Forbid, or Replace",
-					"default":     "Allow",
-				},
-				"successful_jobs_history_limit": map[string]any{
-					"type":        "number",
-					"description": "Number of successful jobs to keep",
-					"default":     3,
-				},
-				"failed_jobs_history_limit": map[string]any{
-					"type":        "number",
-					"description": "Number of failed jobs to keep",
-					"default":     1,
-				},
-				"restart_policy": map[string]any{
-					"type":        "string",
-					"description": "Restart policy (OnFailure, Never)",
-					"default":     "OnFailure",
-				},
-				"env": map[string]any{
-					"type":        "object",
-					"description": "Environment variables",
-				},
-				"labels": map[string]any{
-					"type":        "object",
-					"description": "Labels to apply to the CronJob",
-				},
-			},
-			"required": []string{"cluster_id", "cronjob_name", "schedule", "image"},
-		},
 	}, m.handleCreateCronJob)
 
 	// register tool k8s_cronjob_delete
-	mcp.AddTool(m.server, &mcp.Tool{
+	addTool(m.server, &mcp.Tool{
 		Name:        "k8s_cronjob_delete",
 		Description: "Delete a CronJob from a Kubernetes namespace",
-		InputSchema: map[string]any{
-			"type": "object",
-			"properties": map[string]any{
-				"cluster_id": map[string]any{
-					"type":        "string",
-					"description": "ID of the cluster",
-				},
-				"namespace": map[string]any{
-					"type":        "string",
-					"description": "Namespace of the CronJob",
-					"default":     "default",
-				},
-				"cronjob_name": map[string]any{
-					"type":        "string",
-					"description": "Name of the CronJob to delete",
-				},
-			},
-			"required": []string{"cluster_id", "cronjob_name"},
-		},
 	}, m.handleDeleteCronJob)
 
 	// register tool k8s_cronjob_suspend
-	mcp.AddTool(m.server, &mcp.Tool{
+	addTool(m.server, &mcp.Tool{
 		Name:        "k8s_cronjob_suspend",
 		Description: "Suspend or resume a CronJob",
-		InputSchema: map[string]any{
-			"type": "object",
-			"properties": map[string]any{
-				"cluster_id": map[string]any{
-					"type":        "string",
-					"description": "ID of the cluster",
-				},
-				"namespace": map[string]any{
-					"type":        "string",
-					"description": "Namespace of the CronJob",
-					"default":     "default",
-				},
-				"cronjob_name": map[string]any{
-					"type":        "string",
-					"description": "Name of the CronJob",
-				},
-				"suspend": map[string]any{
-					"type":        "boolean",
-					"description": "True to suspend, false to resume",
-				},
-			},
-			"required": []string{"cluster_id", "cronjob_name", "suspend"},
-		},
 	}, m.handleSuspendCronJob)
 
 	// register tool k8s_cronjob_trigger
-	mcp.AddTool(m.server, &mcp.Tool{
+	addTool(m.server, &mcp.Tool{
 		Name:        "k8s_cronjob_trigger",
 		Description: "Manually trigger a CronJob to run immediately",
-		InputSchema: map[string]any{
-			"type": "object",
-			"properties": map[string]any{
-				"cluster_id": map[string]any{
-					"type":        "string",
-					"description": "ID of the cluster",
-				},
-				"namespace": map[string]any{
-					"type":        "string",
-					"description": "Namespace of the CronJob",
-					"default":     "default",
-				},
-				"cronjob_name": map[string]any{
-					"type":        "string",
-					"description": "Name of the CronJob to trigger",
-				},
-			},
-			"required": []string{"cluster_id", "cronjob_name"},
-		},
 	}, m.handleTriggerCronJob)
 
 	// register tool k8s_statefulset_list
-	mcp.AddTool(m.server, &mcp.Tool{
+	addTool(m.server, &mcp.Tool{
 		Name:        "k8s_statefulset_list",
 		Description: "List all StatefulSets in a Kubernetes namespace",
-		InputSchema: map[string]any{
-			"type": "object",
-			"properties": map[string]any{
-				"cluster_id": map[string]any{
-					"type":        "string",
-					"description": "ID of the cluster",
-				},
-				"namespace": map[string]any{
-					"type":        "string",
-					"description": "Namespace to list StatefulSets from",
-					"default":     "default",
-				},
-			},
-			"required": []string{"cluster_id"},
-		},
 	}, m.handleListStatefulSets)
 
 	// register tool k8s_statefulset_get
-	mcp.AddTool(m.server, &mcp.Tool{
+	addTool(m.server, &mcp.Tool{
 		Name:        "k8s_statefulset_get",
 		Description: "Get detailed information about a specific StatefulSet",
-		InputSchema: map[string]any{
-			"type": "object",
-			"properties": map[string]any{
-				"cluster_id": map[string]any{
-					"type":        "string",
-					"description": "ID of the cluster",
-				},
-				"namespace": map[string]any{
-					"type":        "string",
-					"description": "Namespace of the StatefulSet",
-					"default":     "default",
-				},
-				"statefulset_name": map[string]any{
-					"type":        "string",
-					"description": "Name of the StatefulSet",
-				},
-			},
-			"required": []string{"cluster_id", "statefulset_name"},
-		},
 	}, m.handleGetStatefulSet)
 
 	// register tool k8s_statefulset_scale
-	mcp.AddTool(m.server, &mcp.Tool{
+	addTool(m.server, &mcp.Tool{
 		Name:        "k8s_statefulset_scale",
 		Description: "Scale a StatefulSet to a specific number of replicas",
-		InputSchema: map[string]any{
-			"type": "object",
-			"properties": map[string]any{
-				"cluster_id": map[string]any{
-					"type":        "string",
-					"description": "ID of the cluster",
-				},
-				"namespace": map[string]any{
-					"type":        "string",
-					"description": "Namespace of the StatefulSet",
-					"default":     "default",
-				},
-				"statefulset_name": map[string]any{
-					"type":        "string",
-					"description": "Name of the StatefulSet",
-				},
-				"replicas": map[string]any{
-					"type":        "number",
-					"description": "Desired number of replicas",
-					"minimum":     0,
-				},
-			},
-			"required": []string{"cluster_id", "statefulset_name", "replicas"},
-		},
 	}, m.handleScaleStatefulSet)
 
 	// register tool k8s_statefulset_restart
-	mcp.AddTool(m.server, &mcp.Tool{
+	addTool(m.server, &mcp.Tool{
 		Name:        "k8s_statefulset_restart",
 		Description: "Restart a StatefulSet by adding a restart annotation",
-		InputSchema: map[string]any{
-			"type": "object",
-			"properties": map[string]any{
-				"cluster_id": map[string]any{
-					"type":        "string",
-					"description": "ID of the cluster",
-				},
-				"namespace": map[string]any{
-					"type":        "string",
-					"description": "Namespace of the StatefulSet",
-					"default":     "default",
-				},
-				"statefulset_name": map[string]any{
-					"type":        "string",
-					"description": "Name of the StatefulSet",
-				},
-			},
-			"required": []string{"cluster_id", "statefulset_name"},
-		},
 	}, m.handleRestartStatefulSet)
 
 	// register tool k8s_statefulset_delete
-	mcp.AddTool(m.server, &mcp.Tool{
+	addTool(m.server, &mcp.Tool{
 		Name:        "k8s_statefulset_delete",
 		Description: "Delete a StatefulSet from a Kubernetes namespace",
-		InputSchema: map[string]any{
-			"type": "object",
-			"properties": map[string]any{
-				"cluster_id": map[string]any{
-					"type":        "string",
-					"description": "ID of the cluster",
-				},
-				"namespace": map[string]any{
-					"type":        "string",
-					"description": "Namespace of the StatefulSet",
-					"default":     "default",
-				},
-				"statefulset_name": map[string]any{
-					"type":        "string",
-					"description": "Name of the StatefulSet to delete",
-				},
-			},
-			"required": []string{"cluster_id", "statefulset_name"},
-		},
 	}, m.handleDeleteStatefulSet)
 
 	// ==================== DaemonSet Tools ====================
 
 	// register tool k8s_daemonset_list
-	mcp.AddTool(m.server, &mcp.Tool{
+	addTool(m.server, &mcp.Tool{
 		Name:        "k8s_daemonset_list",
 		Description: "List all DaemonSets in a Kubernetes namespace",
-		InputSchema: map[string]any{
-			"type": "object",
-			"properties": map[string]any{
-				"cluster_id": map[string]any{
-					"type":        "string",
-					"description": "ID of the cluster",
-				},
-				"namespace": map[string]any{
-					"type":        "string",
-					"description": "Namespace to list DaemonSets from",
-					"default":     "default",
-				},
-			},
-			"required": []string{"cluster_id"},
-		},
 	}, m.handleListDaemonSets)
 
 	// register tool k8s_daemonset_get
-	mcp.AddTool(m.server, &mcp.Tool{
+	addTool(m.server, &mcp.Tool{
 		Name:        "k8s_daemonset_get",
 		Description: "Get detailed information about a specific DaemonSet",
-		InputSchema: map[string]any{
-			"type": "object",
-			"properties": map[string]any{
-				"cluster_id": map[string]any{
-					"type":        "string",
-					"description": "ID of the cluster",
-				},
-				"namespace": map[string]any{
-					"type":        "string",
-					"description": "Namespace of the DaemonSet",
-					"default":     "default",
-				},
-				"daemonset_name": map[string]any{
-					"type":        "string",
-					"description": "Name of the DaemonSet",
-				},
-			},
-			"required": []string{"cluster_id", "daemonset_name"},
-		},
 	}, m.handleGetDaemonSet)
 
 	// register tool k8s_daemonset_restart
-	mcp.AddTool(m.server, &mcp.Tool{
+	addTool(m.server, &mcp.Tool{
 		Name:        "k8s_daemonset_restart",
 		Description: "Restart a DaemonSet by adding a restart annotation",
-		InputSchema: map[string]any{
-			"type": "object",
-			"properties": map[string]any{
-				"cluster_id": map[string]any{
-					"type":        "string",
-					"description": "ID of the cluster",
-				},
-				"namespace": map[string]any{
-					"type":        "string",
-					"description": "Namespace of the DaemonSet",
-					"default":     "default",
-				},
-				"daemonset_name": map[string]any{
-					"type":        "string",
-					"description": "Name of the DaemonSet",
-				},
-			},
-			"required": []string{"cluster_id", "daemonset_name"},
-		},
 	}, m.handleRestartDaemonSet)
 
 	// register tool k8s_daemonset_delete
-	mcp.AddTool(m.server, &mcp.Tool{
+	addTool(m.server, &mcp.Tool{
 		Name:        "k8s_daemonset_delete",
 		Description: "Delete a DaemonSet from a Kubernetes namespace",
-		InputSchema: map[string]any{
-			"type": "object",
-			"properties": map[string]any{
-				"cluster_id": map[string]any{
-					"type":        "string",
-					"description": "ID of the cluster",
-				},
-				"namespace": map[string]any{
-					"type":        "string",
-					"description": "Namespace of the DaemonSet",
-					"default":     "default",
-				},
-				"daemonset_name": map[string]any{
-					"type":        "string",
-					"description": "Name of the DaemonSet to delete",
-				},
-			},
-			"required": []string{"cluster_id", "daemonset_name"},
-		},
 	}, m.handleDeleteDaemonSet)
 
 	// register tool k8s_daemonset_get_pods
-	mcp.AddTool(m.server, &mcp.Tool{
+	addTool(m.server, &mcp.Tool{
 		Name:        "k8s_daemonset_get_pods",
 		Description: "Get all pods managed by a DaemonSet",
-		InputSchema: map[string]any{
-			"type": "object",
-			"properties": map[string]any{
-				"cluster_id": map[string]any{
-					"type":        "string",
-					"description": "ID of the cluster",
-				},
-				"namespace": map[string]any{
-					"type":        "string",
-					"description": "Namespace of the DaemonSet",
-					"default":     "default",
-				},
-				"daemonset_name": map[string]any{
-					"type":        "string",
-					"description": "Name of the DaemonSet",
-				},
-			},
-			"required": []string{"cluster_id", "daemonset_name"},
-		},
 	}, m.handleGetDaemonSetPods)
 	// register tool k8s_configmap_list
-	mcp.AddTool(m.server, &mcp.Tool{
+	addTool(m.server, &mcp.Tool{
 		Name:        "k8s_configmap_list",
 		Description: "List all ConfigMaps in a Kubernetes namespace",
-		InputSchema: map[string]any{
-			"type": "object",
-			"properties": map[string]any{
-				"cluster_id": map[string]any{
-					"type":        "string",
-					"description": "ID of the cluster",
-				},
-				"namespace": map[string]any{
-					"type":        "string",
-					"description": "Namespace to list ConfigMaps from",
-					"default":     "default",
-				},
-			},
-			"required": []string{"cluster_id"},
-		},
 	}, m.handleListConfigMaps)
 
 	// register tool k8s_configmap_get
-	mcp.AddTool(m.server, &mcp.Tool{
+	addTool(m.server, &mcp.Tool{
 		Name:        "k8s_configmap_get",
 		Description: "Get detailed information about a specific ConfigMap",
-		InputSchema: map[string]any{
-			"type": "object",
-			"properties": map[string]any{
-				"cluster_id": map[string]any{
-					"type":        "string",
-					"description": "ID of the cluster",
-				},
-				"namespace": map[string]any{
-					"type":        "string",
-					"description": "Namespace of the ConfigMap",
-					"default":     "default",
-				},
-				"configmap_name": map[string]any{
-					"type":        "string",
-					"description": "Name of the ConfigMap",
-				},
-			},
-			"required": []string{"cluster_id", "configmap_name"},
-		},
 	}, m.handleGetConfigMap)
 
 	// register tool k8s_configmap_create
-	mcp.AddTool(m.server, &mcp.Tool{
+	addTool(m.server, &mcp.Tool{
 		Name:        "k8s_configmap_create",
 		Description: "Create a new ConfigMap in a Kubernetes namespace",
-		InputSchema: map[string]any{
-			"type": "object",
-			"properties": map[string]any{
-				"cluster_id": map[string]any{
-					"type":        "string",
-					"description": "ID of the cluster",
-				},
-				"namespace": map[string]any{
-					"type":        "string",
-					"description": "Namespace to create the ConfigMap in",
-					"default":     "default",
-				},
-				"configmap_name": map[string]any{
-					"type":        "string",
-					"description": "Name of the ConfigMap",
-				},
-				"data": map[string]any{
-					"type":        "object",
-					"description": "Key-value pairs for the ConfigMap data",
-				},
-				"labels": map[string]any{
-					"type":        "object",
-					"description": "Labels to apply to the ConfigMap",
-				},
-			},
-			"required": []string{"cluster_id", "configmap_name", "data"},
-		},
 	}, m.handleCreateConfigMap)
 
 	// register tool k8s_configmap_delete
-	mcp.AddTool(m.server, &mcp.Tool{
+	addTool(m.server, &mcp.Tool{
 		Name:        "k8s_configmap_delete",
 		Description: "Delete a ConfigMap from a Kubernetes namespace",
-		InputSchema: map[string]any{
-			"type": "object",
-			"properties": map[string]any{
-				"cluster_id": map[string]any{
-					"type":        "string",
-					"description": "ID of the cluster",
-				},
-				"namespace": map[string]any{
-					"type":        "string",
-					"description": "Namespace of the ConfigMap",
-					"default":     "default",
-				},
-				"configmap_name": map[string]any{
-					"type":        "string",
-					"description": "Name of the ConfigMap to delete",
-				},
-			},
-			"required": []string{"cluster_id", "configmap_name"},
-		},
 	}, m.handleDeleteConfigMap)
 
 	// ==================== Secret Tools ====================
 
 	// register tool k8s_secret_list
-	mcp.AddTool(m.server, &mcp.Tool{
+	addTool(m.server, &mcp.Tool{
 		Name:        "k8s_secret_list",
 		Description: "List all Secrets in a Kubernetes namespace",
-		InputSchema: map[string]any{
-			"type": "object",
-			"properties": map[string]any{
-				"cluster_id": map[string]any{
-					"type":        "string",
-					"description": "ID of the cluster",
-				},
-				"namespace": map[string]any{
-					"type":        "string",
-					"description": "Namespace to list Secrets from",
-					"default":     "default",
-				},
-			},
-			"required": []string{"cluster_id"},
-		},
 	}, m.handleListSecrets)
 
 	// register tool k8s_secret_get
-	mcp.AddTool(m.server, &mcp.Tool{
+	addTool(m.server, &mcp.Tool{
 		Name:        "k8s_secret_get",
 		Description: "Get information about a specific Secret (keys only, not values)",
-		InputSchema: map[string]any{
-			"type": "object",
-			"properties": map[string]any{
-				"cluster_id": map[string]any{
-					"type":        "string",
-					"description": "ID of the cluster",
-				},
-				"namespace": map[string]any{
-					"type":        "string",
-					"description": "Namespace of the Secret",
-					"default":     "default",
-				},
-				"secret_name": map[string]any{
-					"type":        "string",
-					"description": "Name of the Secret",
-				},
-			},
-			"required": []string{"cluster_id", "secret_name"},
-		},
 	}, m.handleGetSecret)
 
 	// register tool k8s_secret_create
-	mcp.AddTool(m.server, &mcp.Tool{
+	addTool(m.server, &mcp.Tool{
 		Name:        "k8s_secret_create",
 		Description: "Create a new Secret in a Kubernetes namespace",
-		InputSchema: map[string]any{
-			"type": "object",
-			"properties": map[string]any{
-				"cluster_id": map[string]any{
-					"type":        "string",
-					"description": "ID of the cluster",
-				},
-				"namespace": map[string]any{
-					"type":        "string",
-					"description": "Namespace to create the Secret in",
-					"default":     "default",
-				},
-				"secret_name": map[string]any{
-					"type":        "string",
-					"description": "Name of the Secret",
-				},
-				"type": map[string]any{
-					"type":        "string",
-					"description": "Type of the Secret (Opaque, kubernetes.io/tls, etc.)",
-					"default":     "Opaque",
-				},
-				"string_data": map[string]any{
-					"type":        "object",
-					"description": "Key-value pairs for the Secret data (will be base64 encoded)",
-				},
-				"labels": map[string]any{
-					"type":        "object",
-					"description": "Labels to apply to the Secret",
-				},
-			},
-			"required": []string{"cluster_id", "secret_name", "string_data"},
-		},
 	}, m.handleCreateSecret)
 
 	// register tool k8s_secret_delete
-	mcp.AddTool(m.server, &mcp.Tool{
+	addTool(m.server, &mcp.Tool{
 		Name:        "k8s_secret_delete",
 		Description: "Delete a Secret from a Kubernetes namespace",
-		InputSchema: map[string]any{
-			"type": "object",
-			"properties": map[string]any{
-				"cluster_id": map[string]any{
-					"type":        "string",
-					"description": "ID of the cluster",
-				},
-				"namespace": map[string]any{
-					"type":        "string",
-					"description": "Namespace of the Secret",
-					"default":     "default",
-				},
-				"secret_name": map[string]any{
-					"type":        "string",
-					"description": "Name of the Secret to delete",
-				},
-			},
-			"required": []string{"cluster_id", "secret_name"},
-		},
 	}, m.handleDeleteSecret)
 
 	// register tool k8s_service_list
-	mcp.AddTool(m.server, &mcp.Tool{
+	addTool(m.server, &mcp.Tool{
 		Name:        "k8s_service_list",
 		Description: "List all services in a Kubernetes namespace",
-		InputSchema: map[string]any{
-			"type": "object",
-			"properties": map[string]any{
-				"cluster_id": map[string]any{
-					"type":        "string",
-					"description": "ID of the cluster",
-				},
-				"namespace": map[string]any{
-					"type":        "string",
-					"description": "Namespace to list services from",
-					"default":     "default",
-				},
-			},
-			"required": []string{"cluster_id"},
-		},
 	}, m.handleListServices)
-	mcp.AddTool(m.server, &mcp.Tool{
+	addTool(m.server, &mcp.Tool{
 		Name:        "k8s_service_get",
 		Description: "Get detailed information about a Kubernetes service",
-		InputSchema: map[string]any{
-			"type": "object",
-			"properties": map[string]any{
-				"cluster_id": map[string]any{
-					"type":        "string",
-					"description": "ID of the cluster",
-				},
-				"namespace": map[string]any{
-					"type":        "string",
-					"description": "Namespace of the service",
-					"default":     "default",
-				},
-				"service_name": map[string]any{
-					"type":        "string",
-					"description": "Name of the service",
-				},
-			},
-			"required": []string{"cluster_id", "service_name"},
-		},
 	}, m.handleGetService)
-	mcp.AddTool(m.server, &mcp.Tool{
+	addTool(m.server, &mcp.Tool{
 		Name:        "k8s_service_delete",
 		Description: "Delete a Kubernetes service by name and namespace",
-		InputSchema: map[string]any{
-			"type": "object",
-			"properties": map[string]any{
-				"cluster_id": map[string]any{
-					"type":        "string",
-					"description": "ID of the cluster",
-				},
-				"namespace": map[string]any{
-					"type":        "string",
-					"description": "Namespace of the service",
-					"default":     "default",
-				},
-				"service_name": map[string]any{
-					"type":        "string",
-					"description": "Name of the service to delete",
-				},
-			},
-			"required": []string{"cluster_id", "service_name"},
-		},
 	}, m.handleDeleteService)
 
 	// --- Ingress Tools ---
 
 	//  register tool k8s_ingress_list
-	mcp.AddTool(m.server, &mcp.Tool{
+	addTool(m.server, &mcp.Tool{
 		Name:        "k8s_ingress_list",
 		Description: "List all ingresses in a Kubernetes namespace",
-		InputSchema: map[string]any{
-			"type": "object",
-			"properties": map[string]any{
-				"cluster_id": map[string]any{
-					"type":        "string",
-					"description": "ID of the cluster",
-				},
-				"namespace": map[string]any{
-					"type":        "string",
-					"description": "Namespace to list ingresses from",
-					"default":     "default",
-				},
-			},
-			"required": []string{"cluster_id"},
-		},
 	}, m.handleListIngresses)
 
 	//  register tool k8s_ingress_get
-	mcp.AddTool(m.server, &mcp.Tool{
+	addTool(m.server, &mcp.Tool{
 		Name:        "k8s_ingress_get",
 		Description: "Get detailed information about a Kubernetes ingress",
-		InputSchema: map[string]any{
-			"type": "object",
-			"properties": map[string]any{
-				"cluster_id": map[string]any{
-					"type":        "string",
-					"description": "ID of the cluster",
-				},
-				"namespace": map[string]any{
-					"type":        "string",
-					"description": "Namespace of the ingress",
-					"default":     "default",
-				},
-				"ingress_name": map[string]any{
-					"type":        "string",
-					"description": "Name of the ingress",
-				},
-			},
-			"required": []string{"cluster_id", "ingress_name"},
-		},
 	}, m.handleGetIngress)
 
 	//  register tool k8s_ingress_delete
-	mcp.AddTool(m.server, &mcp.Tool{
+	addTool(m.server, &mcp.Tool{
 		Name:        "k8s_ingress_delete",
 		Description: "Delete a Kubernetes ingress by name and namespace",
-		InputSchema: map[string]any{
-			"type": "object",
-			"properties": map[string]any{
-				"cluster_id": map[string]any{
-					"type":        "string",
-					"description": "ID of the cluster",
-				},
-				"namespace": map[string]any{
-					"type":        "string",
-					"description": "Namespace of the ingress",
-					"default":     "default",
-				},
-				"ingress_name": map[string]any{
-					"type":        "string",
-					"description": "Name of the ingress to delete",
-				},
-			},
-			"required": []string{"cluster_id", "ingress_name"},
-		},
 	}, m.handleDeleteIngress)
 	// register tool k8s_cluster_register
-	mcp.AddTool(m.server, &mcp.Tool{
+	addTool(m.server, &mcp.Tool{
 		Name:        "k8s_cluster_register",
 		Description: "Register a new Kubernetes cluster with kubeconfig",
-		InputSchema: map[string]any{
-			"type": "object",
-			"properties": map[string]any{
-				"cluster_id": map[string]any{
-					"type":        "string",
-					"description": "Unique identifier for the cluster",
-				},
-				"kubeconfig_path": map[string]any{
-					"type":        "string",
-					"description": "Path to kubeconfig file",
-				},
-				"kubeconfig_data": map[string]any{
-					"type":        "string",
-					"description": "Base64 encoded kubeconfig data",
-				},
-				"context": map[string]any{
-					"type":        "string",
-					"description": "Kubernetes context to use",
-				},
-				"in_cluster": map[string]any{
-					"type":        "boolean",
-					"description": "Use in-cluster config",
-					"default":     false,
-				},
-			},
-			"required": []string{"cluster_id"},
-		},
 	}, m.handleClusterRegister)
 
+	// register tool k8s_cluster_list
+	addTool(m.server, &mcp.Tool{
+		Name:        "k8s_cluster_list",
+		Description: "List registered Kubernetes clusters and their connection status. Kubeconfig data is never returned.",
+	}, m.handleClusterList)
+
+	// register tool k8s_cluster_status
+	addTool(m.server, &mcp.Tool{
+		Name:        "k8s_cluster_status",
+		Description: "Check connectivity to a registered Kubernetes cluster",
+	}, m.handleClusterStatus)
+
+	// register tool k8s_cluster_unregister
+	addTool(m.server, &mcp.Tool{
+		Name:        "k8s_cluster_unregister",
+		Description: "Unregister a Kubernetes cluster and remove it from the persisted registry",
+	}, m.handleClusterUnregister)
+
 	// register tool k8s_pod_get_logs
-	mcp.AddTool(m.server, &mcp.Tool{
+	addTool(m.server, &mcp.Tool{
 		Name:        "k8s_pod_get_logs",
-		Description: "Get logs from a pod in a Kubernetes cluster",
-		InputSchema: map[string]any{
-			"type": "object",
-			"properties": map[string]any{
-				"cluster_id": map[string]any{
-					"type":        "string",
-					"description": "ID of the cluster",
-				},
-				"namespace": map[string]any{
-					"type":        "string",
-					"description": "Namespace of the pod",
-					"default":     "default",
-				},
-				"pod_name": map[string]any{
-					"type":        "string",
-					"description": "Name of the pod",
-				},
-				"tail_lines": map[string]any{
-					"type":        "number",
-					"description": "Number of lines to tail",
-					"default":     100,
-				},
-			},
-			"required": []string{"cluster_id", "pod_name"},
-		},
+		Description: "Get logs from a pod in a Kubernetes cluster, optionally from a given or previous container, within a time window and filtered by a regular expression",
 	}, m.handleGetPodLogs)
 
+	// register tool k8s_pod_logs_follow
+	addTool(m.server, &mcp.Tool{
+		Name: "k8s_pod_logs_follow",
+		Description: "Follow the logs of a pod container live, e.g. while a rollout starts. " +
+			"Each new line is sent as a progress notification (when the call has a progress token) and as a log message, and all lines are returned when following stops: " +
+			"after duration_seconds, after max_lines lines, when the container exits or when the call is cancelled.",
+	}, m.handlePodLogsFollow)
+
+	// register tool k8s_pod_diagnose
+	addTool(m.server, &mcp.Tool{
+		Name: "k8s_pod_diagnose",
+		Description: "Diagnose why a pod is unhealthy. Gathers container states and last terminations, the previous logs of restarted containers, the pod's events, " +
+			"its node's conditions and allocatable resources, the binding of its PVCs and the namespace quotas, and returns the detected problems " +
+			"(e.g. CrashLoopBackOff, OOMKilled, ImagePullBackOff, Unschedulable, unbound PVCs) with their likely causes.",
+	}, m.handlePodDiagnose)
+
+	// register tool k8s_logs_by_selector
+	addTool(m.server, &mcp.Tool{
+		Name: "k8s_logs_by_selector",
+		Description: "Aggregate logs from every pod matching a label selector or selected by a workload, interleaved by timestamp and prefixed with [pod/container]. " +
+			"tail_lines and limit_bytes apply per container.",
+	}, m.handleLogsBySelector)
+
+	// register tool k8s_logs_export
+	addTool(m.server, &mcp.Tool{
+		Name: "k8s_logs_export",
+		Description: "Export logs of the pods in a namespace, optionally narrowed by a label selector or workload and a time window, into a tar.gz or zip archive on the server's disk. " +
+			"Returns a resource link to the archive and a per-container summary of line and error counts instead of the log text. " +
+			"Whole logs are exported unless tail_lines is set.",
+	}, m.handleLogsExport)
+
 	// register tool k8s_deployment_scale
-	mcp.AddTool(m.server, &mcp.Tool{
+	addTool(m.server, &mcp.Tool{
 		Name:        "k8s_deployment_scale",
 		Description: "Scale a deployment in a Kubernetes cluster",
-		InputSchema: map[string]any{
-			"type": "object",
-			"properties": map[string]any{
-				"cluster_id": map[string]any{
-					"type":        "string",
-					"description": "ID of the cluster",
-				},
-				"namespace": map[string]any{
-					"type":        "string",
-					"description": "Namespace of the deployment",
-					"default":     "default",
-				},
-				"deployment_name": map[string]any{
-					"type":        "string",
-					"description": "Name of the deployment",
-				},
-				"replicas": map[string]any{
-					"type":        "number",
-					"description": "Number of replicas",
-					"minimum":     0,
-				},
-			},
-			"required": []string{"cluster_id", "deployment_name", "replicas"},
-		},
 	}, m.handleScaleDeployment)
 
 	// register tool k8s_pod_list
-	mcp.AddTool(m.server, &mcp.Tool{
+	addTool(m.server, &mcp.Tool{
 		Name:        "k8s_pod_list",
 		Description: "List pods in a Kubernetes namespace",
-		InputSchema: map[string]any{
-			"type": "object",
-			"properties": map[string]any{
-				"cluster_id": map[string]any{
-					"type":        "string",
-					"description": "ID of the cluster",
-				},
-				"namespace": map[string]any{
-					"type":        "string",
-					"description": "Namespace to list pods from",
-					"default":     "default",
-				},
-			},
-			"required": []string{"cluster_id"},
-		},
 	}, m.handleListPods)
 
 	// register tool k8s_deployment_get_info
-	mcp.AddTool(m.server, &mcp.Tool{
+	addTool(m.server, &mcp.Tool{
 		Name:        "k8s_deployment_get_info",
 		Description: "Get detailed information about a Kubernetes deployment",
-		InputSchema: map[string]any{
-			"type": "object",
-			"properties": map[string]any{
-				"cluster_id": map[string]any{
-					"type":        "string",
-					"description": "ID of the cluster",
-				},
-				"namespace": map[string]any{
-					"type":        "string",
-					"description": "Namespace of the deployment",
-					"default":     "default",
-				},
-				"deployment_name": map[string]any{
-					"type":        "string",
-					"description": "Name of the deployment",
-				},
-			},
-			"required": []string{"cluster_id", "deployment_name"},
-		},
 	}, m.handleGetDeploymentInfo)
 
+	// register tool k8s_deployment_list
+	addTool(m.server, &mcp.Tool{
+		Name:        "k8s_deployment_list",
+		Description: "List Deployments in a Kubernetes namespace with their replica counts, revision and paused state",
+	}, m.handleListDeployments)
+
+	// register tool k8s_deployment_restart
+	addTool(m.server, &mcp.Tool{
+		Name:        "k8s_deployment_restart",
+		Description: "Restart a Deployment by adding a restart annotation to its pod template, like 'kubectl rollout restart'",
+	}, m.handleRestartDeployment)
+
+	// register tool k8s_deployment_rollout_status
+	addTool(m.server, &mcp.Tool{
+		Name:        "k8s_deployment_rollout_status",
+		Description: "Wait until the rollout of a Deployment completes, is paused or exceeds its progress deadline, like 'kubectl rollout status'. Returns the current progress when the timeout is reached.",
+	}, m.handleDeploymentRolloutStatus)
+
+	// register tool k8s_deployment_history
+	addTool(m.server, &mcp.Tool{
+		Name:        "k8s_deployment_history",
+		Description: "Show the rollout history of a Deployment: one entry per ReplicaSet revision with its change-cause and the image changes to the previous revision",
+	}, m.handleDeploymentHistory)
+
+	// register tool k8s_deployment_undo
+	addTool(m.server, &mcp.Tool{
+		Name:        "k8s_deployment_undo",
+		Description: "Roll a Deployment back to a previous revision, like 'kubectl rollout undo'",
+	}, m.handleUndoDeployment)
+
+	// register tool k8s_deployment_pause
+	addTool(m.server, &mcp.Tool{
+		Name:        "k8s_deployment_pause",
+		Description: "Pause the rollouts of a Deployment so pod template changes accumulate without being rolled out",
+	}, m.handlePauseDeployment)
+
+	// register tool k8s_deployment_resume
+	addTool(m.server, &mcp.Tool{
+		Name:        "k8s_deployment_resume",
+		Description: "Resume the rollouts of a paused Deployment",
+	}, m.handleResumeDeployment)
+
+	// register tool k8s_workload_set_image
+	addTool(m.server, &mcp.Tool{
+		Name:        "k8s_workload_set_image",
+		Description: "Change container images of a Deployment, StatefulSet, DaemonSet or CronJob template with a strategic merge patch, like 'kubectl set image'. Returns the revision of the resulting rollout.",
+	}, m.handleSetWorkloadImage)
+
+	// register tool k8s_workload_set_env
+	addTool(m.server, &mcp.Tool{
+		Name:        "k8s_workload_set_env",
+		Description: "Set or remove environment variables of a Deployment, StatefulSet, DaemonSet or CronJob template, as literal values, single keys of a ConfigMap or Secret, or whole ConfigMaps/Secrets via envFrom. Returns the revision of the resulting rollout.",
+	}, m.handleSetWorkloadEnv)
+
+	// register tool k8s_workload_set_resources
+	addTool(m.server, &mcp.Tool{
+		Name:        "k8s_workload_set_resources",
+		Description: "Set CPU/memory requests and limits of a Deployment, StatefulSet, DaemonSet or CronJob template with a strategic merge patch, like 'kubectl set resources'. Returns the revision of the resulting rollout.",
+	}, m.handleSetWorkloadResources)
+
 	// register tool k8s_namespace_list
-	mcp.AddTool(m.server, &mcp.Tool{
+	addTool(m.server, &mcp.Tool{
 		Name:        "k8s_namespace_list",
 		Description: "List all namespaces in a Kubernetes cluster",
-		InputSchema: map[string]any{
-			"type": "object",
-			"properties": map[string]any{
-				"cluster_id": map[string]any{
-					"type":        "string",
-					"description": "ID of the cluster",
-				},
-			},
-			"required": []string{"cluster_id"},
-		},
 	}, m.handleListNamespaces)
 
 	// register tool k8s_namespace_get
-	mcp.AddTool(m.server, &mcp.Tool{
+	addTool(m.server, &mcp.Tool{
 		Name:        "k8s_namespace_get",
 		Description: "Get detailed information about a specific namespace",
-		InputSchema: map[string]any{
-			"type": "object",
-			"properties": map[string]any{
-				"cluster_id": map[string]any{
-					"type":        "string",
-					"description": "ID of the cluster",
-				},
-				"namespace": map[string]any{
-					"type":        "string",
-					"description": "Name of the namespace",
-				},
-			},
-			"required": []string{"cluster_id", "namespace"},
-		},
 	}, m.handleGetNamespace)
 
 	// register tool k8s_namespace_create
-	mcp.AddTool(m.server, &mcp.Tool{
+	addTool(m.server, &mcp.Tool{
 		Name:        "k8s_namespace_create",
 		Description: "Create a new namespace in a Kubernetes cluster",
-		InputSchema: map[string]any{
-			"type": "object",
-			"properties": map[string]any{
-				"cluster_id": map[string]any{
-					"type":        "string",
-					"description": "ID of the cluster",
-				},
-				"namespace": map[string]any{
-					"type":        "string",
-					"description": "Name of the namespace to create",
-				},
-			},
-			"required": []string{"cluster_id", "namespace"},
-		},
 	}, m.handleCreateNamespace)
 
 	// register tool k8s_namespace_delete
-	mcp.AddTool(m.server, &mcp.Tool{
+	addTool(m.server, &mcp.Tool{
 		Name:        "k8s_namespace_delete",
 		Description: "Delete a namespace from a Kubernetes cluster",
-		InputSchema: map[string]any{
-			"type": "object",
-			"properties": map[string]any{
-				"cluster_id": map[string]any{
-					"type":        "string",
-					"description": "ID of the cluster",
-				},
-				"namespace": map[string]any{
-					"type":        "string",
-					"description": "Name of the namespace to delete",
-				},
-			},
-			"required": []string{"cluster_id", "namespace"},
-		},
 	}, m.handleDeleteNamespace)
 
 	// register tool k8s_persistentvolume_list
-	mcp.AddTool(m.server, &mcp.Tool{
+	addTool(m.server, &mcp.Tool{
 		Name:        "k8s_persistentvolume_list",
 		Description: "List all PersistentVolumes in a Kubernetes cluster",
-		InputSchema: map[string]any{
-			"type": "object",
-			"properties": map[string]any{
-				"cluster_id": map[string]any{
-					"type":        "string",
-					"description": "ID of the cluster",
-				},
-			},
-			"required": []string{"cluster_id"},
-		},
 	}, m.handleListPersistentVolumes)
 
 	// register tool k8s_storageclass_list
-	mcp.AddTool(m.server, &mcp.Tool{
+	addTool(m.server, &mcp.Tool{
 		Name:        "k8s_storageclass_list",
 		Description: "List all StorageClasses in a Kubernetes cluster",
-		InputSchema: map[string]any{
-			"type": "object",
-			"properties": map[string]any{
-				"cluster_id": map[string]any{
-					"type":        "string",
-					"description": "ID of the cluster",
-				},
-			},
-			"required": []string{"cluster_id"},
-		},
 	}, m.handleListStorageClasses)
+
+	// register tool k8s_audit_query when auditing is enabled
+	if m.auditUC != nil {
+		addTool(m.server, &mcp.Tool{
+			Name:        "k8s_audit_query",
+			Description: "Search recent entries of the audit log of tool invocations, newest first. Arguments of each call are stored with secrets redacted; mutating calls include the resourceVersion of the touched objects before and after the call.",
+		}, m.handleAuditQuery)
+	}
 }
 
-func (m *MCPServer) handleClusterRegister(ctx context.Context, req *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
-	m.logger.Info("Handling cluster register request", "args", args)
+type clusterRegisterArgs struct {
+	ClusterID      string `json:"cluster_id" jsonschema:"Unique identifier for the cluster: lowercase letters, digits and '-', e.g. prod-eu-1"`
+	KubeconfigPath string `json:"kubeconfig_path,omitempty" jsonschema:"Path to kubeconfig file"`
+	KubeconfigData string `json:"kubeconfig_data,omitempty" jsonschema:"Base64 encoded kubeconfig data"`
+	Context        string `json:"context,omitempty" jsonschema:"Kubernetes context to use"`
+	InCluster      bool   `json:"in_cluster,omitempty" jsonschema:"Use in-cluster config" default:"false"`
+}
 
-	// Parse arguments từ args (không phải req.Arguments)
-	clusterID, _ := args["cluster_id"].(string)
-	kubeconfigPath, _ := args["kubeconfig_path"].(string)
-	kubeconfigData, _ := args["kubeconfig_data"].(string)
-	contextName, _ := args["context"].(string)
+type clusterRegisterResult struct {
+	ClusterID string                      `json:"cluster_id"`
+	Status    string                      `json:"status"`
+	Config    clusterRegisterConfigResult `json:"config"`
+}
 
-	var inCluster bool
-	if ic, ok := args["in_cluster"].(bool); ok {
-		inCluster = ic
-	}
+// clusterRegisterConfigResult tells which configuration a cluster was
+// registered with, without echoing the kubeconfig.
+type clusterRegisterConfigResult struct {
+	HasKubeconfigPath bool   `json:"has_kubeconfig_path"`
+	HasKubeconfigData bool   `json:"has_kubeconfig_data"`
+	InCluster         bool   `json:"in_cluster"`
+	Context           string `json:"context"`
+}
 
-	// Validate required fields
-	if clusterID == "" {
-		return &mcp.CallToolResult{
-			Content: []mcp.Content{
-				&mcp.TextContent{Text: "Error: cluster_id is required"}, // ĐÚNG: dùng &mcp.TextContent{Text:
-			},
-			IsError: true,
-		}, nil, nil
-	}
+func (m *MCPServer) handleClusterRegister(ctx context.Context, req *mcp.CallToolRequest, args clusterRegisterArgs) (*mcp.CallToolResult, *clusterRegisterResult, error) {
+	m.logger.Info("Handling cluster register request", "args", args)
 
+	clusterID, kubeconfigPath, kubeconfigData, contextName, inCluster := args.ClusterID, args.KubeconfigPath, args.KubeconfigData, args.Context, args.InCluster
+
+	// Validate required fields
 	if kubeconfigPath == "" && kubeconfigData == "" && !inCluster {
 		return &mcp.CallToolResult{
 			Content: []mcp.Content{
@@ -1587,14 +718,14 @@ func (m *MCPServer) handleClusterRegister(ctx context.Context, req *mcp.CallTool
 		}, nil, nil
 	}
 
-	resultData := map[string]any{
-		"cluster_id": clusterID,
-		"status":     "registered",
-		"config": map[string]any{
-			"has_kubeconfig_path": kubeconfigPath != "",
-			"has_kubeconfig_data": len(kubeconfigData) > 0,
-			"in_cluster":          inCluster,
-			"context":             contextName,
+	resultData := &clusterRegisterResult{
+		ClusterID: clusterID,
+		Status:    "registered",
+		Config: clusterRegisterConfigResult{
+			HasKubeconfigPath: kubeconfigPath != "",
+			HasKubeconfigData: len(kubeconfigData) > 0,
+			InCluster:         inCluster,
+			Context:           contextName,
 		},
 	}
 
@@ -1606,34 +737,33 @@ func (m *MCPServer) handleClusterRegister(ctx context.Context, req *mcp.CallTool
 	}, resultData, nil
 }
 
-func (m *MCPServer) handleGetPodLogs(ctx context.Context, req *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
-	m.logger.Info("Handling get pod logs request", "args", args)
+type getPodLogsArgs struct {
+	ClusterID string `json:"cluster_id" jsonschema:"ID of the cluster"`
+	Namespace string `json:"namespace,omitempty" jsonschema:"Namespace of the pod" default:"default"`
+	PodName   string `json:"pod_name" jsonschema:"Name of the pod"`
+	logOptionArgs
+}
 
-	// Lấy arguments từ args
-	clusterID, _ := args["cluster_id"].(string)
-	namespace, _ := args["namespace"].(string)
-	podName, _ := args["pod_name"].(string)
+type getPodLogsResult struct {
+	ClusterID string `json:"cluster_id"`
+	Namespace string `json:"namespace"`
+	PodName   string `json:"pod_name"`
+	Container string `json:"container,omitempty"`
+	Logs      string `json:"logs"`
+}
 
-	var tailLines int64 = 100
-	if tl, ok := args["tail_lines"].(float64); ok {
-		tailLines = int64(tl)
-	}
+func (m *MCPServer) handleGetPodLogs(ctx context.Context, req *mcp.CallToolRequest, args getPodLogsArgs) (*mcp.CallToolResult, *getPodLogsResult, error) {
+	m.logger.Info("Handling get pod logs request", "args", args)
 
-	// Validate required fields
-	if clusterID == "" || podName == "" {
-		return &mcp.CallToolResult{
-			Content: []mcp.Content{
-				&mcp.TextContent{Text: "Error: cluster_id and pod_name are required"},
-			},
-			IsError: true,
-		}, nil, nil
-	}
+	// Lấy arguments từ args
+	clusterID, namespace, podName := args.ClusterID, args.Namespace, args.PodName
 
-	if namespace == "" {
-		namespace = "default"
+	opts, err := args.logOptions()
+	if err != nil {
+		return errorResult(err), nil, nil
 	}
 
-	logs, err := m.k8sUC.GetPodLogs(ctx, clusterID, namespace, podName, tailLines)
+	logs, err := m.k8sUC.GetPodLogs(ctx, clusterID, namespace, podName, opts)
 	if err != nil {
 		return &mcp.CallToolResult{
 			Content: []mcp.Content{
@@ -1650,44 +780,27 @@ func (m *MCPServer) handleGetPodLogs(ctx context.Context, req *mcp.CallToolReque
 			},
 		},
 		IsError: false,
-	}, nil, nil
+	}, &getPodLogsResult{ClusterID: clusterID, Namespace: namespace, PodName: podName, Container: opts.Container, Logs: logs}, nil
 }
 
-func (m *MCPServer) handleScaleDeployment(ctx context.Context, req *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
-	m.logger.Info("Handling scale deployment request", "args", args)
-
-	// Lấy arguments từ args
-	clusterID, _ := args["cluster_id"].(string)
-	namespace, _ := args["namespace"].(string)
-	deploymentName, _ := args["deployment_name"].(string)
-
-	var replicas int32 = 1
-	if r, ok := args["replicas"].(float64); ok {
-		replicas = int32(r)
-	}
+type scaleDeploymentArgs struct {
+	deploymentArgs
+	Replicas int32 `json:"replicas" jsonschema:"Number of replicas" minimum:"0"`
+}
 
-	// Validate required fields
-	if clusterID == "" || deploymentName == "" {
-		return &mcp.CallToolResult{
-			Content: []mcp.Content{
-				&mcp.TextContent{Text: "Error: cluster_id and deployment_name are required"},
-			},
-			IsError: true,
-		}, nil, nil
-	}
+type scaleDeploymentResult struct {
+	Deployment string `json:"deployment"`
+	Namespace  string `json:"namespace"`
+	Replicas   int32  `json:"replicas"`
+	Status     string `json:"status"`
+	ClusterID  string `json:"cluster_id"`
+}
 
-	if namespace == "" {
-		namespace = "default"
-	}
+func (m *MCPServer) handleScaleDeployment(ctx context.Context, req *mcp.CallToolRequest, args scaleDeploymentArgs) (*mcp.CallToolResult, *scaleDeploymentResult, error) {
+	m.logger.Info("Handling scale deployment request", "args", args)
 
-	if replicas < 0 {
-		return &mcp.CallToolResult{
-			Content: []mcp.Content{
-				&mcp.TextContent{Text: fmt.Sprintf("Error: replicas must be non-negative, got %d", replicas)},
-			},
-			IsError: true,
-		}, nil, nil
-	}
+	// Lấy arguments từ args
+	clusterID, namespace, deploymentName, replicas := args.ClusterID, args.Namespace, args.DeploymentName, args.Replicas
 
 	err := m.k8sUC.ScaleDeployment(ctx, clusterID, namespace, deploymentName, replicas)
 	if err != nil {
@@ -1699,12 +812,12 @@ func (m *MCPServer) handleScaleDeployment(ctx context.Context, req *mcp.CallTool
 		}, nil, nil
 	}
 
-	resultData := map[string]any{
-		"deployment": deploymentName,
-		"namespace":  namespace,
-		"replicas":   replicas,
-		"status":     "scaled",
-		"cluster_id": clusterID,
+	resultData := &scaleDeploymentResult{
+		Deployment: deploymentName,
+		Namespace:  namespace,
+		Replicas:   replicas,
+		Status:     "scaled",
+		ClusterID:  clusterID,
 	}
 
 	return &mcp.CallToolResult{
@@ -1715,26 +828,31 @@ func (m *MCPServer) handleScaleDeployment(ctx context.Context, req *mcp.CallTool
 	}, resultData, nil
 }
 
-func (m *MCPServer) handleListPods(ctx context.Context, req *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
-	m.logger.Info("Handling list pods request", "args", args)
+type listPodsArgs struct {
+	ClusterID string `json:"cluster_id" jsonschema:"ID of the cluster"`
+	Namespace string `json:"namespace,omitempty" jsonschema:"Namespace to list pods from" default:"default"`
+}
 
-	// Lấy arguments từ args
-	clusterID, _ := args["cluster_id"].(string)
-	namespace, _ := args["namespace"].(string)
+type listPodsResult struct {
+	ClusterID string        `json:"cluster_id"`
+	Namespace string        `json:"namespace"`
+	PodCount  int           `json:"pod_count"`
+	Pods      []podListItem `json:"pods"`
+}
 
-	// Validate required field
-	if clusterID == "" {
-		return &mcp.CallToolResult{
-			Content: []mcp.Content{
-				&mcp.TextContent{Text: "Error: cluster_id is required"},
-			},
-			IsError: true,
-		}, nil, nil
-	}
+// podListItem is a pod in the result of k8s_pod_list.
+type podListItem struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+	Status    string `json:"status"`
+	Cluster   string `json:"cluster"`
+}
 
-	if namespace == "" {
-		namespace = "default"
-	}
+func (m *MCPServer) handleListPods(ctx context.Context, req *mcp.CallToolRequest, args listPodsArgs) (*mcp.CallToolResult, *listPodsResult, error) {
+	m.logger.Info("Handling list pods request", "args", args)
+
+	// Lấy arguments từ args
+	clusterID, namespace := args.ClusterID, args.Namespace
 
 	pods, err := m.k8sUC.ListPods(ctx, clusterID, namespace)
 	if err != nil {
@@ -1747,27 +865,27 @@ func (m *MCPServer) handleListPods(ctx context.Context, req *mcp.CallToolRequest
 	}
 
 	// Format pods for display
-	var podList []map[string]any
+	podList := make([]podListItem, 0, len(pods))
 	for _, pod := range pods {
-		podList = append(podList, map[string]any{
-			"name":      string(pod.Name),
-			"namespace": string(pod.Namespace),
-			"status":    string(pod.Status.Phase),
-			"cluster":   string(pod.ClusterID),
+		podList = append(podList, podListItem{
+			Name:      string(pod.Name),
+			Namespace: string(pod.Namespace),
+			Status:    string(pod.Status.Phase),
+			Cluster:   string(pod.ClusterID),
 		})
 	}
 
-	resultData := map[string]any{
-		"cluster_id": clusterID,
-		"namespace":  namespace,
-		"pod_count":  len(podList),
-		"pods":       podList,
+	resultData := &listPodsResult{
+		ClusterID: clusterID,
+		Namespace: namespace,
+		PodCount:  len(podList),
+		Pods:      podList,
 	}
 
 	// Create a readable text summary
 	summary := fmt.Sprintf(" Found %d pods in namespace '%s':\n\n", len(podList), namespace)
 	for i, pod := range podList {
-		summary += fmt.Sprintf("%d. %s - Status: %s\n", i+1, pod["name"], pod["status"])
+		summary += fmt.Sprintf("%d. %s - Status: %s\n", i+1, pod.Name, pod.Status)
 	}
 
 	return &mcp.CallToolResult{
@@ -1778,27 +896,11 @@ func (m *MCPServer) handleListPods(ctx context.Context, req *mcp.CallToolRequest
 	}, resultData, nil
 }
 
-func (m *MCPServer) handleGetDeploymentInfo(ctx context.Context, req *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+func (m *MCPServer) handleGetDeploymentInfo(ctx context.Context, req *mcp.CallToolRequest, args deploymentArgs) (*mcp.CallToolResult, *domain.DeploymentInfo, error) {
 	m.logger.Info("Handling get deployment info request", "args", args)
 
 	// Parse arguments
-	clusterID, _ := args["cluster_id"].(string)
-	namespace, _ := args["namespace"].(string)
-	deploymentName, _ := args["deployment_name"].(string)
-
-	// Validate required fields
-	if clusterID == "" || deploymentName == "" {
-		return &mcp.CallToolResult{
-			Content: []mcp.Content{
-				&mcp.TextContent{Text: "Error: cluster_id and deployment_name are required"},
-			},
-			IsError: true,
-		}, nil, nil
-	}
-
-	if namespace == "" {
-		namespace = "default"
-	}
+	clusterID, namespace, deploymentName := args.ClusterID, args.Namespace, args.DeploymentName
 
 	// Get deployment info from use case
 	deploymentInfo, err := m.k8sUC.GetDeploymentInfo(ctx, clusterID, namespace, deploymentName)
@@ -1813,16 +915,14 @@ func (m *MCPServer) handleGetDeploymentInfo(ctx context.Context, req *mcp.CallTo
 
 	// Format the response
 	summary := fmt.Sprintf(" Deployment '%s' in namespace '%s':\n\n", deploymentName, namespace)
-	summary += fmt.Sprintf("Replicas: %d/%d (desired/ready)\n", deploymentInfo["replicas_ready"], deploymentInfo["replicas_desired"])
-	summary += fmt.Sprintf("Available: %d\n", deploymentInfo["replicas_available"])
-	summary += fmt.Sprintf("Updated: %d\n", deploymentInfo["replicas_updated"])
-	summary += fmt.Sprintf("Strategy: %s\n", deploymentInfo["strategy"])
-
-	if containers, ok := deploymentInfo["containers"].([]map[string]any); ok {
-		summary += fmt.Sprintf("\nContainers (%d):\n", len(containers))
-		for i, container := range containers {
-			summary += fmt.Sprintf("%d. %s - Image: %s\n", i+1, container["name"], container["image"])
-		}
+	summary += fmt.Sprintf("Replicas: %d/%d (desired/ready)\n", deploymentInfo.ReplicasReady, deploymentInfo.ReplicasDesired)
+	summary += fmt.Sprintf("Available: %d\n", deploymentInfo.ReplicasAvailable)
+	summary += fmt.Sprintf("Updated: %d\n", deploymentInfo.ReplicasUpdated)
+	summary += fmt.Sprintf("Strategy: %s\n", deploymentInfo.Strategy)
+
+	summary += fmt.Sprintf("\nContainers (%d):\n", len(deploymentInfo.Containers))
+	for i, container := range deploymentInfo.Containers {
+		summary += fmt.Sprintf("%d. %s - Image: %s\n", i+1, container.Name, container.Image)
 	}
 
 	return &mcp.CallToolResult{
@@ -1833,19 +933,20 @@ func (m *MCPServer) handleGetDeploymentInfo(ctx context.Context, req *mcp.CallTo
 	}, deploymentInfo, nil
 }
 
-func (m *MCPServer) handleListNamespaces(ctx context.Context, req *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
-	m.logger.Info("Handling list namespaces request", "args", args)
+type listNamespacesArgs struct {
+	ClusterID string `json:"cluster_id" jsonschema:"ID of the cluster"`
+}
 
-	clusterID, _ := args["cluster_id"].(string)
+type listNamespacesResult struct {
+	ClusterID  string                    `json:"cluster_id"`
+	Count      int                       `json:"count"`
+	Namespaces []domain.NamespaceSummary `json:"namespaces"`
+}
 
-	if clusterID == "" {
-		return &mcp.CallToolResult{
-			Content: []mcp.Content{
-				&mcp.TextContent{Text: "Error: cluster_id is required"},
-			},
-			IsError: true,
-		}, nil, nil
-	}
+func (m *MCPServer) handleListNamespaces(ctx context.Context, req *mcp.CallToolRequest, args listNamespacesArgs) (*mcp.CallToolResult, *listNamespacesResult, error) {
+	m.logger.Info("Handling list namespaces request", "args", args)
+
+	clusterID := args.ClusterID
 
 	namespaces, err := m.k8sUC.ListNamespaces(ctx, clusterID)
 	if err != nil {
@@ -1859,13 +960,13 @@ func (m *MCPServer) handleListNamespaces(ctx context.Context, req *mcp.CallToolR
 
 	summary := fmt.Sprintf(" Found %d namespaces:\n\n", len(namespaces))
 	for i, ns := range namespaces {
-		summary += fmt.Sprintf("%d. %s - Status: %s\n", i+1, ns["name"], ns["status"])
+		summary += fmt.Sprintf("%d. %s - Status: %s\n", i+1, ns.Name, ns.Status)
 	}
 
-	resultData := map[string]any{
-		"cluster_id": clusterID,
-		"count":      len(namespaces),
-		"namespaces": namespaces,
+	resultData := &listNamespacesResult{
+		ClusterID:  clusterID,
+		Count:      len(namespaces),
+		Namespaces: namespaces,
 	}
 
 	return &mcp.CallToolResult{
@@ -1876,20 +977,15 @@ func (m *MCPServer) handleListNamespaces(ctx context.Context, req *mcp.CallToolR
 	}, resultData, nil
 }
 
-func (m *MCPServer) handleGetNamespace(ctx context.Context, req *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
-	m.logger.Info("Handling get namespace request", "args", args)
+type getNamespaceArgs struct {
+	ClusterID string `json:"cluster_id" jsonschema:"ID of the cluster"`
+	Namespace string `json:"namespace" jsonschema:"Name of the namespace"`
+}
 
-	clusterID, _ := args["cluster_id"].(string)
-	namespace, _ := args["namespace"].(string)
+func (m *MCPServer) handleGetNamespace(ctx context.Context, req *mcp.CallToolRequest, args getNamespaceArgs) (*mcp.CallToolResult, *domain.NamespaceDetail, error) {
+	m.logger.Info("Handling get namespace request", "args", args)
 
-	if clusterID == "" || namespace == "" {
-		return &mcp.CallToolResult{
-			Content: []mcp.Content{
-				&mcp.TextContent{Text: "Error: cluster_id and namespace are required"},
-			},
-			IsError: true,
-		}, nil, nil
-	}
+	clusterID, namespace := args.ClusterID, args.Namespace
 
 	namespaceInfo, err := m.k8sUC.GetNamespace(ctx, clusterID, namespace)
 	if err != nil {
@@ -1902,13 +998,13 @@ func (m *MCPServer) handleGetNamespace(ctx context.Context, req *mcp.CallToolReq
 	}
 
 	summary := fmt.Sprintf(" Namespace '%s':\n\n", namespace)
-	summary += fmt.Sprintf("Status: %s\n", namespaceInfo["status"])
-	summary += fmt.Sprintf("Created: %s\n", namespaceInfo["created"])
+	summary += fmt.Sprintf("Status: %s\n", namespaceInfo.Status)
+	summary += fmt.Sprintf("Created: %s\n", namespaceInfo.Created)
 
-	if labels, ok := namespaceInfo["labels"].(map[string]string); ok && len(labels) > 0 {
+	if labels := namespaceInfo.Labels; len(labels) > 0 {
 		summary += "\nLabels:\n"
-		for k, v := range labels {
-			summary += fmt.Sprintf("  %s: %s\n", k, v)
+		for _, k := range slices.Sorted(maps.Keys(labels)) {
+			summary += fmt.Sprintf("  %s: %s\n", k, labels[k])
 		}
 	}
 
@@ -1920,20 +1016,22 @@ func (m *MCPServer) handleGetNamespace(ctx context.Context, req *mcp.CallToolReq
 	}, namespaceInfo, nil
 }
 
-func (m *MCPServer) handleCreateNamespace(ctx context.Context, req *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
-	m.logger.Info("Handling create namespace request", "args", args)
+type createNamespaceArgs struct {
+	ClusterID string `json:"cluster_id" jsonschema:"ID of the cluster"`
+	Namespace string `json:"namespace" jsonschema:"Name of the namespace to create"`
+}
 
-	clusterID, _ := args["cluster_id"].(string)
-	namespace, _ := args["namespace"].(string)
+// namespaceResult is the result of creating or deleting a namespace.
+type namespaceResult struct {
+	ClusterID string `json:"cluster_id"`
+	Namespace string `json:"namespace"`
+	Status    string `json:"status"`
+}
 
-	if clusterID == "" || namespace == "" {
-		return &mcp.CallToolResult{
-			Content: []mcp.Content{
-				&mcp.TextContent{Text: "Error: cluster_id and namespace are required"},
-			},
-			IsError: true,
-		}, nil, nil
-	}
+func (m *MCPServer) handleCreateNamespace(ctx context.Context, req *mcp.CallToolRequest, args createNamespaceArgs) (*mcp.CallToolResult, *namespaceResult, error) {
+	m.logger.Info("Handling create namespace request", "args", args)
+
+	clusterID, namespace := args.ClusterID, args.Namespace
 
 	err := m.k8sUC.CreateNamespace(ctx, clusterID, namespace)
 	if err != nil {
@@ -1945,10 +1043,10 @@ func (m *MCPServer) handleCreateNamespace(ctx context.Context, req *mcp.CallTool
 		}, nil, nil
 	}
 
-	resultData := map[string]any{
-		"cluster_id": clusterID,
-		"namespace":  namespace,
-		"status":     "created",
+	resultData := &namespaceResult{
+		ClusterID: clusterID,
+		Namespace: namespace,
+		Status:    "created",
 	}
 
 	return &mcp.CallToolResult{
@@ -1959,20 +1057,15 @@ func (m *MCPServer) handleCreateNamespace(ctx context.Context, req *mcp.CallTool
 	}, resultData, nil
 }
 
-func (m *MCPServer) handleDeleteNamespace(ctx context.Context, req *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
-	m.logger.Info("Handling delete namespace request", "args", args)
+type deleteNamespaceArgs struct {
+	ClusterID string `json:"cluster_id" jsonschema:"ID of the cluster"`
+	Namespace string `json:"namespace" jsonschema:"Name of the namespace to delete"`
+}
 
-	clusterID, _ := args["cluster_id"].(string)
-	namespace, _ := args["namespace"].(string)
+func (m *MCPServer) handleDeleteNamespace(ctx context.Context, req *mcp.CallToolRequest, args deleteNamespaceArgs) (*mcp.CallToolResult, *namespaceResult, error) {
+	m.logger.Info("Handling delete namespace request", "args", args)
 
-	if clusterID == "" || namespace == "" {
-		return &mcp.CallToolResult{
-			Content: []mcp.Content{
-				&mcp.TextContent{Text: "Error: cluster_id and namespace are required"},
-			},
-			IsError: true,
-		}, nil, nil
-	}
+	clusterID, namespace := args.ClusterID, args.Namespace
 
 	err := m.k8sUC.DeleteNamespace(ctx, clusterID, namespace)
 	if err != nil {
@@ -1984,10 +1077,10 @@ func (m *MCPServer) handleDeleteNamespace(ctx context.Context, req *mcp.CallTool
 		}, nil, nil
 	}
 
-	resultData := map[string]any{
-		"cluster_id": clusterID,
-		"namespace":  namespace,
-		"status":     "deleted",
+	resultData := &namespaceResult{
+		ClusterID: clusterID,
+		Namespace: namespace,
+		Status:    "deleted",
 	}
 
 	return &mcp.CallToolResult{
@@ -1998,19 +1091,20 @@ func (m *MCPServer) handleDeleteNamespace(ctx context.Context, req *mcp.CallTool
 	}, resultData, nil
 }
 
-func (m *MCPServer) handleListPersistentVolumes(ctx context.Context, req *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
-	m.logger.Info("Handling list persistent volumes request", "args", args)
+type listPersistentVolumesArgs struct {
+	ClusterID string `json:"cluster_id" jsonschema:"ID of the cluster"`
+}
 
-	clusterID, _ := args["cluster_id"].(string)
+type listPersistentVolumesResult struct {
+	ClusterID         string                           `json:"cluster_id"`
+	Count             int                              `json:"count"`
+	PersistentVolumes []domain.PersistentVolumeSummary `json:"persistent_volumes"`
+}
 
-	if clusterID == "" {
-		return &mcp.CallToolResult{
-			Content: []mcp.Content{
-				&mcp.TextContent{Text: "Error: cluster_id is required"},
-			},
-			IsError: true,
-		}, nil, nil
-	}
+func (m *MCPServer) handleListPersistentVolumes(ctx context.Context, req *mcp.CallToolRequest, args listPersistentVolumesArgs) (*mcp.CallToolResult, *listPersistentVolumesResult, error) {
+	m.logger.Info("Handling list persistent volumes request", "args", args)
+
+	clusterID := args.ClusterID
 
 	pvs, err := m.k8sUC.ListPersistentVolumes(ctx, clusterID)
 	if err != nil {
@@ -2025,13 +1119,13 @@ func (m *MCPServer) handleListPersistentVolumes(ctx context.Context, req *mcp.Ca
 	summary := fmt.Sprintf("💾 Found %d PersistentVolumes:\n\n", len(pvs))
 	for i, pv := range pvs {
 		summary += fmt.Sprintf("%d. %s - Capacity: %s, Status: %s, StorageClass: %s\n",
-			i+1, pv["name"], pv["capacity"], pv["status"], pv["storage_class"])
+			i+1, pv.Name, pv.Capacity, pv.Status, pv.StorageClass)
 	}
 
-	resultData := map[string]any{
-		"cluster_id":         clusterID,
-		"count":              len(pvs),
-		"persistent_volumes": pvs,
+	resultData := &listPersistentVolumesResult{
+		ClusterID:         clusterID,
+		Count:             len(pvs),
+		PersistentVolumes: pvs,
 	}
 
 	return &mcp.CallToolResult{
@@ -2042,19 +1136,20 @@ func (m *MCPServer) handleListPersistentVolumes(ctx context.Context, req *mcp.Ca
 	}, resultData, nil
 }
 
-func (m *MCPServer) handleListStorageClasses(ctx context.Context, req *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
-	m.logger.Info("Handling list storage classes request", "args", args)
+type listStorageClassesArgs struct {
+	ClusterID string `json:"cluster_id" jsonschema:"ID of the cluster"`
+}
 
-	clusterID, _ := args["cluster_id"].(string)
+type listStorageClassesResult struct {
+	ClusterID      string                       `json:"cluster_id"`
+	Count          int                          `json:"count"`
+	StorageClasses []domain.StorageClassSummary `json:"storage_classes"`
+}
 
-	if clusterID == "" {
-		return &mcp.CallToolResult{
-			Content: []mcp.Content{
-				&mcp.TextContent{Text: "Error: cluster_id is required"},
-			},
-			IsError: true,
-		}, nil, nil
-	}
+func (m *MCPServer) handleListStorageClasses(ctx context.Context, req *mcp.CallToolRequest, args listStorageClassesArgs) (*mcp.CallToolResult, *listStorageClassesResult, error) {
+	m.logger.Info("Handling list storage classes request", "args", args)
+
+	clusterID := args.ClusterID
 
 	storageClasses, err := m.k8sUC.ListStorageClasses(ctx, clusterID)
 	if err != nil {
@@ -2069,13 +1164,13 @@ func (m *MCPServer) handleListStorageClasses(ctx context.Context, req *mcp.CallT
 	summary := fmt.Sprintf("📦 Found %d StorageClasses:\n\n", len(storageClasses))
 	for i, sc := range storageClasses {
 		summary += fmt.Sprintf("%d. %s - Provisioner: %s, ReclaimPolicy: %s\n",
-			i+1, sc["name"], sc["provisioner"], sc["reclaim_policy"])
+			i+1, sc.Name, sc.Provisioner, sc.ReclaimPolicy)
 	}
 
-	resultData := map[string]any{
-		"cluster_id":      clusterID,
-		"count":           len(storageClasses),
-		"storage_classes": storageClasses,
+	resultData := &listStorageClassesResult{
+		ClusterID:      clusterID,
+		Count:          len(storageClasses),
+		StorageClasses: storageClasses,
 	}
 
 	return &mcp.CallToolResult{
@@ -2086,8 +1181,16 @@ func (m *MCPServer) handleListStorageClasses(ctx context.Context, req *mcp.CallT
 	}, resultData, nil
 }
 
-func (m *MCPServer) Run(ctx context.Context) error {
-	m.logger.Info("Starting MCP server")
+// Run serves MCP over the configured transport until ctx is cancelled.
+func (m *MCPServer) Run(ctx context.Context, cfg TransportConfig) error {
+	if err := cfg.Validate(); err != nil {
+		return err
+	}
+	if cfg.Type != TransportStdio {
+		return m.serveHTTP(ctx, cfg)
+	}
+
+	m.logger.Info("Starting MCP server", "transport", cfg.Type)
 	// Sử dụng &mcp.StdioTransport{} (ĐÚNG)
 	transport := &mcp.StdioTransport{}
 	return m.server.Run(ctx, transport)
@@ -0,0 +1,877 @@
+package mcp
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"io"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/your-org/mcp-k8s-server/internal/domain"
+	"github.com/your-org/mcp-k8s-server/internal/infrastructure"
+	"github.com/your-org/mcp-k8s-server/internal/usecase"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clienttesting "k8s.io/client-go/testing"
+)
+
+type toolCall struct {
+	tool string
+	args map[string]any
+}
+
+// toolCases drive TestTools. Each case runs against a fresh harness seeded
+// with fixtureObjects and compares the result with testdata/golden/<name>.golden.
+var toolCases = []struct {
+	name   string
+	before []toolCall
+	toolCall
+}{
+	// Manifests
+	{name: "apply_yaml", toolCall: toolCall{"k8s_apply_yaml", map[string]any{
+		"cluster_id": testClusterID,
+		"yaml_body":  "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: feature-flags\n  namespace: default\ndata:\n  search: \"on\"\n",
+	}}},
+	{name: "apply_yaml_dry_run_configured", toolCall: toolCall{"k8s_apply_yaml", map[string]any{
+		"cluster_id": testClusterID,
+		"dry_run":    true,
+		"yaml_body":  "apiVersion: apps/v1\nkind: Deployment\nmetadata:\n  name: web\n  namespace: default\n  labels:\n    tier: frontend\n",
+	}}},
+	{name: "apply_yaml_dry_run_unchanged", toolCall: toolCall{"k8s_apply_yaml", map[string]any{
+		"cluster_id": testClusterID,
+		"dry_run":    true,
+		"yaml_body":  "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: app-config\n  namespace: default\n",
+	}}},
+	{name: "apply_yaml_multi_document", toolCall: toolCall{"k8s_apply_yaml", map[string]any{
+		"cluster_id": testClusterID,
+		// Applied as Namespace, ConfigMap, Deployment and then the unknown
+		// kind, which fails on its own.
+		"yaml_body": "apiVersion: apps/v1\nkind: Deployment\nmetadata:\n  name: shop-api\n  namespace: shop\n" +
+			"---\n---\napiVersion: example.com/v1\nkind: Widget\nmetadata:\n  name: w\n  namespace: shop\n" +
+			"---\napiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: shop-config\n  namespace: shop\n" +
+			"---\napiVersion: v1\nkind: Namespace\nmetadata:\n  name: shop\n",
+	}}},
+	{name: "apply_yaml_list", toolCall: toolCall{"k8s_apply_yaml", map[string]any{
+		"cluster_id": testClusterID,
+		"yaml_body": "apiVersion: v1\nkind: List\nitems:\n" +
+			"- apiVersion: v1\n  kind: ConfigMap\n  metadata:\n    name: shop-config\n" +
+			"- apiVersion: v1\n  kind: Secret\n  metadata:\n    name: shop-secret\n",
+	}}},
+	{name: "apply_yaml_path_dir", toolCall: toolCall{"k8s_apply_yaml", map[string]any{"cluster_id": testClusterID, "path": "bundle"}}},
+	{name: "apply_yaml_path_recursive", toolCall: toolCall{"k8s_apply_yaml", map[string]any{"cluster_id": testClusterID, "path": "bundle", "recursive": true}}},
+	{name: "apply_yaml_path_glob", toolCall: toolCall{"k8s_apply_yaml", map[string]any{"cluster_id": testClusterID, "path": "bundle/*/*.yml", "dry_run": true}}},
+	{name: "apply_yaml_path_outside_root", toolCall: toolCall{"k8s_apply_yaml", map[string]any{"cluster_id": testClusterID, "path": "../golden/apply_yaml.golden"}}},
+	{name: "apply_yaml_invalid", toolCall: toolCall{"k8s_apply_yaml", map[string]any{
+		"cluster_id": testClusterID,
+		"yaml_body":  "kind: ConfigMap\nmetadata: [",
+	}}},
+	{name: "resource_get_pvc", toolCall: toolCall{"k8s_resource_get", map[string]any{"cluster_id": testClusterID, "kind": "pvc", "name": "api-data"}}},
+	{name: "resource_get_secret", toolCall: toolCall{"k8s_resource_get", map[string]any{"cluster_id": testClusterID, "kind": "Secret", "name": "app-secret"}}},
+	{name: "resource_get_custom", toolCall: toolCall{"k8s_resource_get", map[string]any{
+		"cluster_id": testClusterID, "kind": "certificates.cert-manager.io", "name": "web-tls", "output": "json",
+	}}},
+	{name: "resource_list_custom", toolCall: toolCall{"k8s_resource_list", map[string]any{"cluster_id": testClusterID, "kind": "cert"}}},
+	{name: "resource_list_selector", toolCall: toolCall{"k8s_resource_list", map[string]any{
+		"cluster_id": testClusterID, "kind": "po", "all_namespaces": true, "label_selector": "app in (web, api)",
+	}}},
+	{name: "resource_list_cluster_scoped", toolCall: toolCall{"k8s_resource_list", map[string]any{"cluster_id": testClusterID, "kind": "Node", "api_version": "v1"}}},
+	{name: "resource_list_unknown", toolCall: toolCall{"k8s_resource_list", map[string]any{"cluster_id": testClusterID, "kind": "widgets"}}},
+	{name: "resource_delete", toolCall: toolCall{"k8s_resource_delete", map[string]any{
+		"cluster_id": testClusterID, "kind": "Certificate", "api_version": "cert-manager.io/v1", "name": "web-tls", "propagation": "foreground",
+	}}},
+	{
+		name:     "resource_delete_gone",
+		before:   []toolCall{{"k8s_resource_delete", map[string]any{"cluster_id": testClusterID, "kind": "pvc", "name": "api-data"}}},
+		toolCall: toolCall{"k8s_resource_get", map[string]any{"cluster_id": testClusterID, "kind": "pvc", "name": "api-data"}},
+	},
+	{name: "api_resources", toolCall: toolCall{"k8s_api_resources", map[string]any{"cluster_id": testClusterID}}},
+	{name: "api_resources_filtered", toolCall: toolCall{"k8s_api_resources", map[string]any{
+		"cluster_id": testClusterID, "api_group": "", "namespaced": false, "verb": "list",
+	}}},
+	{name: "explain_probe", toolCall: toolCall{"k8s_explain", map[string]any{
+		"cluster_id": testClusterID, "path": "deployment.spec.template.spec.containers.readinessProbe",
+	}}},
+	{name: "explain_required", toolCall: toolCall{"k8s_explain", map[string]any{"cluster_id": testClusterID, "path": "po.spec.containers.name"}}},
+	{name: "explain_unknown_field", toolCall: toolCall{"k8s_explain", map[string]any{"cluster_id": testClusterID, "path": "deploy.spec.replica"}}},
+	{name: "explain_no_schema", toolCall: toolCall{"k8s_explain", map[string]any{"cluster_id": testClusterID, "path": "cert.spec"}}},
+	{name: "diff_yaml", toolCall: toolCall{"k8s_diff_yaml", map[string]any{
+		"cluster_id": testClusterID,
+		// The fake drops patch options, so the apply is not forced; a key
+		// nobody manages yet avoids a conflict with the seeded object.
+		"yaml_body": "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: app-config\n  namespace: default\ndata:\n  RETRIES: \"3\"\n",
+	}}},
+	{name: "port_forward", toolCall: toolCall{"k8s_port_forward", map[string]any{
+		"cluster_id": testClusterID, "namespace": "default", "pod_name": "web-7d9c-abcde", "local_port": 8080, "remote_port": 80,
+	}}},
+	{name: "port_forward_stop", toolCall: toolCall{"k8s_port_forward", map[string]any{
+		"cluster_id": testClusterID, "namespace": "default", "pod_name": "web-7d9c-abcde", "action": "stop",
+	}}},
+	{name: "port_forward_stop_id", toolCall: toolCall{"k8s_port_forward", map[string]any{
+		"cluster_id": testClusterID, "id": "test/default/web-7d9c-abcde/8080:80", "action": "stop",
+	}}},
+	{name: "port_forward_service", toolCall: toolCall{"k8s_port_forward", map[string]any{
+		"cluster_id": testClusterID, "namespace": "default", "service_name": "web", "remote_port": 80,
+	}}},
+	{name: "port_forward_service_missing_port", toolCall: toolCall{"k8s_port_forward", map[string]any{
+		"cluster_id": testClusterID, "namespace": "default", "service_name": "web", "remote_port": 9999,
+	}}},
+	{name: "port_forward_two_targets", toolCall: toolCall{"k8s_port_forward", map[string]any{
+		"cluster_id": testClusterID, "namespace": "default", "pod_name": "web-7d9c-abcde", "deployment_name": "web", "remote_port": 80,
+	}}},
+	{name: "port_forward_list", toolCall: toolCall{"k8s_port_forward_list", map[string]any{"cluster_id": testClusterID}}},
+
+	// Admission and RBAC
+	{name: "webhook_mutating_list", toolCall: toolCall{"k8s_webhook_mutating_list", map[string]any{"cluster_id": testClusterID}}},
+	{name: "webhook_mutating_get", toolCall: toolCall{"k8s_webhook_mutating_get", map[string]any{"cluster_id": testClusterID, "webhook_name": "policy-webhook"}}},
+	{name: "webhook_validating_list", toolCall: toolCall{"k8s_webhook_validating_list", map[string]any{"cluster_id": testClusterID}}},
+	{name: "webhook_validating_get", toolCall: toolCall{"k8s_webhook_validating_get", map[string]any{"cluster_id": testClusterID, "webhook_name": "policy-webhook"}}},
+	{name: "rbac_clusterrole_list", toolCall: toolCall{"k8s_rbac_clusterrole_list", map[string]any{"cluster_id": testClusterID}}},
+
+	// Events, autoscaling and quotas
+	{name: "events_for_workload", toolCall: toolCall{"k8s_events_for_workload", map[string]any{"cluster_id": testClusterID, "namespace": "default", "kind": "deployment", "name": "web"}}},
+	{name: "events_for_workload_events_api", toolCall: toolCall{"k8s_events_for_workload", map[string]any{
+		"cluster_id": testClusterID, "namespace": "default", "kind": "deployment", "name": "web", "api": "events.k8s.io/v1",
+	}}},
+	{name: "events_watch", toolCall: toolCall{"k8s_events_watch", map[string]any{"cluster_id": testClusterID, "namespace": "default", "duration_seconds": 1}}},
+	{name: "event_list", toolCall: toolCall{"k8s_event_list", map[string]any{"cluster_id": testClusterID, "namespace": "default"}}},
+	{name: "event_list_involved", toolCall: toolCall{"k8s_event_list", map[string]any{
+		"cluster_id": testClusterID, "namespace": "default", "involved_kind": "Pod", "involved_name": "web-7d9c-abcde",
+	}}},
+	{name: "hpa_list", toolCall: toolCall{"k8s_hpa_list", map[string]any{"cluster_id": testClusterID, "namespace": "default"}}},
+	{name: "hpa_get", toolCall: toolCall{"k8s_hpa_get", map[string]any{"cluster_id": testClusterID, "namespace": "default", "hpa_name": "web"}}},
+	{name: "hpa_delete", toolCall: toolCall{"k8s_hpa_delete", map[string]any{"cluster_id": testClusterID, "namespace": "default", "hpa_name": "web"}}},
+	{name: "quota_list", toolCall: toolCall{"k8s_quota_list", map[string]any{"cluster_id": testClusterID, "namespace": "default"}}},
+	{name: "quota_get", toolCall: toolCall{"k8s_quota_get", map[string]any{"cluster_id": testClusterID, "namespace": "default", "quota_name": "compute"}}},
+	{name: "limitrange_list", toolCall: toolCall{"k8s_limitrange_list", map[string]any{"cluster_id": testClusterID, "namespace": "default"}}},
+	{name: "limitrange_get", toolCall: toolCall{"k8s_limitrange_get", map[string]any{"cluster_id": testClusterID, "namespace": "default", "limit_range_name": "defaults"}}},
+
+	// Nodes
+	{name: "node_list", toolCall: toolCall{"k8s_node_list", map[string]any{"cluster_id": testClusterID}}},
+	{name: "node_get_metrics", toolCall: toolCall{"k8s_node_get_metrics", map[string]any{"cluster_id": testClusterID, "node_name": "node-1"}}},
+	{name: "top_nodes", toolCall: toolCall{"k8s_top_nodes", map[string]any{"cluster_id": testClusterID}}},
+	{name: "top_pods", toolCall: toolCall{"k8s_top_pods", map[string]any{"cluster_id": testClusterID, "namespace": "default", "containers": true}}},
+	{name: "top_pods_by_memory", toolCall: toolCall{"k8s_top_pods", map[string]any{"cluster_id": testClusterID, "all_namespaces": true, "sort_by": "memory"}}},
+	{name: "node_taint_apply", toolCall: toolCall{"k8s_node_taint_apply", map[string]any{
+		"cluster_id": testClusterID, "node_name": "node-1", "taint_key": "maintenance=true:NoSchedule", "action": "add",
+	}}},
+
+	// Jobs
+	{name: "job_list", toolCall: toolCall{"k8s_job_list", map[string]any{"cluster_id": testClusterID, "namespace": "default"}}},
+	{name: "job_get", toolCall: toolCall{"k8s_job_get", map[string]any{"cluster_id": testClusterID, "namespace": "default", "job_name": "migrate"}}},
+	{name: "job_create", toolCall: toolCall{"k8s_job_create", map[string]any{
+		"cluster_id": testClusterID, "namespace": "default", "job_name": "backfill", "image": "busybox:1.36", "command": []any{"sh", "-c", "echo done"},
+	}}},
+	{name: "job_delete", toolCall: toolCall{"k8s_job_delete", map[string]any{"cluster_id": testClusterID, "namespace": "default", "job_name": "migrate"}}},
+	{name: "job_get_logs", toolCall: toolCall{"k8s_job_get_logs", map[string]any{"cluster_id": testClusterID, "namespace": "default", "job_name": "migrate"}}},
+
+	// CronJobs
+	{name: "cronjob_list", toolCall: toolCall{"k8s_cronjob_list", map[string]any{"cluster_id": testClusterID, "namespace": "default"}}},
+	{name: "cronjob_get", toolCall: toolCall{"k8s_cronjob_get", map[string]any{"cluster_id": testClusterID, "namespace": "default", "cronjob_name": "nightly"}}},
+	{name: "cronjob_get_logs", toolCall: toolCall{"k8s_cronjob_get_logs", map[string]any{"cluster_id": testClusterID, "namespace": "default", "cronjob_name": "nightly", "tail_lines": 20}}},
+	{name: "cronjob_create", toolCall: toolCall{"k8s_cronjob_create", map[string]any{
+		"cluster_id": testClusterID, "namespace": "default", "cronjob_name": "cleanup", "schedule": "*/15 * * * *", "image": "busybox:1.36",
+	}}},
+	{name: "cronjob_delete", toolCall: toolCall{"k8s_cronjob_delete", map[string]any{"cluster_id": testClusterID, "namespace": "default", "cronjob_name": "nightly"}}},
+	{name: "cronjob_suspend", toolCall: toolCall{"k8s_cronjob_suspend", map[string]any{"cluster_id": testClusterID, "namespace": "default", "cronjob_name": "nightly", "suspend": true}}},
+	{name: "cronjob_trigger", toolCall: toolCall{"k8s_cronjob_trigger", map[string]any{"cluster_id": testClusterID, "namespace": "default", "cronjob_name": "nightly"}}},
+
+	// StatefulSets and DaemonSets
+	{name: "statefulset_list", toolCall: toolCall{"k8s_statefulset_list", map[string]any{"cluster_id": testClusterID, "namespace": "default"}}},
+	{name: "statefulset_get", toolCall: toolCall{"k8s_statefulset_get", map[string]any{"cluster_id": testClusterID, "namespace": "default", "statefulset_name": "db"}}},
+	{name: "statefulset_scale", toolCall: toolCall{"k8s_statefulset_scale", map[string]any{"cluster_id": testClusterID, "namespace": "default", "statefulset_name": "db", "replicas": 3}}},
+	{name: "statefulset_restart", toolCall: toolCall{"k8s_statefulset_restart", map[string]any{"cluster_id": testClusterID, "namespace": "default", "statefulset_name": "db"}}},
+	{name: "statefulset_delete", toolCall: toolCall{"k8s_statefulset_delete", map[string]any{"cluster_id": testClusterID, "namespace": "default", "statefulset_name": "db"}}},
+	{name: "daemonset_list", toolCall: toolCall{"k8s_daemonset_list", map[string]any{"cluster_id": testClusterID, "namespace": "default"}}},
+	{name: "daemonset_get", toolCall: toolCall{"k8s_daemonset_get", map[string]any{"cluster_id": testClusterID, "namespace": "default", "daemonset_name": "agent"}}},
+	{name: "daemonset_restart", toolCall: toolCall{"k8s_daemonset_restart", map[string]any{"cluster_id": testClusterID, "namespace": "default", "daemonset_name": "agent"}}},
+	{name: "daemonset_delete", toolCall: toolCall{"k8s_daemonset_delete", map[string]any{"cluster_id": testClusterID, "namespace": "default", "daemonset_name": "agent"}}},
+	{name: "daemonset_get_pods", toolCall: toolCall{"k8s_daemonset_get_pods", map[string]any{"cluster_id": testClusterID, "namespace": "default", "daemonset_name": "agent"}}},
+
+	// ConfigMaps and Secrets
+	{name: "configmap_list", toolCall: toolCall{"k8s_configmap_list", map[string]any{"cluster_id": testClusterID, "namespace": "default"}}},
+	{name: "configmap_get", toolCall: toolCall{"k8s_configmap_get", map[string]any{"cluster_id": testClusterID, "namespace": "default", "configmap_name": "app-config"}}},
+	{name: "configmap_get_missing", toolCall: toolCall{"k8s_configmap_get", map[string]any{"cluster_id": testClusterID, "namespace": "default", "configmap_name": "missing"}}},
+	{name: "configmap_create", toolCall: toolCall{"k8s_configmap_create", map[string]any{
+		"cluster_id": testClusterID, "namespace": "default", "configmap_name": "feature-flags", "data": map[string]any{"search": "on"},
+	}}},
+	{name: "configmap_delete", toolCall: toolCall{"k8s_configmap_delete", map[string]any{"cluster_id": testClusterID, "namespace": "default", "configmap_name": "app-config"}}},
+	{name: "secret_list", toolCall: toolCall{"k8s_secret_list", map[string]any{"cluster_id": testClusterID, "namespace": "default"}}},
+	{name: "secret_get", toolCall: toolCall{"k8s_secret_get", map[string]any{"cluster_id": testClusterID, "namespace": "default", "secret_name": "app-secret"}}},
+	{name: "secret_create", toolCall: toolCall{"k8s_secret_create", map[string]any{
+		"cluster_id": testClusterID, "namespace": "default", "secret_name": "api-token", "string_data": map[string]any{"token": "s3cr3t"},
+	}}},
+	{name: "secret_delete", toolCall: toolCall{"k8s_secret_delete", map[string]any{"cluster_id": testClusterID, "namespace": "default", "secret_name": "app-secret"}}},
+
+	// Services and Ingresses
+	{name: "service_list", toolCall: toolCall{"k8s_service_list", map[string]any{"cluster_id": testClusterID, "namespace": "default"}}},
+	{name: "service_get", toolCall: toolCall{"k8s_service_get", map[string]any{"cluster_id": testClusterID, "namespace": "default", "service_name": "web"}}},
+	{name: "service_delete", toolCall: toolCall{"k8s_service_delete", map[string]any{"cluster_id": testClusterID, "namespace": "default", "service_name": "web"}}},
+	{name: "ingress_list", toolCall: toolCall{"k8s_ingress_list", map[string]any{"cluster_id": testClusterID, "namespace": "default"}}},
+	{name: "ingress_get", toolCall: toolCall{"k8s_ingress_get", map[string]any{"cluster_id": testClusterID, "namespace": "default", "ingress_name": "web"}}},
+	{name: "ingress_delete", toolCall: toolCall{"k8s_ingress_delete", map[string]any{"cluster_id": testClusterID, "namespace": "default", "ingress_name": "web"}}},
+
+	// Clusters
+	{name: "cluster_register", toolCall: toolCall{"k8s_cluster_register", map[string]any{"cluster_id": "staging", "kubeconfig_path": "testdata/missing-kubeconfig"}}},
+	{name: "cluster_register_invalid_id", toolCall: toolCall{"k8s_cluster_register", map[string]any{"cluster_id": "../../staging", "kubeconfig_path": "testdata/missing-kubeconfig"}}},
+	{name: "cluster_list", toolCall: toolCall{"k8s_cluster_list", map[string]any{}}},
+	{name: "cluster_status", toolCall: toolCall{"k8s_cluster_status", map[string]any{"cluster_id": testClusterID}}},
+	{name: "cluster_unregister", toolCall: toolCall{"k8s_cluster_unregister", map[string]any{"cluster_id": testClusterID}}},
+
+	// Pods
+	{name: "pod_list", toolCall: toolCall{"k8s_pod_list", map[string]any{"cluster_id": testClusterID, "namespace": "default"}}},
+	{name: "pod_exec", toolCall: toolCall{"k8s_pod_exec", map[string]any{
+		"cluster_id": testClusterID, "namespace": "default", "pod_name": "web-7d9c-abcde", "command": []any{"cat", "/etc/resolv.conf"},
+	}}},
+	{name: "pod_exec_denied", toolCall: toolCall{"k8s_pod_exec", map[string]any{
+		"cluster_id": testClusterID, "namespace": "default", "pod_name": "web-7d9c-abcde", "command": []any{"rm", "-rf", "/"},
+	}}},
+	{name: "pod_exec_secret_denied", toolCall: toolCall{"k8s_pod_exec", map[string]any{
+		"cluster_id": testClusterID, "namespace": "default", "pod_name": "web-7d9c-abcde",
+		"command": []any{"cat", "/var/run/secrets/kubernetes.io/serviceaccount/token"},
+	}}},
+	{name: "pod_exec_unknown_container", toolCall: toolCall{"k8s_pod_exec", map[string]any{
+		"cluster_id": testClusterID, "namespace": "default", "pod_name": "web-7d9c-abcde", "container": "sidecar", "command": []any{"ls"},
+	}}},
+	{name: "pod_get_logs", toolCall: toolCall{"k8s_pod_get_logs", map[string]any{"cluster_id": testClusterID, "namespace": "default", "pod_name": "web-7d9c-abcde", "tail_lines": 10}}},
+	{name: "pod_get_logs_grep", toolCall: toolCall{"k8s_pod_get_logs", map[string]any{
+		"cluster_id": testClusterID, "namespace": "default", "pod_name": "web-7d9c-abcde", "container": "app", "previous": true, "since_seconds": 600, "grep": "^fake",
+	}}},
+	{name: "pod_get_logs_grep_no_match", toolCall: toolCall{"k8s_pod_get_logs", map[string]any{
+		"cluster_id": testClusterID, "namespace": "default", "pod_name": "web-7d9c-abcde", "grep": "ERROR",
+	}}},
+	{name: "pod_get_logs_invalid_since", toolCall: toolCall{"k8s_pod_get_logs", map[string]any{
+		"cluster_id": testClusterID, "namespace": "default", "pod_name": "web-7d9c-abcde", "since_seconds": 60, "since_time": "2024-01-02T03:04:05Z",
+	}}},
+	{name: "pod_logs_follow", toolCall: toolCall{"k8s_pod_logs_follow", map[string]any{
+		"cluster_id": testClusterID, "namespace": "default", "pod_name": "web-7d9c-abcde", "container": "app", "duration_seconds": 5,
+	}}},
+	{name: "pod_logs_follow_max_lines", toolCall: toolCall{"k8s_pod_logs_follow", map[string]any{
+		"cluster_id": testClusterID, "namespace": "default", "pod_name": "web-7d9c-abcde", "max_lines": 1,
+	}}},
+	{name: "pod_diagnose_crashloop", toolCall: toolCall{"k8s_pod_diagnose", map[string]any{
+		"cluster_id": testClusterID, "namespace": "default", "pod_name": "api-6c5d-k2x9p",
+	}}},
+	{name: "pod_diagnose_unschedulable", toolCall: toolCall{"k8s_pod_diagnose", map[string]any{
+		"cluster_id": testClusterID, "namespace": "default", "pod_name": "report-q8z7w",
+	}}},
+	{name: "pod_diagnose_restarts", toolCall: toolCall{"k8s_pod_diagnose", map[string]any{
+		"cluster_id": testClusterID, "namespace": "default", "pod_name": "web-7d9c-abcde",
+	}}},
+	{name: "logs_by_selector_workload", toolCall: toolCall{"k8s_logs_by_selector", map[string]any{
+		"cluster_id": testClusterID, "namespace": "default", "kind": "deployment", "name": "web",
+	}}},
+	{name: "logs_by_selector_labels", toolCall: toolCall{"k8s_logs_by_selector", map[string]any{
+		"cluster_id": testClusterID, "namespace": "default", "label_selector": "app in (web, migrate)", "timestamps": true,
+	}}},
+	{name: "logs_by_selector_invalid", toolCall: toolCall{"k8s_logs_by_selector", map[string]any{
+		"cluster_id": testClusterID, "namespace": "default", "label_selector": "app=web", "kind": "deployment", "name": "web",
+	}}},
+	{name: "logs_export", toolCall: toolCall{"k8s_logs_export", map[string]any{
+		"cluster_id": testClusterID, "namespace": "default", "kind": "deployment", "name": "web", "since_seconds": 3600,
+	}}},
+	{name: "logs_export_zip", toolCall: toolCall{"k8s_logs_export", map[string]any{
+		"cluster_id": testClusterID, "namespace": "default", "format": "zip", "error_pattern": "fake",
+	}}},
+	{name: "logs_export_invalid_pattern", toolCall: toolCall{"k8s_logs_export", map[string]any{
+		"cluster_id": testClusterID, "namespace": "default", "error_pattern": "(unclosed",
+	}}},
+
+	// Deployments
+	{name: "deployment_scale", toolCall: toolCall{"k8s_deployment_scale", map[string]any{"cluster_id": testClusterID, "namespace": "default", "deployment_name": "web", "replicas": 3}}},
+	{name: "deployment_get_info", toolCall: toolCall{"k8s_deployment_get_info", map[string]any{"cluster_id": testClusterID, "namespace": "default", "deployment_name": "web"}}},
+	{name: "deployment_list", toolCall: toolCall{"k8s_deployment_list", map[string]any{"cluster_id": testClusterID, "namespace": "default"}}},
+	{name: "deployment_restart", toolCall: toolCall{"k8s_deployment_restart", map[string]any{"cluster_id": testClusterID, "namespace": "default", "deployment_name": "web"}}},
+	{name: "deployment_rollout_status", toolCall: toolCall{"k8s_deployment_rollout_status", map[string]any{"cluster_id": testClusterID, "namespace": "default", "deployment_name": "web", "timeout_seconds": 0}}},
+	{name: "deployment_history", toolCall: toolCall{"k8s_deployment_history", map[string]any{"cluster_id": testClusterID, "namespace": "default", "deployment_name": "web"}}},
+	{name: "deployment_undo", toolCall: toolCall{"k8s_deployment_undo", map[string]any{"cluster_id": testClusterID, "namespace": "default", "deployment_name": "web"}}},
+	{name: "deployment_pause", toolCall: toolCall{"k8s_deployment_pause", map[string]any{"cluster_id": testClusterID, "namespace": "default", "deployment_name": "web"}}},
+	{name: "deployment_resume", toolCall: toolCall{"k8s_deployment_resume", map[string]any{"cluster_id": testClusterID, "namespace": "default", "deployment_name": "web"}}},
+
+	// Workload edits
+	{name: "workload_set_image", toolCall: toolCall{"k8s_workload_set_image", map[string]any{
+		"cluster_id": testClusterID, "namespace": "default", "kind": "deployment", "name": "web", "images": map[string]any{"app": "nginx:1.26"},
+	}}},
+	{name: "workload_set_env", toolCall: toolCall{"k8s_workload_set_env", map[string]any{
+		"cluster_id": testClusterID, "namespace": "default", "kind": "statefulset", "name": "db",
+		"set":    []any{map[string]any{"name": "LOG_LEVEL", "value": "debug"}},
+		"remove": []any{"UNUSED"},
+	}}},
+	{name: "workload_set_resources", toolCall: toolCall{"k8s_workload_set_resources", map[string]any{
+		"cluster_id": testClusterID, "namespace": "default", "kind": "cronjob", "name": "nightly",
+		"requests": map[string]any{"cpu": "100m"}, "limits": map[string]any{"memory": "256Mi"},
+	}}},
+
+	// Namespaces and storage
+	{name: "namespace_list", toolCall: toolCall{"k8s_namespace_list", map[string]any{"cluster_id": testClusterID}}},
+	{name: "namespace_get", toolCall: toolCall{"k8s_namespace_get", map[string]any{"cluster_id": testClusterID, "namespace": "default"}}},
+	{name: "namespace_create", toolCall: toolCall{"k8s_namespace_create", map[string]any{"cluster_id": testClusterID, "namespace": "staging"}}},
+	{name: "namespace_delete", toolCall: toolCall{"k8s_namespace_delete", map[string]any{"cluster_id": testClusterID, "namespace": "default"}}},
+	{name: "persistentvolume_list", toolCall: toolCall{"k8s_persistentvolume_list", map[string]any{"cluster_id": testClusterID}}},
+	{name: "storageclass_list", toolCall: toolCall{"k8s_storageclass_list", map[string]any{"cluster_id": testClusterID}}},
+
+	// Audit
+	{
+		name:     "audit_query",
+		before:   []toolCall{{"k8s_configmap_get", map[string]any{"cluster_id": testClusterID, "namespace": "default", "configmap_name": "app-config"}}},
+		toolCall: toolCall{"k8s_audit_query", map[string]any{"tool": "k8s_configmap_*"}},
+	},
+}
+
+// newToolsHarness starts the harness TestTools uses: fixtures loaded, audit
+// log in a temporary directory, no confirmation prompts.
+func newToolsHarness(t *testing.T) *testHarness {
+	t.Helper()
+	return newTestHarness(t, toolsHarnessOptions(t), fixtureObjects()...)
+}
+
+// toolsHarnessOptions returns the options of newToolsHarness.
+func toolsHarnessOptions(t *testing.T) ServerOptions {
+	t.Helper()
+
+	store, err := infrastructure.NewFileAuditLog(filepath.Join(t.TempDir(), "audit.log"), 1<<20, 1)
+	if err != nil {
+		t.Fatalf("open audit log: %v", err)
+	}
+	t.Cleanup(func() { _ = store.Close() })
+	logger := infrastructure.NewLeveledLogger(infrastructure.LogLevelError)
+	opts := ServerOptions{
+		Audit: usecase.NewAuditUseCase(store, logger),
+		Exec:  domain.ExecPolicy{AllowCommands: []string{"cat *", "ls"}, DenyCommands: []string{"*/run/secrets/*"}},
+
+		LogExportDir: t.TempDir(),
+		ManifestRoot: filepath.Join("testdata", "manifests"),
+	}
+	return opts
+}
+
+func TestTools(t *testing.T) {
+	for _, tc := range toolCases {
+		t.Run(tc.name, func(t *testing.T) {
+			h := newToolsHarness(t)
+			for _, call := range tc.before {
+				h.call(t, call.tool, call.args)
+			}
+			res := h.call(t, tc.tool, tc.args)
+			assertGolden(t, tc.name, renderResult(res))
+		})
+	}
+}
+
+// TestToolsCovered fails when a tool is registered without a case in
+// toolCases, so new tools get a golden file.
+func TestToolsCovered(t *testing.T) {
+	h := newToolsHarness(t)
+
+	covered := make(map[string]bool)
+	for _, tc := range toolCases {
+		covered[tc.tool] = true
+	}
+	names := h.toolNames(t)
+	for _, name := range names {
+		if !covered[name] {
+			t.Errorf("tool %s has no case in toolCases", name)
+		}
+	}
+	for tool := range covered {
+		if !slices.Contains(names, tool) {
+			t.Errorf("toolCases covers %s, which is not registered", tool)
+		}
+	}
+}
+
+// TestReadOnlyTools runs the cases of the tools exposed in read-only mode
+// and fails when one of them changes a cluster object.
+func TestReadOnlyTools(t *testing.T) {
+	opts := toolsHarnessOptions(t)
+	opts.ReadOnly = true
+	exposed := newTestHarness(t, opts).toolNames(t)
+	for name := range readOnlyTools {
+		if !slices.Contains(exposed, name) {
+			t.Errorf("read-only tool %s is not exposed in read-only mode", name)
+		}
+	}
+
+	for _, tc := range toolCases {
+		if !slices.Contains(exposed, tc.tool) {
+			continue
+		}
+		t.Run(tc.name, func(t *testing.T) {
+			h := newTestHarness(t, opts, fixtureObjects()...)
+			for _, call := range tc.before {
+				h.call(t, call.tool, call.args)
+			}
+			h.call(t, tc.tool, tc.args)
+			actions := append(h.clientset.Actions(), h.dynamic.Actions()...)
+			for _, action := range actions {
+				if isWriteAction(action, tc.tool == "k8s_diff_yaml") {
+					t.Errorf("%s is exposed in read-only mode but sent %s %s", tc.tool, action.GetVerb(), action.GetResource().Resource)
+				}
+			}
+		})
+	}
+}
+
+// isWriteAction reports whether a fake client action changes an object.
+// Access reviews are created without changing anything. The dynamic fake
+// drops patch options, so the dry-run applies k8s_diff_yaml sends count as
+// writes unless dryRun is set.
+func isWriteAction(action clienttesting.Action, dryRun bool) bool {
+	switch action.GetVerb() {
+	case "create":
+		return !strings.HasSuffix(action.GetResource().Resource, "accessreviews")
+	case "patch":
+		patch, ok := action.(clienttesting.PatchAction)
+		return !dryRun || !ok || patch.GetPatchType() != types.ApplyPatchType
+	case "update", "delete", "delete-collection":
+		return true
+	}
+	return false
+}
+
+// TestLogsExportResource reads the archive behind the resource link that
+// k8s_logs_export returns.
+func TestLogsExportResource(t *testing.T) {
+	h := newToolsHarness(t)
+	res := h.call(t, "k8s_logs_export", map[string]any{"cluster_id": testClusterID, "namespace": "default", "kind": "deployment", "name": "web"})
+	if res.IsError {
+		t.Fatalf("export failed: %s", renderResult(res))
+	}
+	var link *mcp.ResourceLink
+	for _, c := range res.Content {
+		if l, ok := c.(*mcp.ResourceLink); ok {
+			link = l
+		}
+	}
+	if link == nil {
+		t.Fatalf("no resource link in %s", renderResult(res))
+	}
+
+	read, err := h.session.ReadResource(context.Background(), &mcp.ReadResourceParams{URI: link.URI})
+	if err != nil {
+		t.Fatalf("read %s: %v", link.URI, err)
+	}
+	gz, err := gzip.NewReader(bytes.NewReader(read.Contents[0].Blob))
+	if err != nil {
+		t.Fatalf("open archive: %v", err)
+	}
+	files := make(map[string]string)
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("read archive: %v", err)
+		}
+		data, _ := io.ReadAll(tr)
+		files[hdr.Name] = string(data)
+	}
+	if got := files["web-7d9c-abcde/app.log"]; got != "fake logs\n" {
+		t.Errorf("web-7d9c-abcde/app.log = %q, want %q", got, "fake logs\n")
+	}
+	if _, ok := files["summary.json"]; !ok {
+		t.Errorf("archive has no summary.json, files: %v", slices.Sorted(maps.Keys(files)))
+	}
+
+	other := h.connect(t, nil)
+	if _, err := other.ReadResource(context.Background(), &mcp.ReadResourceParams{URI: link.URI}); err == nil {
+		t.Errorf("another session read %s", link.URI)
+	}
+}
+
+// TestLogsExportRetention expects archives beyond the TTL or count limit to
+// be deleted and no longer readable.
+func TestLogsExportRetention(t *testing.T) {
+	export := func(t *testing.T, h *testHarness) (string, string) {
+		t.Helper()
+		res := h.call(t, "k8s_logs_export", map[string]any{"cluster_id": testClusterID, "namespace": "default", "kind": "deployment", "name": "web"})
+		if res.IsError {
+			t.Fatalf("export failed: %s", renderResult(res))
+		}
+		out := res.StructuredContent.(map[string]any)
+		return logExportURIPrefix + out["name"].(string), out["path"].(string)
+	}
+	expectDeleted := func(t *testing.T, h *testHarness, uri, path string) {
+		t.Helper()
+		if _, err := os.Stat(path); !errors.Is(err, os.ErrNotExist) {
+			t.Errorf("stat %s: %v, want not exist", path, err)
+		}
+		if _, err := h.session.ReadResource(context.Background(), &mcp.ReadResourceParams{URI: uri}); err == nil {
+			t.Errorf("read %s after it was deleted", uri)
+		}
+	}
+
+	t.Run("max archives", func(t *testing.T) {
+		opts := toolsHarnessOptions(t)
+		opts.LogExportMaxArchives = 1
+		h := newTestHarness(t, opts, fixtureObjects()...)
+		firstURI, firstPath := export(t, h)
+		secondURI, secondPath := export(t, h)
+		expectDeleted(t, h, firstURI, firstPath)
+		if _, err := h.session.ReadResource(context.Background(), &mcp.ReadResourceParams{URI: secondURI}); err != nil {
+			t.Errorf("read %s: %v", secondURI, err)
+		}
+		if _, err := os.Stat(secondPath); err != nil {
+			t.Error(err)
+		}
+	})
+
+	t.Run("ttl", func(t *testing.T) {
+		opts := toolsHarnessOptions(t)
+		opts.LogExportTTL = 100 * time.Millisecond
+		h := newTestHarness(t, opts, fixtureObjects()...)
+		uri, path := export(t, h)
+		time.Sleep(300 * time.Millisecond)
+		expectDeleted(t, h, uri, path)
+	})
+}
+
+// TestEventsWatch creates a Warning event while k8s_events_watch runs and
+// expects the watch to report it and stop at max_events.
+func TestEventsWatch(t *testing.T) {
+	h := newToolsHarness(t)
+	go func() {
+		time.Sleep(300 * time.Millisecond)
+		event := &corev1.Event{
+			ObjectMeta:     metav1.ObjectMeta{Name: "web-7d9c-abcde.18a", Namespace: "default"},
+			InvolvedObject: corev1.ObjectReference{Kind: "Pod", Namespace: "default", Name: "web-7d9c-abcde"},
+			Reason:         "BackOff",
+			Message:        "Back-off restarting failed container app",
+			Type:           corev1.EventTypeWarning,
+			Count:          1,
+			LastTimestamp:  metav1.Now(),
+		}
+		if _, err := h.clientset.CoreV1().Events("default").Create(context.Background(), event, metav1.CreateOptions{}); err != nil {
+			t.Errorf("create event: %v", err)
+		}
+	}()
+
+	res := h.call(t, "k8s_events_watch", map[string]any{"cluster_id": testClusterID, "namespace": "default", "duration_seconds": 10, "max_events": 1})
+	if res.IsError {
+		t.Fatalf("watch failed: %s", renderResult(res))
+	}
+	got := renderResult(res)
+	for _, want := range []string{"1 new Warning event(s), stopped at max_events (1)", "[Warning, BackOff, 1x] Back-off restarting failed container app (on Pod/web-7d9c-abcde)"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("result does not contain %q:\n%s", want, got)
+		}
+	}
+}
+
+func TestTopMetricsUnavailable(t *testing.T) {
+	h := newToolsHarness(t)
+	h.metrics.PrependReactor("list", "*", func(action clienttesting.Action) (bool, runtime.Object, error) {
+		return true, nil, apierrors.NewNotFound(action.GetResource().GroupResource(), "")
+	})
+
+	for _, tool := range []string{"k8s_top_nodes", "k8s_top_pods"} {
+		res := h.call(t, tool, map[string]any{"cluster_id": testClusterID})
+		if res.IsError {
+			t.Fatalf("%s failed: %s", tool, renderResult(res))
+		}
+		if got := renderResult(res); !strings.Contains(got, "install metrics-server") {
+			t.Errorf("%s does not explain the missing metrics API:\n%s", tool, got)
+		}
+	}
+}
+
+// TestObjectResources reads objects through the k8s:// resource templates.
+func TestObjectResources(t *testing.T) {
+	h := newToolsHarness(t)
+	ctx := context.Background()
+
+	templates, err := h.session.ListResourceTemplates(ctx, nil)
+	if err != nil {
+		t.Fatalf("list resource templates: %v", err)
+	}
+	var uriTemplates []string
+	for _, tmpl := range templates.ResourceTemplates {
+		uriTemplates = append(uriTemplates, tmpl.URITemplate)
+	}
+	for _, want := range []string{namespacedObjectTemplate, clusterObjectTemplate} {
+		if !slices.Contains(uriTemplates, want) {
+			t.Errorf("resource templates %v do not include %s", uriTemplates, want)
+		}
+	}
+
+	cases := []struct {
+		uri     string
+		want    []string
+		notWant []string
+	}{
+		{
+			uri:     "k8s://test/default/deployment/web",
+			want:    []string{"kind: Deployment", "name: web", "replicas: 2", "image: nginx:1.25"},
+			notWant: []string{"uid:", "resourceVersion:", "creationTimestamp: \"", "managedFields"},
+		},
+		{uri: "k8s://test/default/secret/app-secret", want: []string{"[REDACTED]"}, notWant: []string{"aHVudGVyMg"}},
+		{uri: "k8s://test/no/node-1", want: []string{"kind: Node", "name: node-1"}},
+		{uri: "k8s://test/default/cert/web-tls", want: []string{"kind: Certificate", "cert-manager.io/certificate-cleanup"}},
+	}
+	for _, tc := range cases {
+		read, err := h.session.ReadResource(ctx, &mcp.ReadResourceParams{URI: tc.uri})
+		if err != nil {
+			t.Errorf("read %s: %v", tc.uri, err)
+			continue
+		}
+		got := read.Contents[0].Text
+		if mime := read.Contents[0].MIMEType; mime != "application/yaml" {
+			t.Errorf("read %s: MIME type %q, want application/yaml", tc.uri, mime)
+		}
+		for _, want := range tc.want {
+			if !strings.Contains(got, want) {
+				t.Errorf("read %s: no %q in\n%s", tc.uri, want, got)
+			}
+		}
+		for _, notWant := range tc.notWant {
+			if strings.Contains(got, notWant) {
+				t.Errorf("read %s: unexpected %q in\n%s", tc.uri, notWant, got)
+			}
+		}
+	}
+
+	for _, uri := range []string{"k8s://test/default/configmap/missing", "k8s://test/configmap/app-config", "k8s://test/default/widgets/w"} {
+		if _, err := h.session.ReadResource(ctx, &mcp.ReadResourceParams{URI: uri}); err == nil {
+			t.Errorf("read %s: no error", uri)
+		}
+	}
+}
+
+// TestObjectResourceSubscribe expects a resources/updated notification when
+// a subscribed ConfigMap changes, and none after unsubscribing.
+func TestObjectResourceSubscribe(t *testing.T) {
+	h := newToolsHarness(t)
+	ctx := context.Background()
+	const uri = "k8s://test/default/configmap/app-config"
+
+	if err := h.session.Subscribe(ctx, &mcp.SubscribeParams{URI: uri}); err != nil {
+		t.Fatalf("subscribe: %v", err)
+	}
+	updateConfigMap := func(value string) {
+		t.Helper()
+		cm, err := h.clientset.CoreV1().ConfigMaps("default").Get(ctx, "app-config", metav1.GetOptions{})
+		if err != nil {
+			t.Fatalf("get configmap: %v", err)
+		}
+		cm.Data["LOG_LEVEL"] = value
+		if _, err := h.clientset.CoreV1().ConfigMaps("default").Update(ctx, cm, metav1.UpdateOptions{}); err != nil {
+			t.Fatalf("update configmap: %v", err)
+		}
+	}
+
+	updateConfigMap("debug")
+	select {
+	case got := <-h.updated:
+		if got != uri {
+			t.Errorf("notified of %s, want %s", got, uri)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("no resources/updated notification after the ConfigMap changed")
+	}
+
+	if err := h.session.Unsubscribe(ctx, &mcp.UnsubscribeParams{URI: uri}); err != nil {
+		t.Fatalf("unsubscribe: %v", err)
+	}
+	updateConfigMap("warn")
+	select {
+	case got := <-h.updated:
+		t.Errorf("notified of %s after unsubscribing", got)
+	case <-time.After(300 * time.Millisecond):
+	}
+
+	if err := h.session.Subscribe(ctx, &mcp.SubscribeParams{URI: "k8s://test/default/widgets/w"}); err == nil {
+		t.Error("subscribing to an unknown kind succeeded")
+	}
+}
+
+// TestObjectResourcePolicy applies the rules of k8s_resource_get to object
+// resources.
+func TestObjectResourcePolicy(t *testing.T) {
+	opts := ServerOptions{PolicyRules: []domain.ToolPolicyRule{{
+		Namespaces: []string{"kube-system"},
+		Tools:      []string{"k8s_resource_get"},
+		Access:     domain.PolicyAccessAny,
+		Effect:     domain.PolicyEffectDeny,
+	}}}
+	h := newTestHarness(t, opts, fixtureObjects()...)
+	ctx := context.Background()
+
+	_, err := h.session.ReadResource(ctx, &mcp.ReadResourceParams{URI: "k8s://test/kube-system/configmap/coredns"})
+	if err == nil || !strings.Contains(err.Error(), "Denied by policy") {
+		t.Errorf("read in kube-system: err = %v, want a policy denial", err)
+	}
+	if err := h.session.Subscribe(ctx, &mcp.SubscribeParams{URI: "k8s://test/kube-system/configmap/coredns"}); err == nil {
+		t.Error("subscribe in kube-system: no error")
+	}
+	if _, err := h.session.ReadResource(ctx, &mcp.ReadResourceParams{URI: "k8s://test/default/configmap/app-config"}); err != nil {
+		t.Errorf("read in default: %v", err)
+	}
+	// Cluster-scoped objects are checked as the namespace a Namespace names
+	// and as every namespace otherwise.
+	for _, uri := range []string{"k8s://test/namespace/kube-system", "k8s://test/node/node-1"} {
+		if _, err := h.session.ReadResource(ctx, &mcp.ReadResourceParams{URI: uri}); err == nil || !strings.Contains(err.Error(), "Denied by policy") {
+			t.Errorf("read %s: err = %v, want a policy denial", uri, err)
+		}
+	}
+	if _, err := h.session.ReadResource(ctx, &mcp.ReadResourceParams{URI: "k8s://test/namespace/default"}); err != nil {
+		t.Errorf("read of namespace default: %v", err)
+	}
+}
+
+// promptCases drive TestPrompts against a harness with the custom prompts in
+// testdata/prompts, comparing with testdata/golden/prompt_<name>.golden.
+var promptCases = []struct {
+	name   string
+	prompt string
+	args   map[string]string
+}{
+	{name: "investigate_failing_pod", prompt: "investigate_failing_pod", args: map[string]string{"cluster_id": testClusterID, "pod": "web-7d9c-abcde"}},
+	{name: "review_namespace_security", prompt: "review_namespace_security", args: map[string]string{"cluster_id": testClusterID, "namespace": "default"}},
+	{name: "plan_node_maintenance", prompt: "plan_node_maintenance", args: map[string]string{"cluster_id": testClusterID, "node": "node-1"}},
+	{name: "prepare_rollout", prompt: "prepare_rollout", args: map[string]string{"cluster_id": testClusterID, "deployment": "web"}},
+	{name: "prepare_rollout_image", prompt: "prepare_rollout", args: map[string]string{"cluster_id": testClusterID, "deployment": "web", "image": "nginx:1.27"}},
+	{name: "custom_check_ingress", prompt: "check_ingress", args: map[string]string{"cluster_id": testClusterID, "ingress": "web", "host": "web.example.com"}},
+}
+
+func newPromptsHarness(t *testing.T, opts ServerOptions) *testHarness {
+	t.Helper()
+
+	prompts, err := infrastructure.LoadPromptTemplates(filepath.Join("testdata", "prompts"))
+	if err != nil {
+		t.Fatalf("load prompts: %v", err)
+	}
+	opts.CustomPrompts = prompts
+	return newTestHarness(t, opts, fixtureObjects()...)
+}
+
+func TestPrompts(t *testing.T) {
+	h := newPromptsHarness(t, ServerOptions{})
+	ctx := context.Background()
+
+	list, err := h.session.ListPrompts(ctx, nil)
+	if err != nil {
+		t.Fatalf("list prompts: %v", err)
+	}
+	var names []string
+	for _, p := range list.Prompts {
+		names = append(names, p.Name)
+	}
+	for _, tc := range promptCases {
+		if !slices.Contains(names, tc.prompt) {
+			t.Errorf("prompts %v do not include %s", names, tc.prompt)
+		}
+	}
+
+	for _, tc := range promptCases {
+		t.Run(tc.name, func(t *testing.T) {
+			res, err := h.session.GetPrompt(ctx, &mcp.GetPromptParams{Name: tc.prompt, Arguments: tc.args})
+			if err != nil {
+				t.Fatalf("get prompt: %v", err)
+			}
+			assertGolden(t, "prompt_"+tc.name, renderPrompt(res))
+		})
+	}
+
+	for _, params := range []*mcp.GetPromptParams{
+		{Name: "investigate_failing_pod", Arguments: map[string]string{"cluster_id": testClusterID}},
+		{Name: "investigate_failing_pod", Arguments: map[string]string{"cluster_id": testClusterID, "pod": "missing"}},
+		{Name: "check_ingress", Arguments: map[string]string{"cluster_id": testClusterID}},
+		{Name: "unknown"},
+	} {
+		if _, err := h.session.GetPrompt(ctx, params); err == nil {
+			t.Errorf("get prompt %s with %v: no error", params.Name, params.Arguments)
+		}
+	}
+}
+
+// TestPromptOptions hides the prompts of hidden tools, applies the policy
+// rules of the tools a prompt reads, and fills in the default cluster.
+func TestPromptOptions(t *testing.T) {
+	opts := ServerOptions{
+		DefaultCluster:     testClusterID,
+		DisabledToolGroups: []string{"node"},
+		PolicyRules: []domain.ToolPolicyRule{{
+			Namespaces: []string{"kube-system"},
+			Tools:      []string{"k8s_pod_*"},
+			Access:     domain.PolicyAccessAny,
+			Effect:     domain.PolicyEffectDeny,
+		}},
+	}
+	h := newPromptsHarness(t, opts)
+	ctx := context.Background()
+
+	list, err := h.session.ListPrompts(ctx, nil)
+	if err != nil {
+		t.Fatalf("list prompts: %v", err)
+	}
+	for _, p := range list.Prompts {
+		if p.Name == "plan_node_maintenance" {
+			t.Error("plan_node_maintenance is listed although the node tools are disabled")
+		}
+		for _, arg := range p.Arguments {
+			if arg.Name == "cluster_id" && arg.Required && p.Name != "check_ingress" {
+				t.Errorf("%s requires cluster_id although a default cluster is set", p.Name)
+			}
+		}
+	}
+
+	_, err = h.session.GetPrompt(ctx, &mcp.GetPromptParams{Name: "investigate_failing_pod", Arguments: map[string]string{"namespace": "kube-system", "pod": "coredns"}})
+	if err == nil || !strings.Contains(err.Error(), "Denied by policy") {
+		t.Errorf("prompt in kube-system: err = %v, want a policy denial", err)
+	}
+	res, err := h.session.GetPrompt(ctx, &mcp.GetPromptParams{Name: "investigate_failing_pod", Arguments: map[string]string{"pod": "web-7d9c-abcde"}})
+	if err != nil {
+		t.Fatalf("prompt with the default cluster: %v", err)
+	}
+	if text := res.Messages[0].Content.(*mcp.TextContent).Text; !strings.Contains(text, "on cluster test") {
+		t.Errorf("prompt does not use the default cluster:\n%s", text)
+	}
+}
+
+func TestCustomPromptErrors(t *testing.T) {
+	cases := map[string]string{
+		"unknown field":       "name: a\ntemplate: hi\nextra: 1\n",
+		"invalid name":        "name: a b\ntemplate: hi\n",
+		"empty template":      "name: a\n",
+		"undeclared argument": "name: a\ntemplate: \"{{.pod}}\"\n",
+		"template syntax":     "name: a\narguments: [{name: pod}]\ntemplate: \"{{.pod\"\n",
+	}
+	for name, content := range cases {
+		dir := t.TempDir()
+		if err := os.WriteFile(filepath.Join(dir, "a.yaml"), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := infrastructure.LoadPromptTemplates(dir); err == nil {
+			t.Errorf("%s: no error", name)
+		}
+	}
+
+	dir := t.TempDir()
+	for _, file := range []string{"a.yaml", "b.yml"} {
+		if err := os.WriteFile(filepath.Join(dir, file), []byte("name: a\ntemplate: hi\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := infrastructure.LoadPromptTemplates(dir); err == nil {
+		t.Error("duplicate names: no error")
+	}
+
+	logger := infrastructure.NewLeveledLogger(infrastructure.LogLevelError)
+	_, err := NewMCPServer(nil, nil, logger, ServerOptions{CustomPrompts: []domain.PromptTemplate{{Name: "prepare_rollout", Template: "hi"}}})
+	if err == nil {
+		t.Error("custom prompt named like a built-in: no error")
+	}
+}
@@ -0,0 +1,383 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/your-org/mcp-k8s-server/internal/domain"
+	"github.com/your-org/mcp-k8s-server/internal/usecase"
+)
+
+// ServerOptions tunes which tools are exposed, how missing arguments are
+// filled in and which calls are allowed.
+type ServerOptions struct {
+	// DefaultCluster is injected as cluster_id when a tool call omits it.
+	DefaultCluster string
+	// DefaultNamespace is injected as namespace when a tool call omits it.
+	DefaultNamespace string
+
+	// EnabledToolGroups, when set, only exposes tools of these groups.
+	EnabledToolGroups []string
+	// DisabledToolGroups hides tools of these groups.
+	DisabledToolGroups []string
+
+	// ReadOnly hides every tool that changes cluster or server state.
+	ReadOnly bool
+	// AllowTools, when set, only exposes tools matching one of the globs.
+	AllowTools []string
+	// DenyTools hides tools matching any of the globs.
+	DenyTools []string
+
+	// PolicyRules allow or deny calls per cluster, namespace and tool.
+	PolicyRules []domain.ToolPolicyRule
+	// ConfirmDestructive asks the user to confirm deletions and NoExecute
+	// taints after showing an impact preview.
+	ConfirmDestructive bool
+
+	// Exec restricts the commands k8s_pod_exec may run. The zero value
+	// allows none.
+	Exec domain.ExecPolicy
+	// LogExportDir is where k8s_logs_export writes archives. Empty disables
+	// exports.
+	LogExportDir string
+	// LogExportTTL deletes archives this long after they were written, and
+	// LogExportMaxArchives the oldest beyond this count. Zero keeps them.
+	LogExportTTL         time.Duration
+	LogExportMaxArchives int
+	// ManifestRoot is the directory the path argument of k8s_apply_yaml and
+	// k8s_diff_yaml is resolved under. Empty disables path.
+	ManifestRoot string
+	// CustomPrompts are offered next to the built-in prompts.
+	CustomPrompts []domain.PromptTemplate
+
+	// Audit, when set, records every tool call and exposes k8s_audit_query.
+	Audit *usecase.AuditUseCase
+}
+
+// toolGroupAliases maps name segments that are not resource names to the
+// group they belong to.
+var toolGroupAliases = map[string]string{
+	"apply":   "manifest",
+	"diff":    "manifest",
+	"port":    "portforward",
+	"events":  "event",
+	"explain": "api",
+}
+
+// readOnlyTools are the tools that only read the cluster; k8s_logs_export
+// writes archives but changes nothing in the cluster. Every other tool,
+// including new ones until they are listed here, is hidden in read-only
+// mode, checked as a write by policy rules and audited as mutating.
+var readOnlyTools = map[string]bool{
+	"k8s_api_resources":             true,
+	"k8s_audit_query":               true,
+	"k8s_cluster_list":              true,
+	"k8s_cluster_status":            true,
+	"k8s_configmap_get":             true,
+	"k8s_configmap_list":            true,
+	"k8s_cronjob_get":               true,
+	"k8s_cronjob_get_logs":          true,
+	"k8s_cronjob_list":              true,
+	"k8s_daemonset_get":             true,
+	"k8s_daemonset_get_pods":        true,
+	"k8s_daemonset_list":            true,
+	"k8s_deployment_get_info":       true,
+	"k8s_deployment_history":        true,
+	"k8s_deployment_list":           true,
+	"k8s_deployment_rollout_status": true,
+	"k8s_diff_yaml":                 true,
+	"k8s_event_list":                true,
+	"k8s_events_for_workload":       true,
+	"k8s_events_watch":              true,
+	"k8s_explain":                   true,
+	"k8s_hpa_get":                   true,
+	"k8s_hpa_list":                  true,
+	"k8s_ingress_get":               true,
+	"k8s_ingress_list":              true,
+	"k8s_job_get":                   true,
+	"k8s_job_get_logs":              true,
+	"k8s_job_list":                  true,
+	"k8s_limitrange_get":            true,
+	"k8s_limitrange_list":           true,
+	"k8s_logs_by_selector":          true,
+	"k8s_logs_export":               true,
+	"k8s_namespace_get":             true,
+	"k8s_namespace_list":            true,
+	"k8s_node_get_metrics":          true,
+	"k8s_node_list":                 true,
+	"k8s_persistentvolume_list":     true,
+	"k8s_pod_diagnose":              true,
+	"k8s_pod_get_logs":              true,
+	"k8s_pod_list":                  true,
+	"k8s_pod_logs_follow":           true,
+	"k8s_port_forward_list":         true,
+	"k8s_quota_get":                 true,
+	"k8s_quota_list":                true,
+	"k8s_rbac_clusterrole_list":     true,
+	"k8s_resource_get":              true,
+	"k8s_resource_list":             true,
+	"k8s_secret_get":                true,
+	"k8s_secret_list":               true,
+	"k8s_service_get":               true,
+	"k8s_service_list":              true,
+	"k8s_statefulset_get":           true,
+	"k8s_statefulset_list":          true,
+	"k8s_storageclass_list":         true,
+	"k8s_top_nodes":                 true,
+	"k8s_top_pods":                  true,
+	"k8s_webhook_mutating_get":      true,
+	"k8s_webhook_mutating_list":     true,
+	"k8s_webhook_validating_get":    true,
+	"k8s_webhook_validating_list":   true,
+}
+
+// toolGroup returns the group of a tool: the resource segment of its name,
+// e.g. "pod" for k8s_pod_list.
+func toolGroup(name string) string {
+	group, _, _ := strings.Cut(strings.TrimPrefix(name, "k8s_"), "_")
+	if alias, ok := toolGroupAliases[group]; ok {
+		return alias
+	}
+	return group
+}
+
+// isReadOnlyTool reports whether a tool only reads state.
+func isReadOnlyTool(name string) bool {
+	return readOnlyTools[name]
+}
+
+func matchAny(patterns []string, name string) bool {
+	for _, p := range patterns {
+		if ok, _ := path.Match(p, name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// disabledReason explains why a tool is hidden under opts, or returns ""
+// when it is exposed.
+func (opts ServerOptions) disabledReason(name string) string {
+	group := toolGroup(name)
+	switch {
+	case len(opts.EnabledToolGroups) > 0 && !slices.Contains(opts.EnabledToolGroups, group):
+		return fmt.Sprintf("tool group %q is not enabled", group)
+	case slices.Contains(opts.DisabledToolGroups, group):
+		return fmt.Sprintf("tool group %q is disabled", group)
+	case opts.ReadOnly && !isReadOnlyTool(name):
+		return "the server is running in read-only mode"
+	case len(opts.AllowTools) > 0 && !matchAny(opts.AllowTools, name):
+		return "the tool is not in the allow list"
+	case matchAny(opts.DenyTools, name):
+		return "the tool is in the deny list"
+	}
+	return ""
+}
+
+// registeredTools lists the tools currently registered on the server by
+// connecting an in-memory client.
+func (m *MCPServer) registeredTools(ctx context.Context) ([]*mcp.Tool, error) {
+	serverTransport, clientTransport := mcp.NewInMemoryTransports()
+	serverSession, err := m.server.Connect(ctx, serverTransport, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer serverSession.Close()
+
+	client := mcp.NewClient(&mcp.Implementation{Name: "tool-inventory", Version: "1.0.0"}, nil)
+	clientSession, err := client.Connect(ctx, clientTransport, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer clientSession.Close()
+
+	var tools []*mcp.Tool
+	for tool, err := range clientSession.Tools(ctx, nil) {
+		if err != nil {
+			return nil, err
+		}
+		tools = append(tools, tool)
+	}
+	sort.Slice(tools, func(i, j int) bool { return tools[i].Name < tools[j].Name })
+	return tools, nil
+}
+
+// toolDefaults records which arguments of a tool may be filled in.
+type toolDefaults struct {
+	cluster   bool
+	namespace bool
+}
+
+// defaultableArguments inspects a tool's input schema. cluster_id is filled
+// in for every tool that takes it except registration, where it names the
+// new cluster. namespace is only filled in where the schema already defaults
+// it to "default"; elsewhere it names a namespace to act on or an empty value
+// means all namespaces.
+func defaultableArguments(tool *mcp.Tool) toolDefaults {
+	schema, _ := tool.InputSchema.(map[string]any)
+	props, _ := schema["properties"].(map[string]any)
+
+	var d toolDefaults
+	if _, ok := props["cluster_id"]; ok && tool.Name != "k8s_cluster_register" {
+		d.cluster = true
+	}
+	if ns, ok := props["namespace"].(map[string]any); ok && ns["default"] == "default" {
+		d.namespace = true
+	}
+	return d
+}
+
+// applyToolOptions removes the tools hidden by opts and installs the
+// middleware that fills in default arguments. Unknown tool groups are
+// reported as errors so typos fail at startup.
+func (m *MCPServer) applyToolOptions(ctx context.Context, opts ServerOptions) error {
+	tools, err := m.registeredTools(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list tools: %w", err)
+	}
+
+	groups := make(map[string]bool)
+	for _, t := range tools {
+		groups[toolGroup(t.Name)] = true
+	}
+	for _, g := range append(slices.Clone(opts.EnabledToolGroups), opts.DisabledToolGroups...) {
+		if !groups[g] {
+			known := make([]string, 0, len(groups))
+			for k := range groups {
+				known = append(known, k)
+			}
+			sort.Strings(known)
+			return fmt.Errorf("unknown tool group %q (known groups: %s)", g, strings.Join(known, ", "))
+		}
+	}
+
+	var hidden []string
+	defaults := make(map[string]toolDefaults)
+	for _, t := range tools {
+		if opts.disabledReason(t.Name) != "" {
+			hidden = append(hidden, t.Name)
+			continue
+		}
+		d := defaultableArguments(t)
+		d.cluster = d.cluster && opts.DefaultCluster != ""
+		d.namespace = d.namespace && opts.DefaultNamespace != ""
+		if d.cluster || d.namespace {
+			defaults[t.Name] = d
+		}
+	}
+	if len(hidden) == len(tools) {
+		return fmt.Errorf("configuration hides every tool")
+	}
+	if len(hidden) > 0 {
+		m.server.RemoveTools(hidden...)
+		m.logger.Info("Hidden tools by configuration", "count", len(hidden), "tools", hidden)
+	}
+
+	// Defaults are filled in first so the policy and the audit log see the
+	// effective cluster and namespace. Auditing wraps the policy and
+	// confirmation steps so rejected calls are recorded too.
+	var middleware []mcp.Middleware
+	if len(defaults) > 0 {
+		middleware = append(middleware, defaultArgumentsMiddleware(defaults, opts.DefaultCluster, opts.DefaultNamespace))
+	}
+	if m.auditUC != nil {
+		middleware = append(middleware, m.auditMiddleware())
+	}
+	policy := usecase.NewPolicyUseCase(opts.PolicyRules)
+	if len(hidden) > 0 || policy.HasRules() {
+		middleware = append(middleware, m.policyMiddleware(opts, policy))
+	}
+	if opts.ConfirmDestructive {
+		middleware = append(middleware, m.confirmationMiddleware())
+	}
+	m.server.AddReceivingMiddleware(middleware...)
+	return nil
+}
+
+// defaultArgumentsMiddleware fills in cluster_id and namespace on tool calls
+// that omit them, before the arguments are validated against the schema.
+// Tools whose cluster_id can be filled in no longer advertise it as required.
+func defaultArgumentsMiddleware(defaults map[string]toolDefaults, cluster, namespace string) mcp.Middleware {
+	return func(next mcp.MethodHandler) mcp.MethodHandler {
+		return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+			switch method {
+			case "tools/call":
+				if params, ok := req.GetParams().(*mcp.CallToolParamsRaw); ok {
+					if d, ok := defaults[params.Name]; ok {
+						params.Arguments = withDefaultArguments(params.Arguments, d, cluster, namespace)
+					}
+				}
+			case "tools/list":
+				res, err := next(ctx, method, req)
+				lr, ok := res.(*mcp.ListToolsResult)
+				if err != nil || !ok {
+					return res, err
+				}
+				copied := *lr
+				copied.Tools = make([]*mcp.Tool, len(lr.Tools))
+				for i, t := range lr.Tools {
+					copied.Tools[i] = t
+					if defaults[t.Name].cluster {
+						tool := *t
+						tool.InputSchema = withoutRequired(t.InputSchema, "cluster_id")
+						copied.Tools[i] = &tool
+					}
+				}
+				return &copied, nil
+			}
+			return next(ctx, method, req)
+		}
+	}
+}
+
+func withDefaultArguments(raw json.RawMessage, d toolDefaults, cluster, namespace string) json.RawMessage {
+	var args map[string]any
+	if len(raw) > 0 {
+		if err := json.Unmarshal(raw, &args); err != nil {
+			// Leave malformed input to the schema validation.
+			return raw
+		}
+	}
+	if args == nil {
+		args = map[string]any{}
+	}
+
+	changed := false
+	if v, _ := args["cluster_id"].(string); v == "" && d.cluster {
+		args["cluster_id"] = cluster
+		changed = true
+	}
+	if v, _ := args["namespace"].(string); v == "" && d.namespace {
+		args["namespace"] = namespace
+		changed = true
+	}
+	if !changed {
+		return raw
+	}
+
+	data, err := json.Marshal(args)
+	if err != nil {
+		return raw
+	}
+	return data
+}
+
+// withoutRequired returns a copy of schema with field removed from its
+// required list. The stored schema is never modified.
+func withoutRequired(schema any, field string) any {
+	s, ok := schema.(*jsonschema.Schema)
+	if !ok || !slices.Contains(s.Required, field) {
+		return schema
+	}
+	copied := *s
+	copied.Required = slices.DeleteFunc(slices.Clone(s.Required), func(r string) bool { return r == field })
+	return &copied
+}
@@ -0,0 +1,211 @@
+package mcp
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+const (
+	TransportStdio = "stdio"
+	TransportHTTP  = "http"
+	TransportSSE   = "sse"
+)
+
+const (
+	// streamableHTTPPath serves the streamable HTTP transport.
+	streamableHTTPPath = "/mcp"
+	// ssePath serves the legacy HTTP+SSE transport (GET opens the stream,
+	// POST ?sessionid=... delivers messages).
+	ssePath = "/sse"
+	// healthPath serves a liveness probe.
+	healthPath = "/healthz"
+
+	shutdownTimeout = 10 * time.Second
+)
+
+// TransportConfig selects how the MCP server is exposed.
+type TransportConfig struct {
+	// Type is one of TransportStdio, TransportHTTP or TransportSSE.
+	Type string
+	// Addr is the listen address for the HTTP based transports, e.g.
+	// "127.0.0.1:8080".
+	Addr string
+	// AuthToken, when set, must be sent as "Authorization: Bearer <token>"
+	// on every MCP request over HTTP.
+	AuthToken string
+	// AllowUnauthenticated permits listening on a non-loopback address
+	// without AuthToken.
+	AllowUnauthenticated bool
+}
+
+// Validate reports whether the configuration is usable.
+func (c TransportConfig) Validate() error {
+	switch c.Type {
+	case TransportStdio:
+		return nil
+	case TransportHTTP, TransportSSE:
+		if c.Addr == "" {
+			return fmt.Errorf("listen address is required for %s transport", c.Type)
+		}
+		if c.AuthToken == "" && !c.AllowUnauthenticated && !isLoopbackAddr(c.Addr) {
+			return fmt.Errorf("refusing to serve %s on %s without an auth token: anyone who can reach it could run every tool; set transport.auth_token, listen on a loopback address or set transport.allow_unauthenticated", c.Type, c.Addr)
+		}
+		return nil
+	default:
+		return fmt.Errorf("unknown transport %q (want %s, %s or %s)", c.Type, TransportStdio, TransportHTTP, TransportSSE)
+	}
+}
+
+// isLoopbackAddr reports whether a listen address only accepts local
+// connections. An empty host listens on every interface.
+func isLoopbackAddr(addr string) bool {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return false
+	}
+	return isLoopbackHost(host)
+}
+
+// isLoopbackHost reports whether host, without a port, names the local
+// machine.
+func isLoopbackHost(host string) bool {
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(strings.Trim(host, "[]"))
+	return ip != nil && ip.IsLoopback()
+}
+
+// serveHTTP exposes the server over HTTP until ctx is cancelled, then shuts
+// the listener down gracefully.
+//
+// Every client connection gets its own mcp.ServerSession (identified by the
+// Mcp-Session-Id header or the SSE session id), so protocol state such as
+// initialization parameters, log level and in-flight requests is never
+// shared between assistants connected to the same instance.
+func (m *MCPServer) serveHTTP(ctx context.Context, cfg TransportConfig) error {
+	if cfg.AuthToken == "" && !isLoopbackAddr(cfg.Addr) {
+		m.logger.Warn("MCP HTTP server accepts requests from the network without authentication", "addr", cfg.Addr)
+	}
+
+	srv := &http.Server{
+		Addr:              cfg.Addr,
+		Handler:           m.httpHandler(cfg),
+		ReadHeaderTimeout: 10 * time.Second,
+		// Long-lived streams inherit ctx so they end when shutdown starts.
+		BaseContext: func(net.Listener) context.Context { return ctx },
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		m.logger.Info("Starting MCP server", "transport", cfg.Type, "addr", cfg.Addr)
+		errCh <- srv.ListenAndServe()
+	}()
+
+	select {
+	case err := <-errCh:
+		return fmt.Errorf("http server: %w", err)
+	case <-ctx.Done():
+	}
+
+	m.logger.Info("Stopping MCP HTTP server", "transport", cfg.Type)
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		m.logger.Warn("Graceful shutdown timed out, closing connections", "error", err)
+		_ = srv.Close()
+	}
+
+	if err := <-errCh; err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("http server: %w", err)
+	}
+	return nil
+}
+
+// httpHandler routes the MCP endpoint of cfg.Type, behind the bearer token
+// check when cfg.AuthToken is set, and the unauthenticated health endpoint,
+// both behind the DNS rebinding checks.
+func (m *MCPServer) httpHandler(cfg TransportConfig) http.Handler {
+	getServer := func(*http.Request) *mcp.Server { return m.server }
+
+	var handler http.Handler
+	path := streamableHTTPPath
+	switch cfg.Type {
+	case TransportHTTP:
+		handler = mcp.NewStreamableHTTPHandler(getServer, nil)
+	case TransportSSE:
+		handler = mcp.NewSSEHandler(getServer, nil)
+		path = ssePath
+	}
+	if cfg.AuthToken != "" {
+		handler = requireBearerToken(cfg.AuthToken, handler)
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle(path, handler)
+	mux.HandleFunc(healthPath, m.handleHealth(cfg))
+	return rejectRebinding(isLoopbackAddr(cfg.Addr), mux)
+}
+
+// rejectRebinding refuses requests a web page could send after pointing
+// its own host name at this server: cross-origin requests, whose Origin
+// does not name the host they are sent to, and on loopback listeners
+// requests for a host that is not a loopback name.
+func rejectRebinding(loopback bool, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		host := r.Host
+		if h, _, err := net.SplitHostPort(host); err == nil {
+			host = h
+		}
+		if loopback && !isLoopbackHost(host) {
+			http.Error(w, "forbidden host", http.StatusForbidden)
+			return
+		}
+		if origin := r.Header.Get("Origin"); origin != "" {
+			u, err := url.Parse(origin)
+			if err != nil || !strings.EqualFold(u.Host, r.Host) {
+				http.Error(w, "cross-origin request", http.StatusForbidden)
+				return
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// requireBearerToken rejects requests that do not carry token as a bearer
+// token.
+func requireBearerToken(token string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(got), []byte(token)) != 1 {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="mcp"`)
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (m *MCPServer) handleHealth(cfg TransportConfig) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		status := map[string]any{
+			"status":    "ok",
+			"transport": cfg.Type,
+		}
+		if clusters, err := m.clusterUC.ListRegisteredClusters(); err == nil {
+			status["clusters"] = len(clusters)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(mustMarshalJSON(status))
+	}
+}
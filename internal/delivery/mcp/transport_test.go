@@ -0,0 +1,164 @@
+package mcp
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+const testAuthToken = "s3cr3t-token"
+
+// bearerTransport adds an Authorization header to every request.
+type bearerTransport struct {
+	token string
+}
+
+func (b bearerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.Header.Set("Authorization", "Bearer "+b.token)
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+// clientTransport returns the client side of a transport type, sending token
+// when it is not empty.
+func clientTransport(typ, baseURL, token string) mcp.Transport {
+	httpClient := http.DefaultClient
+	if token != "" {
+		httpClient = &http.Client{Transport: bearerTransport{token}}
+	}
+	if typ == TransportSSE {
+		return &mcp.SSEClientTransport{Endpoint: clientEndpoint(typ, baseURL), HTTPClient: httpClient}
+	}
+	return &mcp.StreamableClientTransport{Endpoint: clientEndpoint(typ, baseURL), HTTPClient: httpClient, MaxRetries: -1}
+}
+
+func clientEndpoint(typ, baseURL string) string {
+	if typ == TransportSSE {
+		return baseURL + ssePath
+	}
+	return baseURL + streamableHTTPPath
+}
+
+// TestHTTPTransports initializes sessions over both HTTP transports with
+// and without the bearer token.
+func TestHTTPTransports(t *testing.T) {
+	h := newTestHarness(t, ServerOptions{}, fixtureObjects()...)
+
+	for _, typ := range []string{TransportHTTP, TransportSSE} {
+		t.Run(typ, func(t *testing.T) {
+			srv := httptest.NewServer(h.server.httpHandler(TransportConfig{Type: typ, Addr: "127.0.0.1:0", AuthToken: testAuthToken}))
+			t.Cleanup(srv.Close)
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+			client := mcp.NewClient(&mcp.Implementation{Name: "transport-test", Version: "v0.0.0"}, nil)
+
+			session, err := client.Connect(ctx, clientTransport(typ, srv.URL, testAuthToken), nil)
+			if err != nil {
+				t.Fatalf("initialize with token: %v", err)
+			}
+			if name := session.InitializeResult().ServerInfo.Name; name == "" {
+				t.Error("initialize result has no server name")
+			}
+			res, err := session.CallTool(ctx, &mcp.CallToolParams{Name: "k8s_cluster_list", Arguments: map[string]any{}})
+			if err != nil || res.IsError {
+				t.Errorf("call over %s: %v %v", typ, err, res)
+			}
+			_ = session.Close()
+
+			for _, token := range []string{"", "wrong"} {
+				if session, err := client.Connect(ctx, clientTransport(typ, srv.URL, token), nil); err == nil {
+					_ = session.Close()
+					t.Errorf("initialize with token %q succeeded", token)
+				}
+			}
+
+			req, _ := http.NewRequest(http.MethodPost, clientEndpoint(typ, srv.URL), nil)
+			req.Header.Set("Authorization", "Bearer "+testAuthToken)
+			req.Header.Set("Origin", "http://evil.example.com")
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			_ = resp.Body.Close()
+			if resp.StatusCode != http.StatusForbidden {
+				t.Errorf("cross-origin request with token: status %d, want 403", resp.StatusCode)
+			}
+
+			resp, err = http.Get(srv.URL + healthPath)
+			if err != nil {
+				t.Fatal(err)
+			}
+			_ = resp.Body.Close()
+			if resp.StatusCode != http.StatusOK {
+				t.Errorf("health without token: status %d, want 200", resp.StatusCode)
+			}
+		})
+	}
+}
+
+// TestRebindingProtection rejects requests for foreign hosts on loopback
+// listeners and cross-origin requests on every listener.
+func TestRebindingProtection(t *testing.T) {
+	h := newTestHarness(t, ServerOptions{}, fixtureObjects()...)
+
+	for _, tc := range []struct {
+		name       string
+		addr       string
+		host       string
+		origin     string
+		wantStatus int
+	}{
+		{"loopback", "127.0.0.1:8080", "127.0.0.1:8080", "", http.StatusOK},
+		{"localhost", "localhost:8080", "localhost:8080", "", http.StatusOK},
+		{"ipv6 loopback", "[::1]:8080", "[::1]:8080", "", http.StatusOK},
+		{"same origin", "127.0.0.1:8080", "localhost:8080", "http://localhost:8080", http.StatusOK},
+		{"rebound host", "127.0.0.1:8080", "evil.example.com:8080", "", http.StatusForbidden},
+		{"rebound host and origin", "127.0.0.1:8080", "evil.example.com:8080", "http://evil.example.com:8080", http.StatusForbidden},
+		{"cross origin", "127.0.0.1:8080", "127.0.0.1:8080", "http://evil.example.com", http.StatusForbidden},
+		{"null origin", "127.0.0.1:8080", "127.0.0.1:8080", "null", http.StatusForbidden},
+		{"network listener", "0.0.0.0:8080", "mcp.example.com:8080", "", http.StatusOK},
+		{"network listener cross origin", "0.0.0.0:8080", "mcp.example.com:8080", "http://evil.example.com", http.StatusForbidden},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			handler := h.server.httpHandler(TransportConfig{Type: TransportHTTP, Addr: tc.addr, AllowUnauthenticated: true})
+			req := httptest.NewRequest(http.MethodGet, healthPath, nil)
+			req.Host = tc.host
+			if tc.origin != "" {
+				req.Header.Set("Origin", tc.origin)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+			if rec.Code != tc.wantStatus {
+				t.Errorf("status %d, want %d: %s", rec.Code, tc.wantStatus, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestTransportConfigValidate(t *testing.T) {
+	for _, tc := range []struct {
+		cfg   TransportConfig
+		valid bool
+	}{
+		{TransportConfig{Type: TransportStdio}, true},
+		{TransportConfig{Type: TransportHTTP, Addr: "127.0.0.1:8080"}, true},
+		{TransportConfig{Type: TransportHTTP, Addr: "localhost:8080"}, true},
+		{TransportConfig{Type: TransportSSE, Addr: "[::1]:8080"}, true},
+		{TransportConfig{Type: TransportHTTP, Addr: ":8080"}, false},
+		{TransportConfig{Type: TransportHTTP, Addr: "0.0.0.0:8080"}, false},
+		{TransportConfig{Type: TransportSSE, Addr: "10.0.0.5:8080"}, false},
+		{TransportConfig{Type: TransportHTTP, Addr: ":8080", AuthToken: testAuthToken}, true},
+		{TransportConfig{Type: TransportHTTP, Addr: ":8080", AllowUnauthenticated: true}, true},
+		{TransportConfig{Type: TransportHTTP}, false},
+		{TransportConfig{Type: "grpc"}, false},
+	} {
+		err := tc.cfg.Validate()
+		if (err == nil) != tc.valid {
+			t.Errorf("Validate(%+v) = %v, want valid %v", tc.cfg, err, tc.valid)
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package domain
+
+// APIResource is a resource type served by a cluster, as listed by
+// kubectl api-resources.
+type APIResource struct {
+	Name       string   `json:"name"`
+	ShortNames []string `json:"short_names,omitempty"`
+	APIVersion string   `json:"api_version"`
+	Kind       string   `json:"kind"`
+	Namespaced bool     `json:"namespaced"`
+	Verbs      []string `json:"verbs"`
+	Categories []string `json:"categories,omitempty"`
+}
+
+// APIResourceFilter selects API resources. Empty fields match everything;
+// Namespaced, when set, selects namespaced or cluster-scoped types.
+type APIResourceFilter struct {
+	APIGroup   string
+	Namespaced *bool
+	Verb       string
+}
+
+// APIResourceList is the result of API discovery. Discovery of some group
+// versions may fail, e.g. when an aggregated API server is down; their
+// errors are in FailedGroups and the other resources are still listed.
+type APIResourceList struct {
+	Resources    []APIResource     `json:"resources"`
+	FailedGroups map[string]string `json:"failed_groups,omitempty"`
+}
+
+// ExplainField is a field of an explained schema.
+type ExplainField struct {
+	Name string `json:"name"`
+	// Type is written as kubectl explain does: string, integer, boolean,
+	// Object, a type name such as Probe, []Container or map[string]string.
+	Type        string   `json:"type"`
+	Required    bool     `json:"required,omitempty"`
+	Description string   `json:"description,omitempty"`
+	Enum        []string `json:"enum,omitempty"`
+}
+
+// ExplainResult documents the field at Path of a resource type, from the
+// cluster's OpenAPI v3 schema, with the fields it contains when it is an
+// object or a list of objects.
+type ExplainResult struct {
+	APIVersion string         `json:"api_version"`
+	Kind       string         `json:"kind"`
+	Path       string         `json:"path"`
+	Field      ExplainField   `json:"field"`
+	Fields     []ExplainField `json:"fields,omitempty"`
+}
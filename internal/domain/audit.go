@@ -0,0 +1,85 @@
+package domain
+
+import (
+	"path"
+	"time"
+)
+
+// AuditStatus is the outcome of an audited tool call.
+type AuditStatus string
+
+const (
+	AuditStatusSuccess   AuditStatus = "success"
+	AuditStatusError     AuditStatus = "error"
+	AuditStatusDenied    AuditStatus = "denied"
+	AuditStatusCancelled AuditStatus = "cancelled"
+	// AuditStatusConfirmationRequired marks calls that returned an impact
+	// preview and a confirm token instead of running.
+	AuditStatusConfirmationRequired AuditStatus = "confirmation_required"
+)
+
+// AuditEntry records one tool invocation. Arguments are sanitized before the
+// entry is stored.
+type AuditEntry struct {
+	Time      time.Time      `json:"time"`
+	SessionID string         `json:"session_id,omitempty"`
+	Client    string         `json:"client,omitempty"`
+	Tool      string         `json:"tool"`
+	ClusterID string         `json:"cluster_id,omitempty"`
+	Namespace string         `json:"namespace,omitempty"`
+	Arguments map[string]any `json:"arguments,omitempty"`
+	Mutating  bool           `json:"mutating"`
+
+	Status     AuditStatus `json:"status"`
+	Error      string      `json:"error,omitempty"`
+	DurationMS int64       `json:"duration_ms"`
+
+	// Objects lists the objects a mutating call targeted with their
+	// resourceVersion before and after the call. An empty version means the
+	// object did not exist.
+	Objects []AuditObject `json:"objects,omitempty"`
+}
+
+type AuditObject struct {
+	Kind                  string `json:"kind"`
+	Namespace             string `json:"namespace,omitempty"`
+	Name                  string `json:"name"`
+	ResourceVersionBefore string `json:"resource_version_before,omitempty"`
+	ResourceVersionAfter  string `json:"resource_version_after,omitempty"`
+}
+
+// AuditQuery filters audit entries. Empty fields match anything; Tool is a
+// glob.
+type AuditQuery struct {
+	Tool      string
+	ClusterID string
+	Namespace string
+	SessionID string
+	Status    AuditStatus
+	Since     time.Time
+	Limit     int
+}
+
+// Matches reports whether e passes the filter. Limit is not considered.
+func (q AuditQuery) Matches(e AuditEntry) bool {
+	if q.Tool != "" {
+		if ok, _ := path.Match(q.Tool, e.Tool); !ok {
+			return false
+		}
+	}
+	switch {
+	case q.ClusterID != "" && q.ClusterID != e.ClusterID,
+		q.Namespace != "" && q.Namespace != e.Namespace,
+		q.SessionID != "" && q.SessionID != e.SessionID,
+		q.Status != "" && q.Status != e.Status,
+		!q.Since.IsZero() && e.Time.Before(q.Since):
+		return false
+	}
+	return true
+}
+
+// AuditStore persists audit entries. Query returns the newest entries first.
+type AuditStore interface {
+	Append(entry AuditEntry) error
+	Query(q AuditQuery) ([]AuditEntry, error)
+}
@@ -19,3 +19,20 @@ type ConfigMapCreateOptions struct {
 	Data      map[string]string `json:"data"`
 	Labels    map[string]string `json:"labels,omitempty"`
 }
+
+// ConfigMapSummary is a ConfigMap in a list.
+type ConfigMapSummary struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+	DataCount int    `json:"data_count"`
+	Created   string `json:"created"`
+}
+
+// ConfigMapDetail is a ConfigMap with its data.
+type ConfigMapDetail struct {
+	Name      string            `json:"name"`
+	Namespace string            `json:"namespace"`
+	Data      map[string]string `json:"data"`
+	Labels    map[string]string `json:"labels"`
+	Created   string            `json:"created"`
+}
@@ -42,3 +42,43 @@ type CronJobCreateOptions struct {
 	Labels                     map[string]string `json:"labels,omitempty"`
 	Env                        map[string]string `json:"env,omitempty"`
 }
+
+// CronJobLogs are the logs of the most recent Jobs of a CronJob, newest
+// first.
+type CronJobLogs struct {
+	CronJob   string    `json:"cronjob"`
+	Namespace string    `json:"namespace"`
+	Jobs      []JobLogs `json:"jobs"`
+	// JobsOmitted counts older Jobs that were not read.
+	JobsOmitted int `json:"jobs_omitted,omitempty"`
+}
+
+// CronJobSummary is a CronJob in a list.
+type CronJobSummary struct {
+	Name         string `json:"name"`
+	Namespace    string `json:"namespace"`
+	Schedule     string `json:"schedule"`
+	Suspend      bool   `json:"suspend"`
+	Active       int    `json:"active"`
+	LastSchedule string `json:"last_schedule"`
+	Status       string `json:"status"`
+	Created      string `json:"created"`
+}
+
+// CronJobDetail describes a CronJob, its active Jobs and containers.
+type CronJobDetail struct {
+	Name                       string             `json:"name"`
+	Namespace                  string             `json:"namespace"`
+	Schedule                   string             `json:"schedule"`
+	Suspend                    bool               `json:"suspend"`
+	ConcurrencyPolicy          string             `json:"concurrency_policy"`
+	SuccessfulJobsHistoryLimit int32              `json:"successful_jobs_history_limit"`
+	FailedJobsHistoryLimit     int32              `json:"failed_jobs_history_limit"`
+	LastScheduleTime           string             `json:"last_schedule_time"`
+	LastSuccessfulTime         string             `json:"last_successful_time"`
+	ActiveJobs                 []string           `json:"active_jobs"`
+	ActiveCount                int                `json:"active_count"`
+	Containers                 []ContainerCommand `json:"containers"`
+	Labels                     map[string]string  `json:"labels"`
+	Created                    string             `json:"created"`
+}
@@ -32,3 +32,41 @@ type DaemonSetCreateOptions struct {
 	Namespace string            `json:"namespace"`
 	Labels    map[string]string `json:"labels,omitempty"`
 }
+
+// DaemonSetSummary is a DaemonSet in a list.
+type DaemonSetSummary struct {
+	Name                   string `json:"name"`
+	Namespace              string `json:"namespace"`
+	DesiredNumberScheduled int32  `json:"desired_number_scheduled"`
+	CurrentNumberScheduled int32  `json:"current_number_scheduled"`
+	NumberReady            int32  `json:"number_ready"`
+	NumberAvailable        int32  `json:"number_available"`
+	Created                string `json:"created"`
+}
+
+// DaemonSetDetail describes a DaemonSet and its containers.
+type DaemonSetDetail struct {
+	Name                   string            `json:"name"`
+	Namespace              string            `json:"namespace"`
+	DesiredNumberScheduled int32             `json:"desired_number_scheduled"`
+	CurrentNumberScheduled int32             `json:"current_number_scheduled"`
+	NumberReady            int32             `json:"number_ready"`
+	NumberAvailable        int32             `json:"number_available"`
+	NumberMisscheduled     int32             `json:"number_misscheduled"`
+	UpdatedNumberScheduled int32             `json:"updated_number_scheduled"`
+	UpdateStrategy         string            `json:"update_strategy"`
+	Containers             []ContainerImage  `json:"containers"`
+	NodeSelector           map[string]string `json:"node_selector"`
+	Labels                 map[string]string `json:"labels"`
+	Created                string            `json:"created"`
+}
+
+// DaemonSetPod is a pod of a DaemonSet and the node it runs on.
+type DaemonSetPod struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+	Node      string `json:"node"`
+	Status    string `json:"status"`
+	HostIP    string `json:"host_ip"`
+	PodIP     string `json:"pod_ip"`
+}
@@ -1,5 +1,7 @@
 package domain
 
+import "time"
+
 type DeploymentName string
 
 type DeploymentStatus string
@@ -22,3 +24,74 @@ type Deployment struct {
 type ScaleOptions struct {
 	Replicas int32 `json:"replicas"`
 }
+
+// DeploymentCondition mirrors a condition of a Deployment status.
+type DeploymentCondition struct {
+	Type    string `json:"type"`
+	Status  string `json:"status"`
+	Reason  string `json:"reason,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+// RolloutStatus reports the progress of a Deployment rollout.
+type RolloutStatus struct {
+	Name               string `json:"name"`
+	Namespace          string `json:"namespace"`
+	Revision           string `json:"revision,omitempty"`
+	Generation         int64  `json:"generation"`
+	ObservedGeneration int64  `json:"observed_generation"`
+	Replicas           int32  `json:"replicas"`
+	UpdatedReplicas    int32  `json:"updated_replicas"`
+	ReadyReplicas      int32  `json:"ready_replicas"`
+	AvailableReplicas  int32  `json:"available_replicas"`
+	Paused             bool   `json:"paused"`
+	// Complete is true once every replica runs the latest revision and is
+	// available.
+	Complete bool `json:"complete"`
+	// Stuck is true when the rollout exceeded its progress deadline.
+	Stuck bool `json:"stuck"`
+	// TimedOut is true when waiting ended before the rollout completed.
+	TimedOut   bool                  `json:"timed_out"`
+	Message    string                `json:"message"`
+	Conditions []DeploymentCondition `json:"conditions,omitempty"`
+}
+
+// DeploymentRevision is one entry of a Deployment's rollout history, backed
+// by a ReplicaSet.
+type DeploymentRevision struct {
+	Revision    int64             `json:"revision"`
+	ReplicaSet  string            `json:"replicaset"`
+	ChangeCause string            `json:"change_cause,omitempty"`
+	Images      map[string]string `json:"images"`
+	// ImageChanges lists image differences to the previous revision.
+	ImageChanges []string  `json:"image_changes,omitempty"`
+	Replicas     int32     `json:"replicas"`
+	Current      bool      `json:"current"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// DeploymentInfo describes a Deployment, its replicas and containers.
+type DeploymentInfo struct {
+	Name              string           `json:"name"`
+	Namespace         string           `json:"namespace"`
+	ReplicasDesired   int32            `json:"replicas_desired"`
+	ReplicasReady     int32            `json:"replicas_ready"`
+	ReplicasAvailable int32            `json:"replicas_available"`
+	ReplicasUpdated   int32            `json:"replicas_updated"`
+	Strategy          string           `json:"strategy"`
+	Containers        []ContainerImage `json:"containers"`
+}
+
+// DeploymentSummary is a Deployment in a list. Revision is the revision of
+// its current ReplicaSet.
+type DeploymentSummary struct {
+	Name              string `json:"name"`
+	Namespace         string `json:"namespace"`
+	Replicas          int32  `json:"replicas"`
+	ReadyReplicas     int32  `json:"ready_replicas"`
+	UpdatedReplicas   int32  `json:"updated_replicas"`
+	AvailableReplicas int32  `json:"available_replicas"`
+	Revision          string `json:"revision"`
+	Paused            bool   `json:"paused"`
+	Created           string `json:"created"`
+}
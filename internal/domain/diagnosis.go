@@ -0,0 +1,95 @@
+package domain
+
+import "time"
+
+// Severities of a diagnosed problem.
+const (
+	ProblemSeverityCritical = "critical"
+	ProblemSeverityWarning  = "warning"
+)
+
+// PodProblem is a problem found while diagnosing a pod, with the causes
+// most likely behind it.
+type PodProblem struct {
+	Severity string `json:"severity"`
+	// Reason is a short identifier such as CrashLoopBackOff, OOMKilled or
+	// Unschedulable.
+	Reason       string   `json:"reason"`
+	Container    string   `json:"container,omitempty"`
+	Message      string   `json:"message"`
+	LikelyCauses []string `json:"likely_causes"`
+}
+
+// ContainerTermination is how a container instance ended.
+type ContainerTermination struct {
+	Reason     string    `json:"reason,omitempty"`
+	ExitCode   int32     `json:"exit_code"`
+	Signal     int32     `json:"signal,omitempty"`
+	Message    string    `json:"message,omitempty"`
+	FinishedAt time.Time `json:"finished_at,omitempty"`
+}
+
+// ContainerDiagnosis is the state of one container of a diagnosed pod.
+type ContainerDiagnosis struct {
+	Name         string `json:"name"`
+	Image        string `json:"image"`
+	Init         bool   `json:"init,omitempty"`
+	Ready        bool   `json:"ready"`
+	RestartCount int32  `json:"restart_count"`
+	// State is running, waiting or terminated; Reason and Message explain
+	// waiting and terminated states.
+	State           string                `json:"state"`
+	Reason          string                `json:"reason,omitempty"`
+	Message         string                `json:"message,omitempty"`
+	LastTermination *ContainerTermination `json:"last_termination,omitempty"`
+	Requests        map[string]string     `json:"requests,omitempty"`
+	Limits          map[string]string     `json:"limits,omitempty"`
+	// PreviousLogs is the tail of the logs of the last terminated instance.
+	PreviousLogs      string `json:"previous_logs,omitempty"`
+	PreviousLogsError string `json:"previous_logs_error,omitempty"`
+}
+
+// ConditionStatus is a pod or node condition.
+type ConditionStatus struct {
+	Type    string `json:"type"`
+	Status  string `json:"status"`
+	Reason  string `json:"reason,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+// ClaimStatus is the binding of a PersistentVolumeClaim a pod mounts.
+type ClaimStatus struct {
+	Name         string `json:"name"`
+	Phase        string `json:"phase"`
+	Volume       string `json:"volume,omitempty"`
+	StorageClass string `json:"storage_class,omitempty"`
+}
+
+// QuotaUsage is the use of one resource limited by a ResourceQuota.
+type QuotaUsage struct {
+	Quota    string `json:"quota"`
+	Resource string `json:"resource"`
+	Used     string `json:"used"`
+	Hard     string `json:"hard"`
+}
+
+// PodDiagnosis gathers what is needed to tell why a pod is unhealthy, and
+// the problems detected from it.
+type PodDiagnosis struct {
+	Pod        string               `json:"pod"`
+	Namespace  string               `json:"namespace"`
+	Phase      string               `json:"phase"`
+	Reason     string               `json:"reason,omitempty"`
+	Message    string               `json:"message,omitempty"`
+	Node       string               `json:"node,omitempty"`
+	Conditions []ConditionStatus    `json:"conditions"`
+	Containers []ContainerDiagnosis `json:"containers"`
+	// Requests are the pod's total container requests.
+	Requests        map[string]string `json:"requests,omitempty"`
+	NodeConditions  []ConditionStatus `json:"node_conditions,omitempty"`
+	NodeAllocatable map[string]string `json:"node_allocatable,omitempty"`
+	Claims          []ClaimStatus     `json:"claims,omitempty"`
+	Quotas          []QuotaUsage      `json:"quotas,omitempty"`
+	Events          []Event           `json:"events"`
+	Problems        []PodProblem      `json:"problems"`
+}
@@ -28,3 +28,53 @@ type Event struct {
 	FirstTimestamp  time.Time `json:"first_timestamp"`
 	LastTimestamp   time.Time `json:"last_timestamp"`
 }
+
+// Event APIs events can be read from.
+const (
+	EventAPICoreV1   = "core/v1"
+	EventAPIEventsV1 = "events.k8s.io/v1"
+)
+
+// ObjectRef names an object of a namespace.
+type ObjectRef struct {
+	Kind string `json:"kind"`
+	Name string `json:"name"`
+}
+
+// WorkloadEvents is one timeline of the events of a workload and the
+// objects related to it, oldest first.
+type WorkloadEvents struct {
+	Kind      string `json:"kind"`
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+	API       string `json:"api"`
+	// Objects are the workload and the objects whose events are included.
+	Objects []ObjectRef `json:"objects"`
+	Events  []Event     `json:"events"`
+	// EventsOmitted counts older events beyond the requested maximum.
+	EventsOmitted int `json:"events_omitted,omitempty"`
+}
+
+// EventWatchFilter selects the events an event watch reports. Empty fields
+// match every event.
+type EventWatchFilter struct {
+	API          string
+	Type         EventType
+	InvolvedKind string
+	InvolvedName string
+}
+
+// Reasons an event watch stopped.
+const (
+	EventWatchStopMaxEvents = "max_events"
+	EventWatchStopDuration  = "duration"
+	EventWatchStopEnded     = "ended"
+	EventWatchStopCancelled = "cancelled"
+)
+
+// EventWatchResult holds the events seen by an event watch and why it
+// stopped.
+type EventWatchResult struct {
+	Events     []Event `json:"events"`
+	StopReason string  `json:"stop_reason"`
+}
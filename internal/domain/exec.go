@@ -0,0 +1,66 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// ExecPolicy restricts the commands k8s_pod_exec may run. A command is
+// matched as its arguments joined by single spaces against globs in which
+// "*" matches any text, including spaces and slashes, and "?" one character.
+// Deny globs are also matched with paths cleaned and against shell -c
+// scripts.
+type ExecPolicy struct {
+	// AllowCommands lists the permitted commands; an empty list disables
+	// exec.
+	AllowCommands []string
+	// DenyCommands is checked first and overrides AllowCommands.
+	DenyCommands []string
+	// MaxOutputBytes caps stdout and stderr each; output beyond it is
+	// dropped.
+	MaxOutputBytes int64
+	// MaxTimeout caps the timeout a caller may ask for.
+	MaxTimeout time.Duration
+}
+
+// ExecDeniedError is returned for commands rejected by an ExecPolicy.
+type ExecDeniedError struct {
+	Command string
+	// Pattern is the deny glob that matched, empty when no allow glob did.
+	Pattern string
+}
+
+func (e *ExecDeniedError) Error() string {
+	if e.Pattern != "" {
+		return fmt.Sprintf("command %q is denied by exec pattern %q", e.Command, e.Pattern)
+	}
+	return fmt.Sprintf("command %q matches no allowed exec pattern", e.Command)
+}
+
+// PodExecRequest describes a command to run in a container. An empty
+// Container selects the pod's default container.
+type PodExecRequest struct {
+	ClusterID      string
+	Namespace      string
+	PodName        string
+	Container      string
+	Command        []string
+	Timeout        time.Duration
+	MaxOutputBytes int64
+}
+
+// PodExecResult is the outcome of a command run in a container. ExitCode is
+// -1 when the command did not finish.
+type PodExecResult struct {
+	Namespace       string   `json:"namespace"`
+	PodName         string   `json:"pod_name"`
+	Container       string   `json:"container"`
+	Command         []string `json:"command"`
+	ExitCode        int      `json:"exit_code"`
+	Stdout          string   `json:"stdout"`
+	Stderr          string   `json:"stderr"`
+	StdoutTruncated bool     `json:"stdout_truncated,omitempty"`
+	StderrTruncated bool     `json:"stderr_truncated,omitempty"`
+	TimedOut        bool     `json:"timed_out,omitempty"`
+	DurationMS      int64    `json:"duration_ms"`
+}
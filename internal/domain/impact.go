@@ -0,0 +1,17 @@
+package domain
+
+// ImpactPreview describes what a destructive operation would remove or
+// disrupt. It is shown to the user before they confirm the operation.
+type ImpactPreview struct {
+	Operation string `json:"operation"`
+	ClusterID string `json:"cluster_id"`
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+	// Exists is false when the target was not found; the operation will
+	// most likely fail.
+	Exists bool `json:"exists"`
+	// Affected counts dependent objects by resource, e.g. "pods": 3.
+	Affected map[string]int `json:"affected,omitempty"`
+	Warnings []string       `json:"warnings,omitempty"`
+}
@@ -51,3 +51,75 @@ type JobCreateOptions struct {
 	Labels        map[string]string `json:"labels,omitempty"`
 	Env           map[string]string `json:"env,omitempty"`
 }
+
+// JobLogs are the logs of every pod of a Job, oldest attempt first.
+type JobLogs struct {
+	Job       string       `json:"job"`
+	Namespace string       `json:"namespace"`
+	Status    JobStatus    `json:"status"`
+	Pods      []JobPodLogs `json:"pods"`
+	Failed    int          `json:"failed_pods"`
+}
+
+// JobPodLogs are the logs of one attempt of a Job. Failed is set when the
+// pod failed or a container exited non-zero.
+type JobPodLogs struct {
+	Pod        string             `json:"pod"`
+	Phase      string             `json:"phase"`
+	StartTime  *time.Time         `json:"start_time,omitempty"`
+	Failed     bool               `json:"failed"`
+	Reason     string             `json:"reason,omitempty"`
+	Message    string             `json:"message,omitempty"`
+	Containers []JobContainerLogs `json:"containers"`
+}
+
+// JobContainerLogs are the logs and termination state of one container. The
+// termination fields are empty while the container runs.
+type JobContainerLogs struct {
+	Name     string `json:"name"`
+	ExitCode *int32 `json:"exit_code,omitempty"`
+	Reason   string `json:"reason,omitempty"`
+	Message  string `json:"message,omitempty"`
+	Logs     string `json:"logs"`
+	Error    string `json:"error,omitempty"`
+}
+
+// JobSummary is a Job in a list. Completions is succeeded/desired.
+type JobSummary struct {
+	Name        string `json:"name"`
+	Namespace   string `json:"namespace"`
+	Completions string `json:"completions"`
+	Status      string `json:"status"`
+	Active      int32  `json:"active"`
+	Succeeded   int32  `json:"succeeded"`
+	Failed      int32  `json:"failed"`
+	Duration    string `json:"duration"`
+	Created     string `json:"created"`
+}
+
+// JobDetail describes a Job, its containers and conditions.
+type JobDetail struct {
+	Name           string             `json:"name"`
+	Namespace      string             `json:"namespace"`
+	Completions    int32              `json:"completions"`
+	Parallelism    int32              `json:"parallelism"`
+	BackoffLimit   int32              `json:"backoff_limit"`
+	Active         int32              `json:"active"`
+	Succeeded      int32              `json:"succeeded"`
+	Failed         int32              `json:"failed"`
+	StartTime      string             `json:"start_time"`
+	CompletionTime string             `json:"completion_time"`
+	Duration       string             `json:"duration"`
+	Containers     []ContainerCommand `json:"containers"`
+	Conditions     []JobConditionInfo `json:"conditions"`
+	Labels         map[string]string  `json:"labels"`
+	Created        string             `json:"created"`
+}
+
+// JobConditionInfo is a condition of a Job.
+type JobConditionInfo struct {
+	Type    string `json:"type"`
+	Status  string `json:"status"`
+	Reason  string `json:"reason"`
+	Message string `json:"message"`
+}
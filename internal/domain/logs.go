@@ -0,0 +1,99 @@
+package domain
+
+import "time"
+
+// LogTarget selects the pods whose logs are aggregated: those matching
+// LabelSelector, or those selected by the workload Kind/Name.
+type LogTarget struct {
+	LabelSelector string
+	Kind          string
+	Name          string
+}
+
+// LogLine is one line of an aggregated log. Time is nil for lines without a
+// timestamp.
+type LogLine struct {
+	Time      *time.Time `json:"time,omitempty"`
+	Pod       string     `json:"pod"`
+	Container string     `json:"container"`
+	Message   string     `json:"message"`
+}
+
+// LogSourceError records a container whose logs could not be read.
+type LogSourceError struct {
+	Pod       string `json:"pod"`
+	Container string `json:"container"`
+	Error     string `json:"error"`
+}
+
+// AggregatedLogs are the logs of several pods, interleaved by timestamp.
+type AggregatedLogs struct {
+	Selector string           `json:"selector"`
+	Pods     []string         `json:"pods"`
+	Lines    []LogLine        `json:"lines"`
+	Errors   []LogSourceError `json:"errors,omitempty"`
+	// PodsOmitted counts matching pods beyond the requested maximum.
+	PodsOmitted int `json:"pods_omitted,omitempty"`
+}
+
+// LogExportRequest describes the logs to collect into an archive. An empty
+// Target exports every pod of the namespace.
+type LogExportRequest struct {
+	ClusterID string
+	Namespace string
+	Target    LogTarget
+	Options   LogOptions
+	MaxPods   int
+	// Format is "tar.gz" or "zip".
+	Format string
+	// ErrorPattern is a regular expression counting error lines.
+	ErrorPattern string
+}
+
+// LogExport describes a written log archive.
+type LogExport struct {
+	Name      string          `json:"name"`
+	Path      string          `json:"path"`
+	Format    string          `json:"format"`
+	Size      int64           `json:"size"`
+	Namespace string          `json:"namespace"`
+	Selector  string          `json:"selector"`
+	Files     []LogExportFile `json:"files"`
+	Lines     int             `json:"lines"`
+	Errors    int             `json:"error_lines"`
+	CreatedAt time.Time       `json:"created_at"`
+	// ExpiresAt is when the archive is deleted; nil when it is kept.
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	// PodsOmitted counts matching pods beyond the requested maximum.
+	PodsOmitted int `json:"pods_omitted,omitempty"`
+}
+
+// LogExportFile summarizes the logs of one container in a LogExport.
+type LogExportFile struct {
+	Name       string `json:"name"`
+	Pod        string `json:"pod"`
+	Container  string `json:"container"`
+	Lines      int    `json:"lines"`
+	ErrorLines int    `json:"error_lines"`
+	Bytes      int    `json:"bytes"`
+	// Truncated is set when the per-container byte limit was reached.
+	Truncated bool   `json:"truncated,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+// Reasons a log follow stopped.
+const (
+	LogFollowStopMaxLines  = "max_lines"
+	LogFollowStopDuration  = "duration"
+	LogFollowStopEnded     = "ended"
+	LogFollowStopCancelled = "cancelled"
+)
+
+// LogFollowResult holds the lines read while following a container's logs
+// and why following stopped.
+type LogFollowResult struct {
+	Pod        string   `json:"pod"`
+	Container  string   `json:"container,omitempty"`
+	Lines      []string `json:"lines"`
+	StopReason string   `json:"stop_reason"`
+}
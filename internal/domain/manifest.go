@@ -1,9 +1,34 @@
 package domain
 
 type ApplyResult struct {
-	Name      string `json:"name"`
-	Namespace string `json:"namespace"`
-	Kind      string `json:"kind"`
-	Action    string `json:"action"`
-	DryRun    bool   `json:"dry_run"`
+	Name       string `json:"name"`
+	Namespace  string `json:"namespace"`
+	Kind       string `json:"kind"`
+	APIVersion string `json:"api_version"`
+	Action     string `json:"action"`
+	DryRun     bool   `json:"dry_run"`
+	Source     string `json:"source,omitempty"`
+	Error      string `json:"error,omitempty"`
+	// PreviousResourceVersion is empty when the object was created.
+	PreviousResourceVersion string `json:"previous_resource_version,omitempty"`
+	ResourceVersion         string `json:"resource_version,omitempty"`
+}
+
+const (
+	ApplyActionCreated    = "created"
+	ApplyActionConfigured = "configured"
+	ApplyActionUnchanged  = "unchanged"
+	ApplyActionFailed     = "failed"
+)
+
+type DiffResult struct {
+	Name       string `json:"name"`
+	Namespace  string `json:"namespace"`
+	Kind       string `json:"kind"`
+	APIVersion string `json:"api_version"`
+	Source     string `json:"source,omitempty"`
+	Exists     bool   `json:"exists"`
+	Changed    bool   `json:"changed"`
+	Diff       string `json:"diff,omitempty"`
+	Error      string `json:"error,omitempty"`
 }
@@ -0,0 +1,71 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrMetricsUnavailable is returned when a cluster does not serve the
+// metrics.k8s.io API, usually because metrics-server is not installed.
+var ErrMetricsUnavailable = errors.New("the metrics.k8s.io API is not available; install metrics-server (https://github.com/kubernetes-sigs/metrics-server) to see CPU and memory usage")
+
+// NodeUsage is the CPU and memory a node uses, as reported by
+// metrics.k8s.io, next to what it can allocate and what its pods request.
+// CPU is in millicores and memory in Mi.
+type NodeUsage struct {
+	Name              string `json:"name"`
+	CPU               string `json:"cpu"`
+	Memory            string `json:"memory"`
+	CPUMillicores     int64  `json:"cpu_millicores"`
+	MemoryBytes       int64  `json:"memory_bytes"`
+	AllocatableCPU    string `json:"allocatable_cpu"`
+	AllocatableMemory string `json:"allocatable_memory"`
+	// CPUPercent and MemoryPercent are usage as a percentage of allocatable.
+	CPUPercent    *float64 `json:"cpu_percent,omitempty"`
+	MemoryPercent *float64 `json:"memory_percent,omitempty"`
+	// RequestedCPU and RequestedMemory sum the requests of the node's
+	// running pods; the percentages are of allocatable.
+	RequestedCPU           string    `json:"requested_cpu"`
+	RequestedMemory        string    `json:"requested_memory"`
+	RequestedCPUPercent    *float64  `json:"requested_cpu_percent,omitempty"`
+	RequestedMemoryPercent *float64  `json:"requested_memory_percent,omitempty"`
+	Pods                   int       `json:"pods"`
+	Timestamp              time.Time `json:"timestamp"`
+}
+
+// ContainerUsage is the CPU and memory a container uses next to its
+// requests and limits. The percentages are usage of the request or limit
+// and are left out when the container sets none.
+type ContainerUsage struct {
+	Name                 string   `json:"name"`
+	CPU                  string   `json:"cpu"`
+	Memory               string   `json:"memory"`
+	CPUMillicores        int64    `json:"cpu_millicores"`
+	MemoryBytes          int64    `json:"memory_bytes"`
+	CPURequest           string   `json:"cpu_request,omitempty"`
+	MemoryRequest        string   `json:"memory_request,omitempty"`
+	CPULimit             string   `json:"cpu_limit,omitempty"`
+	MemoryLimit          string   `json:"memory_limit,omitempty"`
+	CPURequestPercent    *float64 `json:"cpu_request_percent,omitempty"`
+	MemoryRequestPercent *float64 `json:"memory_request_percent,omitempty"`
+	CPULimitPercent      *float64 `json:"cpu_limit_percent,omitempty"`
+	MemoryLimitPercent   *float64 `json:"memory_limit_percent,omitempty"`
+}
+
+// PodUsage is the CPU and memory a pod uses, summed over its containers,
+// next to the sum of their requests.
+type PodUsage struct {
+	Name                 string           `json:"name"`
+	Namespace            string           `json:"namespace"`
+	Node                 string           `json:"node,omitempty"`
+	CPU                  string           `json:"cpu"`
+	Memory               string           `json:"memory"`
+	CPUMillicores        int64            `json:"cpu_millicores"`
+	MemoryBytes          int64            `json:"memory_bytes"`
+	CPURequest           string           `json:"cpu_request,omitempty"`
+	MemoryRequest        string           `json:"memory_request,omitempty"`
+	CPURequestPercent    *float64         `json:"cpu_request_percent,omitempty"`
+	MemoryRequestPercent *float64         `json:"memory_request_percent,omitempty"`
+	Containers           []ContainerUsage `json:"containers"`
+	Timestamp            time.Time        `json:"timestamp"`
+}
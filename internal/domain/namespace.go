@@ -7,3 +7,17 @@ const (
 	NamespaceSystem  Namespace = "kube-system"
 	NamespaceAll     Namespace = ""
 )
+
+// NamespaceSummary is a namespace in a list.
+type NamespaceSummary struct {
+	Name   string `json:"name"`
+	Status string `json:"status"`
+}
+
+// NamespaceDetail describes a namespace.
+type NamespaceDetail struct {
+	Name    string            `json:"name"`
+	Status  string            `json:"status"`
+	Created string            `json:"created"`
+	Labels  map[string]string `json:"labels"`
+}
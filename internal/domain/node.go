@@ -25,7 +25,11 @@ type NodeMetrics struct {
 	NodeName    NodeName          `json:"node_name"`
 	Capacity    map[string]string `json:"capacity"`
 	Allocatable map[string]string `json:"allocatable"`
-	Labels      map[string]string `json:"labels"`
+	// Usage is the current CPU and memory use from metrics.k8s.io;
+	// UsageError says why it is missing.
+	Usage      map[string]string `json:"usage,omitempty"`
+	UsageError string            `json:"usage_error,omitempty"`
+	Labels     map[string]string `json:"labels"`
 }
 
 type Taint struct {
@@ -38,23 +38,22 @@ type Pod struct {
 	CreatedAt time.Time         `json:"created_at"`
 }
 
-type PortForwardResult struct {
-	PodName    string `json:"pod_name"`
-	LocalPort  int    `json:"local_port"`
-	RemotePort int    `json:"remote_port"`
-	URL        string `json:"url"`
-	Status     string `json:"status"`
-}
-
 type NamespaceResult struct {
 	Name   string `json:"name"`
 	Status string `json:"status"`
 }
 type LogOptions struct {
-	TailLines *int64 `json:"tail_lines,omitempty"`
-	Follow    bool   `json:"follow,omitempty"`
-	Container string `json:"container,omitempty"`
-	Previous  bool   `json:"previous,omitempty"`
+	TailLines    *int64     `json:"tail_lines,omitempty"`
+	Follow       bool       `json:"follow,omitempty"`
+	Container    string     `json:"container,omitempty"`
+	Previous     bool       `json:"previous,omitempty"`
+	SinceSeconds *int64     `json:"since_seconds,omitempty"`
+	SinceTime    *time.Time `json:"since_time,omitempty"`
+	Timestamps   bool       `json:"timestamps,omitempty"`
+	LimitBytes   *int64     `json:"limit_bytes,omitempty"`
+	// Grep is a regular expression; only matching lines are kept. It is
+	// applied by this server after TailLines and LimitBytes.
+	Grep string `json:"grep,omitempty"`
 }
 
 type PodLogs string
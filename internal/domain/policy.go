@@ -0,0 +1,95 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"path"
+)
+
+type PolicyEffect string
+
+const (
+	PolicyEffectAllow PolicyEffect = "allow"
+	PolicyEffectDeny  PolicyEffect = "deny"
+)
+
+type PolicyAccess string
+
+const (
+	// PolicyAccessWrite matches calls that change cluster or server state.
+	PolicyAccessWrite PolicyAccess = "write"
+	// PolicyAccessAny matches every call.
+	PolicyAccessAny PolicyAccess = "any"
+)
+
+// ToolPolicyRule allows or denies tool calls. Empty selector lists match
+// anything; non-empty ones are globs. Rules are evaluated in order and the
+// first matching rule decides; calls matching no rule are allowed.
+type ToolPolicyRule struct {
+	Name       string       `json:"name,omitempty"`
+	Clusters   []string     `json:"clusters,omitempty"`
+	Namespaces []string     `json:"namespaces,omitempty"`
+	Tools      []string     `json:"tools,omitempty"`
+	Access     PolicyAccess `json:"access,omitempty"`
+	Effect     PolicyEffect `json:"effect"`
+	Reason     string       `json:"reason,omitempty"`
+}
+
+// PolicyRequest describes a tool call to evaluate. Namespaces holds every
+// namespace the call touches (several for multi-document manifests).
+// AllNamespaces marks calls on every namespace or on cluster-scoped objects,
+// which deny rules with namespace selectors match and allow rules with
+// namespace selectors do not.
+type PolicyRequest struct {
+	Tool          string
+	ClusterID     string
+	Namespaces    []string
+	AllNamespaces bool
+	Write         bool
+}
+
+// PolicyAllNamespaces is the namespace of decisions on AllNamespaces
+// requests.
+const PolicyAllNamespaces = "*"
+
+// PolicyDecision is returned to the caller when a tool call is denied.
+type PolicyDecision struct {
+	Allowed   bool   `json:"allowed"`
+	Tool      string `json:"tool"`
+	ClusterID string `json:"cluster_id,omitempty"`
+	Namespace string `json:"namespace,omitempty"`
+	Rule      string `json:"rule,omitempty"`
+	Reason    string `json:"reason,omitempty"`
+}
+
+// ValidatePolicyRules reports every malformed rule.
+func ValidatePolicyRules(rules []ToolPolicyRule) error {
+	var errs []error
+	for i, rule := range rules {
+		field := fmt.Sprintf("rules[%d]", i)
+		if rule.Name != "" {
+			field = fmt.Sprintf("%s (%s)", field, rule.Name)
+		}
+
+		switch rule.Effect {
+		case PolicyEffectAllow, PolicyEffectDeny:
+		default:
+			errs = append(errs, fmt.Errorf("%s.effect: must be %q or %q, got %q", field, PolicyEffectAllow, PolicyEffectDeny, rule.Effect))
+		}
+
+		switch rule.Access {
+		case "", PolicyAccessWrite, PolicyAccessAny:
+		default:
+			errs = append(errs, fmt.Errorf("%s.access: must be %q or %q, got %q", field, PolicyAccessWrite, PolicyAccessAny, rule.Access))
+		}
+
+		for _, patterns := range [][]string{rule.Clusters, rule.Namespaces, rule.Tools} {
+			for _, p := range patterns {
+				if _, err := path.Match(p, ""); err != nil {
+					errs = append(errs, fmt.Errorf("%s: invalid glob %q: %w", field, p, err))
+				}
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,50 @@
+package domain
+
+import "time"
+
+// Kinds a port-forward can target. Services and Deployments are resolved to
+// one of their ready pods.
+const (
+	PortForwardTargetPod        = "pod"
+	PortForwardTargetService    = "service"
+	PortForwardTargetDeployment = "deployment"
+)
+
+// PortForwardRequest describes a tunnel to open. LocalPort 0 picks a free
+// local port. For Services, RemotePort is a service port and is mapped to the
+// target port of the chosen pod.
+type PortForwardRequest struct {
+	ClusterID  string
+	Namespace  string
+	TargetKind string
+	TargetName string
+	LocalPort  int
+	RemotePort int
+	// TTL closes the tunnel after this long; zero uses the server limit.
+	TTL time.Duration
+}
+
+// PortForwardSession is an open tunnel.
+type PortForwardSession struct {
+	// ID is <cluster>/<namespace>/<pod>/<local port>:<pod port>.
+	ID         string `json:"id"`
+	ClusterID  string `json:"cluster_id"`
+	Namespace  string `json:"namespace"`
+	TargetKind string `json:"target_kind"`
+	TargetName string `json:"target_name"`
+	PodName    string `json:"pod_name"`
+	LocalPort  int    `json:"local_port"`
+	// RemotePort is the requested port; PodPort the container port it
+	// resolved to.
+	RemotePort int    `json:"remote_port"`
+	PodPort    int    `json:"pod_port"`
+	URL        string `json:"url"`
+
+	StartedAt  time.Time  `json:"started_at"`
+	LastActive time.Time  `json:"last_active"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+
+	Connections   int64 `json:"connections"`
+	BytesSent     int64 `json:"bytes_sent"`
+	BytesReceived int64 `json:"bytes_received"`
+}
@@ -0,0 +1,43 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+)
+
+// PromptTemplate is a custom MCP prompt. Template is a Go text/template
+// rendered with the arguments by name, e.g. {{.namespace}}; optional
+// arguments the client omits are empty strings.
+type PromptTemplate struct {
+	Name        string           `json:"name"`
+	Title       string           `json:"title,omitempty"`
+	Description string           `json:"description,omitempty"`
+	Arguments   []PromptArgument `json:"arguments,omitempty"`
+	Template    string           `json:"template"`
+}
+
+// PromptArgument is an argument a prompt takes.
+type PromptArgument struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Required    bool   `json:"required,omitempty"`
+}
+
+// Render executes the template with args. Every declared argument is set,
+// so referring to an undeclared one fails.
+func (p PromptTemplate) Render(args map[string]string) (string, error) {
+	tmpl, err := template.New(p.Name).Option("missingkey=error").Parse(p.Template)
+	if err != nil {
+		return "", fmt.Errorf("invalid template of prompt %s: %w", p.Name, err)
+	}
+	data := make(map[string]string, len(p.Arguments))
+	for _, arg := range p.Arguments {
+		data[arg.Name] = args[arg.Name]
+	}
+	var sb strings.Builder
+	if err := tmpl.Execute(&sb, data); err != nil {
+		return "", fmt.Errorf("failed to render prompt %s: %w", p.Name, err)
+	}
+	return sb.String(), nil
+}
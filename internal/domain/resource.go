@@ -0,0 +1,82 @@
+package domain
+
+import "time"
+
+// ResourceType is a resource type resolved through API discovery, e.g.
+// apps/v1 Deployment served as "deployments".
+type ResourceType struct {
+	APIVersion string `json:"api_version"`
+	Kind       string `json:"kind"`
+	Resource   string `json:"resource"`
+	Namespaced bool   `json:"namespaced"`
+}
+
+// ResourceSummary is one object of a generic resource list. Status is
+// status.phase, or Ready/NotReady from a Ready condition, when the object
+// reports either.
+type ResourceSummary struct {
+	Name      string            `json:"name"`
+	Namespace string            `json:"namespace,omitempty"`
+	Status    string            `json:"status,omitempty"`
+	Labels    map[string]string `json:"labels,omitempty"`
+	CreatedAt time.Time         `json:"created_at"`
+}
+
+// ResourceListRequest selects the objects of one resource type. Kind is a
+// kind, resource name or short name (e.g. "pvc"), optionally qualified by a
+// group as in "certificates.cert-manager.io"; APIVersion pins the group and
+// version.
+type ResourceListRequest struct {
+	Kind          string
+	APIVersion    string
+	Namespace     string
+	LabelSelector string
+	FieldSelector string
+	Limit         int64
+	Continue      string
+}
+
+// ResourceList is a page of objects of one resource type. Continue is set
+// when more objects remain.
+type ResourceList struct {
+	ResourceType
+	Namespace     string            `json:"namespace,omitempty"`
+	LabelSelector string            `json:"label_selector,omitempty"`
+	FieldSelector string            `json:"field_selector,omitempty"`
+	Items         []ResourceSummary `json:"items"`
+	Continue      string            `json:"continue,omitempty"`
+}
+
+// ResourceObject is a full object of any resource type, without its
+// managed fields. Secret values are redacted.
+type ResourceObject struct {
+	ResourceType
+	Name      string         `json:"name"`
+	Namespace string         `json:"namespace,omitempty"`
+	Object    map[string]any `json:"object"`
+}
+
+// ResourceDeleteResult reports the deletion of an object of any resource
+// type.
+type ResourceDeleteResult struct {
+	ResourceType
+	Name            string `json:"name"`
+	Namespace       string `json:"namespace,omitempty"`
+	Propagation     string `json:"propagation"`
+	DryRun          bool   `json:"dry_run"`
+	ResourceVersion string `json:"resource_version,omitempty"`
+}
+
+// Types of an ObjectChange.
+const (
+	ObjectChangeAdded    = "added"
+	ObjectChangeModified = "modified"
+	ObjectChangeDeleted  = "deleted"
+)
+
+// ObjectChange reports that a watched object was created, changed or
+// deleted.
+type ObjectChange struct {
+	Type            string `json:"type"`
+	ResourceVersion string `json:"resource_version,omitempty"`
+}
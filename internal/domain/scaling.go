@@ -75,11 +75,12 @@ type LimitRangeName string
 
 // LimitRangeItem mô hình hóa một mục giới hạn trong LimitRange
 type LimitRangeItem struct {
-	Type           string            `json:"type"` // Pod, Container, PersistentVolumeClaim
-	Max            map[string]string `json:"max"`
-	Min            map[string]string `json:"min"`
-	Default        map[string]string `json:"default"`
-	DefaultRequest map[string]string `json:"default_request"`
+	Type                 string            `json:"type"` // Pod, Container, PersistentVolumeClaim
+	Max                  map[string]string `json:"max"`
+	Min                  map[string]string `json:"min"`
+	Default              map[string]string `json:"default"`
+	DefaultRequest       map[string]string `json:"default_request"`
+	MaxLimitRequestRatio map[string]string `json:"max_limit_request_ratio"`
 }
 
 // LimitRange mô hình hóa đối tượng LimitRange
@@ -89,3 +90,41 @@ type LimitRange struct {
 	Limits    []LimitRangeItem `json:"limits"`
 	CreatedAt time.Time        `json:"created_at"`
 }
+
+// ResourceQuotaSummary is a ResourceQuota in a list. Status holds the
+// "used" and "hard" resource lists.
+type ResourceQuotaSummary struct {
+	Name      string                       `json:"name"`
+	Namespace string                       `json:"namespace"`
+	Status    map[string]map[string]string `json:"status"`
+	Age       string                       `json:"age"`
+}
+
+// ResourceQuotaDetail describes a ResourceQuota. RawObject is the object as
+// returned by the API server.
+type ResourceQuotaDetail struct {
+	Name       string            `json:"name"`
+	Namespace  string            `json:"namespace"`
+	HardLimits map[string]string `json:"hard_limits"`
+	Used       map[string]string `json:"used"`
+	Allowed    map[string]string `json:"allowed"`
+	Age        string            `json:"age"`
+	RawObject  any               `json:"raw_object"`
+}
+
+// LimitRangeSummary is a LimitRange in a list.
+type LimitRangeSummary struct {
+	Name        string `json:"name"`
+	Namespace   string `json:"namespace"`
+	LimitsCount int    `json:"limits_count"`
+	Age         string `json:"age"`
+}
+
+// LimitRangeDetail describes a LimitRange. RawObject is the object as
+// returned by the API server.
+type LimitRangeDetail struct {
+	Name      string           `json:"name"`
+	Namespace string           `json:"namespace"`
+	Limits    []LimitRangeItem `json:"limits"`
+	RawObject any              `json:"raw_object"`
+}
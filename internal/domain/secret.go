@@ -33,3 +33,23 @@ type SecretCreateOptions struct {
 	StringData map[string]string `json:"string_data"`
 	Labels     map[string]string `json:"labels,omitempty"`
 }
+
+// SecretSummary is a Secret in a list.
+type SecretSummary struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+	Type      string `json:"type"`
+	DataCount int    `json:"data_count"`
+	Created   string `json:"created"`
+}
+
+// SecretDetail describes a Secret by the keys of its data; the values are
+// left out.
+type SecretDetail struct {
+	Name      string            `json:"name"`
+	Namespace string            `json:"namespace"`
+	Type      string            `json:"type"`
+	DataKeys  []string          `json:"data_keys"`
+	Labels    map[string]string `json:"labels"`
+	Created   string            `json:"created"`
+}
@@ -25,3 +25,44 @@ type ServicePort struct {
 	TargetPort int32  `json:"target_port"`
 	NodePort   int32  `json:"node_port,omitempty"`
 }
+
+// ServiceSummary is a Service in a list.
+type ServiceSummary struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+	Type      string `json:"type"`
+	ClusterIP string `json:"cluster_ip"`
+}
+
+// IngressSummary is an Ingress in a list, with the host of its first rule.
+type IngressSummary struct {
+	Name         string `json:"name"`
+	Namespace    string `json:"namespace"`
+	IngressClass string `json:"ingress_class"`
+	Host         string `json:"host"`
+}
+
+// IngressDetail describes an Ingress and its routing rules.
+type IngressDetail struct {
+	Name                  string            `json:"name"`
+	Namespace             string            `json:"namespace"`
+	IngressClass          string            `json:"ingress_class"`
+	DefaultBackendService string            `json:"default_backend_service"`
+	DefaultBackendPort    int32             `json:"default_backend_port"`
+	Rules                 []IngressRule     `json:"rules"`
+	Labels                map[string]string `json:"labels"`
+}
+
+// IngressRule routes the paths of a host.
+type IngressRule struct {
+	Host  string        `json:"host"`
+	Paths []IngressPath `json:"paths"`
+}
+
+// IngressPath routes a path to a backend service.
+type IngressPath struct {
+	Path           string `json:"path"`
+	PathType       string `json:"path_type"`
+	BackendService string `json:"backend_service"`
+	BackendPort    int32  `json:"backend_port"`
+}
@@ -37,3 +37,38 @@ type StatefulSetCreateOptions struct {
 	ServiceName string            `json:"service_name"`
 	Labels      map[string]string `json:"labels,omitempty"`
 }
+
+// StatefulSetSummary is a StatefulSet in a list.
+type StatefulSetSummary struct {
+	Name            string `json:"name"`
+	Namespace       string `json:"namespace"`
+	Replicas        int32  `json:"replicas"`
+	ReadyReplicas   int32  `json:"ready_replicas"`
+	CurrentReplicas int32  `json:"current_replicas"`
+	ServiceName     string `json:"service_name"`
+	Created         string `json:"created"`
+}
+
+// StatefulSetDetail describes a StatefulSet, its containers and volume
+// claim templates.
+type StatefulSetDetail struct {
+	Name            string                `json:"name"`
+	Namespace       string                `json:"namespace"`
+	ReplicasDesired int32                 `json:"replicas_desired"`
+	ReplicasReady   int32                 `json:"replicas_ready"`
+	ReplicasCurrent int32                 `json:"replicas_current"`
+	ReplicasUpdated int32                 `json:"replicas_updated"`
+	ServiceName     string                `json:"service_name"`
+	UpdateStrategy  string                `json:"update_strategy"`
+	Containers      []ContainerImage      `json:"containers"`
+	VolumeClaims    []VolumeClaimTemplate `json:"volume_claims"`
+	Labels          map[string]string     `json:"labels"`
+	Created         string                `json:"created"`
+}
+
+// VolumeClaimTemplate is a PVC template of a StatefulSet.
+type VolumeClaimTemplate struct {
+	Name         string `json:"name"`
+	Storage      string `json:"storage"`
+	StorageClass string `json:"storage_class"`
+}
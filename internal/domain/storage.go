@@ -0,0 +1,17 @@
+package domain
+
+// PersistentVolumeSummary is a PersistentVolume in a list.
+type PersistentVolumeSummary struct {
+	Name          string `json:"name"`
+	Capacity      string `json:"capacity"`
+	Status        string `json:"status"`
+	StorageClass  string `json:"storage_class"`
+	ReclaimPolicy string `json:"reclaim_policy"`
+}
+
+// StorageClassSummary is a StorageClass in a list.
+type StorageClassSummary struct {
+	Name          string `json:"name"`
+	Provisioner   string `json:"provisioner"`
+	ReclaimPolicy string `json:"reclaim_policy"`
+}
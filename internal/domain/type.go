@@ -15,3 +15,17 @@ type ContainerStatus struct {
 	Image        string `json:"image"`
 	State        string `json:"state"`
 }
+
+// ContainerImage is a container of a pod template and its image.
+type ContainerImage struct {
+	Name  string `json:"name"`
+	Image string `json:"image"`
+}
+
+// ContainerCommand is a container of a pod template with its command.
+type ContainerCommand struct {
+	Name    string   `json:"name"`
+	Image   string   `json:"image"`
+	Command []string `json:"command"`
+	Args    []string `json:"args"`
+}
@@ -0,0 +1,59 @@
+package domain
+
+// Workload kinds accepted by the k8s_workload_* tools.
+const (
+	WorkloadKindDeployment  = "deployment"
+	WorkloadKindStatefulSet = "statefulset"
+	WorkloadKindDaemonSet   = "daemonset"
+	WorkloadKindCronJob     = "cronjob"
+)
+
+// WorkloadKinds lists the kinds whose pod template can be edited.
+var WorkloadKinds = []string{WorkloadKindDeployment, WorkloadKindStatefulSet, WorkloadKindDaemonSet, WorkloadKindCronJob}
+
+// EnvVarSpec sets one environment variable. Exactly one of Value, ConfigMap
+// or Secret is used; ConfigMap and Secret take the value of Key.
+type EnvVarSpec struct {
+	Name      string `json:"name"`
+	Value     string `json:"value,omitempty"`
+	ConfigMap string `json:"configmap,omitempty"`
+	Secret    string `json:"secret,omitempty"`
+	Key       string `json:"key,omitempty"`
+}
+
+// EnvFromSpec imports every key of a ConfigMap or Secret as variables.
+type EnvFromSpec struct {
+	ConfigMap string `json:"configmap,omitempty"`
+	Secret    string `json:"secret,omitempty"`
+	Prefix    string `json:"prefix,omitempty"`
+}
+
+// EnvChange describes a k8s_workload_set_env call.
+type EnvChange struct {
+	Set     []EnvVarSpec  `json:"set,omitempty"`
+	Remove  []string      `json:"remove,omitempty"`
+	EnvFrom []EnvFromSpec `json:"env_from,omitempty"`
+}
+
+// ResourceChange sets requests and limits by resource name, e.g. "cpu":
+// "250m". An empty quantity removes the entry.
+type ResourceChange struct {
+	Requests map[string]string `json:"requests,omitempty"`
+	Limits   map[string]string `json:"limits,omitempty"`
+}
+
+// WorkloadUpdate is the result of patching a workload's pod template.
+type WorkloadUpdate struct {
+	Kind       string   `json:"kind"`
+	Namespace  string   `json:"namespace"`
+	Name       string   `json:"name"`
+	Containers []string `json:"containers"`
+	// Changed is false when the patch left the object unchanged.
+	Changed    bool  `json:"changed"`
+	Generation int64 `json:"generation"`
+	// Revision identifies the rollout that applies the change: the
+	// deployment revision, the StatefulSet update revision or the DaemonSet
+	// template generation. CronJobs have none; their next Job uses the new
+	// template.
+	Revision string `json:"revision,omitempty"`
+}
@@ -0,0 +1,173 @@
+package infrastructure
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+
+	"github.com/your-org/mcp-k8s-server/internal/domain"
+)
+
+// FileAuditLog appends audit entries as JSON lines. When the file would grow
+// beyond maxSize it is rotated to <path>.1, older files shift to <path>.2 and
+// so on, and files beyond maxBackups are removed.
+type FileAuditLog struct {
+	mu         sync.Mutex
+	path       string
+	maxSize    int64
+	maxBackups int
+	file       *os.File
+	size       int64
+}
+
+func NewFileAuditLog(path string, maxSize int64, maxBackups int) (*FileAuditLog, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return nil, fmt.Errorf("failed to create audit log directory: %w", err)
+	}
+	l := &FileAuditLog{
+		path:       path,
+		maxSize:    maxSize,
+		maxBackups: maxBackups,
+	}
+	if err := l.open(); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+func (l *FileAuditLog) open() error {
+	f, err := os.OpenFile(l.path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to open audit log: %w", err)
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return fmt.Errorf("failed to stat audit log: %w", err)
+	}
+	l.file = f
+	l.size = info.Size()
+	return nil
+}
+
+func (l *FileAuditLog) Append(entry domain.AuditEntry) error {
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to encode audit entry: %w", err)
+	}
+	line = append(line, '\n')
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.file == nil {
+		return errors.New("audit log is closed")
+	}
+	if l.maxSize > 0 && l.size > 0 && l.size+int64(len(line)) > l.maxSize {
+		if err := l.rotate(); err != nil {
+			return err
+		}
+	}
+	n, err := l.file.Write(line)
+	l.size += int64(n)
+	if err != nil {
+		return fmt.Errorf("failed to write audit entry: %w", err)
+	}
+	return nil
+}
+
+// rotate shifts the backups and starts a new file. The caller holds l.mu.
+func (l *FileAuditLog) rotate() error {
+	if err := l.file.Close(); err != nil {
+		return fmt.Errorf("failed to close audit log: %w", err)
+	}
+	l.file = nil
+
+	if l.maxBackups > 0 {
+		for i := l.maxBackups - 1; i >= 1; i-- {
+			if err := os.Rename(l.backupPath(i), l.backupPath(i+1)); err != nil && !errors.Is(err, os.ErrNotExist) {
+				return fmt.Errorf("failed to rotate audit log: %w", err)
+			}
+		}
+		if err := os.Rename(l.path, l.backupPath(1)); err != nil {
+			return fmt.Errorf("failed to rotate audit log: %w", err)
+		}
+	} else if err := os.Remove(l.path); err != nil {
+		return fmt.Errorf("failed to rotate audit log: %w", err)
+	}
+	return l.open()
+}
+
+func (l *FileAuditLog) backupPath(i int) string {
+	return fmt.Sprintf("%s.%d", l.path, i)
+}
+
+// Query scans the current file and the backups, newest first, and returns
+// up to q.Limit matching entries (all when q.Limit is 0). Lines that cannot
+// be decoded are skipped.
+func (l *FileAuditLog) Query(q domain.AuditQuery) ([]domain.AuditEntry, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	var result []domain.AuditEntry
+	for i := 0; i <= l.maxBackups; i++ {
+		p := l.path
+		if i > 0 {
+			p = l.backupPath(i)
+		}
+		entries, err := readAuditFile(p, q)
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		slices.Reverse(entries)
+		result = append(result, entries...)
+		if q.Limit > 0 && len(result) >= q.Limit {
+			return result[:q.Limit], nil
+		}
+	}
+	return result, nil
+}
+
+func readAuditFile(path string, q domain.AuditQuery) ([]domain.AuditEntry, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var entries []domain.AuditEntry
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 4*1024*1024)
+	for scanner.Scan() {
+		var e domain.AuditEntry
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			continue
+		}
+		if q.Matches(e) {
+			entries = append(entries, e)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read audit log %s: %w", path, err)
+	}
+	return entries, nil
+}
+
+func (l *FileAuditLog) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.file == nil {
+		return nil
+	}
+	err := l.file.Close()
+	l.file = nil
+	return err
+}
@@ -5,14 +5,18 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"sort"
 	"sync"
 	"time"
 
 	"github.com/your-org/mcp-k8s-server/internal/domain"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
+	metricsclient "k8s.io/metrics/pkg/client/clientset/versioned"
 )
 
 type ClusterClient interface {
@@ -27,40 +31,43 @@ type ClusterClient interface {
 }
 
 type ClusterManager struct {
-	clusters      map[domain.ClusterID]*ClusterContext
-	mu            sync.RWMutex
-	logger        Logger
-	activeTunnels map[string]chan struct{}
-	tunnelMu      sync.Mutex
-}
-
-func (cm *ClusterManager) SaveTunnel(podName string, stopChan chan struct{}) {
-	cm.tunnelMu.Lock()
-	defer cm.tunnelMu.Unlock()
-	if cm.activeTunnels == nil {
-		cm.activeTunnels = make(map[string]chan struct{})
-	}
-	cm.activeTunnels[podName] = stopChan
-}
-
-func (cm *ClusterManager) StopTunnel(podName string) bool {
-	cm.tunnelMu.Lock()
-	defer cm.tunnelMu.Unlock()
-	if stopChan, ok := cm.activeTunnels[podName]; ok {
-		close(stopChan)
-		delete(cm.activeTunnels, podName)
-		return true
-	}
-	return false
+	clusters map[domain.ClusterID]*ClusterContext
+	mu       sync.RWMutex
+	logger   Logger
+
+	// activeTunnels are the open port-forwards by session ID; see
+	// port_forward.go.
+	activeTunnels     map[string]*Tunnel
+	tunnelMu          sync.Mutex
+	tunnelIdleTimeout time.Duration
+	tunnelMaxLifetime time.Duration
+	reaperOnce        sync.Once
 }
 
 type ClusterContext struct {
-	Config     domain.ClusterConfig
-	ClientSet  kubernetes.Interface
+	Config    domain.ClusterConfig
+	ClientSet kubernetes.Interface
+	// Dynamic, Discovery and Metrics are created from RestConfig on first
+	// use unless they were injected with RegisterClusterClients.
+	Dynamic    dynamic.Interface
+	Discovery  discovery.DiscoveryInterface
+	Metrics    metricsclient.Interface
 	RestConfig *rest.Config
 	LastUsed   time.Time
 }
 
+// ClusterClients are prebuilt clients for RegisterClusterClients, e.g. the
+// fakes from k8s.io/client-go/kubernetes/fake and dynamic/fake. Dynamic,
+// Metrics and RestConfig are optional; Discovery defaults to
+// Clientset.Discovery().
+type ClusterClients struct {
+	Clientset  kubernetes.Interface
+	Dynamic    dynamic.Interface
+	Discovery  discovery.DiscoveryInterface
+	Metrics    metricsclient.Interface
+	RestConfig *rest.Config
+}
+
 var (
 	clusterManager *ClusterManager
 	once           sync.Once
@@ -74,6 +81,12 @@ func GetClusterManager(logger Logger) *ClusterManager {
 }
 
 func NewClusterClient(logger Logger) ClusterClient {
+	return NewClusterManager(logger)
+}
+
+// NewClusterManager returns a ClusterManager independent of the shared one
+// returned by GetClusterManager.
+func NewClusterManager(logger Logger) *ClusterManager {
 	return &ClusterManager{
 		clusters: make(map[domain.ClusterID]*ClusterContext),
 		logger:   logger,
@@ -119,6 +132,26 @@ func (cm *ClusterManager) RegisterCluster(ctx context.Context, clusterID domain.
 	return nil
 }
 
+// RegisterClusterClients registers a cluster that talks through the given
+// clients instead of clients built from a kubeconfig.
+func (cm *ClusterManager) RegisterClusterClients(clusterID domain.ClusterID, config domain.ClusterConfig, clients ClusterClients) {
+	cm.mu.Lock()
+	defer cm.mu.Unlock()
+
+	disc := clients.Discovery
+	if disc == nil && clients.Clientset != nil {
+		disc = clients.Clientset.Discovery()
+	}
+	cm.clusters[clusterID] = &ClusterContext{
+		Config:     config,
+		ClientSet:  clients.Clientset,
+		Dynamic:    clients.Dynamic,
+		Discovery:  disc,
+		Metrics:    clients.Metrics,
+		RestConfig: clients.RestConfig,
+	}
+}
+
 func (cm *ClusterManager) GetClusterStatus(ctx context.Context, clusterID domain.ClusterID) (*domain.ClusterStatus, error) {
 	cm.mu.RLock()
 	clusterCtx, exists := cm.clusters[clusterID]
@@ -139,28 +172,36 @@ func (cm *ClusterManager) GetClusterStatus(ctx context.Context, clusterID domain
 }
 
 func (cm *ClusterManager) ListClusters(ctx context.Context) ([]domain.Cluster, error) {
+	// Snapshot under the lock; GetClusterStatus takes the lock itself and
+	// talks to the API server, so it must not run while we hold it.
 	cm.mu.RLock()
-	defer cm.mu.RUnlock()
-
-	var clusters []domain.Cluster
+	clusters := make([]domain.Cluster, 0, len(cm.clusters))
 	for clusterID, clusterCtx := range cm.clusters {
+		clusters = append(clusters, domain.Cluster{
+			ID:     clusterID,
+			Config: clusterCtx.Config,
+		})
+	}
+	cm.mu.RUnlock()
+
+	sort.Slice(clusters, func(i, j int) bool { return clusters[i].ID < clusters[j].ID })
+
+	for i := range clusters {
 		// For each cluster, check status
-		status, err := cm.GetClusterStatus(ctx, clusterID)
+		status, err := cm.GetClusterStatus(ctx, clusters[i].ID)
 		if err != nil {
 			// If there's an error, set status to error
 			errorStatus := domain.ClusterStatusError
 			status = &errorStatus
 		}
-		clusters = append(clusters, domain.Cluster{
-			ID:     clusterID,
-			Config: clusterCtx.Config,
-			Status: *status,
-		})
+		clusters[i].Status = *status
 	}
 	return clusters, nil
 }
 
 func (cm *ClusterManager) DeleteCluster(ctx context.Context, clusterID domain.ClusterID) error {
+	cm.StopTunnels(func(s domain.PortForwardSession) bool { return s.ClusterID == string(clusterID) })
+
 	cm.mu.Lock()
 	defer cm.mu.Unlock()
 
@@ -169,23 +210,9 @@ func (cm *ClusterManager) DeleteCluster(ctx context.Context, clusterID domain.Cl
 }
 
 func (cm *ClusterManager) GetPodLogs(ctx context.Context, clusterID domain.ClusterID, namespace domain.Namespace, podName domain.PodName, options domain.LogOptions) (domain.PodLogs, error) {
-	cm.mu.RLock()
-	clusterCtx, exists := cm.clusters[clusterID]
-	cm.mu.RUnlock()
-
-	if !exists {
-		return "", fmt.Errorf("cluster not found: %s", clusterID)
-	}
-
-	logOpts := &v1.PodLogOptions{}
-	if options.TailLines != nil {
-		logOpts.TailLines = options.TailLines
-	}
-
-	// use Stream() instead of use Raw()
-	readCloser, err := clusterCtx.ClientSet.CoreV1().Pods(string(namespace)).GetLogs(string(podName), logOpts).Stream(ctx)
+	readCloser, err := cm.StreamPodLogs(ctx, clusterID, namespace, podName, options)
 	if err != nil {
-		return "", fmt.Errorf("failed to open log stream: %w", err)
+		return "", err
 	}
 	defer readCloser.Close()
 
@@ -197,6 +224,44 @@ func (cm *ClusterManager) GetPodLogs(ctx context.Context, clusterID domain.Clust
 
 	return domain.PodLogs(buf.String()), nil
 }
+
+// StreamPodLogs opens the log stream of a pod. With options.Follow the
+// stream stays open until ctx is done or the container stops.
+func (cm *ClusterManager) StreamPodLogs(ctx context.Context, clusterID domain.ClusterID, namespace domain.Namespace, podName domain.PodName, options domain.LogOptions) (io.ReadCloser, error) {
+	cm.mu.RLock()
+	clusterCtx, exists := cm.clusters[clusterID]
+	cm.mu.RUnlock()
+
+	if !exists {
+		return nil, fmt.Errorf("cluster not found: %s", clusterID)
+	}
+
+	stream, err := clusterCtx.ClientSet.CoreV1().Pods(string(namespace)).GetLogs(string(podName), PodLogOptions(options)).Stream(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open log stream: %w", err)
+	}
+	return stream, nil
+}
+
+// PodLogOptions converts options to the API form. Grep is not an API option
+// and is left to the caller.
+func PodLogOptions(options domain.LogOptions) *v1.PodLogOptions {
+	logOpts := &v1.PodLogOptions{
+		Container:    options.Container,
+		Follow:       options.Follow,
+		Previous:     options.Previous,
+		Timestamps:   options.Timestamps,
+		TailLines:    options.TailLines,
+		SinceSeconds: options.SinceSeconds,
+		LimitBytes:   options.LimitBytes,
+	}
+	if options.SinceTime != nil {
+		t := metav1.NewTime(*options.SinceTime)
+		logOpts.SinceTime = &t
+	}
+	return logOpts
+}
+
 func (cm *ClusterManager) GetPodStatus(ctx context.Context, clusterID domain.ClusterID, namespace domain.Namespace, podName domain.PodName) (*domain.PodStatus, error) {
 	cm.mu.RLock()
 	clusterCtx, exists := cm.clusters[clusterID]
@@ -263,6 +328,10 @@ func (cm *ClusterManager) ListPods(ctx context.Context, clusterID domain.Cluster
 }
 
 func (cm *ClusterManager) CloseAll() {
+	for _, s := range cm.StopTunnels(func(domain.PortForwardSession) bool { return true }) {
+		cm.logger.Info("Closed port-forward", "id", s.ID)
+	}
+
 	cm.mu.Lock()
 	defer cm.mu.Unlock()
 
@@ -287,3 +356,70 @@ func (cm *ClusterManager) GetRESTConfig(id domain.ClusterID) (*rest.Config, erro
 
 	return ctx.RestConfig, nil
 }
+
+// GetDynamicClient returns the dynamic client of a cluster.
+func (cm *ClusterManager) GetDynamicClient(id domain.ClusterID) (dynamic.Interface, error) {
+	cm.mu.Lock()
+	defer cm.mu.Unlock()
+
+	ctx, ok := cm.clusters[id]
+	if !ok {
+		return nil, fmt.Errorf("cluster not found: %s", id)
+	}
+	if ctx.Dynamic == nil {
+		if ctx.RestConfig == nil {
+			return nil, fmt.Errorf("rest config is nil for cluster: %s", id)
+		}
+		dynClient, err := dynamic.NewForConfig(ctx.RestConfig)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create dynamic client: %w", err)
+		}
+		ctx.Dynamic = dynClient
+	}
+	return ctx.Dynamic, nil
+}
+
+// GetDiscoveryClient returns the discovery client of a cluster.
+func (cm *ClusterManager) GetDiscoveryClient(id domain.ClusterID) (discovery.DiscoveryInterface, error) {
+	cm.mu.Lock()
+	defer cm.mu.Unlock()
+
+	ctx, ok := cm.clusters[id]
+	if !ok {
+		return nil, fmt.Errorf("cluster not found: %s", id)
+	}
+	if ctx.Discovery == nil {
+		if ctx.RestConfig == nil {
+			return nil, fmt.Errorf("rest config is nil for cluster: %s", id)
+		}
+		discClient, err := discovery.NewDiscoveryClientForConfig(ctx.RestConfig)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create discovery client: %w", err)
+		}
+		ctx.Discovery = discClient
+	}
+	return ctx.Discovery, nil
+}
+
+// GetMetricsClient returns the metrics.k8s.io client of a cluster. Whether
+// the metrics API is served is only known once it is called.
+func (cm *ClusterManager) GetMetricsClient(id domain.ClusterID) (metricsclient.Interface, error) {
+	cm.mu.Lock()
+	defer cm.mu.Unlock()
+
+	ctx, ok := cm.clusters[id]
+	if !ok {
+		return nil, fmt.Errorf("cluster not found: %s", id)
+	}
+	if ctx.Metrics == nil {
+		if ctx.RestConfig == nil {
+			return nil, fmt.Errorf("rest config is nil for cluster: %s", id)
+		}
+		metricsClient, err := metricsclient.NewForConfig(ctx.RestConfig)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create metrics client: %w", err)
+		}
+		ctx.Metrics = metricsClient
+	}
+	return ctx.Metrics, nil
+}
@@ -0,0 +1,97 @@
+package infrastructure
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/your-org/mcp-k8s-server/internal/domain"
+)
+
+// FileClusterRepository keeps clusters in memory and writes every change
+// through to a Persistence so the registry survives restarts. A change that
+// cannot be persisted leaves the in-memory registry unchanged.
+type FileClusterRepository struct {
+	*InMemoryClusterRepository
+	persistence Persistence
+	logger      Logger
+	// writeMu serializes changes so the existence check, the write to
+	// persistence and the in-memory change happen as one step.
+	writeMu sync.Mutex
+}
+
+// NewFileClusterRepository loads every cluster already stored in persistence.
+func NewFileClusterRepository(ctx context.Context, persistence Persistence, logger Logger) (*FileClusterRepository, error) {
+	stored, err := persistence.LoadAllClusters(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load clusters: %w", err)
+	}
+
+	repo := &FileClusterRepository{
+		InMemoryClusterRepository: NewInMemoryClusterRepository(),
+		persistence:               persistence,
+		logger:                    logger,
+	}
+	for i := range stored {
+		cluster := stored[i]
+		// Bypass Save so the stored timestamps are kept.
+		repo.clusters[cluster.ID] = &cluster
+	}
+
+	logger.Info("Loaded persisted clusters", "count", len(stored))
+	return repo, nil
+}
+
+func (r *FileClusterRepository) Save(cluster *domain.Cluster) error {
+	r.writeMu.Lock()
+	defer r.writeMu.Unlock()
+
+	if err := r.InMemoryClusterRepository.Save(cluster); err != nil {
+		return err
+	}
+
+	if err := r.persistence.SaveCluster(context.Background(), *cluster); err != nil {
+		_ = r.InMemoryClusterRepository.Delete(cluster.ID)
+		return fmt.Errorf("failed to persist cluster: %w", err)
+	}
+	return nil
+}
+
+// Update persists cluster before replacing the stored one. Callers may pass
+// the pointer FindByID returned, changed in place, so there is no earlier
+// value to roll back to.
+func (r *FileClusterRepository) Update(cluster *domain.Cluster) error {
+	r.writeMu.Lock()
+	defer r.writeMu.Unlock()
+
+	if _, err := r.FindByID(cluster.ID); err != nil {
+		return err
+	}
+
+	updated := *cluster
+	updated.UpdatedAt = time.Now()
+	if err := r.persistence.SaveCluster(context.Background(), updated); err != nil {
+		return fmt.Errorf("failed to persist cluster: %w", err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	cluster.UpdatedAt = updated.UpdatedAt
+	r.clusters[cluster.ID] = cluster
+	return nil
+}
+
+func (r *FileClusterRepository) Delete(id domain.ClusterID) error {
+	r.writeMu.Lock()
+	defer r.writeMu.Unlock()
+
+	if _, err := r.FindByID(id); err != nil {
+		return err
+	}
+
+	if err := r.persistence.DeleteCluster(context.Background(), id); err != nil {
+		return fmt.Errorf("failed to delete persisted cluster: %w", err)
+	}
+	return r.InMemoryClusterRepository.Delete(id)
+}
@@ -0,0 +1,118 @@
+package infrastructure
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Archive formats supported by LogArchive.
+const (
+	ArchiveFormatTarGz = "tar.gz"
+	ArchiveFormatZip   = "zip"
+)
+
+// LogArchive writes files into a tar.gz or zip archive. The archive is
+// written to a temporary file and only appears at its path on Close, so
+// readers never see a partial archive.
+type LogArchive struct {
+	path   string
+	tmp    *os.File
+	tarW   *tar.Writer
+	gzipW  *gzip.Writer
+	zipW   *zip.Writer
+	closed bool
+}
+
+// NewLogArchive starts an archive at path in the given format.
+func NewLogArchive(path, format string) (*LogArchive, error) {
+	if format != ArchiveFormatTarGz && format != ArchiveFormatZip {
+		return nil, fmt.Errorf("unsupported archive format %q (want %s or %s)", format, ArchiveFormatTarGz, ArchiveFormatZip)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return nil, fmt.Errorf("failed to create export directory: %w", err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".export-*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create archive: %w", err)
+	}
+
+	a := &LogArchive{path: path, tmp: tmp}
+	if format == ArchiveFormatZip {
+		a.zipW = zip.NewWriter(tmp)
+	} else {
+		a.gzipW = gzip.NewWriter(tmp)
+		a.tarW = tar.NewWriter(a.gzipW)
+	}
+	return a, nil
+}
+
+// Add writes a file named name with the given content.
+func (a *LogArchive) Add(name string, data []byte, modTime time.Time) error {
+	if a.zipW != nil {
+		w, err := a.zipW.CreateHeader(&zip.FileHeader{Name: name, Method: zip.Deflate, Modified: modTime})
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(data)
+		return err
+	}
+	if err := a.tarW.WriteHeader(&tar.Header{
+		Name:    name,
+		Mode:    0600,
+		Size:    int64(len(data)),
+		ModTime: modTime,
+	}); err != nil {
+		return err
+	}
+	_, err := a.tarW.Write(data)
+	return err
+}
+
+// Close finishes the archive, moves it to its path and returns its size.
+func (a *LogArchive) Close() (int64, error) {
+	if a.closed {
+		return 0, fmt.Errorf("archive already closed")
+	}
+	a.closed = true
+
+	var closers []io.Closer
+	if a.zipW != nil {
+		closers = append(closers, a.zipW)
+	} else {
+		closers = append(closers, a.tarW, a.gzipW)
+	}
+	closers = append(closers, a.tmp)
+	for _, c := range closers {
+		if err := c.Close(); err != nil {
+			os.Remove(a.tmp.Name())
+			return 0, fmt.Errorf("failed to write archive: %w", err)
+		}
+	}
+
+	info, err := os.Stat(a.tmp.Name())
+	if err != nil {
+		os.Remove(a.tmp.Name())
+		return 0, fmt.Errorf("failed to write archive: %w", err)
+	}
+	if err := os.Rename(a.tmp.Name(), a.path); err != nil {
+		os.Remove(a.tmp.Name())
+		return 0, fmt.Errorf("failed to write archive: %w", err)
+	}
+	return info.Size(), nil
+}
+
+// Abort discards the archive.
+func (a *LogArchive) Abort() {
+	if a.closed {
+		return
+	}
+	a.closed = true
+	a.tmp.Close()
+	os.Remove(a.tmp.Name())
+}
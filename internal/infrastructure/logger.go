@@ -1,8 +1,10 @@
 package infrastructure
 
 import (
+	"fmt"
 	"log"
 	"os"
+	"strings"
 )
 
 type Logger interface {
@@ -12,28 +14,70 @@ type Logger interface {
 	Warn(msg string, keyvals ...interface{})
 }
 
+type LogLevel int
+
+const (
+	LogLevelDebug LogLevel = iota
+	LogLevelInfo
+	LogLevelWarn
+	LogLevelError
+)
+
+// ParseLogLevel converts "debug", "info", "warn" or "error" to a LogLevel.
+func ParseLogLevel(s string) (LogLevel, error) {
+	switch strings.ToLower(s) {
+	case "debug":
+		return LogLevelDebug, nil
+	case "info", "":
+		return LogLevelInfo, nil
+	case "warn", "warning":
+		return LogLevelWarn, nil
+	case "error":
+		return LogLevelError, nil
+	default:
+		return LogLevelInfo, fmt.Errorf("unknown log level %q (want debug, info, warn or error)", s)
+	}
+}
+
 type SimpleLogger struct {
 	logger *log.Logger
+	level  LogLevel
 }
 
+// NewLogger returns a logger that writes every level to stderr.
 func NewLogger() Logger {
+	return NewLeveledLogger(LogLevelDebug)
+}
+
+// NewLeveledLogger returns a logger that writes messages at or above level
+// to stderr.
+func NewLeveledLogger(level LogLevel) Logger {
 	return &SimpleLogger{
 		logger: log.New(os.Stderr, "[mcp-k8s-server] ", log.LstdFlags|log.Lshortfile),
+		level:  level,
 	}
 }
 
 func (l *SimpleLogger) Info(msg string, keyvals ...interface{}) {
-	l.logger.Printf("[INFO] %s %v", msg, keyvals)
+	l.log(LogLevelInfo, "[INFO]", msg, keyvals)
 }
 
 func (l *SimpleLogger) Error(msg string, keyvals ...interface{}) {
-	l.logger.Printf("[ERROR] %s %v", msg, keyvals)
+	l.log(LogLevelError, "[ERROR]", msg, keyvals)
 }
 
 func (l *SimpleLogger) Debug(msg string, keyvals ...interface{}) {
-	l.logger.Printf("[DEBUG] %s %v", msg, keyvals)
+	l.log(LogLevelDebug, "[DEBUG]", msg, keyvals)
 }
 
 func (l *SimpleLogger) Warn(msg string, keyvals ...interface{}) {
-	l.logger.Printf("[WARN] %s %v", msg, keyvals)
+	l.log(LogLevelWarn, "[WARN]", msg, keyvals)
+}
+
+func (l *SimpleLogger) log(level LogLevel, tag, msg string, keyvals []interface{}) {
+	if level < l.level {
+		return
+	}
+	// Skip log and the level method so Lshortfile reports the caller.
+	_ = l.logger.Output(3, fmt.Sprintf("%s %s %v", tag, msg, keyvals))
 }
@@ -3,8 +3,11 @@ package infrastructure
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/your-org/mcp-k8s-server/internal/domain"
 )
@@ -18,14 +21,22 @@ type Persistence interface {
 
 type FilePersistence struct {
 	dataDir string
+	key     []byte
 	logger  Logger
 }
 
+// persistedCluster is the on-disk form of a cluster. When an encryption key
+// is configured the kubeconfig data is only stored encrypted.
+type persistedCluster struct {
+	domain.Cluster
+	EncryptedKubeconfig []byte `json:"encrypted_kubeconfig,omitempty"`
+}
+
 func NewFilePersistence(dataDir string, logger Logger) Persistence {
 	if dataDir == "" {
 		dataDir = "./data"
 	}
-	if err := os.MkdirAll(dataDir, 0755); err != nil {
+	if err := os.MkdirAll(dataDir, 0700); err != nil {
 		logger.Error("Failed to create data directory", "error", err)
 	}
 	return &FilePersistence{
@@ -34,27 +45,63 @@ func NewFilePersistence(dataDir string, logger Logger) Persistence {
 	}
 }
 
+// NewEncryptedFilePersistence is like NewFilePersistence but encrypts
+// kubeconfig data at rest with the given AES-256 key.
+func NewEncryptedFilePersistence(dataDir string, key []byte, logger Logger) Persistence {
+	fp := NewFilePersistence(dataDir, logger).(*FilePersistence)
+	fp.key = key
+	return fp
+}
+
 func (fp *FilePersistence) SaveCluster(ctx context.Context, cluster domain.Cluster) error {
-	filePath := filepath.Join(fp.dataDir, string(cluster.ID)+".json")
-	data, err := json.MarshalIndent(cluster, "", "  ")
+	record := persistedCluster{Cluster: cluster}
+	if fp.key != nil && len(cluster.Config.KubeconfigData) > 0 {
+		encrypted, err := Encrypt(fp.key, cluster.Config.KubeconfigData)
+		if err != nil {
+			return fmt.Errorf("failed to encrypt kubeconfig data: %w", err)
+		}
+		record.EncryptedKubeconfig = encrypted
+		record.Config.KubeconfigData = nil
+	}
+
+	filePath, err := fp.clusterFile(cluster.ID)
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(record, "", "  ")
 	if err != nil {
 		return err
 	}
-	return os.WriteFile(filePath, data, 0644)
+	return os.WriteFile(filePath, data, 0600)
 }
 
 func (fp *FilePersistence) LoadCluster(ctx context.Context, clusterID domain.ClusterID) (*domain.Cluster, error) {
-	filePath := filepath.Join(fp.dataDir, string(clusterID)+".json")
+	filePath, err := fp.clusterFile(clusterID)
+	if err != nil {
+		return nil, err
+	}
 	data, err := os.ReadFile(filePath)
 	if err != nil {
 		return nil, err
 	}
 
-	var cluster domain.Cluster
-	if err := json.Unmarshal(data, &cluster); err != nil {
+	var record persistedCluster
+	if err := json.Unmarshal(data, &record); err != nil {
 		return nil, err
 	}
 
+	if len(record.EncryptedKubeconfig) > 0 {
+		if fp.key == nil {
+			return nil, fmt.Errorf("cluster %s has encrypted kubeconfig data but no key is configured", clusterID)
+		}
+		plaintext, err := Decrypt(fp.key, record.EncryptedKubeconfig)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decrypt kubeconfig data for cluster %s: %w", clusterID, err)
+		}
+		record.Config.KubeconfigData = plaintext
+	}
+
+	cluster := record.Cluster
 	return &cluster, nil
 }
 
@@ -83,6 +130,23 @@ func (fp *FilePersistence) LoadAllClusters(ctx context.Context) ([]domain.Cluste
 }
 
 func (fp *FilePersistence) DeleteCluster(ctx context.Context, clusterID domain.ClusterID) error {
-	filePath := filepath.Join(fp.dataDir, string(clusterID)+".json")
-	return os.Remove(filePath)
+	filePath, err := fp.clusterFile(clusterID)
+	if err != nil {
+		return err
+	}
+	err = os.Remove(filePath)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
+// clusterFile returns the file a cluster is stored in. IDs that could name a
+// file outside dataDir are rejected.
+func (fp *FilePersistence) clusterFile(clusterID domain.ClusterID) (string, error) {
+	id := string(clusterID)
+	if id == "" || strings.ContainsAny(id, `/\`) || strings.Contains(id, "..") {
+		return "", fmt.Errorf("invalid cluster ID %q", clusterID)
+	}
+	return filepath.Join(fp.dataDir, id+".json"), nil
 }
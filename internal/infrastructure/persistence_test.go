@@ -0,0 +1,185 @@
+package infrastructure
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/your-org/mcp-k8s-server/internal/domain"
+)
+
+var testKubeconfig = []byte("apiVersion: v1\nkind: Config\nusers:\n- name: admin\n  user:\n    token: s3cr3t\n")
+
+func TestEncryptedFilePersistence(t *testing.T) {
+	ctx := context.Background()
+	logger := NewLeveledLogger(LogLevelError)
+	dir := t.TempDir()
+	key, err := LoadOrCreateKey(filepath.Join(dir, "keys", "cluster.key"))
+	if err != nil {
+		t.Fatalf("create key: %v", err)
+	}
+	if again, err := LoadOrCreateKey(filepath.Join(dir, "keys", "cluster.key")); err != nil || !bytes.Equal(again, key) {
+		t.Fatalf("reload key: %v, same key %v", err, bytes.Equal(again, key))
+	}
+
+	dataDir := filepath.Join(dir, "data")
+	cluster := domain.Cluster{ID: "prod", Config: domain.ClusterConfig{KubeconfigData: testKubeconfig, Context: "admin"}}
+	if err := NewEncryptedFilePersistence(dataDir, key, logger).SaveCluster(ctx, cluster); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+
+	stored, err := os.ReadFile(filepath.Join(dataDir, "prod.json"))
+	if err != nil {
+		t.Fatalf("read stored cluster: %v", err)
+	}
+	if strings.Contains(string(stored), "s3cr3t") || strings.Contains(string(stored), "kubeconfig_data") {
+		t.Errorf("stored cluster holds the kubeconfig in plain text:\n%s", stored)
+	}
+
+	loaded, err := NewEncryptedFilePersistence(dataDir, key, logger).LoadCluster(ctx, "prod")
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if !bytes.Equal(loaded.Config.KubeconfigData, testKubeconfig) || loaded.Config.Context != "admin" {
+		t.Errorf("loaded config = %+v, want the saved one", loaded.Config)
+	}
+
+	wrongKey := bytes.Repeat([]byte{1}, encryptionKeySize)
+	if _, err := NewEncryptedFilePersistence(dataDir, wrongKey, logger).LoadCluster(ctx, "prod"); err == nil {
+		t.Error("load with a wrong key succeeded")
+	}
+	if _, err := NewFilePersistence(dataDir, logger).LoadCluster(ctx, "prod"); err == nil {
+		t.Error("load without a key succeeded")
+	}
+	if clusters, err := NewFilePersistence(dataDir, logger).LoadAllClusters(ctx); err != nil || len(clusters) != 0 {
+		t.Errorf("load all without a key = %d clusters, %v; want the undecryptable one skipped", len(clusters), err)
+	}
+}
+
+func TestFileClusterRepositoryRestart(t *testing.T) {
+	ctx := context.Background()
+	logger := NewLeveledLogger(LogLevelError)
+	dataDir := t.TempDir()
+	key := bytes.Repeat([]byte{7}, encryptionKeySize)
+
+	repo, err := NewFileClusterRepository(ctx, NewEncryptedFilePersistence(dataDir, key, logger), logger)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, id := range []domain.ClusterID{"prod", "staging"} {
+		if err := repo.Save(&domain.Cluster{ID: id, Config: domain.ClusterConfig{KubeconfigData: testKubeconfig}}); err != nil {
+			t.Fatalf("save %s: %v", id, err)
+		}
+	}
+	if err := repo.Delete("staging"); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+
+	restarted, err := NewFileClusterRepository(ctx, NewEncryptedFilePersistence(dataDir, key, logger), logger)
+	if err != nil {
+		t.Fatal(err)
+	}
+	clusters, _ := restarted.FindAll()
+	if len(clusters) != 1 || clusters[0].ID != "prod" || !bytes.Equal(clusters[0].Config.KubeconfigData, testKubeconfig) {
+		t.Errorf("after restart got %+v, want only prod with its kubeconfig", clusters)
+	}
+}
+
+// failingPersistence fails every write once fail is set.
+type failingPersistence struct {
+	Persistence
+	fail bool
+}
+
+func (p *failingPersistence) SaveCluster(ctx context.Context, cluster domain.Cluster) error {
+	if p.fail {
+		return errors.New("disk full")
+	}
+	return p.Persistence.SaveCluster(ctx, cluster)
+}
+
+func (p *failingPersistence) DeleteCluster(ctx context.Context, clusterID domain.ClusterID) error {
+	if p.fail {
+		return errors.New("disk full")
+	}
+	return p.Persistence.DeleteCluster(ctx, clusterID)
+}
+
+// TestFileClusterRepositoryPersistFailure expects a change that cannot be
+// persisted to leave the in-memory registry as it was.
+func TestFileClusterRepositoryPersistFailure(t *testing.T) {
+	ctx := context.Background()
+	logger := NewLeveledLogger(LogLevelError)
+	persistence := &failingPersistence{Persistence: NewEncryptedFilePersistence(t.TempDir(), bytes.Repeat([]byte{7}, encryptionKeySize), logger)}
+	repo, err := NewFileClusterRepository(ctx, persistence, logger)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.Save(&domain.Cluster{ID: "prod", Name: "prod", Config: domain.ClusterConfig{KubeconfigData: testKubeconfig}}); err != nil {
+		t.Fatal(err)
+	}
+	persistence.fail = true
+
+	if err := repo.Save(&domain.Cluster{ID: "staging"}); err == nil {
+		t.Error("save succeeded")
+	}
+	if _, err := repo.FindByID("staging"); err == nil {
+		t.Error("staging is registered after a failed save")
+	}
+
+	if err := repo.Update(&domain.Cluster{ID: "prod", Name: "renamed"}); err == nil {
+		t.Error("update succeeded")
+	}
+	if cluster, err := repo.FindByID("prod"); err != nil || cluster.Name != "prod" {
+		t.Errorf("after a failed update got %+v, %v; want the old cluster", cluster, err)
+	}
+
+	if err := repo.Delete("prod"); err == nil {
+		t.Error("delete succeeded")
+	}
+	if _, err := repo.FindByID("prod"); err != nil {
+		t.Errorf("prod is gone after a failed delete: %v", err)
+	}
+
+	persistence.fail = false
+	if err := repo.Update(&domain.Cluster{ID: "prod", Name: "renamed", Config: domain.ClusterConfig{KubeconfigData: testKubeconfig}}); err != nil {
+		t.Fatal(err)
+	}
+	stored, err := persistence.LoadCluster(ctx, "prod")
+	if err != nil || stored.Name != "renamed" {
+		t.Errorf("persisted cluster = %+v, %v; want the update", stored, err)
+	}
+}
+
+func TestFilePersistenceInvalidID(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	dataDir := filepath.Join(dir, "data")
+	fp := NewFilePersistence(dataDir, NewLeveledLogger(LogLevelError))
+	victim := filepath.Join(dir, "victim.json")
+	if err := os.WriteFile(victim, []byte("{}"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, id := range []domain.ClusterID{"../victim", "../../x", "a/b", `..\victim`, ""} {
+		if err := fp.SaveCluster(ctx, domain.Cluster{ID: id}); err == nil {
+			t.Errorf("SaveCluster(%q) succeeded", id)
+		}
+		if _, err := fp.LoadCluster(ctx, id); err == nil {
+			t.Errorf("LoadCluster(%q) succeeded", id)
+		}
+		if err := fp.DeleteCluster(ctx, id); err == nil {
+			t.Errorf("DeleteCluster(%q) succeeded", id)
+		}
+	}
+	if _, err := os.Stat(victim); err != nil {
+		t.Errorf("file outside the data directory: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "x.json")); err == nil {
+		t.Error("SaveCluster wrote outside the data directory")
+	}
+}
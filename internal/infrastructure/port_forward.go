@@ -0,0 +1,245 @@
+package infrastructure
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/your-org/mcp-k8s-server/internal/domain"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/httpstream"
+)
+
+// tunnelReapInterval is how often idle and expired tunnels are closed.
+const tunnelReapInterval = 30 * time.Second
+
+// Tunnel is a running port-forward. It records traffic through the dialer
+// returned by WrapDialer so idle tunnels can be closed.
+type Tunnel struct {
+	session  domain.PortForwardSession
+	stop     chan struct{}
+	stopOnce sync.Once
+
+	lastActive    atomic.Int64 // unix nanoseconds
+	connections   atomic.Int64
+	bytesSent     atomic.Int64
+	bytesReceived atomic.Int64
+}
+
+// NewTunnel returns a tunnel that is active until Stop is called.
+func NewTunnel() *Tunnel {
+	t := &Tunnel{stop: make(chan struct{})}
+	t.touch()
+	return t
+}
+
+// StopChan is closed when the tunnel is stopped; pass it to portforward.New.
+func (t *Tunnel) StopChan() <-chan struct{} {
+	return t.stop
+}
+
+// Stop closes the tunnel. It is safe to call more than once.
+func (t *Tunnel) Stop() {
+	t.stopOnce.Do(func() { close(t.stop) })
+}
+
+func (t *Tunnel) stopped() bool {
+	select {
+	case <-t.stop:
+		return true
+	default:
+		return false
+	}
+}
+
+func (t *Tunnel) touch() {
+	t.lastActive.Store(time.Now().UnixNano())
+}
+
+// Session returns the tunnel description with its current traffic counters.
+func (t *Tunnel) Session() domain.PortForwardSession {
+	s := t.session
+	s.LastActive = time.Unix(0, t.lastActive.Load()).UTC()
+	s.Connections = t.connections.Load()
+	s.BytesSent = t.bytesSent.Load()
+	s.BytesReceived = t.bytesReceived.Load()
+	return s
+}
+
+// WrapDialer returns a dialer whose connections report their streams and
+// traffic to t.
+func (t *Tunnel) WrapDialer(d httpstream.Dialer) httpstream.Dialer {
+	return &tunnelDialer{Dialer: d, tunnel: t}
+}
+
+type tunnelDialer struct {
+	httpstream.Dialer
+	tunnel *Tunnel
+}
+
+func (d *tunnelDialer) Dial(protocols ...string) (httpstream.Connection, string, error) {
+	conn, protocol, err := d.Dialer.Dial(protocols...)
+	if err != nil {
+		return nil, "", err
+	}
+	return &tunnelConnection{Connection: conn, tunnel: d.tunnel}, protocol, nil
+}
+
+type tunnelConnection struct {
+	httpstream.Connection
+	tunnel *Tunnel
+}
+
+// CreateStream is called for every local connection the port-forward
+// accepts: once for its error stream and once for its data stream.
+func (c *tunnelConnection) CreateStream(headers http.Header) (httpstream.Stream, error) {
+	stream, err := c.Connection.CreateStream(headers)
+	if err != nil {
+		return nil, err
+	}
+	c.tunnel.touch()
+	if headers.Get(v1.StreamType) != v1.StreamTypeData {
+		return stream, nil
+	}
+	c.tunnel.connections.Add(1)
+	return &tunnelStream{Stream: stream, tunnel: c.tunnel}, nil
+}
+
+type tunnelStream struct {
+	httpstream.Stream
+	tunnel *Tunnel
+}
+
+func (s *tunnelStream) Read(p []byte) (int, error) {
+	n, err := s.Stream.Read(p)
+	if n > 0 {
+		s.tunnel.bytesReceived.Add(int64(n))
+		s.tunnel.touch()
+	}
+	return n, err
+}
+
+func (s *tunnelStream) Write(p []byte) (int, error) {
+	n, err := s.Stream.Write(p)
+	if n > 0 {
+		s.tunnel.bytesSent.Add(int64(n))
+		s.tunnel.touch()
+	}
+	return n, err
+}
+
+// SetTunnelLimits sets how long tunnels may stay idle and how long they may
+// live at most. Zero disables a limit.
+func (cm *ClusterManager) SetTunnelLimits(idleTimeout, maxLifetime time.Duration) {
+	cm.tunnelMu.Lock()
+	defer cm.tunnelMu.Unlock()
+	cm.tunnelIdleTimeout = idleTimeout
+	cm.tunnelMaxLifetime = maxLifetime
+}
+
+// SaveTunnel registers a ready tunnel under session.ID. ttl bounds its
+// lifetime; zero or a value above the server limit uses the server limit.
+func (cm *ClusterManager) SaveTunnel(t *Tunnel, session domain.PortForwardSession, ttl time.Duration) (domain.PortForwardSession, error) {
+	cm.tunnelMu.Lock()
+	defer cm.tunnelMu.Unlock()
+
+	if t.stopped() {
+		return domain.PortForwardSession{}, fmt.Errorf("port-forward %s closed before it was registered", session.ID)
+	}
+	if _, ok := cm.activeTunnels[session.ID]; ok {
+		return domain.PortForwardSession{}, fmt.Errorf("port-forward %s is already active", session.ID)
+	}
+	if cm.activeTunnels == nil {
+		cm.activeTunnels = make(map[string]*Tunnel)
+	}
+
+	if ttl <= 0 || (cm.tunnelMaxLifetime > 0 && ttl > cm.tunnelMaxLifetime) {
+		ttl = cm.tunnelMaxLifetime
+	}
+	if ttl > 0 {
+		expires := session.StartedAt.Add(ttl)
+		session.ExpiresAt = &expires
+	}
+	t.session = session
+	cm.activeTunnels[session.ID] = t
+
+	cm.reaperOnce.Do(func() { go cm.reapTunnels() })
+	return t.Session(), nil
+}
+
+// RemoveTunnel forgets a tunnel whose forwarding has ended.
+func (cm *ClusterManager) RemoveTunnel(t *Tunnel) {
+	cm.tunnelMu.Lock()
+	defer cm.tunnelMu.Unlock()
+	if cm.activeTunnels[t.session.ID] == t {
+		delete(cm.activeTunnels, t.session.ID)
+	}
+}
+
+// StopTunnel closes the tunnel with the given ID and reports whether it was
+// open.
+func (cm *ClusterManager) StopTunnel(id string) bool {
+	return len(cm.StopTunnels(func(s domain.PortForwardSession) bool { return s.ID == id })) > 0
+}
+
+// StopTunnels closes every tunnel for which match returns true and returns
+// them.
+func (cm *ClusterManager) StopTunnels(match func(domain.PortForwardSession) bool) []domain.PortForwardSession {
+	cm.tunnelMu.Lock()
+	defer cm.tunnelMu.Unlock()
+
+	var stopped []domain.PortForwardSession
+	for id, t := range cm.activeTunnels {
+		s := t.Session()
+		if !match(s) {
+			continue
+		}
+		t.Stop()
+		delete(cm.activeTunnels, id)
+		stopped = append(stopped, s)
+	}
+	sortSessions(stopped)
+	return stopped
+}
+
+// ListTunnels returns the open tunnels ordered by ID.
+func (cm *ClusterManager) ListTunnels() []domain.PortForwardSession {
+	cm.tunnelMu.Lock()
+	defer cm.tunnelMu.Unlock()
+
+	sessions := make([]domain.PortForwardSession, 0, len(cm.activeTunnels))
+	for _, t := range cm.activeTunnels {
+		sessions = append(sessions, t.Session())
+	}
+	sortSessions(sessions)
+	return sessions
+}
+
+func sortSessions(sessions []domain.PortForwardSession) {
+	sort.Slice(sessions, func(i, j int) bool { return sessions[i].ID < sessions[j].ID })
+}
+
+// reapTunnels closes tunnels that were idle or open for too long.
+func (cm *ClusterManager) reapTunnels() {
+	ticker := time.NewTicker(tunnelReapInterval)
+	defer ticker.Stop()
+
+	for now := range ticker.C {
+		cm.tunnelMu.Lock()
+		idleTimeout := cm.tunnelIdleTimeout
+		cm.tunnelMu.Unlock()
+
+		stopped := cm.StopTunnels(func(s domain.PortForwardSession) bool {
+			if s.ExpiresAt != nil && now.After(*s.ExpiresAt) {
+				return true
+			}
+			return idleTimeout > 0 && now.Sub(s.LastActive) > idleTimeout
+		})
+		for _, s := range stopped {
+			cm.logger.Info("Closed expired port-forward", "id", s.ID, "lastActive", s.LastActive)
+		}
+	}
+}
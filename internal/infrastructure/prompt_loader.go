@@ -0,0 +1,85 @@
+package infrastructure
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/your-org/mcp-k8s-server/internal/domain"
+	"sigs.k8s.io/yaml"
+)
+
+var promptNamePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_.-]*$`)
+
+// LoadPromptTemplates reads the custom prompts in dir, one per *.yaml or
+// *.yml file, sorted by file name. Each file holds the fields of
+// domain.PromptTemplate:
+//
+//	name: check_ingress
+//	description: Check why an ingress does not serve traffic
+//	arguments:
+//	  - name: ingress
+//	    required: true
+//	template: |
+//	  Find out why ingress {{.ingress}} ...
+//
+// Unknown fields, duplicate names and templates that do not render are
+// errors, so mistakes show up at startup.
+func LoadPromptTemplates(dir string) ([]domain.PromptTemplate, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read prompt directory: %w", err)
+	}
+
+	var prompts []domain.PromptTemplate
+	files := make(map[string]string)
+	for _, entry := range entries {
+		ext := strings.ToLower(filepath.Ext(entry.Name()))
+		if entry.IsDir() || (ext != ".yaml" && ext != ".yml") {
+			continue
+		}
+		path := filepath.Join(dir, entry.Name())
+		prompt, err := loadPromptTemplate(path)
+		if err != nil {
+			return nil, err
+		}
+		if other, ok := files[prompt.Name]; ok {
+			return nil, fmt.Errorf("prompt %s is defined in both %s and %s", prompt.Name, other, path)
+		}
+		files[prompt.Name] = path
+		prompts = append(prompts, prompt)
+	}
+	return prompts, nil
+}
+
+func loadPromptTemplate(path string) (domain.PromptTemplate, error) {
+	var prompt domain.PromptTemplate
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return prompt, fmt.Errorf("failed to read prompt file: %w", err)
+	}
+	if err := yaml.UnmarshalStrict(data, &prompt); err != nil {
+		return prompt, fmt.Errorf("failed to parse prompt file %s: %w", path, err)
+	}
+
+	if !promptNamePattern.MatchString(prompt.Name) {
+		return prompt, fmt.Errorf("prompt file %s: invalid name %q (use letters, digits, '_', '.' and '-')", path, prompt.Name)
+	}
+	if strings.TrimSpace(prompt.Template) == "" {
+		return prompt, fmt.Errorf("prompt file %s: template is empty", path)
+	}
+	var names []string
+	for _, arg := range prompt.Arguments {
+		if arg.Name == "" || slices.Contains(names, arg.Name) {
+			return prompt, fmt.Errorf("prompt file %s: argument names must be set and unique", path)
+		}
+		names = append(names, arg.Name)
+	}
+	if _, err := prompt.Render(nil); err != nil {
+		return prompt, fmt.Errorf("prompt file %s: %w", path, err)
+	}
+	return prompt, nil
+}
@@ -0,0 +1,250 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/your-org/mcp-k8s-server/internal/domain"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+	"k8s.io/kube-openapi/pkg/spec3"
+	"k8s.io/kube-openapi/pkg/validation/spec"
+)
+
+// ListAPIResources returns the resource types the cluster serves in their
+// preferred versions, sorted by group and name, like kubectl api-resources.
+// Subresources are left out. Group versions whose discovery fails are
+// reported in FailedGroups.
+func (uc *K8sUseCase) ListAPIResources(ctx context.Context, clusterID string, filter domain.APIResourceFilter) (*domain.APIResourceList, error) {
+	discClient, err := uc.clusterManager.GetDiscoveryClient(domain.ClusterID(clusterID))
+	if err != nil {
+		return nil, err
+	}
+
+	lists, err := discovery.ServerPreferredResources(discClient)
+	result := &domain.APIResourceList{Resources: []domain.APIResource{}}
+	if err != nil {
+		failed, ok := err.(*discovery.ErrGroupDiscoveryFailed)
+		if !ok {
+			return nil, fmt.Errorf("failed to discover API resources: %w", err)
+		}
+		result.FailedGroups = make(map[string]string, len(failed.Groups))
+		for gv, groupErr := range failed.Groups {
+			result.FailedGroups[gv.String()] = groupErr.Error()
+		}
+	}
+
+	for _, list := range lists {
+		gv, err := schema.ParseGroupVersion(list.GroupVersion)
+		if err != nil {
+			continue
+		}
+		if filter.APIGroup != "" && gv.Group != filter.APIGroup {
+			continue
+		}
+		for _, r := range list.APIResources {
+			if strings.Contains(r.Name, "/") {
+				continue
+			}
+			if filter.Namespaced != nil && r.Namespaced != *filter.Namespaced {
+				continue
+			}
+			if filter.Verb != "" && !slices.Contains(r.Verbs, filter.Verb) {
+				continue
+			}
+			result.Resources = append(result.Resources, domain.APIResource{
+				Name:       r.Name,
+				ShortNames: r.ShortNames,
+				APIVersion: list.GroupVersion,
+				Kind:       r.Kind,
+				Namespaced: r.Namespaced,
+				Verbs:      r.Verbs,
+				Categories: r.Categories,
+			})
+		}
+	}
+	group := func(r domain.APIResource) string {
+		gv, _ := schema.ParseGroupVersion(r.APIVersion)
+		return gv.Group
+	}
+	sort.SliceStable(result.Resources, func(i, j int) bool {
+		gi, gj := group(result.Resources[i]), group(result.Resources[j])
+		if gi != gj {
+			return gi < gj
+		}
+		return result.Resources[i].Name < result.Resources[j].Name
+	})
+	return result, nil
+}
+
+// Explain documents a field of a resource type from the cluster's OpenAPI
+// v3 schema. path starts with the resource, which is resolved like the
+// kind of GetResource, followed by field names, e.g.
+// "deployment.spec.template.spec.containers.readinessProbe". Lists are
+// walked through to their items. apiVersion pins the group and version.
+func (uc *K8sUseCase) Explain(ctx context.Context, clusterID, path, apiVersion string) (*domain.ExplainResult, error) {
+	resource, fieldPath, _ := strings.Cut(strings.TrimSpace(path), ".")
+	var fields []string
+	if fieldPath != "" {
+		fields = strings.Split(fieldPath, ".")
+	}
+	rc, err := uc.resourceClientFor(clusterID, resource, apiVersion)
+	if err != nil {
+		return nil, err
+	}
+	gvk := rc.mapping.GroupVersionKind
+
+	doc, err := uc.openAPIDocument(clusterID, gvk.GroupVersion())
+	if err != nil {
+		return nil, err
+	}
+	explainer := openAPIExplainer{schemas: doc.Components.Schemas}
+	root := explainer.kindSchema(gvk)
+	if root == nil {
+		return nil, fmt.Errorf("no OpenAPI schema published for %s", gvk.String())
+	}
+
+	result := &domain.ExplainResult{
+		APIVersion: gvk.GroupVersion().String(),
+		Kind:       gvk.Kind,
+		Path:       strings.Join(append([]string{strings.ToLower(gvk.Kind)}, fields...), "."),
+		Field:      domain.ExplainField{Name: gvk.Kind, Type: explainer.typeName(root), Description: root.Description},
+	}
+	current := root
+	walked := strings.ToLower(gvk.Kind)
+	for _, name := range fields {
+		parent := explainer.object(current)
+		prop, ok := parent.Properties[name]
+		if !ok {
+			return nil, fmt.Errorf("field %q does not exist in %s (fields: %s)", name, walked, strings.Join(slices.Sorted(maps.Keys(parent.Properties)), ", "))
+		}
+		current = &prop
+		walked += "." + name
+		result.Field = explainer.field(name, current, slices.Contains(parent.Required, name))
+	}
+
+	if obj := explainer.object(current); len(obj.Properties) > 0 {
+		for _, name := range slices.Sorted(maps.Keys(obj.Properties)) {
+			prop := obj.Properties[name]
+			result.Fields = append(result.Fields, explainer.field(name, &prop, slices.Contains(obj.Required, name)))
+		}
+	}
+	return result, nil
+}
+
+// openAPIDocument fetches the OpenAPI v3 document of a group version.
+func (uc *K8sUseCase) openAPIDocument(clusterID string, gv schema.GroupVersion) (*spec3.OpenAPI, error) {
+	discClient, err := uc.clusterManager.GetDiscoveryClient(domain.ClusterID(clusterID))
+	if err != nil {
+		return nil, err
+	}
+	paths, err := discClient.OpenAPIV3().Paths()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list OpenAPI v3 documents: %w", err)
+	}
+	key := "apis/" + gv.Group + "/" + gv.Version
+	if gv.Group == "" {
+		key = "api/" + gv.Version
+	}
+	groupVersion, ok := paths[key]
+	if !ok {
+		return nil, fmt.Errorf("the cluster publishes no OpenAPI v3 document for %s", gv.String())
+	}
+	body, err := groupVersion.Schema("application/json")
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch the OpenAPI v3 document for %s: %w", gv.String(), err)
+	}
+	var doc spec3.OpenAPI
+	if err := json.Unmarshal(body, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse the OpenAPI v3 document for %s: %w", gv.String(), err)
+	}
+	if doc.Components == nil {
+		return nil, fmt.Errorf("the OpenAPI v3 document for %s has no schemas", gv.String())
+	}
+	return &doc, nil
+}
+
+// openAPIExplainer reads the schemas of an OpenAPI v3 document, following
+// "#/components/schemas/..." references.
+type openAPIExplainer struct {
+	schemas map[string]*spec.Schema
+}
+
+// kindSchema returns the schema of the kind gvk, or nil.
+func (e openAPIExplainer) kindSchema(gvk schema.GroupVersionKind) *spec.Schema {
+	for _, s := range e.schemas {
+		var gvks []schema.GroupVersionKind
+		if err := s.Extensions.GetObject("x-kubernetes-group-version-kind", &gvks); err != nil {
+			continue
+		}
+		if slices.Contains(gvks, gvk) {
+			return s
+		}
+	}
+	return nil
+}
+
+// resolve returns the schema s refers to, directly or through a single
+// allOf entry as Kubernetes writes references with their own description.
+func (e openAPIExplainer) resolve(s *spec.Schema) *spec.Schema {
+	for range 10 {
+		ref := s.Ref.String()
+		if ref == "" && len(s.AllOf) == 1 {
+			ref = s.AllOf[0].Ref.String()
+		}
+		target, ok := e.schemas[strings.TrimPrefix(ref, "#/components/schemas/")]
+		if ref == "" || !ok {
+			return s
+		}
+		s = target
+	}
+	return s
+}
+
+// object returns the object schema holding the fields below s, going
+// through lists to their items.
+func (e openAPIExplainer) object(s *spec.Schema) *spec.Schema {
+	s = e.resolve(s)
+	if s.Items != nil && s.Items.Schema != nil {
+		s = e.resolve(s.Items.Schema)
+	}
+	return s
+}
+
+// typeName writes the type of s as kubectl explain does.
+func (e openAPIExplainer) typeName(s *spec.Schema) string {
+	ref := s.Ref.String()
+	if ref == "" && len(s.AllOf) == 1 {
+		ref = s.AllOf[0].Ref.String()
+	}
+	if ref != "" {
+		return ref[strings.LastIndex(ref, ".")+1:]
+	}
+	switch {
+	case s.Type.Contains("array") && s.Items != nil && s.Items.Schema != nil:
+		return "[]" + e.typeName(s.Items.Schema)
+	case s.Type.Contains("object") && s.AdditionalProperties != nil && s.AdditionalProperties.Schema != nil:
+		return "map[string]" + e.typeName(s.AdditionalProperties.Schema)
+	case len(s.Type) > 0 && s.Type[0] != "object":
+		return s.Type[0]
+	}
+	return "Object"
+}
+
+// field describes the field name whose schema is s. Descriptions on the
+// reference win over those of the referenced type.
+func (e openAPIExplainer) field(name string, s *spec.Schema, required bool) domain.ExplainField {
+	f := domain.ExplainField{Name: name, Type: e.typeName(s), Required: required, Description: s.Description}
+	if f.Description == "" {
+		f.Description = e.resolve(s).Description
+	}
+	for _, v := range e.resolve(s).Enum {
+		f.Enum = append(f.Enum, fmt.Sprint(v))
+	}
+	return f
+}
@@ -0,0 +1,210 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/your-org/mcp-k8s-server/internal/domain"
+	"github.com/your-org/mcp-k8s-server/internal/infrastructure"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	redactedValue = "[REDACTED]"
+	// maxAuditStringLen truncates long argument values such as manifests.
+	maxAuditStringLen = 4096
+)
+
+// sensitiveArgumentKeys are always redacted. Keys containing one of
+// sensitiveArgumentSubstrings are redacted as well.
+var (
+	sensitiveArgumentKeys       = map[string]bool{"kubeconfig_data": true, "string_data": true}
+	sensitiveArgumentSubstrings = []string{"password", "passwd", "token", "credential", "private_key", "api_key", "apikey"}
+	// sensitiveVariableSubstrings also mark environment variable names whose
+	// values are redacted.
+	sensitiveVariableSubstrings = []string{"secret", "_key"}
+)
+
+// AuditUseCase records tool invocations in an append-only store.
+type AuditUseCase struct {
+	store  domain.AuditStore
+	logger infrastructure.Logger
+}
+
+func NewAuditUseCase(store domain.AuditStore, logger infrastructure.Logger) *AuditUseCase {
+	return &AuditUseCase{store: store, logger: logger}
+}
+
+// Record sanitizes the arguments of entry and stores it. Failures are logged
+// rather than returned so auditing never changes the outcome of a call.
+func (uc *AuditUseCase) Record(entry domain.AuditEntry) {
+	entry.Arguments = SanitizeArguments(entry.Tool, entry.Arguments)
+	if err := uc.store.Append(entry); err != nil {
+		uc.logger.Error("Failed to write audit entry", "tool", entry.Tool, "error", err)
+	}
+}
+
+func (uc *AuditUseCase) Query(q domain.AuditQuery) ([]domain.AuditEntry, error) {
+	return uc.store.Query(q)
+}
+
+// SanitizeArguments returns a copy of args that is safe to store: secret
+// values, credentials and manifests containing Secrets are redacted and long
+// strings are truncated.
+func SanitizeArguments(tool string, args map[string]any) map[string]any {
+	if args == nil {
+		return nil
+	}
+	isSecretTool := strings.HasPrefix(tool, "k8s_secret_")
+
+	out := make(map[string]any, len(args))
+	for k, v := range args {
+		switch {
+		case isSensitiveKey(k), isSecretTool && k == "data":
+			out[k] = redactedValue
+		case k == "yaml_body":
+			body, _ := v.(string)
+			if manifestContainsSecret(body) {
+				out[k] = redactedValue + " manifest contains a Secret"
+			} else {
+				out[k] = sanitizeValue(v)
+			}
+		default:
+			out[k] = sanitizeValue(v)
+		}
+	}
+	return out
+}
+
+func sanitizeValue(v any) any {
+	switch val := v.(type) {
+	case string:
+		if len(val) > maxAuditStringLen {
+			return val[:maxAuditStringLen] + "...(truncated)"
+		}
+		return val
+	case map[string]any:
+		// Name/value pairs such as environment variables are redacted by
+		// the variable name.
+		name, _ := val["name"].(string)
+		out := make(map[string]any, len(val))
+		for k, item := range val {
+			if isSensitiveKey(k) || (k == "value" && isSensitiveVariable(name)) {
+				out[k] = redactedValue
+				continue
+			}
+			out[k] = sanitizeValue(item)
+		}
+		return out
+	case []any:
+		out := make([]any, len(val))
+		for i, item := range val {
+			out[i] = sanitizeValue(item)
+		}
+		return out
+	}
+	return v
+}
+
+func isSensitiveKey(key string) bool {
+	key = strings.ToLower(key)
+	if sensitiveArgumentKeys[key] {
+		return true
+	}
+	for _, s := range sensitiveArgumentSubstrings {
+		if strings.Contains(key, s) {
+			return true
+		}
+	}
+	return false
+}
+
+func isSensitiveVariable(name string) bool {
+	if isSensitiveKey(name) {
+		return true
+	}
+	name = strings.ToLower(name)
+	for _, s := range sensitiveVariableSubstrings {
+		if strings.Contains(name, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// manifestContainsSecret reports whether yamlBody declares a Secret. Bodies
+// that cannot be decoded are treated as containing one.
+func manifestContainsSecret(yamlBody string) bool {
+	if strings.TrimSpace(yamlBody) == "" {
+		return false
+	}
+	objects, err := decodeManifests([]byte(yamlBody), "yaml_body")
+	if err != nil {
+		return true
+	}
+	for _, mo := range objects {
+		if mo.obj.GetKind() == "Secret" {
+			return true
+		}
+	}
+	return false
+}
+
+// ResourceVersion returns the current resourceVersion of an object, or ""
+// when it does not exist. kind is the lower-case kind used in tool names, or
+// any kind, resource or short name known to discovery.
+func (uc *K8sUseCase) ResourceVersion(ctx context.Context, clusterID, kind, namespace, name string) (string, error) {
+	client, err := uc.clusterManager.GetClusterClient(domain.ClusterID(clusterID))
+	if err != nil {
+		return "", fmt.Errorf("failed to get client: %w", err)
+	}
+
+	get := metav1.GetOptions{}
+	var meta metav1.Object
+	switch kind {
+	case "namespace":
+		meta, err = client.CoreV1().Namespaces().Get(ctx, name, get)
+	case "node":
+		meta, err = client.CoreV1().Nodes().Get(ctx, name, get)
+	case "pod":
+		meta, err = client.CoreV1().Pods(namespace).Get(ctx, name, get)
+	case "configmap":
+		meta, err = client.CoreV1().ConfigMaps(namespace).Get(ctx, name, get)
+	case "secret":
+		meta, err = client.CoreV1().Secrets(namespace).Get(ctx, name, get)
+	case "service":
+		meta, err = client.CoreV1().Services(namespace).Get(ctx, name, get)
+	case "deployment":
+		meta, err = client.AppsV1().Deployments(namespace).Get(ctx, name, get)
+	case "statefulset":
+		meta, err = client.AppsV1().StatefulSets(namespace).Get(ctx, name, get)
+	case "daemonset":
+		meta, err = client.AppsV1().DaemonSets(namespace).Get(ctx, name, get)
+	case "job":
+		meta, err = client.BatchV1().Jobs(namespace).Get(ctx, name, get)
+	case "cronjob":
+		meta, err = client.BatchV1().CronJobs(namespace).Get(ctx, name, get)
+	case "ingress":
+		meta, err = client.NetworkingV1().Ingresses(namespace).Get(ctx, name, get)
+	case "hpa":
+		meta, err = client.AutoscalingV2().HorizontalPodAutoscalers(namespace).Get(ctx, name, get)
+	default:
+		// Other kinds, custom resources included, are resolved through
+		// discovery.
+		rc, rcErr := uc.resourceClientFor(clusterID, kind, "")
+		if rcErr != nil {
+			return "", rcErr
+		}
+		meta, err = rc.in(namespace).Get(ctx, name, get)
+	}
+
+	if apierrors.IsNotFound(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to get %s: %w", kind, err)
+	}
+	return meta.GetResourceVersion(), nil
+}
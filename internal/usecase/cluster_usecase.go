@@ -4,10 +4,13 @@ package usecase
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strings"
 
 	"github.com/your-org/mcp-k8s-server/internal/domain"
 	"github.com/your-org/mcp-k8s-server/internal/infrastructure"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
 )
 
 type ClusterUseCase struct {
@@ -65,6 +68,9 @@ func (uc *ClusterUseCase) GetPodLogs(ctx context.Context, clusterID, namespace,
 }
 
 func (uc *ClusterUseCase) RegisterCluster(ctx context.Context, clusterID domain.ClusterID, config domain.ClusterConfig) error {
+	if err := ValidateClusterID(clusterID); err != nil {
+		return err
+	}
 	uc.logger.Info("Registering cluster", "clusterID", clusterID)
 
 	// First save to repository
@@ -88,6 +94,36 @@ func (uc *ClusterUseCase) RegisterCluster(ctx context.Context, clusterID domain.
 	return nil
 }
 
+// ValidateClusterID checks that a cluster ID is a DNS-1123 label. IDs name
+// the files registered clusters are persisted in, so anything else, in
+// particular path separators and "..", is rejected.
+func ValidateClusterID(clusterID domain.ClusterID) error {
+	if msgs := validation.IsDNS1123Label(string(clusterID)); len(msgs) > 0 {
+		return fmt.Errorf("invalid cluster ID %q: %s", clusterID, strings.Join(msgs, "; "))
+	}
+	return nil
+}
+
+// RegisterConfiguredCluster registers a cluster declared in the server
+// configuration. It is not saved to the repository because the configuration
+// file remains its source of truth; it replaces a persisted cluster with the
+// same ID for this run.
+func (uc *ClusterUseCase) RegisterConfiguredCluster(ctx context.Context, clusterID domain.ClusterID, config domain.ClusterConfig) error {
+	if _, err := uc.clusterRepo.FindByID(clusterID); err == nil {
+		uc.logger.Warn("Configured cluster overrides persisted cluster", "clusterID", clusterID)
+	}
+	if err := uc.clusterManager.RegisterCluster(ctx, clusterID, config); err != nil {
+		return fmt.Errorf("failed to register cluster: %w", err)
+	}
+	return nil
+}
+
+// IsRegistered reports whether clusterID is known to the cluster manager.
+func (uc *ClusterUseCase) IsRegistered(clusterID domain.ClusterID) bool {
+	_, err := uc.clusterManager.GetClusterClient(clusterID)
+	return err == nil
+}
+
 func (uc *ClusterUseCase) ListPods(ctx context.Context, clusterID string, namespace domain.Namespace) ([]domain.Pod, error) {
 	return uc.clusterManager.ListPods(ctx, domain.ClusterID(clusterID), namespace)
 }
@@ -96,10 +132,102 @@ func (uc *ClusterUseCase) GetClusterStatus(ctx context.Context, clusterID domain
 	return uc.clusterManager.GetClusterStatus(ctx, clusterID)
 }
 
+// ListClusters returns the clusters known to the cluster manager together
+// with any registered cluster that could not be connected (for example one
+// whose kubeconfig failed to load on startup), reported with error status.
 func (uc *ClusterUseCase) ListClusters(ctx context.Context) ([]domain.Cluster, error) {
-	return uc.clusterManager.ListClusters(ctx)
+	clusters, err := uc.clusterManager.ListClusters(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	registered, err := uc.clusterRepo.FindAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list registered clusters: %w", err)
+	}
+
+	byID := make(map[domain.ClusterID]*domain.Cluster, len(registered))
+	for _, c := range registered {
+		byID[c.ID] = c
+	}
+
+	seen := make(map[domain.ClusterID]bool, len(clusters))
+	for i := range clusters {
+		seen[clusters[i].ID] = true
+		if c, ok := byID[clusters[i].ID]; ok {
+			clusters[i].Name = c.Name
+			clusters[i].Description = c.Description
+			clusters[i].CreatedAt = c.CreatedAt
+			clusters[i].UpdatedAt = c.UpdatedAt
+		}
+	}
+	for _, c := range registered {
+		if seen[c.ID] {
+			continue
+		}
+		cluster := *c
+		cluster.Status = domain.ClusterStatusError
+		clusters = append(clusters, cluster)
+	}
+
+	sort.Slice(clusters, func(i, j int) bool { return clusters[i].ID < clusters[j].ID })
+	return clusters, nil
 }
 
+// ListRegisteredClusters returns the clusters in the repository without
+// contacting them.
+func (uc *ClusterUseCase) ListRegisteredClusters() ([]*domain.Cluster, error) {
+	return uc.clusterRepo.FindAll()
+}
+
+// DeleteCluster unregisters a cluster from the cluster manager and removes it
+// from the repository, including its persisted copy.
 func (uc *ClusterUseCase) DeleteCluster(ctx context.Context, clusterID domain.ClusterID) error {
-	return uc.clusterManager.DeleteCluster(ctx, clusterID)
+	_, repoErr := uc.clusterRepo.FindByID(clusterID)
+	_, clientErr := uc.clusterManager.GetClusterClient(clusterID)
+	if repoErr != nil && clientErr != nil {
+		return fmt.Errorf("cluster not found: %s", clusterID)
+	}
+
+	uc.logger.Info("Unregistering cluster", "clusterID", clusterID)
+
+	if err := uc.clusterManager.DeleteCluster(ctx, clusterID); err != nil {
+		return fmt.Errorf("failed to unregister cluster: %w", err)
+	}
+	if repoErr == nil {
+		if err := uc.clusterRepo.Delete(clusterID); err != nil {
+			return fmt.Errorf("failed to delete cluster: %w", err)
+		}
+	}
+	return nil
+}
+
+// RestoreClusters registers every cluster from the repository with the
+// cluster manager. Clusters that fail to load stay in the repository with
+// error status so they can be inspected or unregistered.
+func (uc *ClusterUseCase) RestoreClusters(ctx context.Context) (int, error) {
+	clusters, err := uc.clusterRepo.FindAll()
+	if err != nil {
+		return 0, fmt.Errorf("failed to list registered clusters: %w", err)
+	}
+
+	restored := 0
+	for _, cluster := range clusters {
+		status := domain.ClusterStatusActive
+		if err := uc.clusterManager.RegisterCluster(ctx, cluster.ID, cluster.Config); err != nil {
+			uc.logger.Error("Failed to restore cluster", "clusterID", cluster.ID, "error", err)
+			status = domain.ClusterStatusError
+		} else {
+			restored++
+		}
+
+		if cluster.Status != status {
+			cluster.Status = status
+			if err := uc.clusterRepo.Update(cluster); err != nil {
+				uc.logger.Warn("Failed to update cluster status", "clusterID", cluster.ID, "error", err)
+			}
+		}
+	}
+
+	return restored, nil
 }
@@ -0,0 +1,49 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/your-org/mcp-k8s-server/internal/domain"
+	"github.com/your-org/mcp-k8s-server/internal/infrastructure"
+)
+
+func TestValidateClusterID(t *testing.T) {
+	for _, tc := range []struct {
+		id    domain.ClusterID
+		valid bool
+	}{
+		{"prod", true},
+		{"prod-eu-1", true},
+		{"", false},
+		{"Prod", false},
+		{"prod_eu", false},
+		{"prod.eu", false},
+		{"..", false},
+		{"../../x", false},
+		{"a/b", false},
+		{`a\b`, false},
+		{"-prod", false},
+	} {
+		err := ValidateClusterID(tc.id)
+		if (err == nil) != tc.valid {
+			t.Errorf("ValidateClusterID(%q) = %v, want valid %v", tc.id, err, tc.valid)
+		}
+	}
+}
+
+// TestRegisterClusterInvalidID checks that invalid IDs are rejected before
+// the cluster is saved.
+func TestRegisterClusterInvalidID(t *testing.T) {
+	logger := infrastructure.NewLeveledLogger(infrastructure.LogLevelError)
+	repo := infrastructure.NewInMemoryClusterRepository()
+	uc := NewClusterUseCase(infrastructure.NewClusterManager(logger), repo, logger)
+
+	err := uc.RegisterCluster(context.Background(), "../../x", domain.ClusterConfig{InCluster: true})
+	if err == nil {
+		t.Fatal("RegisterCluster accepted ../../x")
+	}
+	if clusters, _ := repo.FindAll(); len(clusters) != 0 {
+		t.Errorf("repository holds %d clusters, want none", len(clusters))
+	}
+}
@@ -1,12 +1,16 @@
 package usecase
 
 import (
+	"cmp"
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/your-org/mcp-k8s-server/internal/domain"
 	corev1 "k8s.io/api/core/v1"
+	eventsv1 "k8s.io/api/events/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
 )
 
 // ListEvents lists events in a namespace, optionally filtered by an involved object.
@@ -63,7 +67,77 @@ func convertK8sEventToDomain(k8sEvent corev1.Event) domain.Event {
 		InvolvedName:    k8sEvent.InvolvedObject.Name,
 		InvolvedUID:     string(k8sEvent.InvolvedObject.UID),
 		Count:           k8sEvent.Count,
-		FirstTimestamp:  k8sEvent.FirstTimestamp.Time,
-		LastTimestamp:   k8sEvent.LastTimestamp.Time,
+		FirstTimestamp:  firstNonZeroTime(k8sEvent.FirstTimestamp.Time, k8sEvent.EventTime.Time, k8sEvent.CreationTimestamp.Time),
+		LastTimestamp:   eventLastTimestamp(k8sEvent.LastTimestamp.Time, k8sEvent.Series, k8sEvent.EventTime.Time, k8sEvent.CreationTimestamp.Time),
 	}
 }
+
+// convertEventsV1ToDomain converts an events.k8s.io/v1 Event to a
+// domain.Event, reading the deprecated core fields when the new ones are
+// unset.
+func convertEventsV1ToDomain(k8sEvent eventsv1.Event) domain.Event {
+	event := domain.Event{
+		Name:            domain.EventName(k8sEvent.Name),
+		Namespace:       domain.Namespace(k8sEvent.Namespace),
+		Type:            domain.EventType(k8sEvent.Type),
+		Reason:          k8sEvent.Reason,
+		Message:         k8sEvent.Note,
+		SourceComponent: cmp.Or(k8sEvent.ReportingController, k8sEvent.DeprecatedSource.Component, "N/A"),
+		SourceHost:      cmp.Or(k8sEvent.ReportingInstance, k8sEvent.DeprecatedSource.Host, "N/A"),
+		InvolvedKind:    k8sEvent.Regarding.Kind,
+		InvolvedName:    k8sEvent.Regarding.Name,
+		InvolvedUID:     string(k8sEvent.Regarding.UID),
+		Count:           max(k8sEvent.DeprecatedCount, 1),
+		FirstTimestamp:  firstNonZeroTime(k8sEvent.EventTime.Time, k8sEvent.DeprecatedFirstTimestamp.Time, k8sEvent.CreationTimestamp.Time),
+		LastTimestamp:   firstNonZeroTime(k8sEvent.DeprecatedLastTimestamp.Time, k8sEvent.EventTime.Time, k8sEvent.CreationTimestamp.Time),
+	}
+	if k8sEvent.Series != nil {
+		event.Count = k8sEvent.Series.Count
+		event.LastTimestamp = k8sEvent.Series.LastObservedTime.Time
+	}
+	return event
+}
+
+// eventLastTimestamp returns when a core event last occurred. Events
+// written through events.k8s.io/v1 leave LastTimestamp unset.
+func eventLastTimestamp(last time.Time, series *corev1.EventSeries, eventTime, created time.Time) time.Time {
+	if series != nil && !series.LastObservedTime.IsZero() {
+		return firstNonZeroTime(last, series.LastObservedTime.Time)
+	}
+	return firstNonZeroTime(last, eventTime, created)
+}
+
+// listNamespaceEvents lists the events of a namespace from the given API.
+func listNamespaceEvents(ctx context.Context, client kubernetes.Interface, namespace, api string) ([]domain.Event, error) {
+	var events []domain.Event
+	switch api {
+	case "", domain.EventAPICoreV1:
+		list, err := client.CoreV1().Events(namespace).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list events in namespace %s: %w", namespace, err)
+		}
+		for _, e := range list.Items {
+			events = append(events, convertK8sEventToDomain(e))
+		}
+	case domain.EventAPIEventsV1:
+		list, err := client.EventsV1().Events(namespace).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list events in namespace %s: %w", namespace, err)
+		}
+		for _, e := range list.Items {
+			events = append(events, convertEventsV1ToDomain(e))
+		}
+	default:
+		return nil, fmt.Errorf("unsupported event API %q (want %s or %s)", api, domain.EventAPICoreV1, domain.EventAPIEventsV1)
+	}
+	return events, nil
+}
+
+func firstNonZeroTime(times ...time.Time) time.Time {
+	for _, t := range times {
+		if !t.IsZero() {
+			return t
+		}
+	}
+	return time.Time{}
+}
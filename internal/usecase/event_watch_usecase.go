@@ -0,0 +1,130 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/your-org/mcp-k8s-server/internal/domain"
+	corev1 "k8s.io/api/core/v1"
+	eventsv1 "k8s.io/api/events/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// DefaultEventWatchDuration and MaxEventWatchDuration bound how long
+	// one call watches events.
+	DefaultEventWatchDuration = 60 * time.Second
+	MaxEventWatchDuration     = 10 * time.Minute
+	// DefaultEventWatchEvents and MaxEventWatchEvents bound how many events
+	// one call returns.
+	DefaultEventWatchEvents = 100
+	MaxEventWatchEvents     = 1000
+)
+
+// WatchEvents watches for new and updated events in namespace (every
+// namespace when empty) and passes those matching filter to onEvent as they
+// arrive. It stops after maxEvents events, after duration, when the watch
+// closes or when ctx is cancelled, and returns the events seen with the
+// reason it stopped.
+func (uc *K8sUseCase) WatchEvents(ctx context.Context, clusterID, namespace string, filter domain.EventWatchFilter, maxEvents int, duration time.Duration, onEvent func(event domain.Event, n int)) (*domain.EventWatchResult, error) {
+	client, err := uc.clusterManager.GetClusterClient(domain.ClusterID(clusterID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get client: %w", err)
+	}
+	watchCtx, cancel := context.WithTimeout(ctx, duration)
+	defer cancel()
+
+	w, convert, err := watchEvents(watchCtx, client, namespace, filter.API)
+	if err != nil {
+		return nil, err
+	}
+	defer w.Stop()
+
+	result := &domain.EventWatchResult{Events: []domain.Event{}}
+	for {
+		select {
+		case <-watchCtx.Done():
+			result.StopReason = domain.EventWatchStopDuration
+			if ctx.Err() != nil {
+				result.StopReason = domain.EventWatchStopCancelled
+			}
+			return result, nil
+		case e, ok := <-w.ResultChan():
+			if !ok {
+				result.StopReason = domain.EventWatchStopEnded
+				return result, nil
+			}
+			switch e.Type {
+			case watch.Error:
+				return nil, fmt.Errorf("event watch failed: %w", apierrors.FromObject(e.Object))
+			case watch.Added, watch.Modified:
+			default:
+				continue
+			}
+			event, ok := convert(e.Object)
+			if !ok || !eventMatches(event, filter) {
+				continue
+			}
+			result.Events = append(result.Events, event)
+			if onEvent != nil {
+				onEvent(event, len(result.Events))
+			}
+			if len(result.Events) >= maxEvents {
+				result.StopReason = domain.EventWatchStopMaxEvents
+				return result, nil
+			}
+		}
+	}
+}
+
+// watchEvents starts a watch from the current resource version of the
+// given event API, so existing events are not replayed, and returns it with
+// the converter for its objects.
+func watchEvents(ctx context.Context, client kubernetes.Interface, namespace, api string) (watch.Interface, func(obj runtime.Object) (domain.Event, bool), error) {
+	switch api {
+	case "", domain.EventAPICoreV1:
+		list, err := client.CoreV1().Events(namespace).List(ctx, metav1.ListOptions{Limit: 1})
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to list events: %w", err)
+		}
+		w, err := client.CoreV1().Events(namespace).Watch(ctx, metav1.ListOptions{ResourceVersion: list.ResourceVersion})
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to watch events: %w", err)
+		}
+		return w, func(obj runtime.Object) (domain.Event, bool) {
+			e, ok := obj.(*corev1.Event)
+			if !ok {
+				return domain.Event{}, false
+			}
+			return convertK8sEventToDomain(*e), true
+		}, nil
+	case domain.EventAPIEventsV1:
+		list, err := client.EventsV1().Events(namespace).List(ctx, metav1.ListOptions{Limit: 1})
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to list events: %w", err)
+		}
+		w, err := client.EventsV1().Events(namespace).Watch(ctx, metav1.ListOptions{ResourceVersion: list.ResourceVersion})
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to watch events: %w", err)
+		}
+		return w, func(obj runtime.Object) (domain.Event, bool) {
+			e, ok := obj.(*eventsv1.Event)
+			if !ok {
+				return domain.Event{}, false
+			}
+			return convertEventsV1ToDomain(*e), true
+		}, nil
+	}
+	return nil, nil, fmt.Errorf("unsupported event API %q (want %s or %s)", api, domain.EventAPICoreV1, domain.EventAPIEventsV1)
+}
+
+func eventMatches(event domain.Event, filter domain.EventWatchFilter) bool {
+	return (filter.Type == "" || event.Type == filter.Type) &&
+		(filter.InvolvedKind == "" || event.InvolvedKind == filter.InvolvedKind) &&
+		(filter.InvolvedName == "" || event.InvolvedName == filter.InvolvedName)
+}
@@ -0,0 +1,225 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/your-org/mcp-k8s-server/internal/domain"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// DefaultWorkloadEvents and MaxWorkloadEvents bound how many events a
+	// workload timeline returns.
+	DefaultWorkloadEvents = 100
+	MaxWorkloadEvents     = 500
+)
+
+// EventWorkloadKinds lists the kinds whose related objects
+// WorkloadEvents can walk.
+var EventWorkloadKinds = []string{"deployment", "statefulset", "daemonset", "replicaset", "job", "cronjob"}
+
+// WorkloadEvents merges the events of a workload, the objects it owns down
+// to its pods, and the PVCs and Services those pods use, into one timeline
+// sorted by last occurrence. Only the newest maxEvents events are kept.
+func (uc *K8sUseCase) WorkloadEvents(ctx context.Context, clusterID, namespace, kind, name, api string, maxEvents int) (*domain.WorkloadEvents, error) {
+	client, err := uc.clusterManager.GetClusterClient(domain.ClusterID(clusterID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get client: %w", err)
+	}
+	if api == "" {
+		api = domain.EventAPICoreV1
+	}
+
+	objects, err := workloadRelatedObjects(ctx, client, namespace, kind, name)
+	if err != nil {
+		return nil, err
+	}
+	related := make(map[domain.ObjectRef]bool, len(objects))
+	for _, ref := range objects {
+		related[ref] = true
+	}
+
+	events, err := listNamespaceEvents(ctx, client, namespace, api)
+	if err != nil {
+		return nil, err
+	}
+	result := &domain.WorkloadEvents{Kind: kind, Name: name, Namespace: namespace, API: api, Objects: objects, Events: []domain.Event{}}
+	for _, e := range events {
+		if related[domain.ObjectRef{Kind: e.InvolvedKind, Name: e.InvolvedName}] {
+			result.Events = append(result.Events, e)
+		}
+	}
+	sort.SliceStable(result.Events, func(i, j int) bool {
+		return result.Events[i].LastTimestamp.Before(result.Events[j].LastTimestamp)
+	})
+	if maxEvents > 0 && len(result.Events) > maxEvents {
+		result.EventsOmitted = len(result.Events) - maxEvents
+		result.Events = result.Events[result.EventsOmitted:]
+	}
+	return result, nil
+}
+
+// workloadRelatedObjects returns the workload, the ReplicaSets, Jobs and
+// Pods it controls, and the PVCs and Services of those pods, each once.
+func workloadRelatedObjects(ctx context.Context, client kubernetes.Interface, namespace, kind, name string) ([]domain.ObjectRef, error) {
+	get := metav1.GetOptions{}
+	var (
+		refs     []domain.ObjectRef
+		owners   []metav1.Object
+		selector *metav1.LabelSelector
+		template map[string]string
+	)
+
+	switch kind {
+	case "deployment":
+		d, err := client.AppsV1().Deployments(namespace).Get(ctx, name, get)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get deployment: %w", err)
+		}
+		refs = append(refs, domain.ObjectRef{Kind: "Deployment", Name: d.Name})
+		selector, template = d.Spec.Selector, d.Spec.Template.Labels
+		replicaSets, err := controlledReplicaSets(ctx, client, namespace, d)
+		if err != nil {
+			return nil, err
+		}
+		for i := range replicaSets {
+			refs = append(refs, domain.ObjectRef{Kind: "ReplicaSet", Name: replicaSets[i].Name})
+			owners = append(owners, &replicaSets[i])
+		}
+	case "statefulset":
+		sts, err := client.AppsV1().StatefulSets(namespace).Get(ctx, name, get)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get statefulset: %w", err)
+		}
+		refs = append(refs, domain.ObjectRef{Kind: "StatefulSet", Name: sts.Name})
+		selector, template = sts.Spec.Selector, sts.Spec.Template.Labels
+		owners = append(owners, sts)
+	case "daemonset":
+		ds, err := client.AppsV1().DaemonSets(namespace).Get(ctx, name, get)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get daemonset: %w", err)
+		}
+		refs = append(refs, domain.ObjectRef{Kind: "DaemonSet", Name: ds.Name})
+		selector, template = ds.Spec.Selector, ds.Spec.Template.Labels
+		owners = append(owners, ds)
+	case "replicaset":
+		rs, err := client.AppsV1().ReplicaSets(namespace).Get(ctx, name, get)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get replicaset: %w", err)
+		}
+		refs = append(refs, domain.ObjectRef{Kind: "ReplicaSet", Name: rs.Name})
+		selector, template = rs.Spec.Selector, rs.Spec.Template.Labels
+		owners = append(owners, rs)
+	case "job":
+		job, err := client.BatchV1().Jobs(namespace).Get(ctx, name, get)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get job: %w", err)
+		}
+		refs = append(refs, domain.ObjectRef{Kind: "Job", Name: job.Name})
+		template = job.Spec.Template.Labels
+		owners = append(owners, job)
+	case "cronjob":
+		cj, err := client.BatchV1().CronJobs(namespace).Get(ctx, name, get)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get cronjob: %w", err)
+		}
+		refs = append(refs, domain.ObjectRef{Kind: "CronJob", Name: cj.Name})
+		template = cj.Spec.JobTemplate.Spec.Template.Labels
+		jobs, err := client.BatchV1().Jobs(namespace).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list jobs: %w", err)
+		}
+		for i := range jobs.Items {
+			if metav1.IsControlledBy(&jobs.Items[i], cj) {
+				refs = append(refs, domain.ObjectRef{Kind: "Job", Name: jobs.Items[i].Name})
+				owners = append(owners, &jobs.Items[i])
+			}
+		}
+	default:
+		return nil, fmt.Errorf("unsupported workload kind %q (want deployment, statefulset, daemonset, replicaset, job or cronjob)", kind)
+	}
+
+	pods, err := controlledPods(ctx, client, namespace, selector, owners)
+	if err != nil {
+		return nil, err
+	}
+	claims := make(map[string]bool)
+	for _, pod := range pods {
+		refs = append(refs, domain.ObjectRef{Kind: "Pod", Name: pod.Name})
+		for _, v := range pod.Spec.Volumes {
+			if v.PersistentVolumeClaim != nil && !claims[v.PersistentVolumeClaim.ClaimName] {
+				claims[v.PersistentVolumeClaim.ClaimName] = true
+				refs = append(refs, domain.ObjectRef{Kind: "PersistentVolumeClaim", Name: v.PersistentVolumeClaim.ClaimName})
+			}
+		}
+	}
+
+	if len(template) > 0 {
+		services, err := client.CoreV1().Services(namespace).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list services: %w", err)
+		}
+		for _, svc := range services.Items {
+			if len(svc.Spec.Selector) > 0 && labels.SelectorFromSet(svc.Spec.Selector).Matches(labels.Set(template)) {
+				refs = append(refs, domain.ObjectRef{Kind: "Service", Name: svc.Name})
+			}
+		}
+	}
+	return refs, nil
+}
+
+// controlledReplicaSets returns the ReplicaSets controlled by a deployment.
+func controlledReplicaSets(ctx context.Context, client kubernetes.Interface, namespace string, d *appsv1.Deployment) ([]appsv1.ReplicaSet, error) {
+	selector, err := metav1.LabelSelectorAsSelector(d.Spec.Selector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid deployment selector: %w", err)
+	}
+	list, err := client.AppsV1().ReplicaSets(namespace).List(ctx, metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list replicasets: %w", err)
+	}
+	var owned []appsv1.ReplicaSet
+	for _, rs := range list.Items {
+		if metav1.IsControlledBy(&rs, d) {
+			owned = append(owned, rs)
+		}
+	}
+	return owned, nil
+}
+
+// controlledPods returns the pods, among those matching selector (all of
+// the namespace when nil), that are controlled by one of owners.
+func controlledPods(ctx context.Context, client kubernetes.Interface, namespace string, selector *metav1.LabelSelector, owners []metav1.Object) ([]corev1.Pod, error) {
+	if len(owners) == 0 {
+		return nil, nil
+	}
+	opts := metav1.ListOptions{}
+	if selector != nil {
+		s, err := metav1.LabelSelectorAsSelector(selector)
+		if err != nil {
+			return nil, fmt.Errorf("invalid selector: %w", err)
+		}
+		opts.LabelSelector = s.String()
+	}
+	list, err := client.CoreV1().Pods(namespace).List(ctx, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods: %w", err)
+	}
+	var pods []corev1.Pod
+	for _, pod := range list.Items {
+		for _, owner := range owners {
+			if metav1.IsControlledBy(&pod, owner) {
+				pods = append(pods, pod)
+				break
+			}
+		}
+	}
+	sort.Slice(pods, func(i, j int) bool { return pods[i].Name < pods[j].Name })
+	return pods, nil
+}
@@ -0,0 +1,237 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"path"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/your-org/mcp-k8s-server/internal/domain"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/httpstream"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/remotecommand"
+	utilexec "k8s.io/client-go/util/exec"
+)
+
+// defaultContainerAnnotation names the container kubectl exec and logs use
+// when none is given.
+const defaultContainerAnnotation = "kubectl.kubernetes.io/default-container"
+
+// CheckExecCommand reports whether policy permits command. Deny patterns
+// are matched against every form of the command execDenyCandidates returns;
+// allow patterns only against the command as given.
+func CheckExecCommand(policy domain.ExecPolicy, command []string) error {
+	if len(command) == 0 || command[0] == "" {
+		return errors.New("command is required")
+	}
+	line := strings.Join(command, " ")
+	for _, candidate := range execDenyCandidates(command) {
+		for _, p := range policy.DenyCommands {
+			if commandGlob(p).MatchString(candidate) {
+				return &domain.ExecDeniedError{Command: line, Pattern: p}
+			}
+		}
+	}
+	for _, p := range policy.AllowCommands {
+		if commandGlob(p).MatchString(line) {
+			return nil
+		}
+	}
+	return &domain.ExecDeniedError{Command: line}
+}
+
+// shellInterpreters are the programs whose -c argument is a script that
+// execDenyCandidates checks as well.
+var shellInterpreters = []string{"sh", "bash", "ash", "dash", "zsh", "ksh", "mksh"}
+
+// execDenyCandidates returns the command lines deny patterns are matched
+// against: the command as given, and with the program named by its base
+// name and path arguments cleaned, so /bin/cat /run//secrets/../secrets/x
+// reads as cat /run/secrets/x. Relative paths are resolved against /, as
+// the working directory is unknown. The script of a shell -c invocation is
+// added as given and as its cleaned words.
+func execDenyCandidates(command []string) []string {
+	normalized := make([]string, len(command))
+	normalized[0] = path.Base(command[0])
+	for i, arg := range command[1:] {
+		normalized[i+1] = cleanPathArg(arg)
+	}
+	candidates := []string{strings.Join(command, " "), strings.Join(normalized, " ")}
+
+	if len(command) < 3 || !slices.Contains(shellInterpreters, normalized[0]) {
+		return candidates
+	}
+	for i, arg := range command[1 : len(command)-1] {
+		if strings.HasPrefix(arg, "-") && !strings.HasPrefix(arg, "--") && strings.Contains(arg, "c") {
+			script := command[i+2]
+			words := strings.FieldsFunc(script, func(r rune) bool {
+				return unicode.IsSpace(r) || strings.ContainsRune(";&|()<>`'\"$", r)
+			})
+			for j, w := range words {
+				words[j] = cleanPathArg(w)
+			}
+			candidates = append(candidates, script, strings.Join(words, " "))
+			break
+		}
+	}
+	return candidates
+}
+
+// cleanPathArg cleans an argument that contains a slash, resolving relative
+// paths against /.
+func cleanPathArg(arg string) string {
+	if !strings.Contains(arg, "/") {
+		return arg
+	}
+	return path.Join("/", arg)
+}
+
+// commandGlob compiles an exec pattern: "*" matches any text and "?" one
+// character.
+func commandGlob(pattern string) *regexp.Regexp {
+	var sb strings.Builder
+	sb.WriteString(`^`)
+	for _, r := range pattern {
+		switch r {
+		case '*':
+			sb.WriteString(`.*`)
+		case '?':
+			sb.WriteString(`.`)
+		default:
+			sb.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	sb.WriteString(`$`)
+	return regexp.MustCompile(sb.String())
+}
+
+// ExecPod runs a command in a container without a TTY or stdin. It uses the
+// WebSocket exec protocol and falls back to SPDY for older API servers. A
+// non-zero exit status or a timeout is reported in the result, not as an
+// error.
+func (uc *K8sUseCase) ExecPod(ctx context.Context, req domain.PodExecRequest) (*domain.PodExecResult, error) {
+	client, err := uc.clusterManager.GetClusterClient(domain.ClusterID(req.ClusterID))
+	if err != nil {
+		return nil, err
+	}
+	pod, err := client.CoreV1().Pods(req.Namespace).Get(ctx, req.PodName, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pod: %w", err)
+	}
+	container, err := execContainer(pod, req.Container)
+	if err != nil {
+		return nil, err
+	}
+	if pod.Status.Phase != corev1.PodRunning {
+		return nil, fmt.Errorf("pod %s is %s, not Running", pod.Name, pod.Status.Phase)
+	}
+
+	restConfig, err := uc.clusterManager.GetRESTConfig(domain.ClusterID(req.ClusterID))
+	if err != nil {
+		return nil, err
+	}
+	url := client.CoreV1().RESTClient().Post().
+		Resource("pods").Namespace(pod.Namespace).Name(pod.Name).SubResource("exec").
+		VersionedParams(&corev1.PodExecOptions{
+			Container: container,
+			Command:   req.Command,
+			Stdout:    true,
+			Stderr:    true,
+		}, scheme.ParameterCodec).URL()
+
+	spdyExec, err := remotecommand.NewSPDYExecutor(restConfig, http.MethodPost, url)
+	if err != nil {
+		return nil, err
+	}
+	wsExec, err := remotecommand.NewWebSocketExecutor(restConfig, http.MethodGet, url.String())
+	if err != nil {
+		return nil, err
+	}
+	executor, err := remotecommand.NewFallbackExecutor(wsExec, spdyExec, func(err error) bool {
+		return httpstream.IsUpgradeFailure(err) || httpstream.IsHTTPSProxyError(err)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := &domain.PodExecResult{
+		Namespace: pod.Namespace,
+		PodName:   pod.Name,
+		Container: container,
+		Command:   req.Command,
+	}
+	stdout := &cappedBuffer{max: req.MaxOutputBytes}
+	stderr := &cappedBuffer{max: req.MaxOutputBytes}
+
+	execCtx, cancel := context.WithTimeout(ctx, req.Timeout)
+	defer cancel()
+	start := time.Now()
+	err = executor.StreamWithContext(execCtx, remotecommand.StreamOptions{Stdout: stdout, Stderr: stderr})
+	result.DurationMS = time.Since(start).Milliseconds()
+	result.Stdout, result.StdoutTruncated = stdout.String(), stdout.truncated
+	result.Stderr, result.StderrTruncated = stderr.String(), stderr.truncated
+
+	var exitErr utilexec.ExitError
+	switch {
+	case err == nil:
+	case errors.As(err, &exitErr):
+		result.ExitCode = exitErr.ExitStatus()
+	case errors.Is(execCtx.Err(), context.DeadlineExceeded):
+		result.ExitCode = -1
+		result.TimedOut = true
+	default:
+		return nil, fmt.Errorf("failed to exec in pod %s: %w", pod.Name, err)
+	}
+	uc.logger.Info("Executed command in pod", "namespace", pod.Namespace, "pod", pod.Name, "container", container, "exitCode", result.ExitCode)
+	return result, nil
+}
+
+// execContainer validates name against the containers of pod, or picks the
+// default container when name is empty.
+func execContainer(pod *corev1.Pod, name string) (string, error) {
+	if name == "" {
+		name = pod.Annotations[defaultContainerAnnotation]
+	}
+	if name == "" {
+		if len(pod.Spec.Containers) == 0 {
+			return "", fmt.Errorf("pod %s has no containers", pod.Name)
+		}
+		return pod.Spec.Containers[0].Name, nil
+	}
+	names := make([]string, 0, len(pod.Spec.Containers))
+	for _, c := range pod.Spec.Containers {
+		names = append(names, c.Name)
+	}
+	if !slices.Contains(names, name) {
+		return "", fmt.Errorf("container %q not found in pod %s (containers: %s)", name, pod.Name, strings.Join(names, ", "))
+	}
+	return name, nil
+}
+
+// cappedBuffer keeps the first max bytes written to it and discards the
+// rest, so a chatty command cannot stall or exhaust the server.
+type cappedBuffer struct {
+	strings.Builder
+	max       int64
+	truncated bool
+}
+
+func (b *cappedBuffer) Write(p []byte) (int, error) {
+	room := b.max - int64(b.Len())
+	if int64(len(p)) > room {
+		b.truncated = true
+		if room > 0 {
+			b.Builder.Write(p[:room])
+		}
+		return len(p), nil
+	}
+	return b.Builder.Write(p)
+}
@@ -0,0 +1,64 @@
+package usecase
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/your-org/mcp-k8s-server/internal/config"
+	"github.com/your-org/mcp-k8s-server/internal/domain"
+)
+
+func TestCheckExecCommand(t *testing.T) {
+	defaults := config.Default().Exec
+	defaultPolicy := domain.ExecPolicy{AllowCommands: defaults.AllowCommands, DenyCommands: defaults.DenyCommands}
+	// shellPolicy allows shells but still denies secrets.
+	shellPolicy := domain.ExecPolicy{AllowCommands: []string{"sh -c *", "/bin/bash -c *", "cat *"}, DenyCommands: []string{"*/run/secrets/*"}}
+
+	for _, tc := range []struct {
+		name        string
+		policy      domain.ExecPolicy
+		command     []string
+		wantPattern string // deny glob that must match; empty when allowed
+		wantDenied  bool
+	}{
+		{"allowed", defaultPolicy, []string{"cat", "/etc/resolv.conf"}, "", false},
+		{"allowed relative", defaultPolicy, []string{"ls", "-la", "logs/"}, "", false},
+		{"not allowed", defaultPolicy, []string{"rm", "-rf", "/"}, "", true},
+		{"secret", defaultPolicy, []string{"cat", "/run/secrets/kubernetes.io/serviceaccount/token"}, "*/run/secrets/*", true},
+		{"dot segment", defaultPolicy, []string{"cat", "/var/run/./secrets/token"}, "*/run/secrets/*", true},
+		{"double slash", defaultPolicy, []string{"cat", "/run//secrets/x"}, "*/run/secrets/*", true},
+		{"dot dot", defaultPolicy, []string{"cat", "/etc/../run/secrets/x"}, "*/run/secrets/*", true},
+		{"relative", defaultPolicy, []string{"cat", "../../run/secrets/x"}, "*/run/secrets/*", true},
+		{"relative from root", defaultPolicy, []string{"cat", "run/secrets/x"}, "*/run/secrets/*", true},
+		{"flag value", defaultPolicy, []string{"tail", "--follow=/run/./secrets/x"}, "*/run/secrets/*", true},
+		{"default denies sh", defaultPolicy, []string{"sh", "-c", "cd /run; cat secrets/x"}, "sh *", true},
+		{"default denies bash by path", defaultPolicy, []string{"/bin/bash", "-c", "ls"}, "bash *", true},
+		{"default denies zsh", defaultPolicy, []string{"zsh", "-c", "id"}, "zsh *", true},
+		{"shell allowed", shellPolicy, []string{"sh", "-c", "cat /etc/hostname"}, "", false},
+		{"shell script", shellPolicy, []string{"sh", "-c", "cat /run/secrets/x"}, "*/run/secrets/*", true},
+		{"shell script cleaned", shellPolicy, []string{"sh", "-c", "cat '/run/./secrets/x'"}, "*/run/secrets/*", true},
+		{"shell script substitution", shellPolicy, []string{"/bin/bash", "-ec", "echo $(cat /run//secrets/x)"}, "*/run/secrets/*", true},
+		{"shell script relative", shellPolicy, []string{"sh", "-c", "cd / && cat run/secrets/x"}, "*/run/secrets/*", true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := CheckExecCommand(tc.policy, tc.command)
+			if !tc.wantDenied {
+				if err != nil {
+					t.Fatalf("CheckExecCommand(%q) = %v, want allowed", tc.command, err)
+				}
+				return
+			}
+			var denied *domain.ExecDeniedError
+			if !errors.As(err, &denied) {
+				t.Fatalf("CheckExecCommand(%q) = %v, want denied", tc.command, err)
+			}
+			if denied.Pattern != tc.wantPattern {
+				t.Errorf("CheckExecCommand(%q) denied by %q, want %q", tc.command, denied.Pattern, tc.wantPattern)
+			}
+		})
+	}
+
+	if err := CheckExecCommand(defaultPolicy, nil); err == nil {
+		t.Error("empty command allowed")
+	}
+}
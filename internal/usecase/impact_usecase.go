@@ -0,0 +1,279 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/your-org/mcp-k8s-server/internal/domain"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// DeletionImpact previews what deleting the given object would remove. kind
+// is the lower-case resource kind used in tool names (namespace,
+// statefulset, daemonset, job, cronjob, configmap, secret, service,
+// ingress, hpa).
+func (uc *K8sUseCase) DeletionImpact(ctx context.Context, clusterID, kind, namespace, name string) (*domain.ImpactPreview, error) {
+	client, err := uc.clusterManager.GetClusterClient(domain.ClusterID(clusterID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get client: %w", err)
+	}
+
+	preview := &domain.ImpactPreview{
+		Operation: fmt.Sprintf("delete %s %s", kind, name),
+		ClusterID: clusterID,
+		Kind:      kind,
+		Namespace: namespace,
+		Name:      name,
+		Affected:  map[string]int{},
+	}
+	if kind == "namespace" {
+		preview.Namespace = ""
+	}
+
+	var getErr error
+	switch kind {
+	case "namespace":
+		_, getErr = client.CoreV1().Namespaces().Get(ctx, name, metav1.GetOptions{})
+		if getErr == nil {
+			if err := countNamespaceContents(ctx, client, name, preview.Affected); err != nil {
+				return nil, err
+			}
+			preview.Warnings = append(preview.Warnings, "Every object in the namespace is deleted, including PersistentVolumeClaims and the data of volumes with reclaim policy Delete.")
+		}
+	case "statefulset":
+		sts, err := client.AppsV1().StatefulSets(namespace).Get(ctx, name, metav1.GetOptions{})
+		getErr = err
+		if err == nil {
+			if err := countSelectedPods(ctx, client, namespace, sts.Spec.Selector, preview.Affected); err != nil {
+				return nil, err
+			}
+			if len(sts.Spec.VolumeClaimTemplates) > 0 {
+				pvcs, err := client.CoreV1().PersistentVolumeClaims(namespace).List(ctx, metav1.ListOptions{})
+				if err != nil {
+					return nil, fmt.Errorf("failed to list persistentvolumeclaims: %w", err)
+				}
+				retained := 0
+				for _, pvc := range pvcs.Items {
+					for _, tmpl := range sts.Spec.VolumeClaimTemplates {
+						prefix := tmpl.Name + "-" + name + "-"
+						if strings.HasPrefix(pvc.Name, prefix) {
+							retained++
+						}
+					}
+				}
+				if retained > 0 {
+					preview.Warnings = append(preview.Warnings, fmt.Sprintf("%d PersistentVolumeClaim(s) created from volumeClaimTemplates are kept unless the retention policy says otherwise.", retained))
+				}
+			}
+		}
+	case "daemonset":
+		ds, err := client.AppsV1().DaemonSets(namespace).Get(ctx, name, metav1.GetOptions{})
+		getErr = err
+		if err == nil {
+			if err := countSelectedPods(ctx, client, namespace, ds.Spec.Selector, preview.Affected); err != nil {
+				return nil, err
+			}
+		}
+	case "job":
+		job, err := client.BatchV1().Jobs(namespace).Get(ctx, name, metav1.GetOptions{})
+		getErr = err
+		if err == nil {
+			if err := countSelectedPods(ctx, client, namespace, job.Spec.Selector, preview.Affected); err != nil {
+				return nil, err
+			}
+		}
+	case "cronjob":
+		_, getErr = client.BatchV1().CronJobs(namespace).Get(ctx, name, metav1.GetOptions{})
+		if getErr == nil {
+			jobs, err := client.BatchV1().Jobs(namespace).List(ctx, metav1.ListOptions{})
+			if err != nil {
+				return nil, fmt.Errorf("failed to list jobs: %w", err)
+			}
+			for _, job := range jobs.Items {
+				for _, ref := range job.OwnerReferences {
+					if ref.Kind == "CronJob" && ref.Name == name {
+						preview.Affected["jobs"]++
+					}
+				}
+			}
+		}
+	case "configmap":
+		_, getErr = client.CoreV1().ConfigMaps(namespace).Get(ctx, name, metav1.GetOptions{})
+	case "secret":
+		_, getErr = client.CoreV1().Secrets(namespace).Get(ctx, name, metav1.GetOptions{})
+	case "service":
+		_, getErr = client.CoreV1().Services(namespace).Get(ctx, name, metav1.GetOptions{})
+	case "ingress":
+		_, getErr = client.NetworkingV1().Ingresses(namespace).Get(ctx, name, metav1.GetOptions{})
+	case "hpa":
+		_, getErr = client.AutoscalingV2().HorizontalPodAutoscalers(namespace).Get(ctx, name, metav1.GetOptions{})
+	default:
+		return nil, fmt.Errorf("no impact preview for kind %q", kind)
+	}
+
+	switch {
+	case getErr == nil:
+		preview.Exists = true
+	case apierrors.IsNotFound(getErr):
+		preview.Warnings = append(preview.Warnings, fmt.Sprintf("%s %q was not found.", kind, name))
+	default:
+		return nil, fmt.Errorf("failed to get %s: %w", kind, getErr)
+	}
+
+	return preview, nil
+}
+
+// TaintImpact previews which pods a NoExecute taint would evict from a node.
+func (uc *K8sUseCase) TaintImpact(ctx context.Context, clusterID, nodeName, taintKey string) (*domain.ImpactPreview, error) {
+	client, err := uc.clusterManager.GetClusterClient(domain.ClusterID(clusterID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get client: %w", err)
+	}
+
+	taint, err := parseTaintKey(taintKey)
+	if err != nil {
+		return nil, err
+	}
+
+	preview := &domain.ImpactPreview{
+		Operation: fmt.Sprintf("taint node %s with %s", nodeName, taintKey),
+		ClusterID: clusterID,
+		Kind:      "node",
+		Name:      nodeName,
+		Affected:  map[string]int{},
+	}
+
+	if _, err := client.CoreV1().Nodes().Get(ctx, nodeName, metav1.GetOptions{}); err != nil {
+		if apierrors.IsNotFound(err) {
+			preview.Warnings = append(preview.Warnings, fmt.Sprintf("node %q was not found.", nodeName))
+			return preview, nil
+		}
+		return nil, fmt.Errorf("failed to get node: %w", err)
+	}
+	preview.Exists = true
+
+	pods, err := client.CoreV1().Pods("").List(ctx, metav1.ListOptions{FieldSelector: "spec.nodeName=" + nodeName})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods: %w", err)
+	}
+	for _, pod := range pods.Items {
+		if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+		if !toleratesTaint(pod.Spec.Tolerations, &taint) {
+			preview.Affected["evicted_pods"]++
+		}
+	}
+	if taint.Effect == corev1.TaintEffectNoExecute && preview.Affected["evicted_pods"] > 0 {
+		preview.Warnings = append(preview.Warnings, "Running pods without a matching toleration are evicted immediately.")
+	}
+
+	return preview, nil
+}
+
+func toleratesTaint(tolerations []corev1.Toleration, taint *corev1.Taint) bool {
+	for i := range tolerations {
+		if tolerations[i].ToleratesTaint(taint) {
+			return true
+		}
+	}
+	return false
+}
+
+func countSelectedPods(ctx context.Context, client kubernetes.Interface, namespace string, selector *metav1.LabelSelector, affected map[string]int) error {
+	if selector == nil {
+		return nil
+	}
+	sel, err := metav1.LabelSelectorAsSelector(selector)
+	if err != nil {
+		return nil
+	}
+	pods, err := client.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{LabelSelector: sel.String()})
+	if err != nil {
+		return fmt.Errorf("failed to list pods: %w", err)
+	}
+	affected["pods"] = len(pods.Items)
+	return nil
+}
+
+// countNamespaceContents counts the main workload and storage objects that
+// disappear with a namespace.
+func countNamespaceContents(ctx context.Context, client kubernetes.Interface, namespace string, affected map[string]int) error {
+	list := metav1.ListOptions{}
+	counters := []struct {
+		resource string
+		count    func() (int, error)
+	}{
+		{"pods", func() (int, error) {
+			l, err := client.CoreV1().Pods(namespace).List(ctx, list)
+			if err != nil {
+				return 0, err
+			}
+			return len(l.Items), nil
+		}},
+		{"persistentvolumeclaims", func() (int, error) {
+			l, err := client.CoreV1().PersistentVolumeClaims(namespace).List(ctx, list)
+			if err != nil {
+				return 0, err
+			}
+			return len(l.Items), nil
+		}},
+		{"deployments", func() (int, error) {
+			l, err := client.AppsV1().Deployments(namespace).List(ctx, list)
+			if err != nil {
+				return 0, err
+			}
+			return len(l.Items), nil
+		}},
+		{"statefulsets", func() (int, error) {
+			l, err := client.AppsV1().StatefulSets(namespace).List(ctx, list)
+			if err != nil {
+				return 0, err
+			}
+			return len(l.Items), nil
+		}},
+		{"daemonsets", func() (int, error) {
+			l, err := client.AppsV1().DaemonSets(namespace).List(ctx, list)
+			if err != nil {
+				return 0, err
+			}
+			return len(l.Items), nil
+		}},
+		{"services", func() (int, error) {
+			l, err := client.CoreV1().Services(namespace).List(ctx, list)
+			if err != nil {
+				return 0, err
+			}
+			return len(l.Items), nil
+		}},
+		{"configmaps", func() (int, error) {
+			l, err := client.CoreV1().ConfigMaps(namespace).List(ctx, list)
+			if err != nil {
+				return 0, err
+			}
+			return len(l.Items), nil
+		}},
+		{"secrets", func() (int, error) {
+			l, err := client.CoreV1().Secrets(namespace).List(ctx, list)
+			if err != nil {
+				return 0, err
+			}
+			return len(l.Items), nil
+		}},
+	}
+
+	for _, c := range counters {
+		n, err := c.count()
+		if err != nil {
+			return fmt.Errorf("failed to list %s: %w", c.resource, err)
+		}
+		if n > 0 {
+			affected[c.resource] = n
+		}
+	}
+	return nil
+}
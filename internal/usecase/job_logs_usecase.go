@@ -0,0 +1,219 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/your-org/mcp-k8s-server/internal/domain"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// DefaultCronJobLogJobs and MaxCronJobLogJobs bound how many Jobs of a
+	// CronJob one request reads.
+	DefaultCronJobLogJobs = 3
+	MaxCronJobLogJobs     = 20
+)
+
+// GetJobLogs reads the logs of every pod of a Job, including failed
+// attempts, ordered by start time. opts applies to each container.
+func (uc *K8sUseCase) GetJobLogs(ctx context.Context, clusterID, namespace, jobName string, opts domain.LogOptions) (*domain.JobLogs, error) {
+	if _, err := compileLogGrep(opts.Grep); err != nil {
+		return nil, err
+	}
+	client, err := uc.clusterManager.GetClusterClient(domain.ClusterID(clusterID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get client: %w", err)
+	}
+
+	job, err := client.BatchV1().Jobs(namespace).Get(ctx, jobName, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get job: %w", err)
+	}
+	return uc.jobLogs(ctx, client, clusterID, job, opts)
+}
+
+// GetCronJobLogs reads the logs of the maxJobs most recent Jobs created by a
+// CronJob, newest first.
+func (uc *K8sUseCase) GetCronJobLogs(ctx context.Context, clusterID, namespace, cronJobName string, maxJobs int, opts domain.LogOptions) (*domain.CronJobLogs, error) {
+	if _, err := compileLogGrep(opts.Grep); err != nil {
+		return nil, err
+	}
+	client, err := uc.clusterManager.GetClusterClient(domain.ClusterID(clusterID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get client: %w", err)
+	}
+
+	cronJob, err := client.BatchV1().CronJobs(namespace).Get(ctx, cronJobName, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cronjob: %w", err)
+	}
+	jobList, err := client.BatchV1().Jobs(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list jobs: %w", err)
+	}
+
+	var jobs []*batchv1.Job
+	for i := range jobList.Items {
+		job := &jobList.Items[i]
+		if owner := metav1.GetControllerOf(job); owner != nil && owner.Kind == "CronJob" && owner.UID == cronJob.UID {
+			jobs = append(jobs, job)
+		}
+	}
+	sort.Slice(jobs, func(i, j int) bool {
+		ti, tj := jobs[i].CreationTimestamp, jobs[j].CreationTimestamp
+		if !ti.Equal(&tj) {
+			return tj.Before(&ti)
+		}
+		return jobs[i].Name > jobs[j].Name
+	})
+
+	if maxJobs <= 0 {
+		maxJobs = DefaultCronJobLogJobs
+	}
+	result := &domain.CronJobLogs{CronJob: cronJob.Name, Namespace: namespace, Jobs: []domain.JobLogs{}}
+	if len(jobs) > maxJobs {
+		result.JobsOmitted = len(jobs) - maxJobs
+		jobs = jobs[:maxJobs]
+	}
+	for _, job := range jobs {
+		logs, err := uc.jobLogs(ctx, client, clusterID, job, opts)
+		if err != nil {
+			return nil, err
+		}
+		result.Jobs = append(result.Jobs, *logs)
+	}
+	return result, nil
+}
+
+func (uc *K8sUseCase) jobLogs(ctx context.Context, client kubernetes.Interface, clusterID string, job *batchv1.Job, opts domain.LogOptions) (*domain.JobLogs, error) {
+	selector, err := jobPodSelector(job)
+	if err != nil {
+		return nil, err
+	}
+	podList, err := client.CoreV1().Pods(job.Namespace).List(ctx, metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods: %w", err)
+	}
+	pods := podList.Items
+	sort.Slice(pods, func(i, j int) bool {
+		ti, tj := podStartTime(&pods[i]), podStartTime(&pods[j])
+		if !ti.Equal(tj) {
+			return ti.Before(tj)
+		}
+		return pods[i].Name < pods[j].Name
+	})
+
+	result := &domain.JobLogs{
+		Job:       job.Name,
+		Namespace: job.Namespace,
+		Status:    jobStatus(job),
+		Pods:      []domain.JobPodLogs{},
+	}
+	for i := range pods {
+		podLogs := uc.jobPodLogs(ctx, clusterID, &pods[i], opts)
+		if podLogs.Failed {
+			result.Failed++
+		}
+		result.Pods = append(result.Pods, podLogs)
+	}
+	return result, nil
+}
+
+func (uc *K8sUseCase) jobPodLogs(ctx context.Context, clusterID string, pod *corev1.Pod, opts domain.LogOptions) domain.JobPodLogs {
+	start := podStartTime(pod)
+	podLogs := domain.JobPodLogs{
+		Pod:        pod.Name,
+		Phase:      string(pod.Status.Phase),
+		StartTime:  &start,
+		Failed:     pod.Status.Phase == corev1.PodFailed,
+		Reason:     pod.Status.Reason,
+		Message:    pod.Status.Message,
+		Containers: []domain.JobContainerLogs{},
+	}
+
+	statuses := make(map[string]corev1.ContainerStatus, len(pod.Status.ContainerStatuses))
+	for _, cs := range pod.Status.ContainerStatuses {
+		statuses[cs.Name] = cs
+	}
+	for _, c := range pod.Spec.Containers {
+		if opts.Container != "" && opts.Container != c.Name {
+			continue
+		}
+		cl := domain.JobContainerLogs{Name: c.Name}
+		cs := statuses[c.Name]
+		terminated := cs.State.Terminated
+		if terminated == nil {
+			terminated = cs.LastTerminationState.Terminated
+		}
+		if terminated != nil {
+			cl.ExitCode = &terminated.ExitCode
+			cl.Reason = terminated.Reason
+			cl.Message = terminated.Message
+			if terminated.ExitCode != 0 {
+				podLogs.Failed = true
+			}
+		}
+
+		o := opts
+		o.Container = c.Name
+		logs, err := uc.GetPodLogs(ctx, clusterID, pod.Namespace, pod.Name, o)
+		if err != nil {
+			cl.Error = err.Error()
+		}
+		cl.Logs = logs
+		podLogs.Containers = append(podLogs.Containers, cl)
+	}
+	return podLogs
+}
+
+// jobPodSelector returns the selector of the pods of job. Jobs created
+// before the API server set selectors only have the job-name label.
+func jobPodSelector(job *batchv1.Job) (labels.Selector, error) {
+	if job.Spec.Selector == nil {
+		return labels.SelectorFromSet(labels.Set{"job-name": job.Name}), nil
+	}
+	selector, err := metav1.LabelSelectorAsSelector(job.Spec.Selector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid selector on job %s: %w", job.Name, err)
+	}
+	return selector, nil
+}
+
+func jobStatus(job *batchv1.Job) domain.JobStatus {
+	for _, c := range job.Status.Conditions {
+		if c.Status != corev1.ConditionTrue {
+			continue
+		}
+		switch c.Type {
+		case batchv1.JobComplete:
+			return domain.JobStatusComplete
+		case batchv1.JobFailed:
+			return domain.JobStatusFailed
+		}
+	}
+	switch {
+	case job.Status.Active > 0:
+		return domain.JobStatusActive
+	case job.Status.Succeeded > 0:
+		return domain.JobStatusSucceeded
+	case job.Status.Failed > 0:
+		return domain.JobStatusFailed
+	}
+	return domain.JobStatusActive
+}
+
+// podStartTime is when the pod was started, or created if it has not
+// started yet.
+func podStartTime(pod *corev1.Pod) time.Time {
+	if pod.Status.StartTime != nil {
+		return pod.Status.StartTime.Time
+	}
+	return pod.CreationTimestamp.Time
+}
@@ -3,6 +3,9 @@ package usecase
 import (
 	"context"
 	"fmt"
+	"maps"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/your-org/mcp-k8s-server/internal/domain"
@@ -30,20 +33,37 @@ func NewK8sUseCase(
 	}
 }
 
-func (uc *K8sUseCase) GetPodLogs(ctx context.Context, clusterID, namespace, podName string, tailLines int64) (string, error) {
+// GetPodLogs reads the logs of one container. opts.Grep, if set, keeps only
+// the matching lines.
+func (uc *K8sUseCase) GetPodLogs(ctx context.Context, clusterID, namespace, podName string, opts domain.LogOptions) (string, error) {
+	grep, err := compileLogGrep(opts.Grep)
+	if err != nil {
+		return "", err
+	}
+
 	logs, err := uc.clusterManager.GetPodLogs(
 		ctx,
 		domain.ClusterID(clusterID),
 		domain.Namespace(namespace),
 		domain.PodName(podName),
-		domain.LogOptions{TailLines: &tailLines},
+		opts,
 	)
 
 	if err != nil {
 		return "", fmt.Errorf("failed to get pod logs: %w", err)
 	}
 
-	return string(logs), nil
+	if grep == nil {
+		return string(logs), nil
+	}
+	var sb strings.Builder
+	for _, line := range splitLogLines(string(logs)) {
+		if _, msg := splitLogTimestamp(line, opts.Timestamps); grep.MatchString(msg) {
+			sb.WriteString(line)
+			sb.WriteString("\n")
+		}
+	}
+	return sb.String(), nil
 }
 
 func (uc *K8sUseCase) ScaleDeployment(ctx context.Context, clusterID, namespace, deploymentName string, replicas int32) error {
@@ -80,7 +100,7 @@ func (uc *K8sUseCase) ListPods(ctx context.Context, clusterID, namespace string)
 	return pods, nil
 }
 
-func (uc *K8sUseCase) GetDeploymentInfo(ctx context.Context, clusterID, namespace, deploymentName string) (map[string]any, error) {
+func (uc *K8sUseCase) GetDeploymentInfo(ctx context.Context, clusterID, namespace, deploymentName string) (*domain.DeploymentInfo, error) {
 	client, err := uc.clusterManager.GetClusterClient(domain.ClusterID(clusterID))
 	if err != nil {
 		return nil, fmt.Errorf("failed to get client: %w", err)
@@ -92,30 +112,36 @@ func (uc *K8sUseCase) GetDeploymentInfo(ctx context.Context, clusterID, namespac
 	}
 
 	// Extract container information
-	containers := make([]map[string]any, 0, len(deployment.Spec.Template.Spec.Containers))
-	for _, container := range deployment.Spec.Template.Spec.Containers {
-		containers = append(containers, map[string]any{
-			"name":  container.Name,
-			"image": container.Image,
-		})
-	}
+	containers := containerImages(deployment.Spec.Template.Spec.Containers)
 
 	// Build deployment info
-	info := map[string]any{
-		"name":               deployment.Name,
-		"namespace":          deployment.Namespace,
-		"replicas_desired":   *deployment.Spec.Replicas,
-		"replicas_ready":     deployment.Status.ReadyReplicas,
-		"replicas_available": deployment.Status.AvailableReplicas,
-		"replicas_updated":   deployment.Status.UpdatedReplicas,
-		"strategy":           deployment.Spec.Strategy.Type,
-		"containers":         containers,
+	info := &domain.DeploymentInfo{
+		Name:              deployment.Name,
+		Namespace:         deployment.Namespace,
+		ReplicasDesired:   *deployment.Spec.Replicas,
+		ReplicasReady:     deployment.Status.ReadyReplicas,
+		ReplicasAvailable: deployment.Status.AvailableReplicas,
+		ReplicasUpdated:   deployment.Status.UpdatedReplicas,
+		Strategy:          string(deployment.Spec.Strategy.Type),
+		Containers:        containers,
 	}
 
 	return info, nil
 }
 
-func (uc *K8sUseCase) ListNamespaces(ctx context.Context, clusterID string) ([]map[string]any, error) {
+// containerImages lists the containers of a pod template with their images.
+func containerImages(containers []v1.Container) []domain.ContainerImage {
+	images := make([]domain.ContainerImage, 0, len(containers))
+	for _, container := range containers {
+		images = append(images, domain.ContainerImage{
+			Name:  container.Name,
+			Image: container.Image,
+		})
+	}
+	return images
+}
+
+func (uc *K8sUseCase) ListNamespaces(ctx context.Context, clusterID string) ([]domain.NamespaceSummary, error) {
 	client, err := uc.clusterManager.GetClusterClient(domain.ClusterID(clusterID))
 	if err != nil {
 		return nil, fmt.Errorf("failed to get client: %w", err)
@@ -126,18 +152,18 @@ func (uc *K8sUseCase) ListNamespaces(ctx context.Context, clusterID string) ([]m
 		return nil, fmt.Errorf("failed to list namespaces: %w", err)
 	}
 
-	namespaces := make([]map[string]any, 0, len(namespaceList.Items))
+	namespaces := make([]domain.NamespaceSummary, 0, len(namespaceList.Items))
 	for _, ns := range namespaceList.Items {
-		namespaces = append(namespaces, map[string]any{
-			"name":   ns.Name,
-			"status": string(ns.Status.Phase),
+		namespaces = append(namespaces, domain.NamespaceSummary{
+			Name:   ns.Name,
+			Status: string(ns.Status.Phase),
 		})
 	}
 
 	return namespaces, nil
 }
 
-func (uc *K8sUseCase) GetNamespace(ctx context.Context, clusterID, namespace string) (map[string]any, error) {
+func (uc *K8sUseCase) GetNamespace(ctx context.Context, clusterID, namespace string) (*domain.NamespaceDetail, error) {
 	client, err := uc.clusterManager.GetClusterClient(domain.ClusterID(clusterID))
 	if err != nil {
 		return nil, fmt.Errorf("failed to get client: %w", err)
@@ -148,11 +174,11 @@ func (uc *K8sUseCase) GetNamespace(ctx context.Context, clusterID, namespace str
 		return nil, fmt.Errorf("failed to get namespace: %w", err)
 	}
 
-	info := map[string]any{
-		"name":    ns.Name,
-		"status":  string(ns.Status.Phase),
-		"created": ns.CreationTimestamp.Format("2006-01-02 15:04:05"),
-		"labels":  ns.Labels,
+	info := &domain.NamespaceDetail{
+		Name:    ns.Name,
+		Status:  string(ns.Status.Phase),
+		Created: ns.CreationTimestamp.Format("2006-01-02 15:04:05"),
+		Labels:  ns.Labels,
 	}
 
 	return info, nil
@@ -192,7 +218,7 @@ func (uc *K8sUseCase) DeleteNamespace(ctx context.Context, clusterID, namespace
 	return nil
 }
 
-func (uc *K8sUseCase) ListPersistentVolumes(ctx context.Context, clusterID string) ([]map[string]any, error) {
+func (uc *K8sUseCase) ListPersistentVolumes(ctx context.Context, clusterID string) ([]domain.PersistentVolumeSummary, error) {
 	client, err := uc.clusterManager.GetClusterClient(domain.ClusterID(clusterID))
 	if err != nil {
 		return nil, fmt.Errorf("failed to get client: %w", err)
@@ -203,7 +229,7 @@ func (uc *K8sUseCase) ListPersistentVolumes(ctx context.Context, clusterID strin
 		return nil, fmt.Errorf("failed to list persistent volumes: %w", err)
 	}
 
-	pvs := make([]map[string]any, 0, len(pvList.Items))
+	pvs := make([]domain.PersistentVolumeSummary, 0, len(pvList.Items))
 	for _, pv := range pvList.Items {
 		capacity := ""
 		if storage, ok := pv.Spec.Capacity[v1.ResourceStorage]; ok {
@@ -215,19 +241,19 @@ func (uc *K8sUseCase) ListPersistentVolumes(ctx context.Context, clusterID strin
 			storageClass = pv.Spec.StorageClassName
 		}
 
-		pvs = append(pvs, map[string]any{
-			"name":           pv.Name,
-			"capacity":       capacity,
-			"status":         string(pv.Status.Phase),
-			"storage_class":  storageClass,
-			"reclaim_policy": string(pv.Spec.PersistentVolumeReclaimPolicy),
+		pvs = append(pvs, domain.PersistentVolumeSummary{
+			Name:          pv.Name,
+			Capacity:      capacity,
+			Status:        string(pv.Status.Phase),
+			StorageClass:  storageClass,
+			ReclaimPolicy: string(pv.Spec.PersistentVolumeReclaimPolicy),
 		})
 	}
 
 	return pvs, nil
 }
 
-func (uc *K8sUseCase) ListStorageClasses(ctx context.Context, clusterID string) ([]map[string]any, error) {
+func (uc *K8sUseCase) ListStorageClasses(ctx context.Context, clusterID string) ([]domain.StorageClassSummary, error) {
 	client, err := uc.clusterManager.GetClusterClient(domain.ClusterID(clusterID))
 	if err != nil {
 		return nil, fmt.Errorf("failed to get client: %w", err)
@@ -238,24 +264,24 @@ func (uc *K8sUseCase) ListStorageClasses(ctx context.Context, clusterID string)
 		return nil, fmt.Errorf("failed to list storage classes: %w", err)
 	}
 
-	storageClasses := make([]map[string]any, 0, len(scList.Items))
+	storageClasses := make([]domain.StorageClassSummary, 0, len(scList.Items))
 	for _, sc := range scList.Items {
 		reclaimPolicy := "Delete"
 		if sc.ReclaimPolicy != nil {
 			reclaimPolicy = string(*sc.ReclaimPolicy)
 		}
 
-		storageClasses = append(storageClasses, map[string]any{
-			"name":           sc.Name,
-			"provisioner":    sc.Provisioner,
-			"reclaim_policy": reclaimPolicy,
+		storageClasses = append(storageClasses, domain.StorageClassSummary{
+			Name:          sc.Name,
+			Provisioner:   sc.Provisioner,
+			ReclaimPolicy: reclaimPolicy,
 		})
 	}
 
 	return storageClasses, nil
 }
 
-func (uc *K8sUseCase) ListConfigMaps(ctx context.Context, clusterID, namespace string) ([]map[string]any, error) {
+func (uc *K8sUseCase) ListConfigMaps(ctx context.Context, clusterID, namespace string) ([]domain.ConfigMapSummary, error) {
 	client, err := uc.clusterManager.GetClusterClient(domain.ClusterID(clusterID))
 	if err != nil {
 		return nil, fmt.Errorf("failed to get client: %w", err)
@@ -266,20 +292,20 @@ func (uc *K8sUseCase) ListConfigMaps(ctx context.Context, clusterID, namespace s
 		return nil, fmt.Errorf("failed to list configmaps: %w", err)
 	}
 
-	configMaps := make([]map[string]any, 0, len(configMapList.Items))
+	configMaps := make([]domain.ConfigMapSummary, 0, len(configMapList.Items))
 	for _, cm := range configMapList.Items {
-		configMaps = append(configMaps, map[string]any{
-			"name":       cm.Name,
-			"namespace":  cm.Namespace,
-			"data_count": len(cm.Data),
-			"created":    cm.CreationTimestamp.Format("2006-01-02 15:04:05"),
+		configMaps = append(configMaps, domain.ConfigMapSummary{
+			Name:      cm.Name,
+			Namespace: cm.Namespace,
+			DataCount: len(cm.Data),
+			Created:   cm.CreationTimestamp.Format("2006-01-02 15:04:05"),
 		})
 	}
 
 	return configMaps, nil
 }
 
-func (uc *K8sUseCase) GetConfigMap(ctx context.Context, clusterID, namespace, configMapName string) (map[string]any, error) {
+func (uc *K8sUseCase) GetConfigMap(ctx context.Context, clusterID, namespace, configMapName string) (*domain.ConfigMapDetail, error) {
 	client, err := uc.clusterManager.GetClusterClient(domain.ClusterID(clusterID))
 	if err != nil {
 		return nil, fmt.Errorf("failed to get client: %w", err)
@@ -290,12 +316,12 @@ func (uc *K8sUseCase) GetConfigMap(ctx context.Context, clusterID, namespace, co
 		return nil, fmt.Errorf("failed to get configmap: %w", err)
 	}
 
-	info := map[string]any{
-		"name":      cm.Name,
-		"namespace": cm.Namespace,
-		"data":      cm.Data,
-		"labels":    cm.Labels,
-		"created":   cm.CreationTimestamp.Format("2006-01-02 15:04:05"),
+	info := &domain.ConfigMapDetail{
+		Name:      cm.Name,
+		Namespace: cm.Namespace,
+		Data:      cm.Data,
+		Labels:    cm.Labels,
+		Created:   cm.CreationTimestamp.Format("2006-01-02 15:04:05"),
 	}
 
 	return info, nil
@@ -340,7 +366,7 @@ func (uc *K8sUseCase) DeleteConfigMap(ctx context.Context, clusterID, namespace,
 
 // ==================== Secret Methods ====================
 
-func (uc *K8sUseCase) ListSecrets(ctx context.Context, clusterID, namespace string) ([]map[string]any, error) {
+func (uc *K8sUseCase) ListSecrets(ctx context.Context, clusterID, namespace string) ([]domain.SecretSummary, error) {
 	client, err := uc.clusterManager.GetClusterClient(domain.ClusterID(clusterID))
 	if err != nil {
 		return nil, fmt.Errorf("failed to get client: %w", err)
@@ -351,21 +377,21 @@ func (uc *K8sUseCase) ListSecrets(ctx context.Context, clusterID, namespace stri
 		return nil, fmt.Errorf("failed to list secrets: %w", err)
 	}
 
-	secrets := make([]map[string]any, 0, len(secretList.Items))
+	secrets := make([]domain.SecretSummary, 0, len(secretList.Items))
 	for _, secret := range secretList.Items {
-		secrets = append(secrets, map[string]any{
-			"name":       secret.Name,
-			"namespace":  secret.Namespace,
-			"type":       string(secret.Type),
-			"data_count": len(secret.Data),
-			"created":    secret.CreationTimestamp.Format("2006-01-02 15:04:05"),
+		secrets = append(secrets, domain.SecretSummary{
+			Name:      secret.Name,
+			Namespace: secret.Namespace,
+			Type:      string(secret.Type),
+			DataCount: len(secret.Data),
+			Created:   secret.CreationTimestamp.Format("2006-01-02 15:04:05"),
 		})
 	}
 
 	return secrets, nil
 }
 
-func (uc *K8sUseCase) GetSecret(ctx context.Context, clusterID, namespace, secretName string) (map[string]any, error) {
+func (uc *K8sUseCase) GetSecret(ctx context.Context, clusterID, namespace, secretName string) (*domain.SecretDetail, error) {
 	client, err := uc.clusterManager.GetClusterClient(domain.ClusterID(clusterID))
 	if err != nil {
 		return nil, fmt.Errorf("failed to get client: %w", err)
@@ -377,18 +403,15 @@ func (uc *K8sUseCase) GetSecret(ctx context.Context, clusterID, namespace, secre
 	}
 
 	// Convert secret data to base64 strings for display (keys only, not actual values for security)
-	dataKeys := make([]string, 0, len(secret.Data))
-	for key := range secret.Data {
-		dataKeys = append(dataKeys, key)
-	}
+	dataKeys := slices.Sorted(maps.Keys(secret.Data))
 
-	info := map[string]any{
-		"name":      secret.Name,
-		"namespace": secret.Namespace,
-		"type":      string(secret.Type),
-		"data_keys": dataKeys,
-		"labels":    secret.Labels,
-		"created":   secret.CreationTimestamp.Format("2006-01-02 15:04:05"),
+	info := &domain.SecretDetail{
+		Name:      secret.Name,
+		Namespace: secret.Namespace,
+		Type:      string(secret.Type),
+		DataKeys:  dataKeys,
+		Labels:    secret.Labels,
+		Created:   secret.CreationTimestamp.Format("2006-01-02 15:04:05"),
 	}
 
 	return info, nil
@@ -437,7 +460,7 @@ func (uc *K8sUseCase) DeleteSecret(ctx context.Context, clusterID, namespace, se
 	return nil
 }
 
-func (uc *K8sUseCase) ListStatefulSets(ctx context.Context, clusterID, namespace string) ([]map[string]any, error) {
+func (uc *K8sUseCase) ListStatefulSets(ctx context.Context, clusterID, namespace string) ([]domain.StatefulSetSummary, error) {
 	client, err := uc.clusterManager.GetClusterClient(domain.ClusterID(clusterID))
 	if err != nil {
 		return nil, fmt.Errorf("failed to get client: %w", err)
@@ -448,28 +471,28 @@ func (uc *K8sUseCase) ListStatefulSets(ctx context.Context, clusterID, namespace
 		return nil, fmt.Errorf("failed to list statefulsets: %w", err)
 	}
 
-	statefulSets := make([]map[string]any, 0, len(statefulSetList.Items))
+	statefulSets := make([]domain.StatefulSetSummary, 0, len(statefulSetList.Items))
 	for _, sts := range statefulSetList.Items {
 		replicas := int32(0)
 		if sts.Spec.Replicas != nil {
 			replicas = *sts.Spec.Replicas
 		}
 
-		statefulSets = append(statefulSets, map[string]any{
-			"name":             sts.Name,
-			"namespace":        sts.Namespace,
-			"replicas":         replicas,
-			"ready_replicas":   sts.Status.ReadyReplicas,
-			"current_replicas": sts.Status.CurrentReplicas,
-			"service_name":     sts.Spec.ServiceName,
-			"created":          sts.CreationTimestamp.Format("2006-01-02 15:04:05"),
+		statefulSets = append(statefulSets, domain.StatefulSetSummary{
+			Name:            sts.Name,
+			Namespace:       sts.Namespace,
+			Replicas:        replicas,
+			ReadyReplicas:   sts.Status.ReadyReplicas,
+			CurrentReplicas: sts.Status.CurrentReplicas,
+			ServiceName:     sts.Spec.ServiceName,
+			Created:         sts.CreationTimestamp.Format("2006-01-02 15:04:05"),
 		})
 	}
 
 	return statefulSets, nil
 }
 
-func (uc *K8sUseCase) GetStatefulSet(ctx context.Context, clusterID, namespace, statefulSetName string) (map[string]any, error) {
+func (uc *K8sUseCase) GetStatefulSet(ctx context.Context, clusterID, namespace, statefulSetName string) (*domain.StatefulSetDetail, error) {
 	client, err := uc.clusterManager.GetClusterClient(domain.ClusterID(clusterID))
 	if err != nil {
 		return nil, fmt.Errorf("failed to get client: %w", err)
@@ -486,47 +509,41 @@ func (uc *K8sUseCase) GetStatefulSet(ctx context.Context, clusterID, namespace,
 	}
 
 	// Extract container information
-	containers := make([]map[string]any, 0, len(sts.Spec.Template.Spec.Containers))
-	for _, container := range sts.Spec.Template.Spec.Containers {
-		containers = append(containers, map[string]any{
-			"name":  container.Name,
-			"image": container.Image,
-		})
-	}
+	containers := containerImages(sts.Spec.Template.Spec.Containers)
 
 	// Extract volume claim templates
-	volumeClaims := make([]map[string]any, 0, len(sts.Spec.VolumeClaimTemplates))
+	volumeClaims := make([]domain.VolumeClaimTemplate, 0, len(sts.Spec.VolumeClaimTemplates))
 	for _, pvc := range sts.Spec.VolumeClaimTemplates {
 		storage := ""
 		if storageQty, ok := pvc.Spec.Resources.Requests[v1.ResourceStorage]; ok {
 			storage = storageQty.String()
 		}
 
-		volumeClaims = append(volumeClaims, map[string]any{
-			"name":    pvc.Name,
-			"storage": storage,
-			"storage_class": func() string {
-				if pvc.Spec.StorageClassName != nil {
-					return *pvc.Spec.StorageClassName
-				}
-				return ""
-			}(),
+		storageClass := ""
+		if pvc.Spec.StorageClassName != nil {
+			storageClass = *pvc.Spec.StorageClassName
+		}
+
+		volumeClaims = append(volumeClaims, domain.VolumeClaimTemplate{
+			Name:         pvc.Name,
+			Storage:      storage,
+			StorageClass: storageClass,
 		})
 	}
 
-	info := map[string]any{
-		"name":             sts.Name,
-		"namespace":        sts.Namespace,
-		"replicas_desired": replicas,
-		"replicas_ready":   sts.Status.ReadyReplicas,
-		"replicas_current": sts.Status.CurrentReplicas,
-		"replicas_updated": sts.Status.UpdatedReplicas,
-		"service_name":     sts.Spec.ServiceName,
-		"update_strategy":  string(sts.Spec.UpdateStrategy.Type),
-		"containers":       containers,
-		"volume_claims":    volumeClaims,
-		"labels":           sts.Labels,
-		"created":          sts.CreationTimestamp.Format("2006-01-02 15:04:05"),
+	info := &domain.StatefulSetDetail{
+		Name:            sts.Name,
+		Namespace:       sts.Namespace,
+		ReplicasDesired: replicas,
+		ReplicasReady:   sts.Status.ReadyReplicas,
+		ReplicasCurrent: sts.Status.CurrentReplicas,
+		ReplicasUpdated: sts.Status.UpdatedReplicas,
+		ServiceName:     sts.Spec.ServiceName,
+		UpdateStrategy:  string(sts.Spec.UpdateStrategy.Type),
+		Containers:      containers,
+		VolumeClaims:    volumeClaims,
+		Labels:          sts.Labels,
+		Created:         sts.CreationTimestamp.Format("2006-01-02 15:04:05"),
 	}
 
 	return info, nil
@@ -567,33 +584,12 @@ func (uc *K8sUseCase) DeleteStatefulSet(ctx context.Context, clusterID, namespac
 }
 
 func (uc *K8sUseCase) RestartStatefulSet(ctx context.Context, clusterID, namespace, statefulSetName string) error {
-	client, err := uc.clusterManager.GetClusterClient(domain.ClusterID(clusterID))
-	if err != nil {
-		return fmt.Errorf("failed to get client: %w", err)
-	}
-
-	sts, err := client.AppsV1().StatefulSets(namespace).Get(ctx, statefulSetName, metav1.GetOptions{})
-	if err != nil {
-		return fmt.Errorf("failed to get statefulset: %w", err)
-	}
-
-	// Add or update restart annotation
-	if sts.Spec.Template.Annotations == nil {
-		sts.Spec.Template.Annotations = make(map[string]string)
-	}
-	sts.Spec.Template.Annotations["kubectl.kubernetes.io/restartedAt"] = time.Now().Format(time.RFC3339)
-
-	_, err = client.AppsV1().StatefulSets(namespace).Update(ctx, sts, metav1.UpdateOptions{})
-	if err != nil {
-		return fmt.Errorf("failed to restart statefulset: %w", err)
-	}
-
-	return nil
+	return uc.restartWorkload(ctx, clusterID, domain.WorkloadKindStatefulSet, namespace, statefulSetName)
 }
 
 // ==================== DaemonSet Methods ====================
 
-func (uc *K8sUseCase) ListDaemonSets(ctx context.Context, clusterID, namespace string) ([]map[string]any, error) {
+func (uc *K8sUseCase) ListDaemonSets(ctx context.Context, clusterID, namespace string) ([]domain.DaemonSetSummary, error) {
 	client, err := uc.clusterManager.GetClusterClient(domain.ClusterID(clusterID))
 	if err != nil {
 		return nil, fmt.Errorf("failed to get client: %w", err)
@@ -604,23 +600,23 @@ func (uc *K8sUseCase) ListDaemonSets(ctx context.Context, clusterID, namespace s
 		return nil, fmt.Errorf("failed to list daemonsets: %w", err)
 	}
 
-	daemonSets := make([]map[string]any, 0, len(daemonSetList.Items))
+	daemonSets := make([]domain.DaemonSetSummary, 0, len(daemonSetList.Items))
 	for _, ds := range daemonSetList.Items {
-		daemonSets = append(daemonSets, map[string]any{
-			"name":                     ds.Name,
-			"namespace":                ds.Namespace,
-			"desired_number_scheduled": ds.Status.DesiredNumberScheduled,
-			"current_number_scheduled": ds.Status.CurrentNumberScheduled,
-			"number_ready":             ds.Status.NumberReady,
-			"number_available":         ds.Status.NumberAvailable,
-			"created":                  ds.CreationTimestamp.Format("2006-01-02 15:04:05"),
+		daemonSets = append(daemonSets, domain.DaemonSetSummary{
+			Name:                   ds.Name,
+			Namespace:              ds.Namespace,
+			DesiredNumberScheduled: ds.Status.DesiredNumberScheduled,
+			CurrentNumberScheduled: ds.Status.CurrentNumberScheduled,
+			NumberReady:            ds.Status.NumberReady,
+			NumberAvailable:        ds.Status.NumberAvailable,
+			Created:                ds.CreationTimestamp.Format("2006-01-02 15:04:05"),
 		})
 	}
 
 	return daemonSets, nil
 }
 
-func (uc *K8sUseCase) GetDaemonSet(ctx context.Context, clusterID, namespace, daemonSetName string) (map[string]any, error) {
+func (uc *K8sUseCase) GetDaemonSet(ctx context.Context, clusterID, namespace, daemonSetName string) (*domain.DaemonSetDetail, error) {
 	client, err := uc.clusterManager.GetClusterClient(domain.ClusterID(clusterID))
 	if err != nil {
 		return nil, fmt.Errorf("failed to get client: %w", err)
@@ -632,31 +628,25 @@ func (uc *K8sUseCase) GetDaemonSet(ctx context.Context, clusterID, namespace, da
 	}
 
 	// Extract container information
-	containers := make([]map[string]any, 0, len(ds.Spec.Template.Spec.Containers))
-	for _, container := range ds.Spec.Template.Spec.Containers {
-		containers = append(containers, map[string]any{
-			"name":  container.Name,
-			"image": container.Image,
-		})
-	}
+	containers := containerImages(ds.Spec.Template.Spec.Containers)
 
 	// Extract node selector
 	nodeSelector := ds.Spec.Template.Spec.NodeSelector
 
-	info := map[string]any{
-		"name":                     ds.Name,
-		"namespace":                ds.Namespace,
-		"desired_number_scheduled": ds.Status.DesiredNumberScheduled,
-		"current_number_scheduled": ds.Status.CurrentNumberScheduled,
-		"number_ready":             ds.Status.NumberReady,
-		"number_available":         ds.Status.NumberAvailable,
-		"number_misscheduled":      ds.Status.NumberMisscheduled,
-		"updated_number_scheduled": ds.Status.UpdatedNumberScheduled,
-		"update_strategy":          string(ds.Spec.UpdateStrategy.Type),
-		"containers":               containers,
-		"node_selector":            nodeSelector,
-		"labels":                   ds.Labels,
-		"created":                  ds.CreationTimestamp.Format("2006-01-02 15:04:05"),
+	info := &domain.DaemonSetDetail{
+		Name:                   ds.Name,
+		Namespace:              ds.Namespace,
+		DesiredNumberScheduled: ds.Status.DesiredNumberScheduled,
+		CurrentNumberScheduled: ds.Status.CurrentNumberScheduled,
+		NumberReady:            ds.Status.NumberReady,
+		NumberAvailable:        ds.Status.NumberAvailable,
+		NumberMisscheduled:     ds.Status.NumberMisscheduled,
+		UpdatedNumberScheduled: ds.Status.UpdatedNumberScheduled,
+		UpdateStrategy:         string(ds.Spec.UpdateStrategy.Type),
+		Containers:             containers,
+		NodeSelector:           nodeSelector,
+		Labels:                 ds.Labels,
+		Created:                ds.CreationTimestamp.Format("2006-01-02 15:04:05"),
 	}
 
 	return info, nil
@@ -677,31 +667,10 @@ func (uc *K8sUseCase) DeleteDaemonSet(ctx context.Context, clusterID, namespace,
 }
 
 func (uc *K8sUseCase) RestartDaemonSet(ctx context.Context, clusterID, namespace, daemonSetName string) error {
-	client, err := uc.clusterManager.GetClusterClient(domain.ClusterID(clusterID))
-	if err != nil {
-		return fmt.Errorf("failed to get client: %w", err)
-	}
-
-	ds, err := client.AppsV1().DaemonSets(namespace).Get(ctx, daemonSetName, metav1.GetOptions{})
-	if err != nil {
-		return fmt.Errorf("failed to get daemonset: %w", err)
-	}
-
-	// Add or update restart annotation
-	if ds.Spec.Template.Annotations == nil {
-		ds.Spec.Template.Annotations = make(map[string]string)
-	}
-	ds.Spec.Template.Annotations["kubectl.kubernetes.io/restartedAt"] = time.Now().Format(time.RFC3339)
-
-	_, err = client.AppsV1().DaemonSets(namespace).Update(ctx, ds, metav1.UpdateOptions{})
-	if err != nil {
-		return fmt.Errorf("failed to restart daemonset: %w", err)
-	}
-
-	return nil
+	return uc.restartWorkload(ctx, clusterID, domain.WorkloadKindDaemonSet, namespace, daemonSetName)
 }
 
-func (uc *K8sUseCase) GetDaemonSetPods(ctx context.Context, clusterID, namespace, daemonSetName string) ([]map[string]any, error) {
+func (uc *K8sUseCase) GetDaemonSetPods(ctx context.Context, clusterID, namespace, daemonSetName string) ([]domain.DaemonSetPod, error) {
 	client, err := uc.clusterManager.GetClusterClient(domain.ClusterID(clusterID))
 	if err != nil {
 		return nil, fmt.Errorf("failed to get client: %w", err)
@@ -722,22 +691,22 @@ func (uc *K8sUseCase) GetDaemonSetPods(ctx context.Context, clusterID, namespace
 		return nil, fmt.Errorf("failed to list pods: %w", err)
 	}
 
-	pods := make([]map[string]any, 0, len(podList.Items))
+	pods := make([]domain.DaemonSetPod, 0, len(podList.Items))
 	for _, pod := range podList.Items {
-		pods = append(pods, map[string]any{
-			"name":      pod.Name,
-			"namespace": pod.Namespace,
-			"node":      pod.Spec.NodeName,
-			"status":    string(pod.Status.Phase),
-			"host_ip":   pod.Status.HostIP,
-			"pod_ip":    pod.Status.PodIP,
+		pods = append(pods, domain.DaemonSetPod{
+			Name:      pod.Name,
+			Namespace: pod.Namespace,
+			Node:      pod.Spec.NodeName,
+			Status:    string(pod.Status.Phase),
+			HostIP:    pod.Status.HostIP,
+			PodIP:     pod.Status.PodIP,
 		})
 	}
 
 	return pods, nil
 }
 
-func (uc *K8sUseCase) ListJobs(ctx context.Context, clusterID, namespace string) ([]map[string]any, error) {
+func (uc *K8sUseCase) ListJobs(ctx context.Context, clusterID, namespace string) ([]domain.JobSummary, error) {
 	client, err := uc.clusterManager.GetClusterClient(domain.ClusterID(clusterID))
 	if err != nil {
 		return nil, fmt.Errorf("failed to get client: %w", err)
@@ -748,7 +717,7 @@ func (uc *K8sUseCase) ListJobs(ctx context.Context, clusterID, namespace string)
 		return nil, fmt.Errorf("failed to list jobs: %w", err)
 	}
 
-	jobs := make([]map[string]any, 0, len(jobList.Items))
+	jobs := make([]domain.JobSummary, 0, len(jobList.Items))
 	for _, job := range jobList.Items {
 		completions := int32(1)
 		if job.Spec.Completions != nil {
@@ -771,23 +740,23 @@ func (uc *K8sUseCase) ListJobs(ctx context.Context, clusterID, namespace string)
 			}
 		}
 
-		jobs = append(jobs, map[string]any{
-			"name":        job.Name,
-			"namespace":   job.Namespace,
-			"completions": fmt.Sprintf("%d/%d", job.Status.Succeeded, completions),
-			"status":      status,
-			"active":      job.Status.Active,
-			"succeeded":   job.Status.Succeeded,
-			"failed":      job.Status.Failed,
-			"duration":    duration,
-			"created":     job.CreationTimestamp.Format("2006-01-02 15:04:05"),
+		jobs = append(jobs, domain.JobSummary{
+			Name:        job.Name,
+			Namespace:   job.Namespace,
+			Completions: fmt.Sprintf("%d/%d", job.Status.Succeeded, completions),
+			Status:      status,
+			Active:      job.Status.Active,
+			Succeeded:   job.Status.Succeeded,
+			Failed:      job.Status.Failed,
+			Duration:    duration,
+			Created:     job.CreationTimestamp.Format("2006-01-02 15:04:05"),
 		})
 	}
 
 	return jobs, nil
 }
 
-func (uc *K8sUseCase) GetJob(ctx context.Context, clusterID, namespace, jobName string) (map[string]any, error) {
+func (uc *K8sUseCase) GetJob(ctx context.Context, clusterID, namespace, jobName string) (*domain.JobDetail, error) {
 	client, err := uc.clusterManager.GetClusterClient(domain.ClusterID(clusterID))
 	if err != nil {
 		return nil, fmt.Errorf("failed to get client: %w", err)
@@ -813,17 +782,6 @@ func (uc *K8sUseCase) GetJob(ctx context.Context, clusterID, namespace, jobName
 		backoffLimit = *job.Spec.BackoffLimit
 	}
 
-	// Extract container information
-	containers := make([]map[string]any, 0, len(job.Spec.Template.Spec.Containers))
-	for _, container := range job.Spec.Template.Spec.Containers {
-		containers = append(containers, map[string]any{
-			"name":    container.Name,
-			"image":   container.Image,
-			"command": container.Command,
-			"args":    container.Args,
-		})
-	}
-
 	// Calculate duration
 	duration := ""
 	if job.Status.StartTime != nil {
@@ -835,47 +793,51 @@ func (uc *K8sUseCase) GetJob(ctx context.Context, clusterID, namespace, jobName
 	}
 
 	// Job conditions
-	conditions := make([]map[string]any, 0, len(job.Status.Conditions))
+	conditions := make([]domain.JobConditionInfo, 0, len(job.Status.Conditions))
 	for _, condition := range job.Status.Conditions {
-		conditions = append(conditions, map[string]any{
-			"type":    string(condition.Type),
-			"status":  string(condition.Status),
-			"reason":  condition.Reason,
-			"message": condition.Message,
+		conditions = append(conditions, domain.JobConditionInfo{
+			Type:    string(condition.Type),
+			Status:  string(condition.Status),
+			Reason:  condition.Reason,
+			Message: condition.Message,
 		})
 	}
 
-	info := map[string]any{
-		"name":          job.Name,
-		"namespace":     job.Namespace,
-		"completions":   completions,
-		"parallelism":   parallelism,
-		"backoff_limit": backoffLimit,
-		"active":        job.Status.Active,
-		"succeeded":     job.Status.Succeeded,
-		"failed":        job.Status.Failed,
-		"start_time": func() string {
-			if job.Status.StartTime != nil {
-				return job.Status.StartTime.Format(time.RFC3339)
-			}
-			return ""
-		}(),
-		"completion_time": func() string {
-			if job.Status.CompletionTime != nil {
-				return job.Status.CompletionTime.Format(time.RFC3339)
-			}
-			return ""
-		}(),
-		"duration":   duration,
-		"containers": containers,
-		"conditions": conditions,
-		"labels":     job.Labels,
-		"created":    job.CreationTimestamp.Format("2006-01-02 15:04:05"),
+	info := &domain.JobDetail{
+		Name:         job.Name,
+		Namespace:    job.Namespace,
+		Completions:  completions,
+		Parallelism:  parallelism,
+		BackoffLimit: backoffLimit,
+		Active:       job.Status.Active,
+		Succeeded:    job.Status.Succeeded,
+		Failed:       job.Status.Failed,
+		Duration:     duration,
+		Containers:   containerCommands(job.Spec.Template.Spec.Containers),
+		Conditions:   conditions,
+		Labels:       job.Labels,
+		Created:      job.CreationTimestamp.Format("2006-01-02 15:04:05"),
+	}
+	if job.Status.StartTime != nil {
+		info.StartTime = job.Status.StartTime.Format(time.RFC3339)
+	}
+	if job.Status.CompletionTime != nil {
+		info.CompletionTime = job.Status.CompletionTime.Format(time.RFC3339)
 	}
 
 	return info, nil
 }
 
+// containerCommands returns the name, image, command and args of each
+// container.
+func containerCommands(containers []v1.Container) []domain.ContainerCommand {
+	out := make([]domain.ContainerCommand, 0, len(containers))
+	for _, c := range containers {
+		out = append(out, domain.ContainerCommand{Name: c.Name, Image: c.Image, Command: c.Command, Args: c.Args})
+	}
+	return out
+}
+
 func (uc *K8sUseCase) CreateJob(ctx context.Context, clusterID string, options domain.JobCreateOptions) error {
 	client, err := uc.clusterManager.GetClusterClient(domain.ClusterID(clusterID))
 	if err != nil {
@@ -945,51 +907,9 @@ func (uc *K8sUseCase) DeleteJob(ctx context.Context, clusterID, namespace, jobNa
 	return nil
 }
 
-func (uc *K8sUseCase) GetJobLogs(ctx context.Context, clusterID, namespace, jobName string, tailLines int64) (string, error) {
-	client, err := uc.clusterManager.GetClusterClient(domain.ClusterID(clusterID))
-	if err != nil {
-		return "", fmt.Errorf("failed to get client: %w", err)
-	}
-
-	// Get job to find pod selector
-	_, err = client.BatchV1().Jobs(namespace).Get(ctx, jobName, metav1.GetOptions{})
-	if err != nil {
-		return "", fmt.Errorf("failed to get job: %w", err)
-	}
-
-	// List pods for this job
-	labelSelector := metav1.FormatLabelSelector(&metav1.LabelSelector{
-		MatchLabels: map[string]string{"job-name": jobName},
-	})
-
-	podList, err := client.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{
-		LabelSelector: labelSelector,
-	})
-	if err != nil {
-		return "", fmt.Errorf("failed to list pods: %w", err)
-	}
-
-	if len(podList.Items) == 0 {
-		return "No pods found for this job", nil
-	}
-
-	// Get logs from the first pod
-	pod := podList.Items[0]
-	req := client.CoreV1().Pods(namespace).GetLogs(pod.Name, &v1.PodLogOptions{
-		TailLines: &tailLines,
-	})
-
-	logs, err := req.Do(ctx).Raw()
-	if err != nil {
-		return "", fmt.Errorf("failed to get logs: %w", err)
-	}
-
-	return string(logs), nil
-}
-
 // ==================== CronJob Methods ====================
 
-func (uc *K8sUseCase) ListCronJobs(ctx context.Context, clusterID, namespace string) ([]map[string]any, error) {
+func (uc *K8sUseCase) ListCronJobs(ctx context.Context, clusterID, namespace string) ([]domain.CronJobSummary, error) {
 	client, err := uc.clusterManager.GetClusterClient(domain.ClusterID(clusterID))
 	if err != nil {
 		return nil, fmt.Errorf("failed to get client: %w", err)
@@ -1000,7 +920,7 @@ func (uc *K8sUseCase) ListCronJobs(ctx context.Context, clusterID, namespace str
 		return nil, fmt.Errorf("failed to list cronjobs: %w", err)
 	}
 
-	cronJobs := make([]map[string]any, 0, len(cronJobList.Items))
+	cronJobs := make([]domain.CronJobSummary, 0, len(cronJobList.Items))
 	for _, cj := range cronJobList.Items {
 		lastSchedule := ""
 		if cj.Status.LastScheduleTime != nil {
@@ -1012,22 +932,22 @@ func (uc *K8sUseCase) ListCronJobs(ctx context.Context, clusterID, namespace str
 			status = "Suspended"
 		}
 
-		cronJobs = append(cronJobs, map[string]any{
-			"name":          cj.Name,
-			"namespace":     cj.Namespace,
-			"schedule":      cj.Spec.Schedule,
-			"suspend":       *cj.Spec.Suspend,
-			"active":        len(cj.Status.Active),
-			"last_schedule": lastSchedule,
-			"status":        status,
-			"created":       cj.CreationTimestamp.Format("2006-01-02 15:04:05"),
+		cronJobs = append(cronJobs, domain.CronJobSummary{
+			Name:         cj.Name,
+			Namespace:    cj.Namespace,
+			Schedule:     cj.Spec.Schedule,
+			Suspend:      *cj.Spec.Suspend,
+			Active:       len(cj.Status.Active),
+			LastSchedule: lastSchedule,
+			Status:       status,
+			Created:      cj.CreationTimestamp.Format("2006-01-02 15:04:05"),
 		})
 	}
 
 	return cronJobs, nil
 }
 
-func (uc *K8sUseCase) GetCronJob(ctx context.Context, clusterID, namespace, cronJobName string) (map[string]any, error) {
+func (uc *K8sUseCase) GetCronJob(ctx context.Context, clusterID, namespace, cronJobName string) (*domain.CronJobDetail, error) {
 	client, err := uc.clusterManager.GetClusterClient(domain.ClusterID(clusterID))
 	if err != nil {
 		return nil, fmt.Errorf("failed to get client: %w", err)
@@ -1038,58 +958,37 @@ func (uc *K8sUseCase) GetCronJob(ctx context.Context, clusterID, namespace, cron
 		return nil, fmt.Errorf("failed to get cronjob: %w", err)
 	}
 
-	// Extract container information
-	containers := make([]map[string]any, 0, len(cj.Spec.JobTemplate.Spec.Template.Spec.Containers))
-	for _, container := range cj.Spec.JobTemplate.Spec.Template.Spec.Containers {
-		containers = append(containers, map[string]any{
-			"name":    container.Name,
-			"image":   container.Image,
-			"command": container.Command,
-			"args":    container.Args,
-		})
-	}
-
 	// Active jobs
 	activeJobs := make([]string, 0, len(cj.Status.Active))
 	for _, activeJob := range cj.Status.Active {
 		activeJobs = append(activeJobs, activeJob.Name)
 	}
 
-	info := map[string]any{
-		"name":               cj.Name,
-		"namespace":          cj.Namespace,
-		"schedule":           cj.Spec.Schedule,
-		"suspend":            *cj.Spec.Suspend,
-		"concurrency_policy": string(cj.Spec.ConcurrencyPolicy),
-		"successful_jobs_history_limit": func() int32 {
-			if cj.Spec.SuccessfulJobsHistoryLimit != nil {
-				return *cj.Spec.SuccessfulJobsHistoryLimit
-			}
-			return 3
-		}(),
-		"failed_jobs_history_limit": func() int32 {
-			if cj.Spec.FailedJobsHistoryLimit != nil {
-				return *cj.Spec.FailedJobsHistoryLimit
-			}
-			return 1
-		}(),
-		"last_schedule_time": func() string {
-			if cj.Status.LastScheduleTime != nil {
-				return cj.Status.LastScheduleTime.Format(time.RFC3339)
-			}
-			return ""
-		}(),
-		"last_successful_time": func() string {
-			if cj.Status.LastSuccessfulTime != nil {
-				return cj.Status.LastSuccessfulTime.Format(time.RFC3339)
-			}
-			return ""
-		}(),
-		"active_jobs":  activeJobs,
-		"active_count": len(activeJobs),
-		"containers":   containers,
-		"labels":       cj.Labels,
-		"created":      cj.CreationTimestamp.Format("2006-01-02 15:04:05"),
+	info := &domain.CronJobDetail{
+		Name:                       cj.Name,
+		Namespace:                  cj.Namespace,
+		Schedule:                   cj.Spec.Schedule,
+		Suspend:                    *cj.Spec.Suspend,
+		ConcurrencyPolicy:          string(cj.Spec.ConcurrencyPolicy),
+		SuccessfulJobsHistoryLimit: 3,
+		FailedJobsHistoryLimit:     1,
+		ActiveJobs:                 activeJobs,
+		ActiveCount:                len(activeJobs),
+		Containers:                 containerCommands(cj.Spec.JobTemplate.Spec.Template.Spec.Containers),
+		Labels:                     cj.Labels,
+		Created:                    cj.CreationTimestamp.Format("2006-01-02 15:04:05"),
+	}
+	if cj.Spec.SuccessfulJobsHistoryLimit != nil {
+		info.SuccessfulJobsHistoryLimit = *cj.Spec.SuccessfulJobsHistoryLimit
+	}
+	if cj.Spec.FailedJobsHistoryLimit != nil {
+		info.FailedJobsHistoryLimit = *cj.Spec.FailedJobsHistoryLimit
+	}
+	if cj.Status.LastScheduleTime != nil {
+		info.LastScheduleTime = cj.Status.LastScheduleTime.Format(time.RFC3339)
+	}
+	if cj.Status.LastSuccessfulTime != nil {
+		info.LastSuccessfulTime = cj.Status.LastSuccessfulTime.Format(time.RFC3339)
 	}
 
 	return info, nil
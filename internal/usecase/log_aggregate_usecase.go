@@ -0,0 +1,240 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/your-org/mcp-k8s-server/internal/domain"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// DefaultLogPods and MaxLogPods bound how many pods one aggregated log
+	// request reads.
+	DefaultLogPods = 10
+	MaxLogPods     = 50
+	// logFetchConcurrency is how many containers are read at once.
+	logFetchConcurrency = 5
+)
+
+// GetLogsBySelector reads the logs of every container of the pods selected
+// by target, up to maxPods pods ordered by name, and interleaves their lines
+// by timestamp. opts.Container restricts it to one container per pod.
+// Containers whose logs cannot be read are reported in the result.
+func (uc *K8sUseCase) GetLogsBySelector(ctx context.Context, clusterID, namespace string, target domain.LogTarget, opts domain.LogOptions, maxPods int) (*domain.AggregatedLogs, error) {
+	grep, err := compileLogGrep(opts.Grep)
+	if err != nil {
+		return nil, err
+	}
+	client, err := uc.clusterManager.GetClusterClient(domain.ClusterID(clusterID))
+	if err != nil {
+		return nil, err
+	}
+	selector, err := logTargetSelector(ctx, client, namespace, target)
+	if err != nil {
+		return nil, err
+	}
+
+	pods, omitted, err := listLogPods(ctx, client, namespace, selector, maxPods)
+	if err != nil {
+		return nil, err
+	}
+	result := &domain.AggregatedLogs{Selector: selector.String(), Pods: []string{}, Lines: []domain.LogLine{}, PodsOmitted: omitted}
+
+	type source struct{ pod, container string }
+	var sources []source
+	for _, pod := range pods {
+		result.Pods = append(result.Pods, pod.Name)
+		for _, c := range pod.Spec.Containers {
+			if opts.Container == "" || opts.Container == c.Name {
+				sources = append(sources, source{pod.Name, c.Name})
+			}
+		}
+	}
+
+	// Timestamps are needed to interleave lines; they are kept in the
+	// result but only shown when the caller asked for them.
+	fetchOpts := opts
+	fetchOpts.Timestamps = true
+	fetchOpts.Grep = ""
+
+	var (
+		mu  sync.Mutex
+		wg  sync.WaitGroup
+		sem = make(chan struct{}, logFetchConcurrency)
+	)
+	for _, src := range sources {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			o := fetchOpts
+			o.Container = src.container
+			logs, err := uc.clusterManager.GetPodLogs(ctx, domain.ClusterID(clusterID), domain.Namespace(namespace), domain.PodName(src.pod), o)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				result.Errors = append(result.Errors, domain.LogSourceError{Pod: src.pod, Container: src.container, Error: err.Error()})
+				return
+			}
+			for _, line := range splitLogLines(string(logs)) {
+				ts, msg := splitLogTimestamp(line, true)
+				if grep != nil && !grep.MatchString(msg) {
+					continue
+				}
+				result.Lines = append(result.Lines, domain.LogLine{Time: ts, Pod: src.pod, Container: src.container, Message: msg})
+			}
+		}()
+	}
+	wg.Wait()
+
+	sort.SliceStable(result.Lines, func(i, j int) bool {
+		a, b := result.Lines[i], result.Lines[j]
+		switch {
+		case a.Time != nil && b.Time != nil && !a.Time.Equal(*b.Time):
+			return a.Time.Before(*b.Time)
+		case (a.Time == nil) != (b.Time == nil):
+			return a.Time == nil
+		case a.Pod != b.Pod:
+			return a.Pod < b.Pod
+		}
+		return a.Container < b.Container
+	})
+	sort.Slice(result.Errors, func(i, j int) bool {
+		if result.Errors[i].Pod != result.Errors[j].Pod {
+			return result.Errors[i].Pod < result.Errors[j].Pod
+		}
+		return result.Errors[i].Container < result.Errors[j].Container
+	})
+	return result, nil
+}
+
+// listLogPods lists the pods matching selector by name, at most maxPods of
+// them, and returns how many were left out.
+func listLogPods(ctx context.Context, client kubernetes.Interface, namespace string, selector labels.Selector, maxPods int) ([]corev1.Pod, int, error) {
+	podList, err := client.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list pods: %w", err)
+	}
+	pods := podList.Items
+	sort.Slice(pods, func(i, j int) bool { return pods[i].Name < pods[j].Name })
+
+	if maxPods <= 0 {
+		maxPods = DefaultLogPods
+	}
+	if len(pods) > maxPods {
+		return pods[:maxPods], len(pods) - maxPods, nil
+	}
+	return pods, 0, nil
+}
+
+// logTargetSelector returns the label selector of target: its own, or the
+// pod selector of the named workload.
+func logTargetSelector(ctx context.Context, client kubernetes.Interface, namespace string, target domain.LogTarget) (labels.Selector, error) {
+	if target.LabelSelector != "" {
+		if target.Kind != "" {
+			return nil, fmt.Errorf("label_selector and a workload are mutually exclusive")
+		}
+		selector, err := labels.Parse(target.LabelSelector)
+		if err != nil {
+			return nil, fmt.Errorf("invalid label_selector: %w", err)
+		}
+		return selector, nil
+	}
+	if target.Kind == "" || target.Name == "" {
+		return nil, fmt.Errorf("label_selector or a workload kind and name is required")
+	}
+
+	var ls *metav1.LabelSelector
+	switch target.Kind {
+	case "deployment":
+		obj, err := client.AppsV1().Deployments(namespace).Get(ctx, target.Name, metav1.GetOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get deployment: %w", err)
+		}
+		ls = obj.Spec.Selector
+	case "statefulset":
+		obj, err := client.AppsV1().StatefulSets(namespace).Get(ctx, target.Name, metav1.GetOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get statefulset: %w", err)
+		}
+		ls = obj.Spec.Selector
+	case "daemonset":
+		obj, err := client.AppsV1().DaemonSets(namespace).Get(ctx, target.Name, metav1.GetOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get daemonset: %w", err)
+		}
+		ls = obj.Spec.Selector
+	case "replicaset":
+		obj, err := client.AppsV1().ReplicaSets(namespace).Get(ctx, target.Name, metav1.GetOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get replicaset: %w", err)
+		}
+		ls = obj.Spec.Selector
+	case "job":
+		obj, err := client.BatchV1().Jobs(namespace).Get(ctx, target.Name, metav1.GetOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get job: %w", err)
+		}
+		return jobPodSelector(obj)
+	default:
+		return nil, fmt.Errorf("unsupported workload kind %q (want deployment, statefulset, daemonset, replicaset or job)", target.Kind)
+	}
+
+	selector, err := metav1.LabelSelectorAsSelector(ls)
+	if err != nil {
+		return nil, fmt.Errorf("invalid selector on %s %s: %w", target.Kind, target.Name, err)
+	}
+	if selector.Empty() {
+		return nil, fmt.Errorf("%s %s has an empty selector", target.Kind, target.Name)
+	}
+	return selector, nil
+}
+
+func compileLogGrep(pattern string) (*regexp.Regexp, error) {
+	if pattern == "" {
+		return nil, nil
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid grep pattern: %w", err)
+	}
+	return re, nil
+}
+
+// splitLogLines splits logs into lines without their trailing newline.
+func splitLogLines(logs string) []string {
+	logs = strings.TrimSuffix(logs, "\n")
+	if logs == "" {
+		return nil
+	}
+	return strings.Split(logs, "\n")
+}
+
+// splitLogTimestamp separates the RFC 3339 timestamp the API server prefixes
+// lines with when timestamps are requested.
+func splitLogTimestamp(line string, timestamps bool) (*time.Time, string) {
+	if !timestamps {
+		return nil, line
+	}
+	prefix, msg, ok := strings.Cut(line, " ")
+	if !ok {
+		prefix, msg = line, ""
+	}
+	t, err := time.Parse(time.RFC3339Nano, prefix)
+	if err != nil {
+		return nil, line
+	}
+	return &t, msg
+}
@@ -0,0 +1,148 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/your-org/mcp-k8s-server/internal/domain"
+	"github.com/your-org/mcp-k8s-server/internal/infrastructure"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+const (
+	// DefaultLogExportLimitBytes caps each container's logs in an export
+	// unless the caller sets a limit.
+	DefaultLogExportLimitBytes = 10 << 20
+	// DefaultLogErrorPattern counts the error lines in an export summary.
+	DefaultLogErrorPattern = `(?i)\b(error|fatal|panic|exception)\b`
+)
+
+var unsafeFileChars = regexp.MustCompile(`[^A-Za-z0-9_.-]+`)
+
+// ExportLogs writes the logs of every container of the selected pods into an
+// archive in dir, one <pod>/<container>.log file each plus summary.json, and
+// returns the summary.
+func (uc *K8sUseCase) ExportLogs(ctx context.Context, req domain.LogExportRequest, dir string) (*domain.LogExport, error) {
+	grep, err := compileLogGrep(req.Options.Grep)
+	if err != nil {
+		return nil, err
+	}
+	errorPattern := req.ErrorPattern
+	if errorPattern == "" {
+		errorPattern = DefaultLogErrorPattern
+	}
+	errorRE, err := regexp.Compile(errorPattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid error_pattern: %w", err)
+	}
+	if req.Format == "" {
+		req.Format = infrastructure.ArchiveFormatTarGz
+	}
+
+	client, err := uc.clusterManager.GetClusterClient(domain.ClusterID(req.ClusterID))
+	if err != nil {
+		return nil, err
+	}
+	selector := labels.Everything()
+	if req.Target != (domain.LogTarget{}) {
+		if selector, err = logTargetSelector(ctx, client, req.Namespace, req.Target); err != nil {
+			return nil, err
+		}
+	}
+	pods, omitted, err := listLogPods(ctx, client, req.Namespace, selector, req.MaxPods)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	suffix := make([]byte, 4)
+	if _, err := rand.Read(suffix); err != nil {
+		return nil, err
+	}
+	name := fmt.Sprintf("logs-%s-%s-%s-%s.%s",
+		unsafeFileChars.ReplaceAllString(req.ClusterID, "-"),
+		unsafeFileChars.ReplaceAllString(req.Namespace, "-"),
+		now.Format("20060102-150405"), hex.EncodeToString(suffix), req.Format)
+	export := &domain.LogExport{
+		Name:        name,
+		Path:        filepath.Join(dir, name),
+		Format:      req.Format,
+		Namespace:   req.Namespace,
+		Selector:    selector.String(),
+		Files:       []domain.LogExportFile{},
+		CreatedAt:   now,
+		PodsOmitted: omitted,
+	}
+
+	archive, err := infrastructure.NewLogArchive(export.Path, req.Format)
+	if err != nil {
+		return nil, err
+	}
+	defer archive.Abort()
+
+	opts := req.Options
+	opts.Grep = ""
+	if opts.LimitBytes == nil {
+		limit := int64(DefaultLogExportLimitBytes)
+		opts.LimitBytes = &limit
+	}
+	for _, pod := range pods {
+		for _, c := range pod.Spec.Containers {
+			if req.Options.Container != "" && req.Options.Container != c.Name {
+				continue
+			}
+			file := domain.LogExportFile{Name: pod.Name + "/" + c.Name + ".log", Pod: pod.Name, Container: c.Name}
+
+			o := opts
+			o.Container = c.Name
+			logs, err := uc.clusterManager.GetPodLogs(ctx, domain.ClusterID(req.ClusterID), domain.Namespace(req.Namespace), domain.PodName(pod.Name), o)
+			if err != nil {
+				file.Error = err.Error()
+				export.Files = append(export.Files, file)
+				continue
+			}
+			file.Truncated = int64(len(logs)) >= *opts.LimitBytes
+
+			var sb strings.Builder
+			for _, line := range splitLogLines(string(logs)) {
+				_, msg := splitLogTimestamp(line, opts.Timestamps)
+				if grep != nil && !grep.MatchString(msg) {
+					continue
+				}
+				file.Lines++
+				if errorRE.MatchString(msg) {
+					file.ErrorLines++
+				}
+				sb.WriteString(line)
+				sb.WriteString("\n")
+			}
+			file.Bytes = sb.Len()
+			if err := archive.Add(file.Name, []byte(sb.String()), now); err != nil {
+				return nil, fmt.Errorf("failed to write archive: %w", err)
+			}
+			export.Lines += file.Lines
+			export.Errors += file.ErrorLines
+			export.Files = append(export.Files, file)
+		}
+	}
+
+	summary, err := json.MarshalIndent(export, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if err := archive.Add("summary.json", summary, now); err != nil {
+		return nil, fmt.Errorf("failed to write archive: %w", err)
+	}
+	if export.Size, err = archive.Close(); err != nil {
+		return nil, err
+	}
+	uc.logger.Info("Exported logs", "path", export.Path, "files", len(export.Files), "size", export.Size)
+	return export, nil
+}
@@ -0,0 +1,81 @@
+package usecase
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/your-org/mcp-k8s-server/internal/domain"
+)
+
+const (
+	// DefaultLogFollowDuration and MaxLogFollowDuration bound how long one
+	// call follows logs.
+	DefaultLogFollowDuration = 30 * time.Second
+	MaxLogFollowDuration     = 5 * time.Minute
+	// DefaultLogFollowLines and MaxLogFollowLines bound how many lines one
+	// call returns.
+	DefaultLogFollowLines = 200
+	MaxLogFollowLines     = 2000
+)
+
+// FollowPodLogs follows the logs of a pod container and passes each line
+// matching opts.Grep to onLine as it arrives. It stops after maxLines lines,
+// after duration, when the log stream ends or when ctx is cancelled, and
+// returns the lines read with the reason it stopped.
+func (uc *K8sUseCase) FollowPodLogs(ctx context.Context, clusterID, namespace, podName string, opts domain.LogOptions, maxLines int, duration time.Duration, onLine func(line string, n int)) (*domain.LogFollowResult, error) {
+	grep, err := compileLogGrep(opts.Grep)
+	if err != nil {
+		return nil, err
+	}
+	followCtx, cancel := context.WithTimeout(ctx, duration)
+	defer cancel()
+
+	opts.Follow = true
+	stream, err := uc.clusterManager.StreamPodLogs(followCtx, domain.ClusterID(clusterID), domain.Namespace(namespace), domain.PodName(podName), opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to follow pod logs: %w", err)
+	}
+	defer stream.Close()
+	// Unblock the read below once the time is up or the call is cancelled.
+	stop := context.AfterFunc(followCtx, func() { stream.Close() })
+	defer stop()
+
+	result := &domain.LogFollowResult{Pod: podName, Container: opts.Container, Lines: []string{}}
+	reader := bufio.NewReader(stream)
+	for {
+		line, readErr := reader.ReadString('\n')
+		line = strings.TrimSuffix(line, "\n")
+		if line != "" || readErr == nil {
+			if _, msg := splitLogTimestamp(line, opts.Timestamps); grep == nil || grep.MatchString(msg) {
+				result.Lines = append(result.Lines, line)
+				if onLine != nil {
+					onLine(line, len(result.Lines))
+				}
+				if len(result.Lines) >= maxLines {
+					result.StopReason = domain.LogFollowStopMaxLines
+					return result, nil
+				}
+			}
+		}
+		if readErr == nil {
+			continue
+		}
+
+		switch {
+		case ctx.Err() != nil:
+			result.StopReason = domain.LogFollowStopCancelled
+		case followCtx.Err() != nil:
+			result.StopReason = domain.LogFollowStopDuration
+		case errors.Is(readErr, io.EOF):
+			result.StopReason = domain.LogFollowStopEnded
+		default:
+			return nil, fmt.Errorf("failed to read log stream: %w", readErr)
+		}
+		return result, nil
+	}
+}
@@ -0,0 +1,216 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/your-org/mcp-k8s-server/internal/domain"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/restmapper"
+	"sigs.k8s.io/yaml"
+)
+
+// diffContextLines is the number of unchanged lines shown around each change.
+const diffContextLines = 3
+
+// DiffYAML performs a server-side dry-run apply of every object in yamlBody
+// and/or files and returns a unified diff between the live object and the
+// object the API server would persist. Server-populated fields (managedFields,
+// resourceVersion, generation, uid, creationTimestamp) and status are ignored.
+func (uc *K8sUseCase) DiffYAML(ctx context.Context, clusterID string, yamlBody string, files ManifestFiles, manager string) ([]domain.DiffResult, error) {
+	objects, err := loadManifestObjects(yamlBody, files)
+	if err != nil {
+		return nil, err
+	}
+
+	dynClient, mapper, err := uc.newDynamicClients(clusterID)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]domain.DiffResult, 0, len(objects))
+	for _, mo := range objects {
+		result := domain.DiffResult{
+			Name:       mo.obj.GetName(),
+			Kind:       mo.obj.GetKind(),
+			APIVersion: mo.obj.GetAPIVersion(),
+			Source:     mo.source,
+		}
+
+		if err := diffObject(ctx, &result, dynClient, mapper, mo.obj, manager); err != nil {
+			result.Error = err.Error()
+		}
+		result.Namespace = mo.obj.GetNamespace()
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+func diffObject(ctx context.Context, result *domain.DiffResult, dynClient dynamic.Interface, mapper *restmapper.DeferredDiscoveryRESTMapper, obj *unstructured.Unstructured, manager string) error {
+	dr, err := resourceFor(dynClient, mapper, obj)
+	if err != nil {
+		return err
+	}
+
+	var liveYAML string
+	live, err := dr.Get(ctx, obj.GetName(), metav1.GetOptions{})
+	switch {
+	case err == nil:
+		result.Exists = true
+		liveYAML, err = cleanObjectYAML(live)
+		if err != nil {
+			return err
+		}
+	case apierrors.IsNotFound(err):
+		// Everything in the merged object is an addition.
+	default:
+		return fmt.Errorf("failed to get live object: %w", err)
+	}
+
+	merged, err := serverSideApply(ctx, dr, obj, manager, true)
+	if err != nil {
+		return err
+	}
+	mergedYAML, err := cleanObjectYAML(merged)
+	if err != nil {
+		return err
+	}
+
+	name := strings.ToLower(obj.GetKind()) + "/" + obj.GetName()
+	if obj.GetNamespace() != "" {
+		name = obj.GetNamespace() + "/" + name
+	}
+	result.Diff = unifiedDiff(liveYAML, mergedYAML, "live/"+name, "merged/"+name)
+	result.Changed = result.Diff != ""
+	return nil
+}
+
+// cleanObjectYAML renders obj as YAML without the fields that change on every
+// write or are owned by controllers, so the diff only shows spec changes.
+func cleanObjectYAML(obj *unstructured.Unstructured) (string, error) {
+	clean := obj.DeepCopy()
+	unstructured.RemoveNestedField(clean.Object, "status")
+	unstructured.RemoveNestedField(clean.Object, "metadata", "managedFields")
+	unstructured.RemoveNestedField(clean.Object, "metadata", "resourceVersion")
+	unstructured.RemoveNestedField(clean.Object, "metadata", "generation")
+	unstructured.RemoveNestedField(clean.Object, "metadata", "uid")
+	unstructured.RemoveNestedField(clean.Object, "metadata", "creationTimestamp")
+
+	data, err := yaml.Marshal(clean.Object)
+	if err != nil {
+		return "", fmt.Errorf("failed to render object as YAML: %w", err)
+	}
+	return string(data), nil
+}
+
+// unifiedDiff returns a unified diff of two texts, or "" when they are equal.
+func unifiedDiff(from, to, fromName, toName string) string {
+	if from == to {
+		return ""
+	}
+
+	a := splitLines(from)
+	b := splitLines(to)
+
+	// Longest common subsequence table, lcs[i][j] for a[i:] and b[j:].
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	type diffLine struct {
+		op   byte // ' ', '-' or '+'
+		text string
+		ai   int // line index in a (for ' ' and '-')
+		bi   int // line index in b (for ' ' and '+')
+	}
+	var lines []diffLine
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			lines = append(lines, diffLine{' ', a[i], i, j})
+			i++
+			j++
+		case i < len(a) && (j == len(b) || lcs[i+1][j] >= lcs[i][j+1]):
+			lines = append(lines, diffLine{'-', a[i], i, j})
+			i++
+		default:
+			lines = append(lines, diffLine{'+', b[j], i, j})
+			j++
+		}
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "--- %s\n+++ %s\n", fromName, toName)
+
+	for start := 0; start < len(lines); {
+		if lines[start].op == ' ' {
+			start++
+			continue
+		}
+
+		// Grow the hunk until there are more than 2*context unchanged lines.
+		hunkStart := max(start-diffContextLines, 0)
+		end := start
+		for k := start; k < len(lines); k++ {
+			if lines[k].op != ' ' {
+				end = k
+				continue
+			}
+			if k-end > 2*diffContextLines {
+				break
+			}
+		}
+		hunkEnd := min(end+diffContextLines+1, len(lines))
+
+		fromCount, toCount := 0, 0
+		for _, l := range lines[hunkStart:hunkEnd] {
+			if l.op != '+' {
+				fromCount++
+			}
+			if l.op != '-' {
+				toCount++
+			}
+		}
+		first := lines[hunkStart]
+		fmt.Fprintf(&sb, "@@ -%s +%s @@\n", hunkRange(first.ai, fromCount), hunkRange(first.bi, toCount))
+		for _, l := range lines[hunkStart:hunkEnd] {
+			sb.WriteByte(l.op)
+			sb.WriteString(l.text)
+			sb.WriteByte('\n')
+		}
+
+		start = hunkEnd
+	}
+
+	return sb.String()
+}
+
+func hunkRange(start, count int) string {
+	if count == 0 {
+		return fmt.Sprintf("%d,0", start)
+	}
+	return fmt.Sprintf("%d,%d", start+1, count)
+}
+
+func splitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+}
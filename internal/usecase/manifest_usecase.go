@@ -1,60 +1,295 @@
 package usecase
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
 
 	"github.com/your-org/mcp-k8s-server/internal/domain"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
-	"k8s.io/apimachinery/pkg/runtime/serializer/yaml"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
-	"k8s.io/client-go/discovery"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
 	"k8s.io/client-go/discovery/cached/memory"
 	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/restmapper"
 )
 
-// kubectl apply
-func (uc *K8sUseCase) ApplyYAML(ctx context.Context, clusterID string, yamlBody string, manager string, dryRun bool) (*domain.ApplyResult, error) {
-	restConfig, err := uc.clusterManager.GetRESTConfig(domain.ClusterID(clusterID))
+// applyKindOrder is the order in which kinds are applied so that dependencies
+// (CRDs, Namespaces, config, RBAC) exist before the workloads that use them.
+// Kinds not listed here (custom resources included) are applied last.
+var applyKindOrder = []string{
+	"CustomResourceDefinition",
+	"Namespace",
+	"NetworkPolicy",
+	"ResourceQuota",
+	"LimitRange",
+	"PodDisruptionBudget",
+	"ServiceAccount",
+	"Secret",
+	"ConfigMap",
+	"StorageClass",
+	"PersistentVolume",
+	"PersistentVolumeClaim",
+	"ClusterRole",
+	"ClusterRoleBinding",
+	"Role",
+	"RoleBinding",
+	"Service",
+	"DaemonSet",
+	"Pod",
+	"ReplicationController",
+	"ReplicaSet",
+	"Deployment",
+	"HorizontalPodAutoscaler",
+	"StatefulSet",
+	"Job",
+	"CronJob",
+	"IngressClass",
+	"Ingress",
+	"APIService",
+	"MutatingWebhookConfiguration",
+	"ValidatingWebhookConfiguration",
+}
+
+// ManifestFiles selects manifest files to read next to inline YAML. Path,
+// a file, directory or glob pattern, is resolved under Root and every file
+// it selects must stay inside Root once symlinks are followed. Reading
+// files is disabled when Root is empty.
+type ManifestFiles struct {
+	Root      string
+	Path      string
+	Recursive bool
+}
+
+// manifestObject is a single decoded document together with where it came from.
+type manifestObject struct {
+	obj    *unstructured.Unstructured
+	source string
+}
+
+// ApplyYAML applies every object found in yamlBody and/or files. yamlBody may
+// contain several documents separated by "---". Objects are applied in
+// dependency order and each one gets its own result, so a single failure does
+// not hide the rest of the bundle. With dryRun set, every request is sent with
+// DryRun=All and nothing is persisted.
+func (uc *K8sUseCase) ApplyYAML(ctx context.Context, clusterID string, yamlBody string, files ManifestFiles, manager string, dryRun bool) ([]domain.ApplyResult, error) {
+	objects, err := loadManifestObjects(yamlBody, files)
+	if err != nil {
+		return nil, err
+	}
+
+	dynClient, mapper, err := uc.newDynamicClients(clusterID)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]domain.ApplyResult, 0, len(objects))
+	for _, mo := range objects {
+		result := domain.ApplyResult{
+			Name:       mo.obj.GetName(),
+			Namespace:  mo.obj.GetNamespace(),
+			Kind:       mo.obj.GetKind(),
+			APIVersion: mo.obj.GetAPIVersion(),
+			DryRun:     dryRun,
+			Source:     mo.source,
+		}
+
+		action, previousVersion, appliedVersion, err := uc.applyObject(ctx, dynClient, mapper, mo.obj, manager, dryRun)
+		if err != nil {
+			result.Action = domain.ApplyActionFailed
+			result.Error = err.Error()
+		} else {
+			result.Action = action
+			result.PreviousResourceVersion = previousVersion
+			result.ResourceVersion = appliedVersion
+			result.Namespace = mo.obj.GetNamespace()
+			// New CRDs add kinds that later objects in the bundle may use.
+			if mo.obj.GetKind() == "CustomResourceDefinition" && !dryRun {
+				mapper.Reset()
+			}
+		}
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+// ManifestNamespaces returns the namespaces touched by the manifests in
+// yamlBody and/or files, without contacting a cluster. A Namespace object
+// counts as its own name. Objects without a namespace are reported as
+// "default" since whether their kind is cluster-scoped is unknown here.
+func ManifestNamespaces(yamlBody string, files ManifestFiles) ([]string, error) {
+	objects, err := loadManifestObjects(yamlBody, files)
 	if err != nil {
 		return nil, err
 	}
 
-	dynClient, _ := dynamic.NewForConfig(restConfig)
-	discClient, _ := discovery.NewDiscoveryClientForConfig(restConfig)
+	seen := make(map[string]bool)
+	var namespaces []string
+	for _, mo := range objects {
+		ns := mo.obj.GetNamespace()
+		switch {
+		case mo.obj.GetKind() == "Namespace":
+			ns = mo.obj.GetName()
+		case ns == "":
+			ns = "default"
+		}
+		if !seen[ns] {
+			seen[ns] = true
+			namespaces = append(namespaces, ns)
+		}
+	}
+	return namespaces, nil
+}
+
+// loadManifestObjects decodes yamlBody and the manifests selected by files,
+// sorted in apply order.
+func loadManifestObjects(yamlBody string, files ManifestFiles) ([]manifestObject, error) {
+	var objects []manifestObject
+
+	if strings.TrimSpace(yamlBody) != "" {
+		objs, err := decodeManifests([]byte(yamlBody), "yaml_body")
+		if err != nil {
+			return nil, err
+		}
+		objects = append(objects, objs...)
+	}
+
+	if files.Path != "" {
+		paths, err := resolveManifestPaths(files)
+		if err != nil {
+			return nil, err
+		}
+		for _, file := range paths {
+			data, err := os.ReadFile(file.path)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read manifest %s: %w", file.name, err)
+			}
+			objs, err := decodeManifests(data, file.name)
+			if err != nil {
+				return nil, err
+			}
+			objects = append(objects, objs...)
+		}
+	}
+
+	if len(objects) == 0 {
+		return nil, fmt.Errorf("no Kubernetes objects found to apply")
+	}
+
+	sortManifestObjects(objects)
+	return objects, nil
+}
 
-	// Decode YAML
-	obj := &unstructured.Unstructured{}
-	dec := yaml.NewDecodingSerializer(unstructured.UnstructuredJSONScheme)
-	_, gvk, err := dec.Decode([]byte(yamlBody), nil, obj)
+func (uc *K8sUseCase) newDynamicClients(clusterID string) (dynamic.Interface, *restmapper.DeferredDiscoveryRESTMapper, error) {
+	dynClient, err := uc.clusterManager.GetDynamicClient(domain.ClusterID(clusterID))
 	if err != nil {
-		return nil, fmt.Errorf("decode YAML failed: %w", err)
+		return nil, nil, err
+	}
+	discClient, err := uc.clusterManager.GetDiscoveryClient(domain.ClusterID(clusterID))
+	if err != nil {
+		return nil, nil, err
 	}
 
+	return dynClient, restmapper.NewDeferredDiscoveryRESTMapper(memory.NewMemCacheClient(discClient)), nil
+}
+
+// resourceFor maps obj to its dynamic resource client, defaulting the
+// namespace of namespaced objects to "default".
+func resourceFor(dynClient dynamic.Interface, mapper *restmapper.DeferredDiscoveryRESTMapper, obj *unstructured.Unstructured) (dynamic.ResourceInterface, error) {
+	gvk := obj.GroupVersionKind()
+
 	// Mapping GVR
-	mapper := restmapper.NewDeferredDiscoveryRESTMapper(memory.NewMemCacheClient(discClient))
 	mapping, err := mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if meta.IsNoMatchError(err) {
+		// The kind may come from a CRD that was created moments ago.
+		mapper.Reset()
+		mapping, err = mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	}
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("failed to map %s: %w", gvk.String(), err)
 	}
 
-	var dr dynamic.ResourceInterface
 	if mapping.Scope.Name() == meta.RESTScopeNameNamespace {
-		dr = dynClient.Resource(mapping.Resource).Namespace(obj.GetNamespace())
-	} else {
-		dr = dynClient.Resource(mapping.Resource)
+		if obj.GetNamespace() == "" {
+			obj.SetNamespace("default")
+		}
+		return dynClient.Resource(mapping.Resource).Namespace(obj.GetNamespace()), nil
 	}
+	return dynClient.Resource(mapping.Resource), nil
+}
 
-	data, _ := obj.MarshalJSON()
-	_, err = dr.Patch(ctx, obj.GetName(), types.ApplyPatchType, data, metav1.PatchOptions{
-		FieldManager: manager,
-		Force:        ptrBool(true),
-	})
+// applyObject applies obj and returns the action taken together with the
+// resourceVersion before and after the apply. The previous version is empty
+// for new objects.
+func (uc *K8sUseCase) applyObject(ctx context.Context, dynClient dynamic.Interface, mapper *restmapper.DeferredDiscoveryRESTMapper, obj *unstructured.Unstructured, manager string, dryRun bool) (action, previousVersion, appliedVersion string, err error) {
+	dr, err := resourceFor(dynClient, mapper, obj)
 	if err != nil {
-		return nil, err
+		return "", "", "", err
+	}
+
+	existing, err := dr.Get(ctx, obj.GetName(), metav1.GetOptions{})
+	if err == nil {
+		previousVersion = existing.GetResourceVersion()
+	} else if !apierrors.IsNotFound(err) {
+		return "", "", "", fmt.Errorf("failed to get current object: %w", err)
+	}
+
+	applied, err := serverSideApply(ctx, dr, obj, manager, dryRun)
+	if err != nil {
+		return "", "", "", err
+	}
+	appliedVersion = applied.GetResourceVersion()
+
+	changed := appliedVersion != previousVersion
+	if dryRun && previousVersion != "" {
+		// A dry run never bumps the resourceVersion, so compare the objects.
+		if changed, err = objectChanged(existing, applied); err != nil {
+			return "", "", "", err
+		}
+	}
+
+	switch {
+	case previousVersion == "":
+		action = domain.ApplyActionCreated
+	case !changed:
+		action = domain.ApplyActionUnchanged
+	default:
+		action = domain.ApplyActionConfigured
+	}
+	return action, previousVersion, appliedVersion, nil
+}
+
+// objectChanged reports whether applied differs from existing in more than
+// the fields cleanObjectYAML drops.
+func objectChanged(existing, applied *unstructured.Unstructured) (bool, error) {
+	before, err := cleanObjectYAML(existing)
+	if err != nil {
+		return false, err
+	}
+	after, err := cleanObjectYAML(applied)
+	if err != nil {
+		return false, err
+	}
+	return before != after, nil
+}
+
+// serverSideApply sends obj as an apply patch. The dry-run option is set on the
+// single request that is made, so a dry run never reaches etcd.
+func serverSideApply(ctx context.Context, dr dynamic.ResourceInterface, obj *unstructured.Unstructured, manager string, dryRun bool) (*unstructured.Unstructured, error) {
+	data, err := obj.MarshalJSON()
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode object: %w", err)
 	}
 
 	patchOptions := metav1.PatchOptions{
@@ -66,15 +301,192 @@ func (uc *K8sUseCase) ApplyYAML(ctx context.Context, clusterID string, yamlBody
 		patchOptions.DryRun = []string{metav1.DryRunAll}
 	}
 
+	applied, err := dr.Patch(ctx, obj.GetName(), types.ApplyPatchType, data, patchOptions)
 	if err != nil {
 		return nil, fmt.Errorf("failed to apply (dry-run=%v): %w", dryRun, err)
 	}
+	return applied, nil
+}
+
+// decodeManifests splits a multi-document YAML (or JSON) stream into objects.
+// Empty documents are skipped and "List" kinds are expanded into their items.
+func decodeManifests(data []byte, source string) ([]manifestObject, error) {
+	decoder := utilyaml.NewYAMLOrJSONDecoder(bytes.NewReader(data), 4096)
+
+	var objects []manifestObject
+	for doc := 1; ; doc++ {
+		var raw map[string]any
+		if err := decoder.Decode(&raw); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, fmt.Errorf("decode YAML failed (%s, document %d): %w", source, doc, err)
+		}
+		if len(raw) == 0 {
+			continue
+		}
+
+		obj := &unstructured.Unstructured{Object: raw}
+		docSource := fmt.Sprintf("%s#%d", source, doc)
+
+		if obj.IsList() {
+			err := obj.EachListItem(func(item runtime.Object) error {
+				u, ok := item.(*unstructured.Unstructured)
+				if !ok {
+					return fmt.Errorf("unexpected list item type %T", item)
+				}
+				objects = append(objects, manifestObject{obj: u, source: docSource})
+				return nil
+			})
+			if err != nil {
+				return nil, fmt.Errorf("decode YAML failed (%s): %w", docSource, err)
+			}
+			continue
+		}
+
+		if obj.GetKind() == "" || obj.GetAPIVersion() == "" {
+			return nil, fmt.Errorf("decode YAML failed (%s): apiVersion and kind are required", docSource)
+		}
+		if obj.GetName() == "" {
+			return nil, fmt.Errorf("decode YAML failed (%s): metadata.name is required", docSource)
+		}
+		objects = append(objects, manifestObject{obj: obj, source: docSource})
+	}
 
-	return &domain.ApplyResult{
-		Name:      obj.GetName(),
-		Namespace: obj.GetNamespace(),
-		Kind:      obj.GetKind(),
-		DryRun:    dryRun,
-	}, nil
+	return objects, nil
+}
 
+// manifestFile is a file selected by ManifestFiles: its real path and its
+// name relative to the root, which results report as their source.
+type manifestFile struct {
+	path string
+	name string
+}
+
+// resolveManifestPaths expands the file, directory or glob pattern of files
+// into the manifest files to read, in lexical order. Relative paths are
+// resolved under the root; files outside it, through ".." or symlinks, are
+// rejected.
+func resolveManifestPaths(files ManifestFiles) ([]manifestFile, error) {
+	if files.Root == "" {
+		return nil, errors.New("reading manifests from path is disabled: no manifest root is configured, send the manifests as yaml_body")
+	}
+	root, err := filepath.EvalSymlinks(files.Root)
+	if err != nil {
+		return nil, fmt.Errorf("invalid manifest root: %w", err)
+	}
+	if root, err = filepath.Abs(root); err != nil {
+		return nil, fmt.Errorf("invalid manifest root: %w", err)
+	}
+	path := files.Path
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(root, path)
+	}
+
+	// inRoot returns the file behind p if it is inside root.
+	inRoot := func(p string) (manifestFile, error) {
+		real, err := filepath.EvalSymlinks(p)
+		if err != nil {
+			return manifestFile{}, fmt.Errorf("failed to resolve manifest path: %w", err)
+		}
+		rel, err := filepath.Rel(root, real)
+		if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return manifestFile{}, fmt.Errorf("manifest path %q is outside the manifest root", files.Path)
+		}
+		return manifestFile{path: real, name: filepath.ToSlash(rel)}, nil
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		matches, globErr := filepath.Glob(path)
+		if globErr != nil {
+			return nil, fmt.Errorf("invalid glob pattern %q: %w", files.Path, globErr)
+		}
+		if len(matches) == 0 {
+			return nil, fmt.Errorf("no manifest files match %q", files.Path)
+		}
+		var found []manifestFile
+		for _, match := range matches {
+			if fi, err := os.Stat(match); err != nil || fi.IsDir() {
+				continue
+			}
+			file, err := inRoot(match)
+			if err != nil {
+				return nil, err
+			}
+			found = append(found, file)
+		}
+		sortManifestFiles(found)
+		return found, nil
+	}
+
+	top, err := inRoot(path)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return []manifestFile{top}, nil
+	}
+
+	var found []manifestFile
+	err = filepath.WalkDir(top.path, func(p string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if p != top.path && !files.Recursive {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !isManifestFile(p) {
+			return nil
+		}
+		file, err := inRoot(p)
+		if err != nil {
+			return err
+		}
+		found = append(found, file)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read manifest directory %s: %w", files.Path, err)
+	}
+	if len(found) == 0 {
+		return nil, fmt.Errorf("no .yaml, .yml or .json files found in %s", files.Path)
+	}
+
+	sortManifestFiles(found)
+	return found, nil
+}
+
+func sortManifestFiles(files []manifestFile) {
+	sort.Slice(files, func(i, j int) bool { return files[i].name < files[j].name })
+}
+
+func isManifestFile(path string) bool {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml", ".json":
+		return true
+	}
+	return false
+}
+
+// sortManifestObjects orders objects by applyKindOrder, keeping the original
+// order for objects of the same rank.
+func sortManifestObjects(objects []manifestObject) {
+	rank := make(map[string]int, len(applyKindOrder))
+	for i, kind := range applyKindOrder {
+		rank[kind] = i
+	}
+	kindRank := func(kind string) int {
+		if r, ok := rank[kind]; ok {
+			return r
+		}
+		return len(applyKindOrder)
+	}
+
+	sort.SliceStable(objects, func(i, j int) bool {
+		return kindRank(objects[i].obj.GetKind()) < kindRank(objects[j].obj.GetKind())
+	})
 }
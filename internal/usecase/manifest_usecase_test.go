@@ -0,0 +1,181 @@
+package usecase
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestDecodeManifests(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		data    string
+		want    []string // kind/name#source document
+		wantErr string
+	}{
+		{
+			name: "several documents",
+			data: "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: a\n---\napiVersion: v1\nkind: Secret\nmetadata:\n  name: b\n",
+			want: []string{"ConfigMap/a#1", "Secret/b#2"},
+		},
+		{
+			name: "empty documents are skipped",
+			data: "---\n# comment only\n---\napiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: a\n---\n",
+			want: []string{"ConfigMap/a#2"},
+		},
+		{
+			name: "list is expanded",
+			data: "apiVersion: v1\nkind: List\nitems:\n- apiVersion: v1\n  kind: ConfigMap\n  metadata:\n    name: a\n- apiVersion: apps/v1\n  kind: Deployment\n  metadata:\n    name: b\n",
+			want: []string{"ConfigMap/a#1", "Deployment/b#1"},
+		},
+		{
+			name: "json",
+			data: `{"apiVersion": "v1", "kind": "Namespace", "metadata": {"name": "shop"}}`,
+			want: []string{"Namespace/shop#1"},
+		},
+		{
+			name:    "missing kind",
+			data:    "apiVersion: v1\nmetadata:\n  name: a\n",
+			wantErr: "(m.yaml#1): apiVersion and kind are required",
+		},
+		{
+			name:    "missing name",
+			data:    "apiVersion: v1\nkind: ConfigMap\n---\napiVersion: v1\nkind: ConfigMap\nmetadata: {}\n",
+			wantErr: "(m.yaml#1): metadata.name is required",
+		},
+		{
+			name:    "malformed document",
+			data:    "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: a\n---\nmetadata: [\n",
+			wantErr: "m.yaml, document 2",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			objects, err := decodeManifests([]byte(tc.data), "m.yaml")
+			if tc.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Fatalf("err = %v, want %q", err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []string
+			for _, mo := range objects {
+				got = append(got, mo.obj.GetKind()+"/"+mo.obj.GetName()+strings.TrimPrefix(mo.source, "m.yaml"))
+			}
+			if !slices.Equal(got, tc.want) {
+				t.Errorf("objects = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+// TestSortManifestObjects checks that dependencies are applied first, kinds
+// missing from applyKindOrder last, and the order of equal kinds is kept.
+func TestSortManifestObjects(t *testing.T) {
+	var objects []manifestObject
+	for _, kn := range []string{"Widget/w", "Deployment/api", "ConfigMap/b", "Namespace/shop", "ConfigMap/a", "CustomResourceDefinition/widgets", "Service/api", "Gadget/g"} {
+		kind, name, _ := strings.Cut(kn, "/")
+		obj := &unstructured.Unstructured{}
+		obj.SetKind(kind)
+		obj.SetName(name)
+		objects = append(objects, manifestObject{obj: obj})
+	}
+
+	sortManifestObjects(objects)
+
+	var got []string
+	for _, mo := range objects {
+		got = append(got, mo.obj.GetKind()+"/"+mo.obj.GetName())
+	}
+	want := []string{"CustomResourceDefinition/widgets", "Namespace/shop", "ConfigMap/b", "ConfigMap/a", "Service/api", "Deployment/api", "Widget/w", "Gadget/g"}
+	if !slices.Equal(got, want) {
+		t.Errorf("order = %v, want %v", got, want)
+	}
+}
+
+func TestResolveManifestPaths(t *testing.T) {
+	root := t.TempDir()
+	outside := t.TempDir()
+	for _, file := range []string{"a.yaml", "b.yml", "notes.txt", "sub/c.json", "sub/deep/d.yaml", "empty/.keep"} {
+		writeTestFile(t, filepath.Join(root, file))
+	}
+	writeTestFile(t, filepath.Join(outside, "secret.yaml"))
+	links := filepath.Join(root, "links")
+	if err := os.Mkdir(links, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	for name, target := range map[string]string{
+		"escape.yaml": filepath.Join(outside, "secret.yaml"),
+		"dir":         outside,
+		"inside.yaml": filepath.Join(root, "a.yaml"),
+	} {
+		if err := os.Symlink(target, filepath.Join(links, name)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for _, tc := range []struct {
+		name    string
+		files   ManifestFiles
+		want    []string
+		wantErr string
+	}{
+		{name: "file", files: ManifestFiles{Path: "a.yaml"}, want: []string{"a.yaml"}},
+		{name: "absolute file in root", files: ManifestFiles{Path: filepath.Join(root, "sub", "c.json")}, want: []string{"sub/c.json"}},
+		{name: "directory", files: ManifestFiles{Path: "."}, want: []string{"a.yaml", "b.yml"}},
+		{name: "recursive directory", files: ManifestFiles{Path: "sub", Recursive: true}, want: []string{"sub/c.json", "sub/deep/d.yaml"}},
+		{name: "non-recursive directory", files: ManifestFiles{Path: "sub"}, want: []string{"sub/c.json"}},
+		{name: "glob", files: ManifestFiles{Path: "*.y*ml"}, want: []string{"a.yaml", "b.yml"}},
+		{name: "glob skips directories", files: ManifestFiles{Path: "*"}, want: []string{"a.yaml", "b.yml", "notes.txt"}},
+		{name: "symlink inside root", files: ManifestFiles{Path: "links/inside.yaml"}, want: []string{"a.yaml"}},
+		{name: "parent directory", files: ManifestFiles{Path: "../" + filepath.Base(outside) + "/secret.yaml"}, wantErr: "outside the manifest root"},
+		{name: "absolute file outside root", files: ManifestFiles{Path: filepath.Join(outside, "secret.yaml")}, wantErr: "outside the manifest root"},
+		{name: "symlink to file outside root", files: ManifestFiles{Path: "links/escape.yaml"}, wantErr: "outside the manifest root"},
+		{name: "symlink to directory outside root", files: ManifestFiles{Path: "links/dir"}, wantErr: "outside the manifest root"},
+		{name: "file under symlinked directory", files: ManifestFiles{Path: "links/dir/secret.yaml"}, wantErr: "outside the manifest root"},
+		{name: "glob through symlink", files: ManifestFiles{Path: "links/*.yaml"}, wantErr: "outside the manifest root"},
+		{name: "recursive directory with escaping symlink", files: ManifestFiles{Path: "links", Recursive: true}, wantErr: "outside the manifest root"},
+		{name: "no match", files: ManifestFiles{Path: "missing-*.yaml"}, wantErr: "no manifest files match"},
+		{name: "no manifests in directory", files: ManifestFiles{Path: "empty"}, wantErr: "no .yaml, .yml or .json files"},
+		{name: "no root", files: ManifestFiles{Path: "a.yaml"}, wantErr: "no manifest root is configured"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.name != "no root" {
+				tc.files.Root = root
+			}
+			files, err := resolveManifestPaths(tc.files)
+			if tc.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Fatalf("err = %v, want %q", err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []string
+			for _, f := range files {
+				got = append(got, f.name)
+			}
+			if !slices.Equal(got, tc.want) {
+				t.Errorf("files = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func writeTestFile(t *testing.T, name string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(name), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(name, []byte("apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: x\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,263 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/your-org/mcp-k8s-server/internal/domain"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Sort keys of TopNodes and TopPods.
+const (
+	TopSortCPU    = "cpu"
+	TopSortMemory = "memory"
+	TopSortName   = "name"
+)
+
+// TopSortKeys lists the sort keys of TopNodes and TopPods.
+var TopSortKeys = []string{TopSortCPU, TopSortMemory, TopSortName}
+
+// TopNodes returns the CPU and memory usage of every node from
+// metrics.k8s.io, with usage and pod requests as percentages of allocatable.
+// It fails with domain.ErrMetricsUnavailable when the metrics API is not
+// served.
+func (uc *K8sUseCase) TopNodes(ctx context.Context, clusterID, sortBy string) ([]domain.NodeUsage, error) {
+	client, err := uc.clusterManager.GetClusterClient(domain.ClusterID(clusterID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get client: %w", err)
+	}
+	metricsClient, err := uc.clusterManager.GetMetricsClient(domain.ClusterID(clusterID))
+	if err != nil {
+		return nil, err
+	}
+
+	metrics, err := metricsClient.MetricsV1beta1().NodeMetricses().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, metricsError(err)
+	}
+	nodes, err := client.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list nodes: %w", err)
+	}
+	allocatable := make(map[string]corev1.ResourceList, len(nodes.Items))
+	for _, n := range nodes.Items {
+		allocatable[n.Name] = n.Status.Allocatable
+	}
+
+	// Requests are summed over the pods that still hold their resources.
+	pods, err := client.CoreV1().Pods("").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods: %w", err)
+	}
+	requested := make(map[string]corev1.ResourceList)
+	podCount := make(map[string]int)
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		if pod.Spec.NodeName == "" || pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+		podCount[pod.Spec.NodeName]++
+		total := requested[pod.Spec.NodeName]
+		if total == nil {
+			total = corev1.ResourceList{}
+			requested[pod.Spec.NodeName] = total
+		}
+		for name, q := range podRequests(pod) {
+			sum := total[name]
+			sum.Add(q)
+			total[name] = sum
+		}
+	}
+
+	usages := make([]domain.NodeUsage, 0, len(metrics.Items))
+	for _, m := range metrics.Items {
+		cpu, memory := m.Usage.Cpu(), m.Usage.Memory()
+		alloc := allocatable[m.Name]
+		req := requested[m.Name]
+		usages = append(usages, domain.NodeUsage{
+			Name:                   m.Name,
+			CPU:                    formatCPU(cpu),
+			Memory:                 formatMemory(memory),
+			CPUMillicores:          cpu.MilliValue(),
+			MemoryBytes:            memory.Value(),
+			AllocatableCPU:         formatCPU(alloc.Cpu()),
+			AllocatableMemory:      formatMemory(alloc.Memory()),
+			CPUPercent:             percentOf(cpu.MilliValue(), alloc.Cpu().MilliValue()),
+			MemoryPercent:          percentOf(memory.Value(), alloc.Memory().Value()),
+			RequestedCPU:           formatCPU(req.Cpu()),
+			RequestedMemory:        formatMemory(req.Memory()),
+			RequestedCPUPercent:    percentOf(req.Cpu().MilliValue(), alloc.Cpu().MilliValue()),
+			RequestedMemoryPercent: percentOf(req.Memory().Value(), alloc.Memory().Value()),
+			Pods:                   podCount[m.Name],
+			Timestamp:              m.Timestamp.Time,
+		})
+	}
+	sortUsage(usages, sortBy, func(u domain.NodeUsage) (string, int64, int64) {
+		return u.Name, u.CPUMillicores, u.MemoryBytes
+	})
+	return usages, nil
+}
+
+// TopPods returns the CPU and memory usage of the pods in namespace (every
+// namespace when empty) matching labelSelector, per pod and per container,
+// with usage as a percentage of requests and limits. It fails with
+// domain.ErrMetricsUnavailable when the metrics API is not served.
+func (uc *K8sUseCase) TopPods(ctx context.Context, clusterID, namespace, labelSelector, sortBy string) ([]domain.PodUsage, error) {
+	client, err := uc.clusterManager.GetClusterClient(domain.ClusterID(clusterID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get client: %w", err)
+	}
+	metricsClient, err := uc.clusterManager.GetMetricsClient(domain.ClusterID(clusterID))
+	if err != nil {
+		return nil, err
+	}
+
+	opts := metav1.ListOptions{LabelSelector: labelSelector}
+	metrics, err := metricsClient.MetricsV1beta1().PodMetricses(namespace).List(ctx, opts)
+	if err != nil {
+		return nil, metricsError(err)
+	}
+	pods, err := client.CoreV1().Pods(namespace).List(ctx, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods: %w", err)
+	}
+	specs := make(map[string]*corev1.Pod, len(pods.Items))
+	for i := range pods.Items {
+		specs[pods.Items[i].Namespace+"/"+pods.Items[i].Name] = &pods.Items[i]
+	}
+
+	usages := make([]domain.PodUsage, 0, len(metrics.Items))
+	for _, m := range metrics.Items {
+		usage := domain.PodUsage{
+			Name:       m.Name,
+			Namespace:  m.Namespace,
+			Containers: []domain.ContainerUsage{},
+			Timestamp:  m.Timestamp.Time,
+		}
+		resources := make(map[string]corev1.ResourceRequirements)
+		var requests corev1.ResourceList
+		if pod := specs[m.Namespace+"/"+m.Name]; pod != nil {
+			usage.Node = pod.Spec.NodeName
+			for _, c := range pod.Spec.Containers {
+				resources[c.Name] = c.Resources
+			}
+			requests = podRequests(pod)
+		}
+
+		var cpuTotal, memoryTotal resource.Quantity
+		for _, c := range m.Containers {
+			cpu, memory := c.Usage.Cpu(), c.Usage.Memory()
+			cpuTotal.Add(*cpu)
+			memoryTotal.Add(*memory)
+			r := resources[c.Name]
+			cu := domain.ContainerUsage{
+				Name:                 c.Name,
+				CPU:                  formatCPU(cpu),
+				Memory:               formatMemory(memory),
+				CPUMillicores:        cpu.MilliValue(),
+				MemoryBytes:          memory.Value(),
+				CPURequestPercent:    percentOf(cpu.MilliValue(), r.Requests.Cpu().MilliValue()),
+				MemoryRequestPercent: percentOf(memory.Value(), r.Requests.Memory().Value()),
+				CPULimitPercent:      percentOf(cpu.MilliValue(), r.Limits.Cpu().MilliValue()),
+				MemoryLimitPercent:   percentOf(memory.Value(), r.Limits.Memory().Value()),
+			}
+			if q, ok := r.Requests[corev1.ResourceCPU]; ok {
+				cu.CPURequest = formatCPU(&q)
+			}
+			if q, ok := r.Requests[corev1.ResourceMemory]; ok {
+				cu.MemoryRequest = formatMemory(&q)
+			}
+			if q, ok := r.Limits[corev1.ResourceCPU]; ok {
+				cu.CPULimit = formatCPU(&q)
+			}
+			if q, ok := r.Limits[corev1.ResourceMemory]; ok {
+				cu.MemoryLimit = formatMemory(&q)
+			}
+			usage.Containers = append(usage.Containers, cu)
+		}
+		sort.Slice(usage.Containers, func(i, j int) bool { return usage.Containers[i].Name < usage.Containers[j].Name })
+
+		usage.CPU = formatCPU(&cpuTotal)
+		usage.Memory = formatMemory(&memoryTotal)
+		usage.CPUMillicores = cpuTotal.MilliValue()
+		usage.MemoryBytes = memoryTotal.Value()
+		if q, ok := requests[corev1.ResourceCPU]; ok {
+			usage.CPURequest = formatCPU(&q)
+			usage.CPURequestPercent = percentOf(usage.CPUMillicores, q.MilliValue())
+		}
+		if q, ok := requests[corev1.ResourceMemory]; ok {
+			usage.MemoryRequest = formatMemory(&q)
+			usage.MemoryRequestPercent = percentOf(usage.MemoryBytes, q.Value())
+		}
+		usages = append(usages, usage)
+	}
+	sortUsage(usages, sortBy, func(u domain.PodUsage) (string, int64, int64) {
+		return u.Namespace + "/" + u.Name, u.CPUMillicores, u.MemoryBytes
+	})
+	return usages, nil
+}
+
+// nodeUsage returns the current CPU and memory use of a node, or why it is
+// not known.
+func (uc *K8sUseCase) nodeUsage(ctx context.Context, clusterID, nodeName string) (map[string]string, error) {
+	metricsClient, err := uc.clusterManager.GetMetricsClient(domain.ClusterID(clusterID))
+	if err != nil {
+		return nil, err
+	}
+	m, err := metricsClient.MetricsV1beta1().NodeMetricses().Get(ctx, nodeName, metav1.GetOptions{})
+	if err != nil {
+		return nil, metricsError(err)
+	}
+	return map[string]string{
+		"cpu":    formatCPU(m.Usage.Cpu()),
+		"memory": formatMemory(m.Usage.Memory()),
+	}, nil
+}
+
+// metricsError maps the errors of a cluster without a working metrics API
+// to domain.ErrMetricsUnavailable.
+func metricsError(err error) error {
+	if apierrors.IsNotFound(err) || apierrors.IsServiceUnavailable(err) {
+		return fmt.Errorf("%w (%v)", domain.ErrMetricsUnavailable, err)
+	}
+	return fmt.Errorf("failed to read metrics: %w", err)
+}
+
+// sortUsage sorts by CPU or memory use, highest first, or by name.
+func sortUsage[T any](items []T, sortBy string, key func(T) (name string, cpu, memory int64)) {
+	sort.SliceStable(items, func(i, j int) bool {
+		ni, ci, mi := key(items[i])
+		nj, cj, mj := key(items[j])
+		switch {
+		case sortBy == TopSortMemory && mi != mj:
+			return mi > mj
+		case (sortBy == "" || sortBy == TopSortCPU) && ci != cj:
+			return ci > cj
+		}
+		return ni < nj
+	})
+}
+
+func formatCPU(q *resource.Quantity) string {
+	return fmt.Sprintf("%dm", q.MilliValue())
+}
+
+func formatMemory(q *resource.Quantity) string {
+	return fmt.Sprintf("%dMi", q.Value()/(1<<20))
+}
+
+// percentOf returns used as a percentage of total, rounded to one decimal,
+// or nil when total is zero.
+func percentOf(used, total int64) *float64 {
+	if total <= 0 {
+		return nil
+	}
+	p := math.Round(float64(used)/float64(total)*1000) / 10
+	return &p
+}
@@ -68,12 +68,17 @@ func (uc *K8sUseCase) GetNodeMetrics(ctx context.Context, clusterID, nodeName st
 		"pods":   node.Status.Allocatable.Pods().String(),
 	}
 
-	return domain.NodeMetrics{
+	metrics := domain.NodeMetrics{
 		NodeName:    domain.NodeName(nodeName),
 		Capacity:    capacity,
 		Allocatable: allocatable,
 		Labels:      node.Labels,
-	}, nil
+	}
+	// Usage needs metrics-server; without it the node's size is still useful.
+	if metrics.Usage, err = uc.nodeUsage(ctx, clusterID, nodeName); err != nil {
+		metrics.UsageError = err.Error()
+	}
+	return metrics, nil
 }
 
 // getInternalIP remains the same helper function.
@@ -0,0 +1,480 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/your-org/mcp-k8s-server/internal/domain"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// DefaultDiagnoseLogLines is how many lines of previous logs a diagnosis
+// reads per restarted container.
+const DefaultDiagnoseLogLines = 20
+
+// DiagnosePod gathers the container states, previous logs, events, node,
+// PVCs and quotas of a pod and lists the problems they show, each with its
+// likely causes. Parts that cannot be read are left out.
+func (uc *K8sUseCase) DiagnosePod(ctx context.Context, clusterID, namespace, podName string, logLines int64) (*domain.PodDiagnosis, error) {
+	client, err := uc.clusterManager.GetClusterClient(domain.ClusterID(clusterID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get client: %w", err)
+	}
+	pod, err := client.CoreV1().Pods(namespace).Get(ctx, podName, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pod: %w", err)
+	}
+
+	d := &domain.PodDiagnosis{
+		Pod:        pod.Name,
+		Namespace:  pod.Namespace,
+		Phase:      string(pod.Status.Phase),
+		Reason:     pod.Status.Reason,
+		Message:    pod.Status.Message,
+		Node:       pod.Spec.NodeName,
+		Conditions: []domain.ConditionStatus{},
+		Containers: []domain.ContainerDiagnosis{},
+		Events:     []domain.Event{},
+		Problems:   []domain.PodProblem{},
+	}
+	for _, c := range pod.Status.Conditions {
+		d.Conditions = append(d.Conditions, domain.ConditionStatus{Type: string(c.Type), Status: string(c.Status), Reason: c.Reason, Message: c.Message})
+	}
+	requests := podRequests(pod)
+	d.Requests = formatResourceList(requests)
+
+	// Events come first: several checks below look for them.
+	events, err := uc.ListEvents(ctx, clusterID, namespace, "Pod", podName)
+	if err != nil {
+		uc.logger.Warn("Failed to list pod events", "pod", podName, "error", err)
+	}
+	for _, e := range events {
+		if e.InvolvedKind == "Pod" && e.InvolvedName == podName {
+			d.Events = append(d.Events, e)
+		}
+	}
+	sort.SliceStable(d.Events, func(i, j int) bool { return d.Events[i].LastTimestamp.Before(d.Events[j].LastTimestamp) })
+
+	d.Containers = append(d.Containers, uc.diagnoseContainers(ctx, clusterID, pod, pod.Spec.InitContainers, pod.Status.InitContainerStatuses, true, logLines)...)
+	d.Containers = append(d.Containers, uc.diagnoseContainers(ctx, clusterID, pod, pod.Spec.Containers, pod.Status.ContainerStatuses, false, logLines)...)
+	for _, c := range d.Containers {
+		d.Problems = append(d.Problems, containerProblems(c, d.Events)...)
+	}
+	d.Problems = append(d.Problems, podProblems(pod, d)...)
+
+	if pod.Spec.NodeName != "" {
+		if node, err := client.CoreV1().Nodes().Get(ctx, pod.Spec.NodeName, metav1.GetOptions{}); err == nil {
+			for _, c := range node.Status.Conditions {
+				d.NodeConditions = append(d.NodeConditions, domain.ConditionStatus{Type: string(c.Type), Status: string(c.Status), Reason: c.Reason, Message: c.Message})
+			}
+			d.Problems = append(d.Problems, nodeProblems(node)...)
+		} else {
+			uc.logger.Warn("Failed to get pod node", "node", pod.Spec.NodeName, "error", err)
+		}
+		if metrics, err := uc.GetNodeMetrics(ctx, clusterID, pod.Spec.NodeName); err == nil {
+			d.NodeAllocatable = metrics.Allocatable
+		}
+	} else if unschedulable(pod) {
+		d.Problems = append(d.Problems, uc.schedulingProblem(ctx, clusterID, pod, requests))
+	}
+
+	claims, claimProblems := podClaims(ctx, client, pod)
+	d.Claims = claims
+	d.Problems = append(d.Problems, claimProblems...)
+
+	quotas, quotaProblems := namespaceQuotas(ctx, client, namespace)
+	d.Quotas = quotas
+	d.Problems = append(d.Problems, quotaProblems...)
+
+	sort.SliceStable(d.Problems, func(i, j int) bool {
+		return d.Problems[i].Severity == domain.ProblemSeverityCritical && d.Problems[j].Severity != domain.ProblemSeverityCritical
+	})
+	return d, nil
+}
+
+// diagnoseContainers describes containers with their statuses and reads the
+// previous logs of those that terminated before.
+func (uc *K8sUseCase) diagnoseContainers(ctx context.Context, clusterID string, pod *corev1.Pod, containers []corev1.Container, statuses []corev1.ContainerStatus, init bool, logLines int64) []domain.ContainerDiagnosis {
+	byName := make(map[string]corev1.ContainerStatus, len(statuses))
+	for _, s := range statuses {
+		byName[s.Name] = s
+	}
+
+	var out []domain.ContainerDiagnosis
+	for _, c := range containers {
+		cd := domain.ContainerDiagnosis{
+			Name:     c.Name,
+			Image:    c.Image,
+			Init:     init,
+			State:    "waiting",
+			Requests: formatResourceList(c.Resources.Requests),
+			Limits:   formatResourceList(c.Resources.Limits),
+		}
+		s, ok := byName[c.Name]
+		if ok {
+			cd.Ready = s.Ready
+			cd.RestartCount = s.RestartCount
+			switch {
+			case s.State.Running != nil:
+				cd.State = "running"
+			case s.State.Terminated != nil:
+				cd.State = "terminated"
+				cd.Reason = s.State.Terminated.Reason
+				cd.Message = s.State.Terminated.Message
+			case s.State.Waiting != nil:
+				cd.Reason = s.State.Waiting.Reason
+				cd.Message = s.State.Waiting.Message
+			}
+			if t := s.LastTerminationState.Terminated; t != nil {
+				cd.LastTermination = &domain.ContainerTermination{
+					Reason:     t.Reason,
+					ExitCode:   t.ExitCode,
+					Signal:     t.Signal,
+					Message:    t.Message,
+					FinishedAt: t.FinishedAt.Time,
+				}
+				logs, err := uc.GetPodLogs(ctx, clusterID, pod.Namespace, pod.Name, domain.LogOptions{Container: c.Name, Previous: true, TailLines: &logLines})
+				if err != nil {
+					cd.PreviousLogsError = err.Error()
+				} else {
+					cd.PreviousLogs = logs
+				}
+			}
+		}
+		out = append(out, cd)
+	}
+	return out
+}
+
+// containerProblems reads the problems off a container state.
+func containerProblems(c domain.ContainerDiagnosis, events []domain.Event) []domain.PodProblem {
+	var problems []domain.PodProblem
+	critical := func(reason, message string, causes ...string) {
+		problems = append(problems, domain.PodProblem{Severity: domain.ProblemSeverityCritical, Reason: reason, Container: c.Name, Message: message, LikelyCauses: causes})
+	}
+	warning := func(reason, message string, causes ...string) {
+		problems = append(problems, domain.PodProblem{Severity: domain.ProblemSeverityWarning, Reason: reason, Container: c.Name, Message: message, LikelyCauses: causes})
+	}
+
+	switch c.Reason {
+	case "CrashLoopBackOff":
+		message := fmt.Sprintf("Container keeps crashing (%d restarts)", c.RestartCount)
+		causes := []string{"The application exits on startup; see previous_logs"}
+		if t := c.LastTermination; t != nil {
+			message += fmt.Sprintf("; last exit code %d (%s)", t.ExitCode, cmpReason(t.Reason))
+			causes = exitCodeCauses(t, c.Limits["memory"])
+		}
+		if ev := findEvent(events, "Unhealthy", "Liveness probe failed"); ev != nil {
+			causes = append(causes, "The liveness probe fails and the kubelet restarts the container: "+ev.Message)
+		}
+		critical("CrashLoopBackOff", message, causes...)
+	case "ImagePullBackOff", "ErrImagePull", "InvalidImageName", "ErrImageNeverPull":
+		critical(c.Reason, fmt.Sprintf("Image %s cannot be pulled: %s", c.Image, cmpReason(c.Message)),
+			"The image name or tag does not exist in the registry",
+			"The registry is private and the pod has no matching imagePullSecrets",
+			"The node cannot reach the registry, or the registry rate-limits pulls")
+	case "CreateContainerConfigError":
+		critical(c.Reason, cmpReason(c.Message),
+			"A ConfigMap or Secret referenced by env, envFrom or a volume does not exist",
+			"A referenced key is missing from the ConfigMap or Secret")
+	case "CreateContainerError", "RunContainerError", "StartError":
+		critical(c.Reason, cmpReason(c.Message),
+			"The command or entrypoint does not exist in the image",
+			"A volume mount, security context or device setting is rejected by the runtime")
+	case "ContainerCreating", "PodInitializing":
+		if ev := findEvent(events, "FailedMount", ""); ev != nil {
+			critical("VolumeMountFailed", ev.Message,
+				"A volume's ConfigMap, Secret or PVC does not exist or is not bound",
+				"The volume is still attached to another node")
+		}
+	}
+
+	if c.LastTermination != nil && c.LastTermination.Reason == "OOMKilled" && c.Reason != "CrashLoopBackOff" {
+		warning("OOMKilled", fmt.Sprintf("The previous instance was killed for exceeding its memory limit (%s)", cmpReason(c.Limits["memory"])),
+			exitCodeCauses(c.LastTermination, c.Limits["memory"])...)
+	}
+	if c.State == "terminated" && !c.Init && c.Reason != "Completed" {
+		critical("ContainerTerminated", fmt.Sprintf("Container terminated with %s", cmpReason(c.Reason)), exitCodeCauses(&domain.ContainerTermination{Reason: c.Reason}, c.Limits["memory"])...)
+	}
+	if c.State == "running" && !c.Ready && !c.Init {
+		causes := []string{"The readiness probe fails, e.g. the application is still starting or a dependency is down"}
+		if ev := findEvent(events, "Unhealthy", "Readiness probe failed"); ev != nil {
+			causes = []string{"The readiness probe fails: " + ev.Message}
+		}
+		warning("NotReady", "Container is running but not ready; the pod receives no Service traffic", causes...)
+	}
+	if c.State == "running" && c.RestartCount > 0 && c.Reason == "" {
+		message := fmt.Sprintf("Container restarted %d time(s)", c.RestartCount)
+		causes := []string{"The container crashed or was killed earlier"}
+		if t := c.LastTermination; t != nil {
+			message += fmt.Sprintf("; last exit code %d (%s)", t.ExitCode, cmpReason(t.Reason))
+			causes = exitCodeCauses(t, c.Limits["memory"])
+		}
+		if c.LastTermination == nil || c.LastTermination.Reason != "OOMKilled" {
+			warning("Restarts", message, causes...)
+		}
+	}
+	return problems
+}
+
+// exitCodeCauses explains how a container ended.
+func exitCodeCauses(t *domain.ContainerTermination, memoryLimit string) []string {
+	switch {
+	case t.Reason == "OOMKilled":
+		if memoryLimit != "" {
+			return []string{fmt.Sprintf("The memory limit of %s is too low for the workload", memoryLimit), "The application leaks memory"}
+		}
+		return []string{"The node ran out of memory and the container has no memory limit", "The application leaks memory"}
+	case t.ExitCode == 0:
+		return []string{"The main process exits successfully, but the pod's restartPolicy expects a long-running process"}
+	case t.ExitCode == 126:
+		return []string{"The command is not executable (permissions or wrong architecture)"}
+	case t.ExitCode == 127:
+		return []string{"The command is not found in the image; check command and args"}
+	case t.ExitCode == 137:
+		return []string{"The container was killed (SIGKILL), e.g. after failed liveness probes or a graceful shutdown timeout"}
+	case t.ExitCode == 139:
+		return []string{"The application crashed with a segmentation fault"}
+	case t.ExitCode == 143:
+		return []string{"The container was stopped (SIGTERM), e.g. by a liveness probe failure or an eviction"}
+	}
+	return []string{"The application exited with an error; see previous_logs", "Configuration or a dependency it needs at startup is missing"}
+}
+
+// podProblems reads the problems off the pod status.
+func podProblems(pod *corev1.Pod, d *domain.PodDiagnosis) []domain.PodProblem {
+	var problems []domain.PodProblem
+	switch {
+	case pod.Status.Reason == "Evicted":
+		problems = append(problems, domain.PodProblem{
+			Severity:     domain.ProblemSeverityCritical,
+			Reason:       "Evicted",
+			Message:      pod.Status.Message,
+			LikelyCauses: []string{"The node ran low on memory, disk or PIDs and evicted the pod", "The pod uses more ephemeral storage than its limit"},
+		})
+	case pod.Status.Phase == corev1.PodFailed:
+		problems = append(problems, domain.PodProblem{
+			Severity:     domain.ProblemSeverityCritical,
+			Reason:       "Failed",
+			Message:      cmpReason(pod.Status.Message),
+			LikelyCauses: []string{"All containers terminated and at least one failed; see the container states"},
+		})
+	}
+	return problems
+}
+
+// unschedulable reports whether the scheduler could not place the pod.
+func unschedulable(pod *corev1.Pod) bool {
+	for _, c := range pod.Status.Conditions {
+		if c.Type == corev1.PodScheduled && c.Status == corev1.ConditionFalse {
+			return true
+		}
+	}
+	return pod.Status.Phase == corev1.PodPending
+}
+
+// schedulingProblem explains why a pod is not scheduled, comparing its
+// requests with the allocatable resources of the nodes.
+func (uc *K8sUseCase) schedulingProblem(ctx context.Context, clusterID string, pod *corev1.Pod, requests corev1.ResourceList) domain.PodProblem {
+	problem := domain.PodProblem{Severity: domain.ProblemSeverityCritical, Reason: "Unschedulable", Message: "The pod is not scheduled to a node"}
+	for _, c := range pod.Status.Conditions {
+		if c.Type == corev1.PodScheduled && c.Message != "" {
+			problem.Message = c.Message
+		}
+	}
+
+	msg := strings.ToLower(problem.Message)
+	if strings.Contains(msg, "insufficient") {
+		problem.LikelyCauses = append(problem.LikelyCauses, "No node has enough unreserved CPU or memory for the pod's requests")
+	}
+	if strings.Contains(msg, "node selector") || strings.Contains(msg, "affinity") {
+		problem.LikelyCauses = append(problem.LikelyCauses, "No node matches the pod's nodeSelector or node affinity")
+	}
+	if strings.Contains(msg, "taint") {
+		problem.LikelyCauses = append(problem.LikelyCauses, "The nodes have taints the pod does not tolerate")
+	}
+	if strings.Contains(msg, "persistentvolumeclaim") {
+		problem.LikelyCauses = append(problem.LikelyCauses, "A PersistentVolumeClaim of the pod is not bound")
+	}
+
+	if nodes, err := uc.ListNodes(ctx, clusterID); err == nil && len(nodes) > 0 {
+		largest := corev1.ResourceList{}
+		for _, n := range nodes {
+			metrics, err := uc.GetNodeMetrics(ctx, clusterID, string(n.Name))
+			if err != nil {
+				continue
+			}
+			for name, value := range metrics.Allocatable {
+				q, err := resource.ParseQuantity(value)
+				if err != nil {
+					continue
+				}
+				if cur, ok := largest[corev1.ResourceName(name)]; !ok || q.Cmp(cur) > 0 {
+					largest[corev1.ResourceName(name)] = q
+				}
+			}
+		}
+		names := make([]string, 0, len(requests))
+		for name := range requests {
+			names = append(names, string(name))
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			want := requests[corev1.ResourceName(name)]
+			if have, ok := largest[corev1.ResourceName(name)]; ok && want.Cmp(have) > 0 {
+				problem.LikelyCauses = append(problem.LikelyCauses,
+					fmt.Sprintf("The pod requests %s of %s, more than any node can allocate (largest: %s)", want.String(), name, have.String()))
+			}
+		}
+	}
+	if len(problem.LikelyCauses) == 0 {
+		problem.LikelyCauses = []string{"See the pod's FailedScheduling events"}
+	}
+	return problem
+}
+
+// nodeProblems reports a node that is not ready or under pressure.
+func nodeProblems(node *corev1.Node) []domain.PodProblem {
+	var problems []domain.PodProblem
+	for _, c := range node.Status.Conditions {
+		switch {
+		case c.Type == corev1.NodeReady && c.Status != corev1.ConditionTrue:
+			problems = append(problems, domain.PodProblem{
+				Severity:     domain.ProblemSeverityCritical,
+				Reason:       "NodeNotReady",
+				Message:      fmt.Sprintf("Node %s is not ready: %s", node.Name, cmpReason(c.Message)),
+				LikelyCauses: []string{"The kubelet stopped reporting, or the node lost network or shut down"},
+			})
+		case c.Type != corev1.NodeReady && c.Status == corev1.ConditionTrue:
+			problems = append(problems, domain.PodProblem{
+				Severity:     domain.ProblemSeverityWarning,
+				Reason:       string(c.Type),
+				Message:      fmt.Sprintf("Node %s reports %s: %s", node.Name, c.Type, cmpReason(c.Message)),
+				LikelyCauses: []string{"The node is short of resources and may evict pods"},
+			})
+		}
+	}
+	return problems
+}
+
+// podClaims reports the PVCs a pod mounts and those that are not bound.
+func podClaims(ctx context.Context, client kubernetes.Interface, pod *corev1.Pod) ([]domain.ClaimStatus, []domain.PodProblem) {
+	var (
+		claims   []domain.ClaimStatus
+		problems []domain.PodProblem
+	)
+	for _, v := range pod.Spec.Volumes {
+		if v.PersistentVolumeClaim == nil {
+			continue
+		}
+		name := v.PersistentVolumeClaim.ClaimName
+		pvc, err := client.CoreV1().PersistentVolumeClaims(pod.Namespace).Get(ctx, name, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			claims = append(claims, domain.ClaimStatus{Name: name, Phase: "Missing"})
+			problems = append(problems, domain.PodProblem{
+				Severity:     domain.ProblemSeverityCritical,
+				Reason:       "PVCMissing",
+				Message:      fmt.Sprintf("PersistentVolumeClaim %s does not exist", name),
+				LikelyCauses: []string{"The claim was deleted or never created; for a StatefulSet, check its volumeClaimTemplates"},
+			})
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		status := domain.ClaimStatus{Name: name, Phase: string(pvc.Status.Phase), Volume: pvc.Spec.VolumeName}
+		if pvc.Spec.StorageClassName != nil {
+			status.StorageClass = *pvc.Spec.StorageClassName
+		}
+		claims = append(claims, status)
+		if pvc.Status.Phase != corev1.ClaimBound {
+			problems = append(problems, domain.PodProblem{
+				Severity: domain.ProblemSeverityCritical,
+				Reason:   "PVCNotBound",
+				Message:  fmt.Sprintf("PersistentVolumeClaim %s is %s", name, pvc.Status.Phase),
+				LikelyCauses: []string{
+					fmt.Sprintf("The storage class %q cannot provision a volume, or does not exist", status.StorageClass),
+					"No PersistentVolume matches the claim's size, access modes or selector",
+					"The storage class binds on first consumer and the pod is not scheduled yet",
+				},
+			})
+		}
+	}
+	return claims, problems
+}
+
+// namespaceQuotas reports quota usage and the quotas that are used up.
+func namespaceQuotas(ctx context.Context, client kubernetes.Interface, namespace string) ([]domain.QuotaUsage, []domain.PodProblem) {
+	list, err := client.CoreV1().ResourceQuotas(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, nil
+	}
+	var (
+		usages   []domain.QuotaUsage
+		problems []domain.PodProblem
+	)
+	for _, q := range list.Items {
+		names := make([]string, 0, len(q.Status.Hard))
+		for name := range q.Status.Hard {
+			names = append(names, string(name))
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			hard := q.Status.Hard[corev1.ResourceName(name)]
+			used := q.Status.Used[corev1.ResourceName(name)]
+			usages = append(usages, domain.QuotaUsage{Quota: q.Name, Resource: name, Used: used.String(), Hard: hard.String()})
+			if used.Cmp(hard) >= 0 {
+				problems = append(problems, domain.PodProblem{
+					Severity:     domain.ProblemSeverityWarning,
+					Reason:       "QuotaExhausted",
+					Message:      fmt.Sprintf("ResourceQuota %s: %s used %s of %s", q.Name, name, used.String(), hard.String()),
+					LikelyCauses: []string{"New pods or restarts that need more of this resource are rejected until usage drops or the quota is raised"},
+				})
+			}
+		}
+	}
+	return usages, problems
+}
+
+// podRequests sums the requests of a pod's containers; init containers run
+// one at a time, so the largest of them counts when it is larger.
+func podRequests(pod *corev1.Pod) corev1.ResourceList {
+	total := corev1.ResourceList{}
+	for _, c := range pod.Spec.Containers {
+		for name, q := range c.Resources.Requests {
+			sum := total[name]
+			sum.Add(q)
+			total[name] = sum
+		}
+	}
+	for _, c := range pod.Spec.InitContainers {
+		for name, q := range c.Resources.Requests {
+			if cur, ok := total[name]; !ok || q.Cmp(cur) > 0 {
+				total[name] = q
+			}
+		}
+	}
+	return total
+}
+
+func findEvent(events []domain.Event, reason, messagePrefix string) *domain.Event {
+	for i := len(events) - 1; i >= 0; i-- {
+		if events[i].Reason == reason && strings.HasPrefix(events[i].Message, messagePrefix) {
+			return &events[i]
+		}
+	}
+	return nil
+}
+
+func cmpReason(s string) string {
+	if s == "" {
+		return "no reason given"
+	}
+	return s
+}
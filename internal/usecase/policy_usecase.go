@@ -0,0 +1,99 @@
+package usecase
+
+import (
+	"fmt"
+	"path"
+
+	"github.com/your-org/mcp-k8s-server/internal/domain"
+)
+
+// PolicyUseCase evaluates per-cluster / per-namespace rules for tool calls.
+type PolicyUseCase struct {
+	rules []domain.ToolPolicyRule
+}
+
+func NewPolicyUseCase(rules []domain.ToolPolicyRule) *PolicyUseCase {
+	return &PolicyUseCase{rules: rules}
+}
+
+// HasRules reports whether any rule is configured.
+func (uc *PolicyUseCase) HasRules() bool {
+	return len(uc.rules) > 0
+}
+
+// Evaluate applies the rules to req. A call touching several namespaces is
+// denied if any of them is denied, and a call on every namespace if any
+// namespace could be denied.
+func (uc *PolicyUseCase) Evaluate(req domain.PolicyRequest) domain.PolicyDecision {
+	namespaces := req.Namespaces
+	if req.AllNamespaces {
+		namespaces = []string{domain.PolicyAllNamespaces}
+	} else if len(namespaces) == 0 {
+		namespaces = []string{""}
+	}
+
+	for _, ns := range namespaces {
+		decision := uc.evaluate(req, ns)
+		if !decision.Allowed {
+			return decision
+		}
+	}
+	return domain.PolicyDecision{Allowed: true, Tool: req.Tool, ClusterID: req.ClusterID}
+}
+
+func (uc *PolicyUseCase) evaluate(req domain.PolicyRequest, namespace string) domain.PolicyDecision {
+	decision := domain.PolicyDecision{
+		Allowed:   true,
+		Tool:      req.Tool,
+		ClusterID: req.ClusterID,
+		Namespace: namespace,
+	}
+
+	for i, rule := range uc.rules {
+		if !ruleMatches(rule, req, namespace) {
+			continue
+		}
+		if rule.Effect == domain.PolicyEffectDeny {
+			decision.Allowed = false
+			decision.Rule = rule.Name
+			if decision.Rule == "" {
+				decision.Rule = fmt.Sprintf("rules[%d]", i)
+			}
+			decision.Reason = rule.Reason
+		}
+		return decision
+	}
+	return decision
+}
+
+func ruleMatches(rule domain.ToolPolicyRule, req domain.PolicyRequest, namespace string) bool {
+	if rule.Access != domain.PolicyAccessAny && !req.Write {
+		return false
+	}
+	if !globsMatch(rule.Tools, req.Tool) || !globsMatch(rule.Clusters, req.ClusterID) {
+		return false
+	}
+	// Every namespace includes those a deny rule names but not only those
+	// an allow rule names.
+	if namespace == domain.PolicyAllNamespaces {
+		return len(rule.Namespaces) == 0 || rule.Effect == domain.PolicyEffectDeny
+	}
+	return globsMatch(rule.Namespaces, namespace)
+}
+
+// globsMatch reports whether value matches one of patterns. An empty list
+// matches anything; an empty value only matches an empty list.
+func globsMatch(patterns []string, value string) bool {
+	if len(patterns) == 0 {
+		return true
+	}
+	if value == "" {
+		return false
+	}
+	for _, p := range patterns {
+		if ok, _ := path.Match(p, value); ok {
+			return true
+		}
+	}
+	return false
+}
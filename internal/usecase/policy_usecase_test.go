@@ -0,0 +1,119 @@
+package usecase
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/your-org/mcp-k8s-server/internal/domain"
+)
+
+func TestPolicyEvaluate(t *testing.T) {
+	rules := []domain.ToolPolicyRule{
+		{Name: "allow-scale-prod", Clusters: []string{"prod"}, Tools: []string{"k8s_deployment_scale"}, Effect: domain.PolicyEffectAllow},
+		{Name: "prod-read-only", Clusters: []string{"prod*"}, Effect: domain.PolicyEffectDeny, Reason: "prod is read-only"},
+		{Clusters: []string{"staging"}, Namespaces: []string{"kube-*"}, Access: domain.PolicyAccessAny, Effect: domain.PolicyEffectDeny},
+		{Tools: []string{"k8s_*_delete"}, Namespaces: []string{"payments"}, Effect: domain.PolicyEffectDeny},
+		{Name: "no-secrets", Tools: []string{"k8s_secret_get", "k8s_secret_list"}, Access: domain.PolicyAccessAny, Effect: domain.PolicyEffectDeny},
+	}
+	uc := NewPolicyUseCase(rules)
+
+	for _, tc := range []struct {
+		name     string
+		req      domain.PolicyRequest
+		wantRule string // empty when allowed
+	}{
+		{"earlier allow wins", domain.PolicyRequest{Tool: "k8s_deployment_scale", ClusterID: "prod", Namespaces: []string{"web"}, Write: true}, ""},
+		{"cluster glob denies writes", domain.PolicyRequest{Tool: "k8s_deployment_restart", ClusterID: "prod-eu", Namespaces: []string{"web"}, Write: true}, "prod-read-only"},
+		{"write rule ignores reads", domain.PolicyRequest{Tool: "k8s_pod_list", ClusterID: "prod", Namespaces: []string{"web"}}, ""},
+		{"allow only matches its cluster", domain.PolicyRequest{Tool: "k8s_deployment_scale", ClusterID: "prod-eu", Write: true}, "prod-read-only"},
+		{"any access denies reads", domain.PolicyRequest{Tool: "k8s_pod_list", ClusterID: "staging", Namespaces: []string{"kube-system"}}, "rules[2]"},
+		{"namespace glob does not match", domain.PolicyRequest{Tool: "k8s_pod_list", ClusterID: "staging", Namespaces: []string{"default"}}, ""},
+		{"namespace rule skips calls without namespace", domain.PolicyRequest{Tool: "k8s_node_list", ClusterID: "staging"}, ""},
+		{"tool glob", domain.PolicyRequest{Tool: "k8s_secret_delete", ClusterID: "dev", Namespaces: []string{"payments"}, Write: true}, "rules[3]"},
+		{"tool glob other namespace", domain.PolicyRequest{Tool: "k8s_secret_delete", ClusterID: "dev", Namespaces: []string{"web"}, Write: true}, ""},
+		{"tool list", domain.PolicyRequest{Tool: "k8s_secret_list", ClusterID: "dev"}, "no-secrets"},
+		{"tool glob ignores other tools", domain.PolicyRequest{Tool: "k8s_apply_yaml", ClusterID: "dev", Namespaces: []string{"web", "payments", "api"}, Write: true}, ""},
+		{"one of several namespaces", domain.PolicyRequest{Tool: "k8s_apply_yaml", ClusterID: "staging", Namespaces: []string{"web", "kube-public"}, Write: true}, "rules[2]"},
+		{"no matching rule", domain.PolicyRequest{Tool: "k8s_namespace_create", ClusterID: "dev", Write: true}, ""},
+		{"every namespace includes denied ones", domain.PolicyRequest{Tool: "k8s_resource_list", ClusterID: "staging", AllNamespaces: true}, "rules[2]"},
+		{"every namespace with a tool glob", domain.PolicyRequest{Tool: "k8s_resource_delete", ClusterID: "dev", AllNamespaces: true, Write: true}, "rules[3]"},
+		{"every namespace without namespace rules", domain.PolicyRequest{Tool: "k8s_resource_list", ClusterID: "dev", AllNamespaces: true}, ""},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got := uc.Evaluate(tc.req)
+			if got.Allowed != (tc.wantRule == "") || got.Rule != tc.wantRule {
+				t.Errorf("Evaluate(%+v) = allowed %v by %q, want rule %q", tc.req, got.Allowed, got.Rule, tc.wantRule)
+			}
+		})
+	}
+}
+
+func TestPolicyEvaluateDecision(t *testing.T) {
+	uc := NewPolicyUseCase([]domain.ToolPolicyRule{{
+		Name: "protect-kube-system", Namespaces: []string{"kube-system"}, Effect: domain.PolicyEffectDeny, Reason: "system namespace",
+	}})
+
+	got := uc.Evaluate(domain.PolicyRequest{Tool: "k8s_apply_yaml", ClusterID: "dev", Namespaces: []string{"web", "kube-system"}, Write: true})
+	want := domain.PolicyDecision{Tool: "k8s_apply_yaml", ClusterID: "dev", Namespace: "kube-system", Rule: "protect-kube-system", Reason: "system namespace"}
+	if got != want {
+		t.Errorf("decision = %+v, want %+v", got, want)
+	}
+	got = uc.Evaluate(domain.PolicyRequest{Tool: "k8s_resource_delete", ClusterID: "dev", AllNamespaces: true, Write: true})
+	want = domain.PolicyDecision{Tool: "k8s_resource_delete", ClusterID: "dev", Namespace: domain.PolicyAllNamespaces, Rule: "protect-kube-system", Reason: "system namespace"}
+	if got != want {
+		t.Errorf("decision on every namespace = %+v, want %+v", got, want)
+	}
+	if NewPolicyUseCase(nil).HasRules() {
+		t.Error("HasRules without rules")
+	}
+}
+
+// TestPolicyEvaluateAllNamespaces checks that an allow rule limited to some
+// namespaces does not let a call on every namespace past later deny rules.
+func TestPolicyEvaluateAllNamespaces(t *testing.T) {
+	uc := NewPolicyUseCase([]domain.ToolPolicyRule{
+		{Name: "allow-web", Namespaces: []string{"web"}, Access: domain.PolicyAccessAny, Effect: domain.PolicyEffectAllow},
+		{Name: "deny-all", Access: domain.PolicyAccessAny, Effect: domain.PolicyEffectDeny},
+	})
+
+	if got := uc.Evaluate(domain.PolicyRequest{Tool: "k8s_resource_list", Namespaces: []string{"web"}}); !got.Allowed {
+		t.Errorf("call in web denied by %q", got.Rule)
+	}
+	if got := uc.Evaluate(domain.PolicyRequest{Tool: "k8s_resource_list", Namespaces: []string{"web"}, AllNamespaces: true}); got.Allowed || got.Rule != "deny-all" {
+		t.Errorf("call on every namespace = allowed %v by %q, want denied by deny-all", got.Allowed, got.Rule)
+	}
+}
+
+func TestManifestNamespaces(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		yaml string
+		want []string
+	}{
+		{"explicit namespace", "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: a\n  namespace: web\n", []string{"web"}},
+		{"no namespace counts as default", "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: a\n", []string{"default"}},
+		{"cluster-scoped object counts as default", "apiVersion: rbac.authorization.k8s.io/v1\nkind: ClusterRole\nmetadata:\n  name: reader\n", []string{"default"}},
+		{"namespace object is its own namespace", "apiVersion: v1\nkind: Namespace\nmetadata:\n  name: payments\n", []string{"payments"}},
+		{
+			"several documents",
+			"apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: a\n  namespace: web\n---\napiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: b\n---\napiVersion: v1\nkind: Secret\nmetadata:\n  name: c\n  namespace: web\n",
+			[]string{"web", "default"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ManifestNamespaces(tc.yaml, ManifestFiles{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			slices.Sort(got)
+			want := slices.Sorted(slices.Values(tc.want))
+			if !slices.Equal(got, want) {
+				t.Errorf("namespaces = %v, want %v", got, want)
+			}
+		})
+	}
+
+	if _, err := ManifestNamespaces("kind: ConfigMap\nmetadata: [", ManifestFiles{}); err == nil {
+		t.Error("invalid manifest: no error")
+	}
+}
@@ -0,0 +1,282 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/your-org/mcp-k8s-server/internal/domain"
+	"github.com/your-org/mcp-k8s-server/internal/infrastructure"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/portforward"
+	"k8s.io/client-go/transport/spdy"
+)
+
+// portForwardReadyTimeout bounds how long StartPortForward waits for the
+// local listener.
+const portForwardReadyTimeout = 10 * time.Second
+
+// StartPortForward opens a tunnel from a local port to a pod, or to a ready
+// pod backing a Service or Deployment, and registers it with the cluster
+// manager until it is stopped or expires.
+func (uc *K8sUseCase) StartPortForward(ctx context.Context, req domain.PortForwardRequest) (*domain.PortForwardSession, error) {
+	if req.LocalPort < 0 || req.LocalPort > 65535 {
+		return nil, fmt.Errorf("invalid local_port %d", req.LocalPort)
+	}
+	client, err := uc.clusterManager.GetClusterClient(domain.ClusterID(req.ClusterID))
+	if err != nil {
+		return nil, err
+	}
+	pod, podPort, err := resolvePortForwardTarget(ctx, client, req)
+	if err != nil {
+		return nil, err
+	}
+	restConfig, err := uc.clusterManager.GetRESTConfig(domain.ClusterID(req.ClusterID))
+	if err != nil {
+		return nil, err
+	}
+
+	transport, upgrader, err := spdy.RoundTripperFor(restConfig)
+	if err != nil {
+		return nil, err
+	}
+	url := client.CoreV1().RESTClient().Post().
+		Resource("pods").Namespace(pod.Namespace).Name(pod.Name).SubResource("portforward").URL()
+
+	tunnel := infrastructure.NewTunnel()
+	dialer := tunnel.WrapDialer(spdy.NewDialer(upgrader, &http.Client{Transport: transport}, http.MethodPost, url))
+	readyChan := make(chan struct{})
+	// Nothing may be written to stdout: it carries JSON-RPC on the stdio
+	// transport.
+	pf, err := portforward.New(dialer, []string{fmt.Sprintf("%d:%d", req.LocalPort, podPort)}, tunnel.StopChan(), readyChan, io.Discard, os.Stderr)
+	if err != nil {
+		return nil, err
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		err := pf.ForwardPorts()
+		if err != nil {
+			uc.logger.Warn("Port-forward ended", "namespace", pod.Namespace, "pod", pod.Name, "error", err)
+		}
+		tunnel.Stop()
+		uc.clusterManager.RemoveTunnel(tunnel)
+		done <- err
+	}()
+
+	select {
+	case <-readyChan:
+	case err := <-done:
+		if err == nil {
+			err = errors.New("tunnel closed")
+		}
+		return nil, fmt.Errorf("failed to port-forward to pod %s: %w", pod.Name, err)
+	case <-time.After(portForwardReadyTimeout):
+		tunnel.Stop()
+		return nil, fmt.Errorf("timeout waiting for port-forward to pod %s to be ready", pod.Name)
+	case <-ctx.Done():
+		tunnel.Stop()
+		return nil, ctx.Err()
+	}
+
+	ports, err := pf.GetPorts()
+	if err != nil || len(ports) == 0 {
+		tunnel.Stop()
+		return nil, fmt.Errorf("failed to read forwarded ports: %w", err)
+	}
+	localPort := int(ports[0].Local)
+
+	session, err := uc.clusterManager.SaveTunnel(tunnel, domain.PortForwardSession{
+		ID:         fmt.Sprintf("%s/%s/%s/%d:%d", req.ClusterID, pod.Namespace, pod.Name, localPort, podPort),
+		ClusterID:  req.ClusterID,
+		Namespace:  pod.Namespace,
+		TargetKind: req.TargetKind,
+		TargetName: req.TargetName,
+		PodName:    pod.Name,
+		LocalPort:  localPort,
+		RemotePort: req.RemotePort,
+		PodPort:    podPort,
+		URL:        fmt.Sprintf("http://localhost:%d", localPort),
+		StartedAt:  time.Now().UTC(),
+	}, req.TTL)
+	if err != nil {
+		tunnel.Stop()
+		return nil, err
+	}
+	uc.logger.Info("Started port-forward", "id", session.ID)
+	return &session, nil
+}
+
+// StopPortForward closes the tunnel with the given ID or, without an ID, every
+// tunnel to the target of req. A zero RemotePort matches any port.
+func (uc *K8sUseCase) StopPortForward(id string, req domain.PortForwardRequest) ([]domain.PortForwardSession, error) {
+	if id == "" && req.TargetName == "" {
+		return nil, errors.New("id or a target name is required to stop a port-forward")
+	}
+
+	stopped := uc.clusterManager.StopTunnels(func(s domain.PortForwardSession) bool {
+		if id != "" {
+			return s.ID == id
+		}
+		if s.ClusterID != req.ClusterID || s.Namespace != req.Namespace {
+			return false
+		}
+		if req.RemotePort != 0 && s.RemotePort != req.RemotePort {
+			return false
+		}
+		if s.TargetKind == req.TargetKind && s.TargetName == req.TargetName {
+			return true
+		}
+		// A pod can be named directly even if it was reached through a
+		// Service or Deployment.
+		return req.TargetKind == domain.PortForwardTargetPod && s.PodName == req.TargetName
+	})
+	if len(stopped) == 0 {
+		if id != "" {
+			return nil, fmt.Errorf("port-forward not found: %s", id)
+		}
+		return nil, fmt.Errorf("no active port-forward to %s %s/%s", req.TargetKind, req.Namespace, req.TargetName)
+	}
+	return stopped, nil
+}
+
+// ListPortForwards returns the open tunnels, optionally only those of one
+// cluster.
+func (uc *K8sUseCase) ListPortForwards(clusterID string) []domain.PortForwardSession {
+	sessions := []domain.PortForwardSession{}
+	for _, s := range uc.clusterManager.ListTunnels() {
+		if clusterID == "" || s.ClusterID == clusterID {
+			sessions = append(sessions, s)
+		}
+	}
+	return sessions
+}
+
+// resolvePortForwardTarget returns the pod to forward to and the container
+// port that req.RemotePort maps to.
+func resolvePortForwardTarget(ctx context.Context, client kubernetes.Interface, req domain.PortForwardRequest) (*corev1.Pod, int, error) {
+	switch req.TargetKind {
+	case domain.PortForwardTargetPod:
+		if req.RemotePort <= 0 {
+			return nil, 0, errors.New("remote_port is required")
+		}
+		pod, err := client.CoreV1().Pods(req.Namespace).Get(ctx, req.TargetName, metav1.GetOptions{})
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to get pod: %w", err)
+		}
+		if pod.Status.Phase != corev1.PodRunning {
+			return nil, 0, fmt.Errorf("pod %s is %s, not Running", pod.Name, pod.Status.Phase)
+		}
+		return pod, req.RemotePort, nil
+
+	case domain.PortForwardTargetDeployment:
+		if req.RemotePort <= 0 {
+			return nil, 0, errors.New("remote_port is required")
+		}
+		deploy, err := client.AppsV1().Deployments(req.Namespace).Get(ctx, req.TargetName, metav1.GetOptions{})
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to get deployment: %w", err)
+		}
+		selector, err := metav1.LabelSelectorAsSelector(deploy.Spec.Selector)
+		if err != nil {
+			return nil, 0, fmt.Errorf("invalid selector on deployment %s: %w", deploy.Name, err)
+		}
+		pod, err := readyPod(ctx, client, req.Namespace, selector)
+		if err != nil {
+			return nil, 0, fmt.Errorf("deployment %s: %w", deploy.Name, err)
+		}
+		return pod, req.RemotePort, nil
+
+	case domain.PortForwardTargetService:
+		svc, err := client.CoreV1().Services(req.Namespace).Get(ctx, req.TargetName, metav1.GetOptions{})
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to get service: %w", err)
+		}
+		if len(svc.Spec.Selector) == 0 {
+			return nil, 0, fmt.Errorf("service %s has no selector", svc.Name)
+		}
+		svcPort, err := servicePort(svc, req.RemotePort)
+		if err != nil {
+			return nil, 0, err
+		}
+		pod, err := readyPod(ctx, client, req.Namespace, labels.SelectorFromSet(svc.Spec.Selector))
+		if err != nil {
+			return nil, 0, fmt.Errorf("service %s: %w", svc.Name, err)
+		}
+		podPort, err := containerPort(pod, svcPort)
+		if err != nil {
+			return nil, 0, fmt.Errorf("service %s: %w", svc.Name, err)
+		}
+		return pod, podPort, nil
+	}
+	return nil, 0, fmt.Errorf("unsupported port-forward target kind %q", req.TargetKind)
+}
+
+// readyPod picks the first running, ready pod matching selector by name.
+func readyPod(ctx context.Context, client kubernetes.Interface, namespace string, selector labels.Selector) (*corev1.Pod, error) {
+	pods, err := client.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods: %w", err)
+	}
+	sort.Slice(pods.Items, func(i, j int) bool { return pods.Items[i].Name < pods.Items[j].Name })
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		if pod.DeletionTimestamp == nil && pod.Status.Phase == corev1.PodRunning && isPodReady(pod) {
+			return pod, nil
+		}
+	}
+	return nil, fmt.Errorf("no ready pod matches selector %s", selector)
+}
+
+func isPodReady(pod *corev1.Pod) bool {
+	for _, c := range pod.Status.Conditions {
+		if c.Type == corev1.PodReady {
+			return c.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// servicePort finds the service port numbered port; zero picks the only
+// port of a single-port service.
+func servicePort(svc *corev1.Service, port int) (corev1.ServicePort, error) {
+	if port == 0 {
+		if len(svc.Spec.Ports) == 1 {
+			return svc.Spec.Ports[0], nil
+		}
+		return corev1.ServicePort{}, fmt.Errorf("service %s has %d ports; remote_port is required", svc.Name, len(svc.Spec.Ports))
+	}
+	for _, p := range svc.Spec.Ports {
+		if int(p.Port) == port {
+			return p, nil
+		}
+	}
+	return corev1.ServicePort{}, fmt.Errorf("service %s has no port %d", svc.Name, port)
+}
+
+// containerPort maps the target port of a service port to a port of pod.
+func containerPort(pod *corev1.Pod, svcPort corev1.ServicePort) (int, error) {
+	target := svcPort.TargetPort
+	if target.StrVal == "" {
+		if target.IntVal == 0 {
+			return int(svcPort.Port), nil
+		}
+		return int(target.IntVal), nil
+	}
+	for _, c := range pod.Spec.Containers {
+		for _, p := range c.Ports {
+			if p.Name == target.StrVal {
+				return int(p.ContainerPort), nil
+			}
+		}
+	}
+	return 0, fmt.Errorf("pod %s has no container port named %q", pod.Name, target.StrVal)
+}
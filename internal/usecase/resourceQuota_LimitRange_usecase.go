@@ -9,7 +9,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
-func (uc *K8sUseCase) ListResourceQuotas(ctx context.Context, clusterID, namespace string) ([]map[string]any, error) {
+func (uc *K8sUseCase) ListResourceQuotas(ctx context.Context, clusterID, namespace string) ([]domain.ResourceQuotaSummary, error) {
 	client, err := uc.clusterManager.GetClusterClient(domain.ClusterID(clusterID))
 	if err != nil {
 		return nil, fmt.Errorf("failed to get client: %w", err)
@@ -20,20 +20,20 @@ func (uc *K8sUseCase) ListResourceQuotas(ctx context.Context, clusterID, namespa
 		return nil, fmt.Errorf("failed to list resource quotas in namespace %s: %w", namespace, err)
 	}
 
-	quotas := make([]map[string]any, 0, len(quotaList.Items))
+	quotas := make([]domain.ResourceQuotaSummary, 0, len(quotaList.Items))
 	for _, quota := range quotaList.Items {
-		quotas = append(quotas, map[string]any{
-			"name":      quota.Name,
-			"namespace": namespace,
-			"status":    formatResourceQuotaStatus(quota.Status),
-			"age":       quota.CreationTimestamp.Time.Format("2006-01-02 15:04:05"),
+		quotas = append(quotas, domain.ResourceQuotaSummary{
+			Name:      quota.Name,
+			Namespace: namespace,
+			Status:    formatResourceQuotaStatus(quota.Status),
+			Age:       quota.CreationTimestamp.Time.Format("2006-01-02 15:04:05"),
 		})
 	}
 
 	return quotas, nil
 }
 
-func (uc *K8sUseCase) GetResourceQuotaDetail(ctx context.Context, clusterID, namespace, name string) (map[string]any, error) {
+func (uc *K8sUseCase) GetResourceQuotaDetail(ctx context.Context, clusterID, namespace, name string) (*domain.ResourceQuotaDetail, error) {
 	client, err := uc.clusterManager.GetClusterClient(domain.ClusterID(clusterID))
 	if err != nil {
 		return nil, fmt.Errorf("failed to get client: %w", err)
@@ -44,18 +44,18 @@ func (uc *K8sUseCase) GetResourceQuotaDetail(ctx context.Context, clusterID, nam
 		return nil, fmt.Errorf("failed to get resource quota %s/%s: %w", namespace, name, err)
 	}
 
-	return map[string]any{
-		"name":        quota.Name,
-		"namespace":   namespace,
-		"hard_limits": formatResourceList(quota.Spec.Hard),
-		"used":        formatResourceList(quota.Status.Used),
-		"allowed":     formatResourceList(quota.Status.Hard),
-		"age":         quota.CreationTimestamp.Time.Format("2006-01-02 15:04:05"),
-		"raw_object":  quota,
+	return &domain.ResourceQuotaDetail{
+		Name:       quota.Name,
+		Namespace:  namespace,
+		HardLimits: formatResourceList(quota.Spec.Hard),
+		Used:       formatResourceList(quota.Status.Used),
+		Allowed:    formatResourceList(quota.Status.Hard),
+		Age:        quota.CreationTimestamp.Time.Format("2006-01-02 15:04:05"),
+		RawObject:  quota,
 	}, nil
 }
 
-func (uc *K8sUseCase) ListLimitRanges(ctx context.Context, clusterID, namespace string) ([]map[string]any, error) {
+func (uc *K8sUseCase) ListLimitRanges(ctx context.Context, clusterID, namespace string) ([]domain.LimitRangeSummary, error) {
 	client, err := uc.clusterManager.GetClusterClient(domain.ClusterID(clusterID))
 	if err != nil {
 		return nil, fmt.Errorf("failed to get client: %w", err)
@@ -66,20 +66,20 @@ func (uc *K8sUseCase) ListLimitRanges(ctx context.Context, clusterID, namespace
 		return nil, fmt.Errorf("failed to list limit ranges in namespace %s: %w", namespace, err)
 	}
 
-	ranges := make([]map[string]any, 0, len(limitRangeList.Items))
+	ranges := make([]domain.LimitRangeSummary, 0, len(limitRangeList.Items))
 	for _, lr := range limitRangeList.Items {
-		ranges = append(ranges, map[string]any{
-			"name":         lr.Name,
-			"namespace":    namespace,
-			"limits_count": len(lr.Spec.Limits),
-			"age":          lr.CreationTimestamp.Time.Format("2006-01-02 15:04:05"),
+		ranges = append(ranges, domain.LimitRangeSummary{
+			Name:        lr.Name,
+			Namespace:   namespace,
+			LimitsCount: len(lr.Spec.Limits),
+			Age:         lr.CreationTimestamp.Time.Format("2006-01-02 15:04:05"),
 		})
 	}
 
 	return ranges, nil
 }
 
-func (uc *K8sUseCase) GetLimitRangeDetail(ctx context.Context, clusterID, namespace, name string) (map[string]any, error) {
+func (uc *K8sUseCase) GetLimitRangeDetail(ctx context.Context, clusterID, namespace, name string) (*domain.LimitRangeDetail, error) {
 	client, err := uc.clusterManager.GetClusterClient(domain.ClusterID(clusterID))
 	if err != nil {
 		return nil, fmt.Errorf("failed to get client: %w", err)
@@ -90,23 +90,23 @@ func (uc *K8sUseCase) GetLimitRangeDetail(ctx context.Context, clusterID, namesp
 		return nil, fmt.Errorf("failed to get limit range %s/%s: %w", namespace, name, err)
 	}
 
-	formattedLimits := []map[string]any{}
+	formattedLimits := []domain.LimitRangeItem{}
 	for _, limit := range lr.Spec.Limits {
-		formattedLimits = append(formattedLimits, map[string]any{
-			"type":                    limit.Type,
-			"max":                     formatResourceList(limit.Max),
-			"min":                     formatResourceList(limit.Min),
-			"default":                 formatResourceList(limit.Default),
-			"default_request":         formatResourceList(limit.DefaultRequest),
-			"max_limit_request_ratio": formatResourceList(limit.MaxLimitRequestRatio),
+		formattedLimits = append(formattedLimits, domain.LimitRangeItem{
+			Type:                 string(limit.Type),
+			Max:                  formatResourceList(limit.Max),
+			Min:                  formatResourceList(limit.Min),
+			Default:              formatResourceList(limit.Default),
+			DefaultRequest:       formatResourceList(limit.DefaultRequest),
+			MaxLimitRequestRatio: formatResourceList(limit.MaxLimitRequestRatio),
 		})
 	}
 
-	return map[string]any{
-		"name":       lr.Name,
-		"namespace":  namespace,
-		"limits":     formattedLimits,
-		"raw_object": lr,
+	return &domain.LimitRangeDetail{
+		Name:      lr.Name,
+		Namespace: namespace,
+		Limits:    formattedLimits,
+		RawObject: lr,
 	}, nil
 }
 
@@ -0,0 +1,335 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/your-org/mcp-k8s-server/internal/domain"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/restmapper"
+)
+
+const (
+	// DefaultResourceListLimit and MaxResourceListLimit bound the page size
+	// of ListResources.
+	DefaultResourceListLimit = 100
+	MaxResourceListLimit     = 500
+)
+
+// Propagation policies of DeleteResource.
+var DeletePropagationPolicies = []string{"background", "foreground", "orphan"}
+
+// deletionImpactKinds maps the kinds DeletionImpact knows to its kind names.
+var deletionImpactKinds = map[schema.GroupKind]string{
+	{Group: "", Kind: "Namespace"}:                          "namespace",
+	{Group: "apps", Kind: "StatefulSet"}:                    "statefulset",
+	{Group: "apps", Kind: "DaemonSet"}:                      "daemonset",
+	{Group: "batch", Kind: "Job"}:                           "job",
+	{Group: "batch", Kind: "CronJob"}:                       "cronjob",
+	{Group: "", Kind: "ConfigMap"}:                          "configmap",
+	{Group: "", Kind: "Secret"}:                             "secret",
+	{Group: "", Kind: "Service"}:                            "service",
+	{Group: "networking.k8s.io", Kind: "Ingress"}:           "ingress",
+	{Group: "autoscaling", Kind: "HorizontalPodAutoscaler"}: "hpa",
+}
+
+// resourceClient is the dynamic client of a resolved resource type.
+type resourceClient struct {
+	dynamic.NamespaceableResourceInterface
+	mapping *meta.RESTMapping
+}
+
+func (rc resourceClient) resourceType() domain.ResourceType {
+	return domain.ResourceType{
+		APIVersion: rc.mapping.GroupVersionKind.GroupVersion().String(),
+		Kind:       rc.mapping.GroupVersionKind.Kind,
+		Resource:   rc.mapping.Resource.Resource,
+		Namespaced: rc.mapping.Scope.Name() == meta.RESTScopeNameNamespace,
+	}
+}
+
+// in returns the client for namespace, ignoring it for cluster-scoped
+// types. An empty namespace lists every namespace.
+func (rc resourceClient) in(namespace string) dynamic.ResourceInterface {
+	if rc.mapping.Scope.Name() != meta.RESTScopeNameNamespace {
+		return rc.NamespaceableResourceInterface
+	}
+	return rc.Namespace(namespace)
+}
+
+// resourceClientFor resolves kind, which may be a kind, a plural or
+// singular resource name or a short name, optionally qualified with a group
+// ("certificates.cert-manager.io"), through API discovery. apiVersion pins
+// the group and version. Kinds from CRDs created after the discovery cache
+// was filled are found by refreshing it once.
+func (uc *K8sUseCase) resourceClientFor(clusterID, kind, apiVersion string) (resourceClient, error) {
+	if kind == "" {
+		return resourceClient{}, fmt.Errorf("kind is required")
+	}
+	dynClient, mapper, err := uc.newDynamicClients(clusterID)
+	if err != nil {
+		return resourceClient{}, err
+	}
+	discClient, err := uc.clusterManager.GetDiscoveryClient(domain.ClusterID(clusterID))
+	if err != nil {
+		return resourceClient{}, err
+	}
+	expander := restmapper.NewShortcutExpander(mapper, discClient, func(warning string) {
+		uc.logger.Debug("Resource shortcut warning", "warning", warning)
+	})
+
+	gvr := schema.GroupVersionResource{Resource: strings.ToLower(kind)}
+	if apiVersion != "" {
+		gv, err := schema.ParseGroupVersion(apiVersion)
+		if err != nil {
+			return resourceClient{}, fmt.Errorf("invalid api_version %q: %w", apiVersion, err)
+		}
+		gvr.Group, gvr.Version = gv.Group, gv.Version
+	} else if resource, group, ok := strings.Cut(gvr.Resource, "."); ok {
+		gvr.Resource, gvr.Group = resource, group
+	}
+
+	gvk, err := expander.KindFor(gvr)
+	if meta.IsNoMatchError(err) {
+		mapper.Reset()
+		gvk, err = expander.KindFor(gvr)
+	}
+	if err != nil {
+		return resourceClient{}, fmt.Errorf("unknown resource type %q: %w", kind, err)
+	}
+	mapping, err := mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if err != nil {
+		return resourceClient{}, fmt.Errorf("failed to map %s: %w", gvk.String(), err)
+	}
+	return resourceClient{NamespaceableResourceInterface: dynClient.Resource(mapping.Resource), mapping: mapping}, nil
+}
+
+// ResolveResourceType resolves kind like the other resource methods do and
+// returns its type, telling namespaced kinds from cluster-scoped ones.
+func (uc *K8sUseCase) ResolveResourceType(clusterID, kind, apiVersion string) (domain.ResourceType, error) {
+	rc, err := uc.resourceClientFor(clusterID, kind, apiVersion)
+	if err != nil {
+		return domain.ResourceType{}, err
+	}
+	return rc.resourceType(), nil
+}
+
+// GetResource returns an object of any resource type without its managed
+// fields. The values of Secrets are redacted.
+func (uc *K8sUseCase) GetResource(ctx context.Context, clusterID, kind, apiVersion, namespace, name string) (*domain.ResourceObject, error) {
+	rc, err := uc.resourceClientFor(clusterID, kind, apiVersion)
+	if err != nil {
+		return nil, err
+	}
+	if namespace == "" && rc.resourceType().Namespaced {
+		return nil, fmt.Errorf("%s is namespaced: a namespace is required", rc.mapping.GroupVersionKind.Kind)
+	}
+	obj, err := rc.in(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get %s %q: %w", rc.mapping.Resource.Resource, name, err)
+	}
+
+	unstructured.RemoveNestedField(obj.Object, "metadata", "managedFields")
+	if rc.mapping.GroupVersionKind.GroupKind() == (schema.GroupKind{Kind: "Secret"}) {
+		redactSecretObject(obj)
+	}
+	return &domain.ResourceObject{
+		ResourceType: rc.resourceType(),
+		Name:         obj.GetName(),
+		Namespace:    obj.GetNamespace(),
+		Object:       obj.Object,
+	}, nil
+}
+
+// ListResources lists one page of the objects of any resource type. An
+// empty namespace lists every namespace.
+func (uc *K8sUseCase) ListResources(ctx context.Context, clusterID string, req domain.ResourceListRequest) (*domain.ResourceList, error) {
+	rc, err := uc.resourceClientFor(clusterID, req.Kind, req.APIVersion)
+	if err != nil {
+		return nil, err
+	}
+	if req.Limit <= 0 {
+		req.Limit = DefaultResourceListLimit
+	}
+
+	list, err := rc.in(req.Namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: req.LabelSelector,
+		FieldSelector: req.FieldSelector,
+		Limit:         req.Limit,
+		Continue:      req.Continue,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list %s: %w", rc.mapping.Resource.Resource, err)
+	}
+
+	result := &domain.ResourceList{
+		ResourceType:  rc.resourceType(),
+		LabelSelector: req.LabelSelector,
+		FieldSelector: req.FieldSelector,
+		Items:         make([]domain.ResourceSummary, 0, len(list.Items)),
+		Continue:      list.GetContinue(),
+	}
+	if result.Namespaced {
+		result.Namespace = req.Namespace
+	}
+	for i := range list.Items {
+		obj := &list.Items[i]
+		result.Items = append(result.Items, domain.ResourceSummary{
+			Name:      obj.GetName(),
+			Namespace: obj.GetNamespace(),
+			Status:    resourceStatus(obj),
+			Labels:    obj.GetLabels(),
+			CreatedAt: obj.GetCreationTimestamp().Time,
+		})
+	}
+	return result, nil
+}
+
+// DeleteResource deletes an object of any resource type with the given
+// propagation policy (background by default). With dryRun set the request
+// is validated by the API server but nothing is deleted.
+func (uc *K8sUseCase) DeleteResource(ctx context.Context, clusterID, kind, apiVersion, namespace, name, propagation string, dryRun bool) (*domain.ResourceDeleteResult, error) {
+	rc, err := uc.resourceClientFor(clusterID, kind, apiVersion)
+	if err != nil {
+		return nil, err
+	}
+	if propagation == "" {
+		propagation = "background"
+	}
+	var policy metav1.DeletionPropagation
+	switch propagation {
+	case "background":
+		policy = metav1.DeletePropagationBackground
+	case "foreground":
+		policy = metav1.DeletePropagationForeground
+	case "orphan":
+		policy = metav1.DeletePropagationOrphan
+	default:
+		return nil, fmt.Errorf("invalid propagation %q (want background, foreground or orphan)", propagation)
+	}
+
+	ri := rc.in(namespace)
+	current, err := ri.Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get %s %q: %w", rc.mapping.Resource.Resource, name, err)
+	}
+	opts := metav1.DeleteOptions{PropagationPolicy: &policy}
+	if dryRun {
+		opts.DryRun = []string{metav1.DryRunAll}
+	}
+	if err := ri.Delete(ctx, name, opts); err != nil {
+		return nil, fmt.Errorf("failed to delete %s %q: %w", rc.mapping.Resource.Resource, name, err)
+	}
+
+	result := &domain.ResourceDeleteResult{
+		ResourceType:    rc.resourceType(),
+		Name:            name,
+		Propagation:     propagation,
+		DryRun:          dryRun,
+		ResourceVersion: current.GetResourceVersion(),
+	}
+	if result.Namespaced {
+		result.Namespace = namespace
+	}
+	if !dryRun {
+		uc.logger.Info("Deleted resource", "kind", result.Kind, "namespace", result.Namespace, "name", name)
+	}
+	return result, nil
+}
+
+// ResourceDeletionImpact previews the deletion of an object of any resource
+// type. Kinds DeletionImpact knows get its preview; others report whether
+// the object exists and the finalizers that will run.
+func (uc *K8sUseCase) ResourceDeletionImpact(ctx context.Context, clusterID, kind, apiVersion, namespace, name string) (*domain.ImpactPreview, error) {
+	rc, err := uc.resourceClientFor(clusterID, kind, apiVersion)
+	if err != nil {
+		return nil, err
+	}
+	if known, ok := deletionImpactKinds[rc.mapping.GroupVersionKind.GroupKind()]; ok {
+		if known == "namespace" {
+			namespace = name
+		}
+		return uc.DeletionImpact(ctx, clusterID, known, namespace, name)
+	}
+
+	rt := rc.resourceType()
+	preview := &domain.ImpactPreview{
+		Operation: fmt.Sprintf("delete %s %s", rt.Kind, name),
+		ClusterID: clusterID,
+		Kind:      rt.Kind,
+		Name:      name,
+		Affected:  map[string]int{},
+	}
+	if rt.Namespaced {
+		preview.Namespace = namespace
+	}
+
+	obj, err := rc.in(namespace).Get(ctx, name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		preview.Warnings = append(preview.Warnings, fmt.Sprintf("%s %q was not found.", rt.Kind, name))
+		return preview, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get %s: %w", rt.Resource, err)
+	}
+	preview.Exists = true
+
+	if finalizers := obj.GetFinalizers(); len(finalizers) > 0 {
+		preview.Warnings = append(preview.Warnings, fmt.Sprintf("Finalizers %s run before the object is removed; their controllers may delete external resources.", strings.Join(finalizers, ", ")))
+	}
+	switch rc.mapping.GroupVersionKind.GroupKind() {
+	case schema.GroupKind{Kind: "PersistentVolumeClaim"}:
+		preview.Warnings = append(preview.Warnings, "The data of the bound volume is deleted if its reclaim policy is Delete.")
+	case schema.GroupKind{Kind: "Pod"}:
+		preview.Warnings = append(preview.Warnings, "A pod owned by a controller is replaced; a bare pod is gone for good.")
+	case schema.GroupKind{Group: "apiextensions.k8s.io", Kind: "CustomResourceDefinition"}:
+		preview.Warnings = append(preview.Warnings, "Every custom resource of this type is deleted with it.")
+	}
+	return preview, nil
+}
+
+// resourceStatus summarizes the status of an object from status.phase or
+// a Ready condition.
+func resourceStatus(obj *unstructured.Unstructured) string {
+	if phase, _, _ := unstructured.NestedString(obj.Object, "status", "phase"); phase != "" {
+		return phase
+	}
+	conditions, _, _ := unstructured.NestedSlice(obj.Object, "status", "conditions")
+	for _, c := range conditions {
+		condition, ok := c.(map[string]any)
+		if !ok || condition["type"] != "Ready" {
+			continue
+		}
+		if condition["status"] == "True" {
+			return "Ready"
+		}
+		return "NotReady"
+	}
+	return ""
+}
+
+// redactSecretObject hides the values of a Secret, including the copy
+// kubectl keeps in the last-applied-configuration annotation.
+func redactSecretObject(obj *unstructured.Unstructured) {
+	for _, field := range []string{"data", "stringData"} {
+		values, ok, _ := unstructured.NestedMap(obj.Object, field)
+		if !ok {
+			continue
+		}
+		for k := range values {
+			values[k] = redactedValue
+		}
+		_ = unstructured.SetNestedMap(obj.Object, values, field)
+	}
+	annotations := obj.GetAnnotations()
+	if _, ok := annotations["kubectl.kubernetes.io/last-applied-configuration"]; ok {
+		annotations["kubectl.kubernetes.io/last-applied-configuration"] = redactedValue
+		obj.SetAnnotations(annotations)
+	}
+}
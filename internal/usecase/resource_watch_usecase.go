@@ -0,0 +1,128 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/your-org/mcp-k8s-server/internal/domain"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/dynamic"
+)
+
+// resourceWatchRetryInterval is how long WatchResource waits before
+// watching again after a watch failed.
+const resourceWatchRetryInterval = 5 * time.Second
+
+// WatchResource watches one object of any resource type, which need not
+// exist yet, and sends its creation, changes and deletion on the returned
+// channel until ctx is cancelled; the channel is then closed. Watches the
+// API server ends are resumed from the last version seen. When that version
+// has expired the watch starts over, which reports the object as added.
+func (uc *K8sUseCase) WatchResource(ctx context.Context, clusterID, kind, namespace, name string) (<-chan domain.ObjectChange, error) {
+	rc, err := uc.resourceClientFor(clusterID, kind, "")
+	if err != nil {
+		return nil, err
+	}
+	if namespace == "" && rc.resourceType().Namespaced {
+		return nil, fmt.Errorf("%s is namespaced: a namespace is required", rc.mapping.GroupVersionKind.Kind)
+	}
+	client := rc.in(namespace)
+	list, err := client.List(ctx, metav1.ListOptions{FieldSelector: fields.OneTermEqualSelector("metadata.name", name).String()})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list %s: %w", rc.mapping.Resource.Resource, err)
+	}
+	// The first watch starts before returning, so changes made once the
+	// call returns are not missed.
+	resourceVersion := list.GetResourceVersion()
+	w, err := watchObject(ctx, client, name, resourceVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := make(chan domain.ObjectChange)
+	go func() {
+		defer close(changes)
+		for {
+			var err error
+			resourceVersion, err = forwardObjectChanges(ctx, w, name, resourceVersion, changes)
+			// A watch the server ended is resumed at once, a failed one
+			// after resourceWatchRetryInterval.
+			for ctx.Err() == nil {
+				if err != nil {
+					uc.logger.Warn("Resource watch failed", "cluster", clusterID, "resource", rc.mapping.Resource.Resource, "namespace", namespace, "name", name, "error", err)
+					if apierrors.IsResourceExpired(err) || apierrors.IsGone(err) {
+						resourceVersion = ""
+					}
+					select {
+					case <-ctx.Done():
+						return
+					case <-time.After(resourceWatchRetryInterval):
+					}
+				}
+				if w, err = watchObject(ctx, client, name, resourceVersion); err == nil {
+					break
+				}
+			}
+			if ctx.Err() != nil {
+				return
+			}
+		}
+	}()
+	return changes, nil
+}
+
+// watchObject starts watching the object name from resourceVersion.
+func watchObject(ctx context.Context, client dynamic.ResourceInterface, name, resourceVersion string) (watch.Interface, error) {
+	w, err := client.Watch(ctx, metav1.ListOptions{
+		FieldSelector:       fields.OneTermEqualSelector("metadata.name", name).String(),
+		ResourceVersion:     resourceVersion,
+		AllowWatchBookmarks: true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to watch %s: %w", name, err)
+	}
+	return w, nil
+}
+
+// forwardObjectChanges sends the changes of the object name seen by w until
+// the watch ends, fails or ctx is cancelled, then stops w and returns the
+// last version seen.
+func forwardObjectChanges(ctx context.Context, w watch.Interface, name, resourceVersion string, changes chan<- domain.ObjectChange) (string, error) {
+	defer w.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return resourceVersion, nil
+		case e, ok := <-w.ResultChan():
+			if !ok {
+				return resourceVersion, nil
+			}
+			if e.Type == watch.Error {
+				return resourceVersion, apierrors.FromObject(e.Object)
+			}
+			obj, err := meta.Accessor(e.Object)
+			if err != nil {
+				continue
+			}
+			resourceVersion = obj.GetResourceVersion()
+			// Field selectors are not honored by every server, so the name
+			// is checked again.
+			if e.Type == watch.Bookmark || obj.GetName() != name {
+				continue
+			}
+			change := domain.ObjectChange{Type: strings.ToLower(string(e.Type)), ResourceVersion: resourceVersion}
+			select {
+			case changes <- change:
+			case <-ctx.Done():
+				return resourceVersion, nil
+			}
+		}
+	}
+}
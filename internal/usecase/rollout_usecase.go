@@ -0,0 +1,351 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/your-org/mcp-k8s-server/internal/domain"
+	appsv1 "k8s.io/api/apps/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+const (
+	// restartedAtAnnotation is the pod template annotation set by
+	// "kubectl rollout restart".
+	restartedAtAnnotation = "kubectl.kubernetes.io/restartedAt"
+	revisionAnnotation    = "deployment.kubernetes.io/revision"
+	changeCauseAnnotation = "kubernetes.io/change-cause"
+
+	rolloutPollInterval = 2 * time.Second
+)
+
+// restartWorkload rolls the pods of a deployment, statefulset or daemonset
+// by stamping the pod template with the restart time, like
+// "kubectl rollout restart".
+func (uc *K8sUseCase) restartWorkload(ctx context.Context, clusterID, kind, namespace, name string) error {
+	if kind == domain.WorkloadKindCronJob {
+		return fmt.Errorf("cannot restart kind %q", kind)
+	}
+	client, err := uc.clusterManager.GetClusterClient(domain.ClusterID(clusterID))
+	if err != nil {
+		return fmt.Errorf("failed to get client: %w", err)
+	}
+
+	patch, err := json.Marshal(podTemplatePatch(kind, map[string]any{
+		"metadata": map[string]any{
+			"annotations": map[string]any{restartedAtAnnotation: time.Now().Format(time.RFC3339)},
+		},
+	}))
+	if err != nil {
+		return fmt.Errorf("failed to build patch: %w", err)
+	}
+	if _, err := patchWorkload(ctx, client, kind, namespace, name, patch); err != nil {
+		return fmt.Errorf("failed to restart %s: %w", kind, err)
+	}
+	return nil
+}
+
+// ==================== Deployment Methods ====================
+
+func (uc *K8sUseCase) ListDeployments(ctx context.Context, clusterID, namespace string) ([]domain.DeploymentSummary, error) {
+	client, err := uc.clusterManager.GetClusterClient(domain.ClusterID(clusterID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get client: %w", err)
+	}
+
+	deploymentList, err := client.AppsV1().Deployments(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list deployments: %w", err)
+	}
+
+	deployments := make([]domain.DeploymentSummary, 0, len(deploymentList.Items))
+	for _, d := range deploymentList.Items {
+		replicas := int32(0)
+		if d.Spec.Replicas != nil {
+			replicas = *d.Spec.Replicas
+		}
+
+		deployments = append(deployments, domain.DeploymentSummary{
+			Name:              d.Name,
+			Namespace:         d.Namespace,
+			Replicas:          replicas,
+			ReadyReplicas:     d.Status.ReadyReplicas,
+			UpdatedReplicas:   d.Status.UpdatedReplicas,
+			AvailableReplicas: d.Status.AvailableReplicas,
+			Revision:          d.Annotations[revisionAnnotation],
+			Paused:            d.Spec.Paused,
+			Created:           d.CreationTimestamp.Format("2006-01-02 15:04:05"),
+		})
+	}
+
+	return deployments, nil
+}
+
+func (uc *K8sUseCase) RestartDeployment(ctx context.Context, clusterID, namespace, deploymentName string) error {
+	return uc.restartWorkload(ctx, clusterID, domain.WorkloadKindDeployment, namespace, deploymentName)
+}
+
+// SetDeploymentPaused pauses or resumes the rollouts of a deployment.
+func (uc *K8sUseCase) SetDeploymentPaused(ctx context.Context, clusterID, namespace, deploymentName string, paused bool) error {
+	client, err := uc.clusterManager.GetClusterClient(domain.ClusterID(clusterID))
+	if err != nil {
+		return fmt.Errorf("failed to get client: %w", err)
+	}
+
+	patch := fmt.Appendf(nil, `{"spec":{"paused":%t}}`, paused)
+	_, err = client.AppsV1().Deployments(namespace).Patch(ctx, deploymentName, types.StrategicMergePatchType, patch, metav1.PatchOptions{})
+	if err != nil {
+		verb := "resume"
+		if paused {
+			verb = "pause"
+		}
+		return fmt.Errorf("failed to %s deployment: %w", verb, err)
+	}
+	return nil
+}
+
+// DeploymentRolloutStatus waits up to timeout for the rollout of a
+// deployment to complete. It returns early when the rollout is stuck or the
+// deployment is paused. A zero timeout reports the current state.
+func (uc *K8sUseCase) DeploymentRolloutStatus(ctx context.Context, clusterID, namespace, deploymentName string, timeout time.Duration) (*domain.RolloutStatus, error) {
+	client, err := uc.clusterManager.GetClusterClient(domain.ClusterID(clusterID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get client: %w", err)
+	}
+
+	var status *domain.RolloutStatus
+	check := func(ctx context.Context) (bool, error) {
+		deployment, err := client.AppsV1().Deployments(namespace).Get(ctx, deploymentName, metav1.GetOptions{})
+		if err != nil {
+			return false, fmt.Errorf("failed to get deployment: %w", err)
+		}
+		status = rolloutStatus(deployment)
+		return status.Complete || status.Stuck || status.Paused, nil
+	}
+
+	if timeout <= 0 {
+		if _, err := check(ctx); err != nil {
+			return nil, err
+		}
+		return status, nil
+	}
+
+	err = wait.PollUntilContextTimeout(ctx, rolloutPollInterval, timeout, true, check)
+	if err != nil {
+		if status == nil || !wait.Interrupted(err) {
+			return nil, err
+		}
+		status.TimedOut = true
+		status.Message = fmt.Sprintf("timed out after %s: %s", timeout, status.Message)
+	}
+	return status, nil
+}
+
+// rolloutStatus evaluates a deployment the way "kubectl rollout status" does.
+func rolloutStatus(d *appsv1.Deployment) *domain.RolloutStatus {
+	replicas := int32(1)
+	if d.Spec.Replicas != nil {
+		replicas = *d.Spec.Replicas
+	}
+
+	status := &domain.RolloutStatus{
+		Name:               d.Name,
+		Namespace:          d.Namespace,
+		Revision:           d.Annotations[revisionAnnotation],
+		Generation:         d.Generation,
+		ObservedGeneration: d.Status.ObservedGeneration,
+		Replicas:           replicas,
+		UpdatedReplicas:    d.Status.UpdatedReplicas,
+		ReadyReplicas:      d.Status.ReadyReplicas,
+		AvailableReplicas:  d.Status.AvailableReplicas,
+		Paused:             d.Spec.Paused,
+	}
+	for _, c := range d.Status.Conditions {
+		status.Conditions = append(status.Conditions, domain.DeploymentCondition{
+			Type:    string(c.Type),
+			Status:  string(c.Status),
+			Reason:  c.Reason,
+			Message: c.Message,
+		})
+		if c.Type == appsv1.DeploymentProgressing && c.Reason == "ProgressDeadlineExceeded" {
+			status.Stuck = true
+			status.Message = fmt.Sprintf("deployment %q exceeded its progress deadline: %s", d.Name, c.Message)
+		}
+	}
+
+	switch {
+	case status.Stuck:
+	case d.Generation > d.Status.ObservedGeneration:
+		status.Message = "waiting for the deployment spec update to be observed"
+	case d.Status.UpdatedReplicas < replicas:
+		status.Message = fmt.Sprintf("waiting for rollout to finish: %d of %d new replicas have been updated", d.Status.UpdatedReplicas, replicas)
+	case d.Status.Replicas > d.Status.UpdatedReplicas:
+		status.Message = fmt.Sprintf("waiting for rollout to finish: %d old replicas are pending termination", d.Status.Replicas-d.Status.UpdatedReplicas)
+	case d.Status.AvailableReplicas < d.Status.UpdatedReplicas:
+		status.Message = fmt.Sprintf("waiting for rollout to finish: %d of %d updated replicas are available", d.Status.AvailableReplicas, d.Status.UpdatedReplicas)
+	default:
+		status.Complete = true
+		status.Message = fmt.Sprintf("deployment %q successfully rolled out", d.Name)
+	}
+	if status.Paused && !status.Complete {
+		status.Message = "deployment is paused: " + status.Message
+	}
+	return status
+}
+
+// DeploymentHistory returns the rollout history of a deployment, oldest
+// revision first, from the ReplicaSets it owns.
+func (uc *K8sUseCase) DeploymentHistory(ctx context.Context, clusterID, namespace, deploymentName string) ([]domain.DeploymentRevision, error) {
+	_, replicaSets, err := uc.deploymentReplicaSets(ctx, clusterID, namespace, deploymentName)
+	if err != nil {
+		return nil, err
+	}
+	return deploymentRevisions(replicaSets), nil
+}
+
+// UndoDeployment rolls a deployment back to toRevision, or to the previous
+// revision when toRevision is 0. changed is false when the deployment
+// already runs the pod template of that revision.
+func (uc *K8sUseCase) UndoDeployment(ctx context.Context, clusterID, namespace, deploymentName string, toRevision int64) (revision *domain.DeploymentRevision, changed bool, err error) {
+	deployment, replicaSets, err := uc.deploymentReplicaSets(ctx, clusterID, namespace, deploymentName)
+	if err != nil {
+		return nil, false, err
+	}
+	if deployment.Spec.Paused {
+		return nil, false, errors.New("cannot roll back a paused deployment; resume it first")
+	}
+
+	revisions := deploymentRevisions(replicaSets)
+	target := -1
+	for i := len(revisions) - 1; i >= 0; i-- {
+		r := revisions[i]
+		if (toRevision == 0 && !r.Current) || (toRevision != 0 && r.Revision == toRevision) {
+			target = i
+			break
+		}
+	}
+	if target < 0 {
+		if toRevision == 0 {
+			return nil, false, errors.New("no previous revision to roll back to")
+		}
+		return nil, false, fmt.Errorf("revision %d not found", toRevision)
+	}
+	revision = &revisions[target]
+
+	var rs *appsv1.ReplicaSet
+	for i := range replicaSets {
+		if replicaSets[i].Name == revision.ReplicaSet {
+			rs = &replicaSets[i]
+		}
+	}
+
+	template := rs.Spec.Template.DeepCopy()
+	delete(template.Labels, appsv1.DefaultDeploymentUniqueLabelKey)
+	if apiequality.Semantic.DeepEqual(template, &deployment.Spec.Template) {
+		return revision, false, nil
+	}
+
+	deployment.Spec.Template = *template
+	if cause, ok := rs.Annotations[changeCauseAnnotation]; ok {
+		if deployment.Annotations == nil {
+			deployment.Annotations = make(map[string]string)
+		}
+		deployment.Annotations[changeCauseAnnotation] = cause
+	}
+
+	client, err := uc.clusterManager.GetClusterClient(domain.ClusterID(clusterID))
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to get client: %w", err)
+	}
+	if _, err := client.AppsV1().Deployments(namespace).Update(ctx, deployment, metav1.UpdateOptions{}); err != nil {
+		return nil, false, fmt.Errorf("failed to roll back deployment: %w", err)
+	}
+	return revision, true, nil
+}
+
+// deploymentReplicaSets returns a deployment and the ReplicaSets it controls.
+func (uc *K8sUseCase) deploymentReplicaSets(ctx context.Context, clusterID, namespace, deploymentName string) (*appsv1.Deployment, []appsv1.ReplicaSet, error) {
+	client, err := uc.clusterManager.GetClusterClient(domain.ClusterID(clusterID))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get client: %w", err)
+	}
+
+	deployment, err := client.AppsV1().Deployments(namespace).Get(ctx, deploymentName, metav1.GetOptions{})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get deployment: %w", err)
+	}
+
+	owned, err := controlledReplicaSets(ctx, client, namespace, deployment)
+	if err != nil {
+		return nil, nil, err
+	}
+	return deployment, owned, nil
+}
+
+// deploymentRevisions converts ReplicaSets to history entries sorted by
+// revision, with the image changes between consecutive revisions. The
+// highest revision is the current one.
+func deploymentRevisions(replicaSets []appsv1.ReplicaSet) []domain.DeploymentRevision {
+	revisions := make([]domain.DeploymentRevision, 0, len(replicaSets))
+	for _, rs := range replicaSets {
+		revision, err := strconv.ParseInt(rs.Annotations[revisionAnnotation], 10, 64)
+		if err != nil {
+			continue
+		}
+		images := make(map[string]string, len(rs.Spec.Template.Spec.Containers))
+		for _, c := range rs.Spec.Template.Spec.Containers {
+			images[c.Name] = c.Image
+		}
+		replicas := int32(0)
+		if rs.Spec.Replicas != nil {
+			replicas = *rs.Spec.Replicas
+		}
+		revisions = append(revisions, domain.DeploymentRevision{
+			Revision:    revision,
+			ReplicaSet:  rs.Name,
+			ChangeCause: rs.Annotations[changeCauseAnnotation],
+			Images:      images,
+			Replicas:    replicas,
+			CreatedAt:   rs.CreationTimestamp.Time,
+		})
+	}
+	sort.Slice(revisions, func(i, j int) bool { return revisions[i].Revision < revisions[j].Revision })
+
+	for i := range revisions {
+		if i > 0 {
+			revisions[i].ImageChanges = imageChanges(revisions[i-1].Images, revisions[i].Images)
+		}
+	}
+	if len(revisions) > 0 {
+		revisions[len(revisions)-1].Current = true
+	}
+	return revisions
+}
+
+func imageChanges(before, after map[string]string) []string {
+	var changes []string
+	for name, image := range after {
+		old, ok := before[name]
+		switch {
+		case !ok:
+			changes = append(changes, fmt.Sprintf("%s: added %s", name, image))
+		case old != image:
+			changes = append(changes, fmt.Sprintf("%s: %s -> %s", name, old, image))
+		}
+	}
+	for name, image := range before {
+		if _, ok := after[name]; !ok {
+			changes = append(changes, fmt.Sprintf("%s: removed %s", name, image))
+		}
+	}
+	sort.Strings(changes)
+	return changes
+}
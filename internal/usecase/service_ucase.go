@@ -7,9 +7,10 @@ import (
 	"github.com/your-org/mcp-k8s-server/internal/domain"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
 )
 
-func (uc *K8sUseCase) ListServices(ctx context.Context, clusterID, namespace string) ([]map[string]any, error) {
+func (uc *K8sUseCase) ListServices(ctx context.Context, clusterID, namespace string) ([]domain.ServiceSummary, error) {
 	client, err := uc.clusterManager.GetClusterClient(domain.ClusterID(clusterID))
 	if err != nil {
 		return nil, fmt.Errorf("failed to get client: %w", err)
@@ -20,20 +21,20 @@ func (uc *K8sUseCase) ListServices(ctx context.Context, clusterID, namespace str
 		return nil, fmt.Errorf("failed to list services: %w", err)
 	}
 
-	services := make([]map[string]any, 0, len(serviceList.Items))
+	services := make([]domain.ServiceSummary, 0, len(serviceList.Items))
 	for _, svc := range serviceList.Items {
-		services = append(services, map[string]any{
-			"name":       svc.Name,
-			"namespace":  svc.Namespace,
-			"type":       string(svc.Spec.Type),
-			"cluster_ip": svc.Spec.ClusterIP,
+		services = append(services, domain.ServiceSummary{
+			Name:      svc.Name,
+			Namespace: svc.Namespace,
+			Type:      string(svc.Spec.Type),
+			ClusterIP: svc.Spec.ClusterIP,
 		})
 	}
 
 	return services, nil
 }
 
-func (uc *K8sUseCase) GetService(ctx context.Context, clusterID, namespace, serviceName string) (map[string]any, error) {
+func (uc *K8sUseCase) GetService(ctx context.Context, clusterID, namespace, serviceName string) (*domain.Service, error) {
 	client, err := uc.clusterManager.GetClusterClient(domain.ClusterID(clusterID))
 	if err != nil {
 		return nil, fmt.Errorf("failed to get client: %w", err)
@@ -44,23 +45,23 @@ func (uc *K8sUseCase) GetService(ctx context.Context, clusterID, namespace, serv
 		return nil, fmt.Errorf("failed to get service: %w", err)
 	}
 
-	ports := make([]map[string]any, 0, len(svc.Spec.Ports))
+	ports := make([]domain.ServicePort, 0, len(svc.Spec.Ports))
 	for _, port := range svc.Spec.Ports {
-		ports = append(ports, map[string]any{
-			"name":        port.Name,
-			"port":        port.Port,
-			"target_port": port.TargetPort.IntValue(),
-			"node_port":   port.NodePort,
+		ports = append(ports, domain.ServicePort{
+			Name:       port.Name,
+			Port:       port.Port,
+			TargetPort: int32(port.TargetPort.IntValue()),
+			NodePort:   port.NodePort,
 		})
 	}
 
-	return map[string]any{
-		"name":       svc.Name,
-		"namespace":  svc.Namespace,
-		"type":       string(svc.Spec.Type),
-		"cluster_ip": svc.Spec.ClusterIP,
-		"ports":      ports,
-		"labels":     svc.Labels,
+	return &domain.Service{
+		Name:      domain.ServiceName(svc.Name),
+		Namespace: domain.Namespace(svc.Namespace),
+		Type:      domain.ServiceType(svc.Spec.Type),
+		ClusterIP: svc.Spec.ClusterIP,
+		Ports:     ports,
+		Labels:    svc.Labels,
 	}, nil
 }
 func (uc *K8sUseCase) DeleteService(ctx context.Context, clusterID, namespace, serviceName string) error {
@@ -84,7 +85,7 @@ func (uc *K8sUseCase) DeleteService(ctx context.Context, clusterID, namespace, s
 // --- Ingress Implementation ---
 
 // ListIngresses liệt kê tất cả các Ingress trong một namespace.
-func (uc *K8sUseCase) ListIngresses(ctx context.Context, clusterID, namespace string) ([]map[string]any, error) {
+func (uc *K8sUseCase) ListIngresses(ctx context.Context, clusterID, namespace string) ([]domain.IngressSummary, error) {
 	client, err := uc.clusterManager.GetClusterClient(domain.ClusterID(clusterID))
 	if err != nil {
 		return nil, fmt.Errorf("failed to get client: %w", err)
@@ -96,18 +97,18 @@ func (uc *K8sUseCase) ListIngresses(ctx context.Context, clusterID, namespace st
 		return nil, fmt.Errorf("failed to list ingresses: %w", err)
 	}
 
-	ingresses := make([]map[string]any, 0, len(ingressList.Items))
+	ingresses := make([]domain.IngressSummary, 0, len(ingressList.Items))
 	for _, ing := range ingressList.Items {
 		host := ""
 		if len(ing.Spec.Rules) > 0 {
 			host = ing.Spec.Rules[0].Host // Lấy host đầu tiên làm đại diện
 		}
 
-		ingresses = append(ingresses, map[string]any{
-			"name":          ing.Name,
-			"namespace":     ing.Namespace,
-			"ingress_class": ing.Spec.IngressClassName,
-			"host":          host,
+		ingresses = append(ingresses, domain.IngressSummary{
+			Name:         ing.Name,
+			Namespace:    ing.Namespace,
+			IngressClass: ptr.Deref(ing.Spec.IngressClassName, ""),
+			Host:         host,
 		})
 	}
 
@@ -115,7 +116,7 @@ func (uc *K8sUseCase) ListIngresses(ctx context.Context, clusterID, namespace st
 }
 
 // GetIngress lấy thông tin chi tiết của một Ingress cụ thể.
-func (uc *K8sUseCase) GetIngress(ctx context.Context, clusterID, namespace, ingressName string) (map[string]any, error) {
+func (uc *K8sUseCase) GetIngress(ctx context.Context, clusterID, namespace, ingressName string) (*domain.IngressDetail, error) {
 	client, err := uc.clusterManager.GetClusterClient(domain.ClusterID(clusterID))
 	if err != nil {
 		return nil, fmt.Errorf("failed to get client: %w", err)
@@ -127,23 +128,23 @@ func (uc *K8sUseCase) GetIngress(ctx context.Context, clusterID, namespace, ingr
 	}
 
 	// Chuẩn bị thông tin về các Rules (đường dẫn và backend)
-	rulesData := make([]map[string]any, 0, len(ing.Spec.Rules))
+	rulesData := make([]domain.IngressRule, 0, len(ing.Spec.Rules))
 	for _, rule := range ing.Spec.Rules {
-		pathsData := make([]map[string]any, 0)
+		pathsData := make([]domain.IngressPath, 0)
 		if rule.HTTP != nil {
 			for _, path := range rule.HTTP.Paths {
-				pathsData = append(pathsData, map[string]any{
-					"path":            path.Path,
-					"path_type":       string(*path.PathType),
-					"backend_service": path.Backend.Service.Name,
-					"backend_port":    path.Backend.Service.Port.Number,
+				pathsData = append(pathsData, domain.IngressPath{
+					Path:           path.Path,
+					PathType:       string(*path.PathType),
+					BackendService: path.Backend.Service.Name,
+					BackendPort:    path.Backend.Service.Port.Number,
 				})
 			}
 		}
 
-		rulesData = append(rulesData, map[string]any{
-			"host":  rule.Host,
-			"paths": pathsData,
+		rulesData = append(rulesData, domain.IngressRule{
+			Host:  rule.Host,
+			Paths: pathsData,
 		})
 	}
 
@@ -155,14 +156,14 @@ func (uc *K8sUseCase) GetIngress(ctx context.Context, clusterID, namespace, ingr
 	}
 
 	//
-	return map[string]any{
-		"name":                    ing.Name,
-		"namespace":               ing.Namespace,
-		"ingress_class":           ing.Spec.IngressClassName,
-		"default_backend_service": defaultBackendService,
-		"default_backend_port":    defaultBackendPort,
-		"rules":                   rulesData,
-		"labels":                  ing.Labels,
+	return &domain.IngressDetail{
+		Name:                  ing.Name,
+		Namespace:             ing.Namespace,
+		IngressClass:          ptr.Deref(ing.Spec.IngressClassName, ""),
+		DefaultBackendService: defaultBackendService,
+		DefaultBackendPort:    defaultBackendPort,
+		Rules:                 rulesData,
+		Labels:                ing.Labels,
 	}, nil
 }
 
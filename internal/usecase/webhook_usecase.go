@@ -144,13 +144,19 @@ func extractClientConfig(webhooks []admissionv1.MutatingWebhook) (domain.Webhook
 	config := domain.WebhookClientConfig{}
 	if firstWebhook.ClientConfig.Service != nil {
 		config.Service = fmt.Sprintf("%s/%s", firstWebhook.ClientConfig.Service.Namespace, firstWebhook.ClientConfig.Service.Name)
-		config.Path = *firstWebhook.ClientConfig.Service.Path
+		if firstWebhook.ClientConfig.Service.Path != nil {
+			config.Path = *firstWebhook.ClientConfig.Service.Path
+		}
 	} else if firstWebhook.ClientConfig.URL != nil {
 		config.Service = "External URL"
 		config.Path = *firstWebhook.ClientConfig.URL
 	}
 
-	return config, string(*firstWebhook.FailurePolicy), firstWebhook.Rules
+	policy := ""
+	if firstWebhook.FailurePolicy != nil {
+		policy = string(*firstWebhook.FailurePolicy)
+	}
+	return config, policy, firstWebhook.Rules
 }
 
 func convertMutatingWebhookToDomain(item admissionv1.MutatingWebhookConfiguration) domain.MutatingWebhook {
@@ -178,12 +184,16 @@ func convertValidatingWebhookToDomain(item admissionv1.ValidatingWebhookConfigur
 		firstWebhook := item.Webhooks[0]
 		if firstWebhook.ClientConfig.Service != nil {
 			config.Service = fmt.Sprintf("%s/%s", firstWebhook.ClientConfig.Service.Namespace, firstWebhook.ClientConfig.Service.Name)
-			config.Path = *firstWebhook.ClientConfig.Service.Path
+			if firstWebhook.ClientConfig.Service.Path != nil {
+				config.Path = *firstWebhook.ClientConfig.Service.Path
+			}
 		} else if firstWebhook.ClientConfig.URL != nil {
 			config.Service = "External URL"
 			config.Path = *firstWebhook.ClientConfig.URL
 		}
-		policy = string(*firstWebhook.FailurePolicy)
+		if firstWebhook.FailurePolicy != nil {
+			policy = string(*firstWebhook.FailurePolicy)
+		}
 		rules = firstWebhook.Rules
 	}
 
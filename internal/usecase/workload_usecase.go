@@ -0,0 +1,440 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/your-org/mcp-k8s-server/internal/domain"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// workloadRevisionWait bounds how long an update waits for the
+	// controller to observe the new generation and report its revision.
+	workloadRevisionWait         = 10 * time.Second
+	workloadRevisionPollInterval = 500 * time.Millisecond
+
+	daemonSetTemplateGenerationAnnotation = "deprecated.daemonset.template.generation"
+)
+
+// SetWorkloadImage changes container images. images maps container names
+// (regular or init containers) to images; the key "*" sets every regular
+// container.
+func (uc *K8sUseCase) SetWorkloadImage(ctx context.Context, clusterID, kind, namespace, name string, images map[string]string) (*domain.WorkloadUpdate, error) {
+	if len(images) == 0 {
+		return nil, errors.New("at least one container image is required")
+	}
+
+	return uc.updatePodTemplate(ctx, clusterID, kind, namespace, name, func(spec *corev1.PodSpec) ([]string, map[string]any, error) {
+		return imagePatch(spec, images)
+	})
+}
+
+// imagePatch builds the pod spec patch of SetWorkloadImage and returns the
+// changed containers in spec order. "*" is resolved first, so containers
+// named explicitly get their own image; each container appears once.
+func imagePatch(spec *corev1.PodSpec, images map[string]string) ([]string, map[string]any, error) {
+	for container, image := range images {
+		if image == "" {
+			return nil, nil, fmt.Errorf("image for container %q must not be empty", container)
+		}
+	}
+
+	resolved := make(map[string]string, len(images))
+	if image, ok := images["*"]; ok {
+		for _, c := range spec.Containers {
+			resolved[c.Name] = image
+		}
+	}
+	for _, container := range slices.Sorted(maps.Keys(images)) {
+		if container == "*" {
+			continue
+		}
+		isContainer := func(c corev1.Container) bool { return c.Name == container }
+		if !slices.ContainsFunc(spec.Containers, isContainer) && !slices.ContainsFunc(spec.InitContainers, isContainer) {
+			return nil, nil, unknownContainerError(spec, container)
+		}
+		resolved[container] = images[container]
+	}
+
+	var names []string
+	patchList := func(containers []corev1.Container) []any {
+		var list []any
+		for _, c := range containers {
+			if image, ok := resolved[c.Name]; ok {
+				list = append(list, map[string]any{"name": c.Name, "image": image})
+				names = append(names, c.Name)
+			}
+		}
+		return list
+	}
+
+	specPatch := map[string]any{}
+	if containers := patchList(spec.Containers); len(containers) > 0 {
+		specPatch["containers"] = containers
+	}
+	if initContainers := patchList(spec.InitContainers); len(initContainers) > 0 {
+		specPatch["initContainers"] = initContainers
+	}
+	return names, specPatch, nil
+}
+
+// SetWorkloadEnv sets, removes and imports environment variables of one
+// container, or of every regular container when container is empty.
+func (uc *K8sUseCase) SetWorkloadEnv(ctx context.Context, clusterID, kind, namespace, name, container string, change domain.EnvChange) (*domain.WorkloadUpdate, error) {
+	if len(change.Set) == 0 && len(change.Remove) == 0 && len(change.EnvFrom) == 0 {
+		return nil, errors.New("nothing to change: set, remove or env_from is required")
+	}
+	if err := validateEnvChange(change); err != nil {
+		return nil, err
+	}
+
+	return uc.updatePodTemplate(ctx, clusterID, kind, namespace, name, func(spec *corev1.PodSpec) ([]string, map[string]any, error) {
+		targets, err := selectContainers(spec, container)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		containers := make([]any, 0, len(targets))
+		names := make([]string, 0, len(targets))
+		for _, c := range targets {
+			var env []any
+			for _, e := range change.Set {
+				env = append(env, envVarPatch(e))
+			}
+			for _, n := range change.Remove {
+				env = append(env, map[string]any{"name": n, "$patch": "delete"})
+			}
+
+			patch := map[string]any{"name": c.Name}
+			if len(env) > 0 {
+				patch["env"] = env
+			}
+			if len(change.EnvFrom) > 0 {
+				// envFrom has no merge key, so the whole list is replaced.
+				patch["envFrom"] = mergeEnvFrom(c.EnvFrom, change.EnvFrom)
+			}
+			containers = append(containers, patch)
+			names = append(names, c.Name)
+		}
+		return names, map[string]any{"containers": containers}, nil
+	})
+}
+
+// SetWorkloadResources changes resource requests and limits of one
+// container, or of every regular container when container is empty.
+func (uc *K8sUseCase) SetWorkloadResources(ctx context.Context, clusterID, kind, namespace, name, container string, change domain.ResourceChange) (*domain.WorkloadUpdate, error) {
+	if len(change.Requests) == 0 && len(change.Limits) == 0 {
+		return nil, errors.New("nothing to change: requests or limits is required")
+	}
+	requests, err := quantityPatch("requests", change.Requests)
+	if err != nil {
+		return nil, err
+	}
+	limits, err := quantityPatch("limits", change.Limits)
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.updatePodTemplate(ctx, clusterID, kind, namespace, name, func(spec *corev1.PodSpec) ([]string, map[string]any, error) {
+		targets, err := selectContainers(spec, container)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		resources := map[string]any{}
+		if len(requests) > 0 {
+			resources["requests"] = requests
+		}
+		if len(limits) > 0 {
+			resources["limits"] = limits
+		}
+
+		containers := make([]any, 0, len(targets))
+		names := make([]string, 0, len(targets))
+		for _, c := range targets {
+			containers = append(containers, map[string]any{"name": c.Name, "resources": resources})
+			names = append(names, c.Name)
+		}
+		return names, map[string]any{"containers": containers}, nil
+	})
+}
+
+// updatePodTemplate reads the pod spec of a workload, lets build compute a
+// strategic merge patch for it and applies the patch to the pod template.
+func (uc *K8sUseCase) updatePodTemplate(ctx context.Context, clusterID, kind, namespace, name string, build func(spec *corev1.PodSpec) ([]string, map[string]any, error)) (*domain.WorkloadUpdate, error) {
+	if !slices.Contains(domain.WorkloadKinds, kind) {
+		return nil, fmt.Errorf("unsupported workload kind %q (want %s)", kind, strings.Join(domain.WorkloadKinds, ", "))
+	}
+	client, err := uc.clusterManager.GetClusterClient(domain.ClusterID(clusterID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get client: %w", err)
+	}
+
+	spec, generation, err := workloadPodSpec(ctx, client, kind, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+	containers, specPatch, err := build(spec)
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(containers)
+
+	patch, err := json.Marshal(podTemplatePatch(kind, map[string]any{"spec": specPatch}))
+	if err != nil {
+		return nil, fmt.Errorf("failed to build patch: %w", err)
+	}
+	newGeneration, err := patchWorkload(ctx, client, kind, namespace, name, patch)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update %s: %w", kind, err)
+	}
+
+	update := &domain.WorkloadUpdate{
+		Kind:       kind,
+		Namespace:  namespace,
+		Name:       name,
+		Containers: containers,
+		Changed:    newGeneration != generation,
+		Generation: newGeneration,
+	}
+	update.Revision = waitWorkloadRevision(ctx, client, kind, namespace, name, newGeneration)
+	return update, nil
+}
+
+// workloadPodSpec returns the pod spec of a workload and its generation.
+func workloadPodSpec(ctx context.Context, client kubernetes.Interface, kind, namespace, name string) (*corev1.PodSpec, int64, error) {
+	get := metav1.GetOptions{}
+	switch kind {
+	case domain.WorkloadKindDeployment:
+		d, err := client.AppsV1().Deployments(namespace).Get(ctx, name, get)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to get deployment: %w", err)
+		}
+		return &d.Spec.Template.Spec, d.Generation, nil
+	case domain.WorkloadKindStatefulSet:
+		sts, err := client.AppsV1().StatefulSets(namespace).Get(ctx, name, get)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to get statefulset: %w", err)
+		}
+		return &sts.Spec.Template.Spec, sts.Generation, nil
+	case domain.WorkloadKindDaemonSet:
+		ds, err := client.AppsV1().DaemonSets(namespace).Get(ctx, name, get)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to get daemonset: %w", err)
+		}
+		return &ds.Spec.Template.Spec, ds.Generation, nil
+	case domain.WorkloadKindCronJob:
+		cj, err := client.BatchV1().CronJobs(namespace).Get(ctx, name, get)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to get cronjob: %w", err)
+		}
+		return &cj.Spec.JobTemplate.Spec.Template.Spec, cj.Generation, nil
+	}
+	return nil, 0, fmt.Errorf("unsupported workload kind %q", kind)
+}
+
+// podTemplatePatch nests a pod template patch where kind keeps its
+// template.
+func podTemplatePatch(kind string, template map[string]any) map[string]any {
+	if kind == domain.WorkloadKindCronJob {
+		return map[string]any{"spec": map[string]any{"jobTemplate": map[string]any{"spec": map[string]any{"template": template}}}}
+	}
+	return map[string]any{"spec": map[string]any{"template": template}}
+}
+
+// patchWorkload applies a strategic merge patch and returns the resulting
+// generation.
+func patchWorkload(ctx context.Context, client kubernetes.Interface, kind, namespace, name string, patch []byte) (int64, error) {
+	opts := metav1.PatchOptions{}
+	switch kind {
+	case domain.WorkloadKindDeployment:
+		d, err := client.AppsV1().Deployments(namespace).Patch(ctx, name, types.StrategicMergePatchType, patch, opts)
+		if err != nil {
+			return 0, err
+		}
+		return d.Generation, nil
+	case domain.WorkloadKindStatefulSet:
+		sts, err := client.AppsV1().StatefulSets(namespace).Patch(ctx, name, types.StrategicMergePatchType, patch, opts)
+		if err != nil {
+			return 0, err
+		}
+		return sts.Generation, nil
+	case domain.WorkloadKindDaemonSet:
+		ds, err := client.AppsV1().DaemonSets(namespace).Patch(ctx, name, types.StrategicMergePatchType, patch, opts)
+		if err != nil {
+			return 0, err
+		}
+		return ds.Generation, nil
+	case domain.WorkloadKindCronJob:
+		cj, err := client.BatchV1().CronJobs(namespace).Patch(ctx, name, types.StrategicMergePatchType, patch, opts)
+		if err != nil {
+			return 0, err
+		}
+		return cj.Generation, nil
+	}
+	return 0, fmt.Errorf("unsupported workload kind %q", kind)
+}
+
+// waitWorkloadRevision waits briefly for the controller to observe
+// generation and returns the revision of the resulting rollout, or "" if it
+// is not known yet.
+func waitWorkloadRevision(ctx context.Context, client kubernetes.Interface, kind, namespace, name string, generation int64) string {
+	if kind == domain.WorkloadKindCronJob {
+		return ""
+	}
+
+	revision := ""
+	_ = wait.PollUntilContextTimeout(ctx, workloadRevisionPollInterval, workloadRevisionWait, true, func(ctx context.Context) (bool, error) {
+		get := metav1.GetOptions{}
+		switch kind {
+		case domain.WorkloadKindDeployment:
+			d, err := client.AppsV1().Deployments(namespace).Get(ctx, name, get)
+			if err != nil || d.Status.ObservedGeneration < generation {
+				return false, nil
+			}
+			revision = d.Annotations[revisionAnnotation]
+		case domain.WorkloadKindStatefulSet:
+			sts, err := client.AppsV1().StatefulSets(namespace).Get(ctx, name, get)
+			if err != nil || sts.Status.ObservedGeneration < generation {
+				return false, nil
+			}
+			revision = sts.Status.UpdateRevision
+		case domain.WorkloadKindDaemonSet:
+			ds, err := client.AppsV1().DaemonSets(namespace).Get(ctx, name, get)
+			if err != nil || ds.Status.ObservedGeneration < generation {
+				return false, nil
+			}
+			revision = ds.Annotations[daemonSetTemplateGenerationAnnotation]
+		}
+		return true, nil
+	})
+	return revision
+}
+
+// selectContainers returns the named regular container, or all of them when
+// name is empty.
+func selectContainers(spec *corev1.PodSpec, name string) ([]corev1.Container, error) {
+	if name == "" {
+		return spec.Containers, nil
+	}
+	for _, c := range spec.Containers {
+		if c.Name == name {
+			return []corev1.Container{c}, nil
+		}
+	}
+	return nil, unknownContainerError(spec, name)
+}
+
+func unknownContainerError(spec *corev1.PodSpec, name string) error {
+	var names []string
+	for _, c := range spec.Containers {
+		names = append(names, c.Name)
+	}
+	for _, c := range spec.InitContainers {
+		names = append(names, c.Name+" (init)")
+	}
+	return fmt.Errorf("container %q not found (containers: %s)", name, strings.Join(names, ", "))
+}
+
+func validateEnvChange(change domain.EnvChange) error {
+	for _, e := range change.Set {
+		if e.Name == "" {
+			return errors.New("set: every variable needs a name")
+		}
+		if e.ConfigMap != "" && e.Secret != "" {
+			return fmt.Errorf("set %s: configmap and secret are mutually exclusive", e.Name)
+		}
+		if (e.ConfigMap != "" || e.Secret != "") && e.Key == "" {
+			return fmt.Errorf("set %s: key is required with configmap or secret", e.Name)
+		}
+		if (e.ConfigMap != "" || e.Secret != "") && e.Value != "" {
+			return fmt.Errorf("set %s: value cannot be combined with configmap or secret", e.Name)
+		}
+	}
+	for _, n := range change.Remove {
+		if n == "" {
+			return errors.New("remove: variable names must not be empty")
+		}
+		// The patch would both set and delete the variable, and which one
+		// wins depends on how the API server merges the list.
+		if slices.ContainsFunc(change.Set, func(e domain.EnvVarSpec) bool { return e.Name == n }) {
+			return fmt.Errorf("%s is in both set and remove", n)
+		}
+	}
+	for _, f := range change.EnvFrom {
+		if (f.ConfigMap == "") == (f.Secret == "") {
+			return errors.New("env_from: exactly one of configmap or secret is required")
+		}
+	}
+	return nil
+}
+
+// envVarPatch sets a variable and clears the field it does not use, since
+// value and valueFrom are mutually exclusive.
+func envVarPatch(e domain.EnvVarSpec) map[string]any {
+	ref := map[string]any{"key": e.Key}
+	switch {
+	case e.ConfigMap != "":
+		ref["name"] = e.ConfigMap
+		return map[string]any{"name": e.Name, "value": nil, "valueFrom": map[string]any{"configMapKeyRef": ref}}
+	case e.Secret != "":
+		ref["name"] = e.Secret
+		return map[string]any{"name": e.Name, "value": nil, "valueFrom": map[string]any{"secretKeyRef": ref}}
+	}
+	return map[string]any{"name": e.Name, "value": e.Value, "valueFrom": nil}
+}
+
+// mergeEnvFrom adds sources to the existing envFrom list. A source that is
+// already imported keeps its position and gets the new prefix.
+func mergeEnvFrom(existing []corev1.EnvFromSource, sources []domain.EnvFromSpec) []corev1.EnvFromSource {
+	merged := slices.Clone(existing)
+	for _, s := range sources {
+		src := corev1.EnvFromSource{Prefix: s.Prefix}
+		if s.ConfigMap != "" {
+			src.ConfigMapRef = &corev1.ConfigMapEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: s.ConfigMap}}
+		} else {
+			src.SecretRef = &corev1.SecretEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: s.Secret}}
+		}
+
+		i := slices.IndexFunc(merged, func(e corev1.EnvFromSource) bool {
+			return (e.ConfigMapRef != nil && src.ConfigMapRef != nil && e.ConfigMapRef.Name == src.ConfigMapRef.Name) ||
+				(e.SecretRef != nil && src.SecretRef != nil && e.SecretRef.Name == src.SecretRef.Name)
+		})
+		if i >= 0 {
+			merged[i] = src
+		} else {
+			merged = append(merged, src)
+		}
+	}
+	return merged
+}
+
+// quantityPatch validates quantities; empty quantities become null so the
+// patch removes them.
+func quantityPatch(field string, quantities map[string]string) (map[string]any, error) {
+	patch := make(map[string]any, len(quantities))
+	for name, q := range quantities {
+		if q == "" {
+			patch[name] = nil
+			continue
+		}
+		if _, err := resource.ParseQuantity(q); err != nil {
+			return nil, fmt.Errorf("%s.%s: invalid quantity %q: %w", field, name, q, err)
+		}
+		patch[name] = q
+	}
+	return patch, nil
+}
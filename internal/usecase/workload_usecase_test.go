@@ -0,0 +1,113 @@
+package usecase
+
+import (
+	"encoding/json"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/your-org/mcp-k8s-server/internal/domain"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestImagePatch(t *testing.T) {
+	spec := &corev1.PodSpec{
+		InitContainers: []corev1.Container{{Name: "migrate", Image: "app:1"}},
+		Containers: []corev1.Container{
+			{Name: "app", Image: "app:1"},
+			{Name: "sidecar", Image: "envoy:1.29"},
+			{Name: "metrics", Image: "exporter:0.9"},
+		},
+	}
+
+	for _, tc := range []struct {
+		name      string
+		images    map[string]string
+		wantNames []string
+		wantPatch string
+		wantErr   string
+	}{
+		{
+			name:      "one container",
+			images:    map[string]string{"sidecar": "envoy:1.30"},
+			wantNames: []string{"sidecar"},
+			wantPatch: `{"containers":[{"image":"envoy:1.30","name":"sidecar"}]}`,
+		},
+		{
+			name:      "wildcard",
+			images:    map[string]string{"*": "app:2"},
+			wantNames: []string{"app", "sidecar", "metrics"},
+			wantPatch: `{"containers":[{"image":"app:2","name":"app"},{"image":"app:2","name":"sidecar"},{"image":"app:2","name":"metrics"}]}`,
+		},
+		{
+			name:      "explicit name overrides wildcard",
+			images:    map[string]string{"*": "app:2", "sidecar": "envoy:1.30", "migrate": "app:2"},
+			wantNames: []string{"app", "sidecar", "metrics", "migrate"},
+			wantPatch: `{"containers":[{"image":"app:2","name":"app"},{"image":"envoy:1.30","name":"sidecar"},{"image":"app:2","name":"metrics"}],"initContainers":[{"image":"app:2","name":"migrate"}]}`,
+		},
+		{name: "unknown container", images: map[string]string{"*": "app:2", "web": "nginx"}, wantErr: `container "web" not found`},
+		{name: "empty image", images: map[string]string{"app": ""}, wantErr: "must not be empty"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			// Map iteration order varies, so repeat to catch order
+			// dependent results.
+			for range 20 {
+				names, patch, err := imagePatch(spec, tc.images)
+				if tc.wantErr != "" {
+					if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+						t.Fatalf("error = %v, want %q", err, tc.wantErr)
+					}
+					continue
+				}
+				if err != nil {
+					t.Fatal(err)
+				}
+				data, _ := json.Marshal(patch)
+				if string(data) != tc.wantPatch || !slices.Equal(names, tc.wantNames) {
+					t.Fatalf("imagePatch = %v %s, want %v %s", names, data, tc.wantNames, tc.wantPatch)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateEnvChange(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		change  domain.EnvChange
+		wantErr string
+	}{
+		{
+			name: "valid",
+			change: domain.EnvChange{
+				Set:     []domain.EnvVarSpec{{Name: "LOG_LEVEL", Value: "debug"}, {Name: "DB_PASSWORD", Secret: "db", Key: "password"}},
+				Remove:  []string{"DEBUG"},
+				EnvFrom: []domain.EnvFromSpec{{ConfigMap: "app-config"}},
+			},
+		},
+		{name: "set without name", change: domain.EnvChange{Set: []domain.EnvVarSpec{{Value: "x"}}}, wantErr: "every variable needs a name"},
+		{name: "configmap and secret", change: domain.EnvChange{Set: []domain.EnvVarSpec{{Name: "A", ConfigMap: "c", Secret: "s", Key: "k"}}}, wantErr: "mutually exclusive"},
+		{name: "reference without key", change: domain.EnvChange{Set: []domain.EnvVarSpec{{Name: "A", Secret: "s"}}}, wantErr: "key is required"},
+		{name: "value and reference", change: domain.EnvChange{Set: []domain.EnvVarSpec{{Name: "A", ConfigMap: "c", Key: "k", Value: "v"}}}, wantErr: "value cannot be combined"},
+		{name: "empty remove", change: domain.EnvChange{Remove: []string{""}}, wantErr: "must not be empty"},
+		{
+			name:    "set and remove",
+			change:  domain.EnvChange{Set: []domain.EnvVarSpec{{Name: "A", Value: "1"}, {Name: "LOG_LEVEL", Value: "debug"}}, Remove: []string{"B", "LOG_LEVEL"}},
+			wantErr: "LOG_LEVEL is in both set and remove",
+		},
+		{name: "env from without source", change: domain.EnvChange{EnvFrom: []domain.EnvFromSpec{{}}}, wantErr: "exactly one of configmap or secret"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateEnvChange(tc.change)
+			if tc.wantErr == "" {
+				if err != nil {
+					t.Fatal(err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Fatalf("error = %v, want %q", err, tc.wantErr)
+			}
+		})
+	}
+}